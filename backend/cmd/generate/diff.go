@@ -0,0 +1,86 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/kinpatsu-everyone/backend-template/pkg/outorouter"
+)
+
+// runDiff は2つのメタデータJSONを比較し、破壊的変更がある場合は1を返す
+func runDiff(args []string) int {
+	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+	format := fs.String("format", "text", "Output format (text or json)")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: generate diff [-format text|json] <old metadata.json> <new metadata.json>")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return 2
+	}
+
+	changes, err := outorouter.DiffMetadataFiles(fs.Arg(0), fs.Arg(1))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to diff metadata: %v\n", err)
+		return 2
+	}
+
+	switch *format {
+	case "json":
+		err = writeDiffJSON(os.Stdout, changes)
+	case "text":
+		err = writeDiffText(os.Stdout, changes)
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unknown format %q\n", *format)
+		return 2
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to write report: %v\n", err)
+		return 2
+	}
+
+	if outorouter.HasBreakingChanges(changes) {
+		return 1
+	}
+	return 0
+}
+
+func writeDiffJSON(w io.Writer, changes []outorouter.MetadataChange) error {
+	if changes == nil {
+		changes = []outorouter.MetadataChange{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(map[string]any{
+		"breaking": outorouter.HasBreakingChanges(changes),
+		"changes":  changes,
+	})
+}
+
+func writeDiffText(w io.Writer, changes []outorouter.MetadataChange) error {
+	breaking := 0
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, c := range changes {
+		if c.Severity == outorouter.SeverityBreaking {
+			breaking++
+		}
+		field := c.Field
+		if field == "" {
+			field = "-"
+		}
+		fmt.Fprintf(tw, "[%s]\t%s\t%s\t%s\n", c.Severity, c.Path, field, c.Message)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintf(w, "%d changes (%d breaking, %d non-breaking)\n", len(changes), breaking, len(changes)-breaking)
+	return err
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/kinpatsu-everyone/backend-template/pkg/outorouter"
+	"github.com/kinpatsu-everyone/backend-template/router"
+)
+
+const defaultLintConfigPath = ".outolint.json"
+
+// runLint はエンドポイントのメタデータを検査し、エラーレベルの指摘がある場合は1を返す
+func runLint(args []string) int {
+	flags := flag.NewFlagSet("lint", flag.ExitOnError)
+	format := flags.String("format", "text", "Output format (text, json or sarif)")
+	configPath := flags.String("config", defaultLintConfigPath, "Path to the lint config file (JSON)")
+	metadataPath := flags.String("metadata", "", "Lint the given metadata JSON file instead of the registered routes")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: generate lint [-format text|json|sarif] [-config .outolint.json] [-metadata metadata.json]")
+		flags.PrintDefaults()
+	}
+	_ = flags.Parse(args)
+
+	cfg, err := loadLintConfig(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	var issues []outorouter.LintIssue
+	if *metadataPath != "" {
+		issues, err = outorouter.LintMetadataFile(*metadataPath, cfg)
+	} else {
+		r := outorouter.New()
+		if _, err := router.Build(r); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to build router: %v\n", err)
+			return 2
+		}
+		issues, err = outorouter.LintRouter(r, cfg)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to lint metadata: %v\n", err)
+		return 2
+	}
+
+	if err := outorouter.WriteLintReport(os.Stdout, outorouter.LintFormat(*format), issues); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to write report: %v\n", err)
+		return 2
+	}
+
+	if outorouter.HasLintErrors(issues) {
+		return 1
+	}
+	return 0
+}
+
+// loadLintConfig は設定ファイルを読み込む（デフォルトのパスにファイルがない場合は既定の設定を使う）
+func loadLintConfig(path string) (outorouter.LintConfig, error) {
+	cfg, err := outorouter.LoadLintConfig(path)
+	if err != nil && path == defaultLintConfigPath && errors.Is(err, fs.ErrNotExist) {
+		return outorouter.LintConfig{}, nil
+	}
+	return cfg, err
+}
@@ -12,10 +12,26 @@ import (
 )
 
 func main() {
+	// サブコマンド
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "diff":
+			os.Exit(runDiff(os.Args[2:]))
+		case "lint":
+			os.Exit(runLint(os.Args[2:]))
+		}
+	}
+
 	// フラグの定義
 	outputPath := flag.String("output", ".api/client.ts", "Output path for the TypeScript client file")
 	baseURL := flag.String("base-url", "http://localhost:8080", "Base URL for the API client")
 	metadataPath := flag.String("metadata", ".api/metadata.json", "Output path for the metadata JSON file")
+	openAPIPath := flag.String("openapi", "", "Output path for the OpenAPI 3.1 document (.json or .yaml); disabled if empty")
+	dartPath := flag.String("dart", "", "Output path for the Dart (Flutter) client file; disabled if empty")
+	goClientPath := flag.String("go", "", "Output path for the Go client file; disabled if empty")
+	goPackage := flag.String("go-package", "apiclient", "Package name for the Go client")
+	e2ePath := flag.String("e2e", "", "Output path for the generated E2E test file; disabled if empty")
+	e2eHappyPath := flag.Bool("e2e-happy-path", false, "Do not skip happy path cases in the generated E2E test")
 	flag.Parse()
 
 	// Logger の設定（quietモード）
@@ -36,10 +52,26 @@ func main() {
 	}
 
 	// DevConfig でコード生成
-	dev := outorouter.NewDevConfig(
+	opts := []outorouter.DevConfigOption{
 		outorouter.WithMetadataFilePath(*metadataPath),
 		outorouter.WithTypeScriptClient(*outputPath, *baseURL),
-	)
+	}
+	if *openAPIPath != "" {
+		opts = append(opts, outorouter.WithOpenAPI(*openAPIPath, *baseURL))
+	}
+	if *dartPath != "" {
+		opts = append(opts, outorouter.WithDartClient(*dartPath, *baseURL))
+	}
+	if *goClientPath != "" {
+		opts = append(opts, outorouter.WithGoClient(*goClientPath, *goPackage))
+	}
+	if *e2ePath != "" {
+		opts = append(opts, outorouter.WithE2ETest(*e2ePath, ""))
+		if *e2eHappyPath {
+			opts = append(opts, outorouter.WithE2ETestHappyPath())
+		}
+	}
+	dev := outorouter.NewDevConfig(opts...)
 
 	if err := dev.Run(r); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: failed to generate client: %v\n", err)
@@ -48,4 +80,16 @@ func main() {
 
 	fmt.Printf("Generated TypeScript client: %s\n", *outputPath)
 	fmt.Printf("Generated metadata: %s\n", *metadataPath)
+	if *openAPIPath != "" {
+		fmt.Printf("Generated OpenAPI document: %s\n", *openAPIPath)
+	}
+	if *dartPath != "" {
+		fmt.Printf("Generated Dart client: %s\n", *dartPath)
+	}
+	if *goClientPath != "" {
+		fmt.Printf("Generated Go client: %s\n", *goClientPath)
+	}
+	if *e2ePath != "" {
+		fmt.Printf("Generated E2E test: %s\n", *e2ePath)
+	}
 }
@@ -11,7 +11,12 @@ import (
 	"time"
 
 	"github.com/kinpatsu-everyone/backend-template/config"
+	"github.com/kinpatsu-everyone/backend-template/internal/auth"
+	"github.com/kinpatsu-everyone/backend-template/internal/blob"
+	"github.com/kinpatsu-everyone/backend-template/internal/generation"
+	"github.com/kinpatsu-everyone/backend-template/internal/monsterai"
 	"github.com/kinpatsu-everyone/backend-template/internal/mysql"
+	"github.com/kinpatsu-everyone/backend-template/internal/redis"
 	"github.com/kinpatsu-everyone/backend-template/pkg/outologger"
 	"github.com/kinpatsu-everyone/backend-template/pkg/outorouter"
 	"github.com/kinpatsu-everyone/backend-template/router"
@@ -49,9 +54,61 @@ func run(ctx context.Context) {
 		"stat": mysql.GetDB().Stats(),
 	})
 
+	// 画像ストレージの設定（config.BlobStore で GCS・ローカル・メモリを切り替える）
+	store, err := blob.NewFromConfig(ctx)
+	if err != nil {
+		logger.Error(ctx, "failed to create blob store", map[string]any{
+			"error": err,
+		})
+		return
+	}
+	defer store.Close()
+	blob.SetStore(store)
+
+	// モンスター生成AIの設定（config.MonsterAIProvider で Gemini・fake を切り替える）
+	ai, err := monsterai.NewFromConfig()
+	if err != nil {
+		logger.Error(ctx, "failed to create monster AI", map[string]any{
+			"error": err,
+		})
+		return
+	}
+	monsterai.SetMonsterAI(ai)
+
+	// 認証の設定
+	verifiers, err := auth.NewVerifiers(mysql.GetQueries())
+	if err != nil {
+		logger.Error(ctx, "failed to create auth verifiers", map[string]any{
+			"error": err,
+		})
+		return
+	}
+
+	// レート制限の設定（複数インスタンスで共有する場合はRedisを利用する）
+	var rateLimitStore outorouter.RateLimitStore = outorouter.NewMemoryRateLimitStore()
+	if config.RateLimitStore == "redis" {
+		redisClient := redis.NewClient(config.RedisConfig)
+		defer redisClient.Close()
+		rateLimitStore = &outorouter.RedisRateLimitStore{
+			Client: redisClient,
+			Prefix: config.RedisConfig.KeyPrefix + ":ratelimit:",
+		}
+	}
+
+	// クライアントのIPアドレスの判定に使う、信頼するプロキシの設定
+	trustedProxies, err := outorouter.ParseTrustedProxies(config.TrustedProxies)
+	if err != nil {
+		logger.Error(ctx, "failed to parse trusted proxies", map[string]any{
+			"error": err,
+		})
+		return
+	}
+
 	// ルーターの設定
 	r := outorouter.New(
 		outorouter.WithLogger(outologger.GetLogger()),
+		outorouter.WithVerifiers(verifiers...),
+		outorouter.WithRateLimitStore(rateLimitStore),
 	)
 
 	// CORS設定
@@ -60,17 +117,44 @@ func run(ctx context.Context) {
 
 	// ミドルウェアの登録（適用順序が重要）
 	r.Use(
-		outorouter.CORSMiddleware(corsConfig), // 1. CORS処理（最初に実行）
-		outorouter.NowUTCMiddleware(),         // 2. リクエスト時刻を記録
-		outorouter.RequestIDMiddleware(),      // 3. リクエストIDを生成
-		outorouter.LoggingMiddleware(logger),  // 4. アクセスログとパニックリカバリー
+		outorouter.CORSMiddleware(corsConfig),         // 1. CORS処理（最初に実行）
+		outorouter.NowUTCMiddleware(),                 // 2. リクエスト時刻を記録
+		outorouter.RequestIDMiddleware(),              // 3. リクエストIDを生成
+		outorouter.ClientIPMiddleware(trustedProxies), // 4. クライアントのIPアドレスを判定
+		outorouter.LoggingMiddleware(logger),          // 5. アクセスログとパニックリカバリー
 	)
 
+	// モンスター生成ジョブのワーカーを起動（サーバーの停止時に実行中のジョブを待つ）
+	if config.GenerationWorkerConcurrency > 0 {
+		workerCtx, workerCancel := context.WithCancel(ctx)
+		pool := generation.NewPool(generation.NewMySQLStore(mysql.GetQueries()), generation.RunMonsterGeneration, generation.PoolConfig{
+			Concurrency: config.GenerationWorkerConcurrency,
+			Logger:      logger,
+			// 失敗したジョブの分の1日の利用上限を戻す
+			RefundQuota: func(ctx context.Context, key string) error {
+				return rateLimitStore.Refund(ctx, key, router.GenerationQuota(), time.Now())
+			},
+			// 完了・失敗したジョブの入力画像を削除する
+			Storage: store,
+		})
+		workerDone := make(chan struct{})
+		go func() {
+			defer close(workerDone)
+			pool.Run(workerCtx)
+		}()
+		defer func() {
+			workerCancel()
+			<-workerDone
+		}()
+	}
+
 	// 起動ログ
 	logger.Info(ctx, "Starting server", map[string]any{
 		"environment": config.ENV,
 		"port":        config.ApiPort,
 		"development": config.IsDevelopment(),
+		"blob_store":  config.BlobStore,
+		"monster_ai":  config.MonsterAIProvider,
 	})
 
 	mux, err := router.Build(r)
@@ -81,6 +165,11 @@ func run(ctx context.Context) {
 		return
 	}
 
+	// ローカル・開発環境ではOpenAPIドキュメントを配信する（QAチームのツール連携用）
+	if config.IsLocal() || config.IsDevelopment() {
+		r.RegisterCustomHandler(http.MethodGet, outorouter.OpenAPIDocumentPath, outorouter.OpenAPIHandler(r, ""))
+	}
+
 	// Development モードの場合、メタデータをエクスポートする
 	if config.IsLocal() {
 		if err := dev.Run(r); err != nil {
@@ -97,7 +186,7 @@ func run(ctx context.Context) {
 		Addr:         addr,
 		Handler:      mux,
 		ReadTimeout:  15 * time.Second,
-		WriteTimeout: 120 * time.Second, // 大きな画像データを返す場合に備えて延長
+		WriteTimeout: 30 * time.Second, // 同期的に画像を生成するエンドポイントは WriteDeadlineMiddleware で延長する
 		IdleTimeout:  60 * time.Second,
 	}
 
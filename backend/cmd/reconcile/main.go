@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/kinpatsu-everyone/backend-template/config"
+	"github.com/kinpatsu-everyone/backend-template/internal/blob"
+	"github.com/kinpatsu-everyone/backend-template/internal/generation"
+	"github.com/kinpatsu-everyone/backend-template/internal/mysql"
+	"github.com/kinpatsu-everyone/backend-template/pkg/outologger"
+)
+
+// reconcile は作成途中のMonster（画像やゴミ種別がないもの）を探して修復・削除します
+// デフォルトはドライランで、-apply を指定した場合のみ変更します
+//
+//	go run ./cmd/reconcile -older-than 1h          # 修復方法を表示する
+//	go run ./cmd/reconcile -older-than 1h -apply   # 修復する
+func main() {
+	olderThan := flag.Duration("older-than", time.Hour, "Only reconcile monsters created before this duration ago")
+	apply := flag.Bool("apply", false, "Apply the changes (dry run if false)")
+	flag.Parse()
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	os.Exit(run(ctx, *olderThan, *apply))
+}
+
+func run(ctx context.Context, olderThan time.Duration, apply bool) int {
+	config.LoadEnv(ctx)
+	outologger.SetLogger(outologger.NewSlogLogger(slog.New(slog.NewJSONHandler(os.Stderr, nil))))
+
+	// メモリのストレージには元画像が残っていないため、再生成できない
+	if config.BlobStore == "memory" {
+		fmt.Fprintln(os.Stderr, "Error: reconcile requires a persistent blob store (BLOB_STORE=gcs or local)")
+		return 1
+	}
+
+	if err := mysql.InitDB(ctx, mysql.DefaultPoolConfig()); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	defer mysql.Close()
+
+	store, err := blob.NewFromConfig(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	defer store.Close()
+
+	reconciler := &generation.Reconciler{
+		Queries:     mysql.GetQueries(),
+		Storage:     store,
+		MaxAttempts: config.GenerationMaxAttempts,
+		Apply:       apply,
+	}
+	results, err := reconciler.Run(ctx, olderThan)
+
+	failed := 0
+	for _, r := range results {
+		switch {
+		case r.Err != nil:
+			failed++
+			fmt.Printf("%s\t%s\t%s\terror: %v\n", r.MonsterID, r.Action, r.Reason, r.Err)
+		case r.JobID != "":
+			fmt.Printf("%s\t%s\t%s\tjob: %s\n", r.MonsterID, r.Action, r.Reason, r.JobID)
+		default:
+			fmt.Printf("%s\t%s\t%s\n", r.MonsterID, r.Action, r.Reason)
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	if !apply {
+		fmt.Fprintf(os.Stderr, "%d monsters need to be reconciled (dry run, use -apply to reconcile)\n", len(results))
+	} else {
+		fmt.Fprintf(os.Stderr, "%d monsters reconciled (%d failed)\n", len(results)-failed, failed)
+	}
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
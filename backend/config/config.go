@@ -29,9 +29,41 @@ var (
 
 	RedisConfig = CacheConfig{}
 
-	// GeminiAPIKey はGoogle Gemini APIの認証キーです
+	// TrustedProxies は X-Forwarded-For を信頼するプロキシのIPアドレスまたはCIDRのリスト（カンマ区切り）
+	// 空の場合は X-Forwarded-For を使わず、接続元のアドレスをクライアントのIPアドレスとして扱います
+	TrustedProxies = []string{}
+
+	// RateLimitStore はレート制限の状態の保存先です（"memory" または "redis"）
+	RateLimitStore = "memory"
+
+	// GenerationDailyQuota はユーザーごとの1日あたりの画像生成回数の上限です
+	GenerationDailyQuota = 20
+
+	// DeviceRegistrationDailyLimit はIPアドレスごとの24時間あたりの端末登録回数の上限です
+	DeviceRegistrationDailyLimit = 10
+
+	// GenerationWorkerConcurrency はモンスター生成ジョブを並列に実行するワーカー数です（0の場合はこのプロセスで実行しません）
+	GenerationWorkerConcurrency = 2
+
+	// GenerationMaxAttempts はモンスター生成ジョブの最大実行回数です（リトライを含む）
+	GenerationMaxAttempts = 3
+
+	// AuthConfig は認証（JWT・APIキー）の設定です
+	AuthConfig = AuthSettings{}
+
+	// MonsterAIProvider はゴミ箱の画像の分析とモンスターの画像の生成に使うAIです（"gemini" または "fake"）
+	// "fake" の場合はAPIを呼び出さずに決まった結果を返します（ローカル開発・CI用）
+	MonsterAIProvider = "gemini"
+
+	// GeminiAPIKey はGoogle Gemini APIの認証キーです（MonsterAIProvider が "gemini" の場合は必須）
 	GeminiAPIKey = ""
 
+	// GeminiAnalysisModel はゴミ箱の画像の分析に使うGeminiのモデルです
+	GeminiAnalysisModel = "gemini-2.5-flash"
+
+	// GeminiImageModel はモンスターの画像の生成に使うGeminiのモデルです
+	GeminiImageModel = "gemini-3-pro-image-preview"
+
 	// GeminiBaseURL はGoogle Gemini APIのベースURLです
 	GeminiBaseURL = ""
 
@@ -48,6 +80,22 @@ var (
 	// trueの場合、公開URLを使用します（誰でもアクセス可能）
 	// falseの場合、署名付きURL（認証済みURL）を使用します（URLを知っている人のみアクセス可能、有効期限あり）
 	GCSMakePublic = true // デフォルトは公開URL方式
+
+	// BlobStore は画像の保存先です（"gcs"、"local" または "memory"）
+	// ローカル・開発・テスト環境では、GCS_BUCKET_NAME が設定されている場合のデフォルトは "gcs"、それ以外は "local" です
+	// それ以外の環境では必須です
+	BlobStore = "local"
+
+	// BlobLocalDir は BlobStore が "local" の場合に画像を保存するディレクトリです
+	BlobLocalDir = ".data/blobs"
+
+	// BlobSigningSecret は "local"・"memory" の署名付きURLの署名鍵です
+	// ローカル・開発・テスト環境で空の場合は起動ごとにランダムに生成し、それ以外の環境では必須です
+	BlobSigningSecret = ""
+
+	// BlobPublicBaseURL は "local"・"memory" の署名付きURLのベースURL（APIサーバーのURL）です
+	// ローカル・開発・テスト環境のデフォルトは "http://localhost:{PORT}" で、それ以外の環境では必須です
+	BlobPublicBaseURL = ""
 )
 
 type CacheConfig struct {
@@ -59,6 +107,20 @@ type CacheConfig struct {
 	TLSInsecure bool
 	KeyPrefix   string
 	DefaultTTL  time.Duration
+	// PoolSize は接続プールの最大接続数です（0の場合はCPU数の10倍）
+	PoolSize int
+}
+
+type AuthSettings struct {
+	// JWTHS256Secret はHS256のJWTの署名鍵です（空の場合はHS256を受け付けません）
+	JWTHS256Secret string
+	// JWKSFile はRS256のJWTの公開鍵（JWKS形式）のファイルパスです
+	JWKSFile    string
+	JWTIssuer   string
+	JWTAudience string
+	JWTLeeway   time.Duration
+	// APIKeyPrefix はAPIキーの接頭辞です
+	APIKeyPrefix string
 }
 
 func loadEnv(ctx context.Context, key string, isSecret bool) string {
@@ -81,7 +143,14 @@ func LoadEnv(ctx context.Context) {
 	MySQLHost = loadEnv(ctx, "MYSQL_HOST", true)
 	MySQLPort = loadEnv(ctx, "MYSQL_PORT", true)
 	ApiPort = loadEnv(ctx, "PORT", false)
-	GeminiAPIKey = loadEnv(ctx, "GEMINI_API_KEY", true)
+	MonsterAIProvider = defaultString(os.Getenv("MONSTER_AI_PROVIDER"), "gemini")
+	if MonsterAIProvider == "gemini" {
+		GeminiAPIKey = loadEnv(ctx, "GEMINI_API_KEY", true)
+	} else {
+		GeminiAPIKey = os.Getenv("GEMINI_API_KEY")
+	}
+	GeminiAnalysisModel = defaultString(os.Getenv("GEMINI_ANALYSIS_MODEL"), "gemini-2.5-flash")
+	GeminiImageModel = defaultString(os.Getenv("GEMINI_IMAGE_MODEL"), "gemini-3-pro-image-preview")
 	GeminiBaseURL = defaultString(os.Getenv("GEMINI_BASE_URL"), "https://generativelanguage.googleapis.com")
 
 	// CORS設定（オプション、カンマ区切りで複数指定可能）
@@ -90,6 +159,9 @@ func LoadEnv(ctx context.Context) {
 		CORSAllowedOrigins = parseCSV(corsOrigins)
 	}
 
+	// 信頼するプロキシ設定（オプション、カンマ区切りで複数指定可能）
+	TrustedProxies = parseCSV(os.Getenv("TRUSTED_PROXIES"))
+
 	RedisConfig = CacheConfig{
 		Addr:        defaultString(os.Getenv("REDIS_ADDR"), "127.0.0.1:6379"),
 		Username:    os.Getenv("REDIS_USERNAME"),
@@ -99,6 +171,7 @@ func LoadEnv(ctx context.Context) {
 		TLSInsecure: parseBool(os.Getenv("REDIS_TLS_INSECURE"), false),
 		KeyPrefix:   defaultString(os.Getenv("REDIS_KEY_PREFIX"), "app"),
 		DefaultTTL:  parseDuration(os.Getenv("REDIS_DEFAULT_TTL"), 5*time.Minute),
+		PoolSize:    parseInt(os.Getenv("REDIS_POOL_SIZE"), 0),
 	}
 	if RedisConfig.DefaultTTL <= 0 {
 		RedisConfig.DefaultTTL = 5 * time.Minute
@@ -107,10 +180,51 @@ func LoadEnv(ctx context.Context) {
 		RedisConfig.KeyPrefix = "app"
 	}
 
+	// レート制限設定（オプション）
+	RateLimitStore = defaultString(os.Getenv("RATE_LIMIT_STORE"), "memory")
+	GenerationDailyQuota = parseInt(os.Getenv("GENERATION_DAILY_QUOTA"), 20)
+	DeviceRegistrationDailyLimit = parseInt(os.Getenv("DEVICE_REGISTRATION_DAILY_LIMIT"), 10)
+
+	// モンスター生成ジョブ設定（オプション）
+	GenerationWorkerConcurrency = parseInt(os.Getenv("GENERATION_WORKER_CONCURRENCY"), 2)
+	GenerationMaxAttempts = parseInt(os.Getenv("GENERATION_MAX_ATTEMPTS"), 3)
+	if GenerationMaxAttempts < 1 {
+		GenerationMaxAttempts = 1
+	}
+
+	// 認証設定（オプション）
+	AuthConfig = AuthSettings{
+		JWTHS256Secret: os.Getenv("AUTH_JWT_HS256_SECRET"),
+		JWKSFile:       os.Getenv("AUTH_JWKS_FILE"),
+		JWTIssuer:      os.Getenv("AUTH_JWT_ISSUER"),
+		JWTAudience:    os.Getenv("AUTH_JWT_AUDIENCE"),
+		JWTLeeway:      parseDuration(os.Getenv("AUTH_JWT_LEEWAY"), 30*time.Second),
+		APIKeyPrefix:   defaultString(os.Getenv("AUTH_API_KEY_PREFIX"), "ak_"),
+	}
+
 	// GCS設定（オプション）
 	GCSBucketName = os.Getenv("GCS_BUCKET_NAME")
 	GCSBaseURL = os.Getenv("GCS_BASE_URL")
 	GCSCredentialsJSON = os.Getenv("GCS_CREDENTIALS_JSON")
+
+	// 画像ストレージ設定（ローカル・開発・テスト環境以外は保存先の指定が必須）
+	BlobLocalDir = defaultString(os.Getenv("BLOB_LOCAL_DIR"), ".data/blobs")
+	if IsDebugMode() {
+		defaultBlobStore := "local"
+		if GCSBucketName != "" {
+			defaultBlobStore = "gcs"
+		}
+		BlobStore = defaultString(os.Getenv("BLOB_STORE"), defaultBlobStore)
+		BlobSigningSecret = os.Getenv("BLOB_SIGNING_SECRET")
+		BlobPublicBaseURL = defaultString(os.Getenv("BLOB_PUBLIC_BASE_URL"), "http://localhost:"+ApiPort)
+	} else {
+		BlobStore = loadEnv(ctx, "BLOB_STORE", false)
+		// "local"・"memory" はAPIサーバーが署名付きURLを発行するため、再起動しても同じ署名鍵と公開URLが必要
+		if BlobStore == "local" || BlobStore == "memory" {
+			BlobSigningSecret = loadEnv(ctx, "BLOB_SIGNING_SECRET", true)
+			BlobPublicBaseURL = loadEnv(ctx, "BLOB_PUBLIC_BASE_URL", false)
+		}
+	}
 }
 
 func defaultString(value, def string) string {
@@ -262,6 +262,44 @@ func TestLoadEnv_必須環境変数が設定されている場合(t *testing.T)
 	assert.Equal(t, "localhost", MySQLHost)
 	assert.Equal(t, "3306", MySQLPort)
 	assert.Equal(t, "8080", ApiPort)
+	assert.Equal(t, "memory", RateLimitStore)
+	assert.Equal(t, 20, GenerationDailyQuota)
+	assert.Equal(t, 10, DeviceRegistrationDailyLimit)
+	assert.Equal(t, 2, GenerationWorkerConcurrency)
+	assert.Equal(t, 3, GenerationMaxAttempts)
+	assert.Equal(t, "ak_", AuthConfig.APIKeyPrefix)
+	assert.Equal(t, 30*time.Second, AuthConfig.JWTLeeway)
+	assert.Equal(t, "local", BlobStore)
+	assert.Equal(t, ".data/blobs", BlobLocalDir)
+	assert.Equal(t, "http://localhost:8080", BlobPublicBaseURL)
+	assert.Equal(t, "gemini", MonsterAIProvider)
+	assert.Equal(t, "test-api-key", GeminiAPIKey)
+	assert.Equal(t, "gemini-2.5-flash", GeminiAnalysisModel)
+	assert.Equal(t, "gemini-3-pro-image-preview", GeminiImageModel)
+}
+
+func TestLoadEnv_fakeのAIを使う場合はGeminiのAPIキーは不要(t *testing.T) {
+	envVars := map[string]string{
+		"ENV":                 "test",
+		"MYSQL_USER":          "testuser",
+		"MYSQL_PASSWORD":      "testpass",
+		"MYSQL_DATABASE":      "testdb",
+		"MYSQL_HOST":          "localhost",
+		"MYSQL_PORT":          "3306",
+		"PORT":                "8080",
+		"MONSTER_AI_PROVIDER": "fake",
+	}
+	for key, value := range envVars {
+		os.Setenv(key, value)
+		defer os.Unsetenv(key)
+	}
+	os.Unsetenv("GEMINI_API_KEY")
+
+	require.NotPanics(t, func() {
+		LoadEnv(context.Background())
+	})
+	assert.Equal(t, "fake", MonsterAIProvider)
+	assert.Empty(t, GeminiAPIKey)
 }
 
 func TestLoadEnv_必須環境変数が不足している場合はパニックする(t *testing.T) {
@@ -281,3 +319,75 @@ func TestLoadEnv_必須環境変数が不足している場合はパニックす
 		LoadEnv(ctx)
 	})
 }
+
+func TestLoadEnv_本番環境では画像ストレージの設定が必須(t *testing.T) {
+	tests := []struct {
+		name      string
+		env       map[string]string
+		wantPanic bool
+	}{
+		{
+			name:      "保存先が設定されていない場合はパニックする",
+			env:       map[string]string{},
+			wantPanic: true,
+		},
+		{
+			name:      "localで署名鍵が設定されていない場合はパニックする",
+			env:       map[string]string{"BLOB_STORE": "local", "BLOB_PUBLIC_BASE_URL": "https://api.example.com"},
+			wantPanic: true,
+		},
+		{
+			name:      "localで公開URLが設定されていない場合はパニックする",
+			env:       map[string]string{"BLOB_STORE": "local", "BLOB_SIGNING_SECRET": "secret"},
+			wantPanic: true,
+		},
+		{
+			name: "localで署名鍵と公開URLが設定されている場合",
+			env:  map[string]string{"BLOB_STORE": "local", "BLOB_SIGNING_SECRET": "secret", "BLOB_PUBLIC_BASE_URL": "https://api.example.com"},
+		},
+		{
+			name: "gcsの場合は署名鍵と公開URLは不要",
+			env:  map[string]string{"BLOB_STORE": "gcs", "GCS_BUCKET_NAME": "bucket"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			envVars := map[string]string{
+				"ENV":                  "production",
+				"MYSQL_USER":           "testuser",
+				"MYSQL_PASSWORD":       "testpass",
+				"MYSQL_DATABASE":       "testdb",
+				"MYSQL_HOST":           "localhost",
+				"MYSQL_PORT":           "3306",
+				"PORT":                 "8080",
+				"MONSTER_AI_PROVIDER":  "fake",
+				"BLOB_STORE":           "",
+				"BLOB_SIGNING_SECRET":  "",
+				"BLOB_PUBLIC_BASE_URL": "",
+				"GCS_BUCKET_NAME":      "",
+			}
+			for key, value := range tt.env {
+				envVars[key] = value
+			}
+			for key, value := range envVars {
+				t.Setenv(key, value)
+			}
+
+			if tt.wantPanic {
+				assert.Panics(t, func() {
+					LoadEnv(context.Background())
+				})
+				return
+			}
+			require.NotPanics(t, func() {
+				LoadEnv(context.Background())
+			})
+			assert.Equal(t, tt.env["BLOB_STORE"], BlobStore)
+			if BlobStore == "local" {
+				assert.Equal(t, tt.env["BLOB_SIGNING_SECRET"], BlobSigningSecret)
+				assert.Equal(t, tt.env["BLOB_PUBLIC_BASE_URL"], BlobPublicBaseURL)
+			}
+		})
+	}
+}
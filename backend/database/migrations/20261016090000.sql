@@ -0,0 +1,14 @@
+-- Create "ApiKey" table
+CREATE TABLE `ApiKey` (
+  `ApiKeyId` varchar(36) NOT NULL COMMENT "APIキーID(UUID)",
+  `UserId` varchar(36) NOT NULL COMMENT "ユーザーID(UUID)",
+  `KeyHash` char(64) NOT NULL COMMENT "APIキーのハッシュ(SHA-256の16進数)",
+  `Scopes` varchar(255) NOT NULL DEFAULT "" COMMENT "許可するスコープ(スペース区切り)",
+  `ExpiresAt` datetime NULL COMMENT "有効期限(NULLの場合は無期限)",
+  `RevokedAt` datetime NULL COMMENT "無効化日時",
+  `CreatedAt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT "作成日時",
+  `UpdatedAt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT "更新日時",
+  PRIMARY KEY (`ApiKeyId`),
+  UNIQUE INDEX `idx_key_hash` (`KeyHash`),
+  INDEX `idx_user_id` (`UserId`)
+) CHARSET utf8mb4 COLLATE utf8mb4_bin COMMENT "APIキー";
@@ -0,0 +1,2 @@
+-- Modify "Monster" table
+ALTER TABLE `Monster` ADD COLUMN `UserId` varchar(36) NULL COMMENT "所有者のユーザーID(UUID、未ログインで作成した場合はNULL)" AFTER `MonsterId`, ADD INDEX `idx_user_id` (`UserId`);
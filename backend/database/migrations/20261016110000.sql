@@ -0,0 +1,22 @@
+-- Create "MonsterGenerationJob" table
+CREATE TABLE `MonsterGenerationJob` (
+  `JobId` varchar(36) NOT NULL COMMENT "ジョブID(UUID)",
+  `MonsterId` varchar(36) NOT NULL COMMENT "生成するモンスターID(UUID)",
+  `UserId` varchar(36) NULL COMMENT "ジョブを作成したユーザーID(UUID、未ログインで作成した場合はNULL)",
+  `Status` varchar(16) NOT NULL DEFAULT "queued" COMMENT "状態(queued, analyzing, generating, uploading, done, failed)",
+  `Attempts` int NOT NULL DEFAULT 0 COMMENT "実行回数",
+  `MaxAttempts` int NOT NULL COMMENT "最大実行回数(リトライを含む)",
+  `NextRunAt` datetime(3) NOT NULL COMMENT "次に実行できる日時",
+  `LeaseOwner` varchar(64) NULL COMMENT "実行中のワーカーID",
+  `LeaseExpiresAt` datetime(3) NULL COMMENT "リースの有効期限(過ぎた場合は他のワーカーが再取得する)",
+  `ErrorCode` varchar(64) NULL COMMENT "失敗理由のコード",
+  `ErrorMessage` varchar(1024) NULL COMMENT "失敗理由の詳細",
+  `InputImageKey` varchar(255) NOT NULL DEFAULT "" COMMENT "アップロードされたゴミ箱の画像のストレージのキー(完了後に削除)",
+  `InputMimeType` varchar(64) NOT NULL COMMENT "アップロードされたゴミ箱の画像のMIMEタイプ",
+  `CompletedAt` datetime NULL COMMENT "完了日時",
+  `CreatedAt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT "作成日時",
+  `UpdatedAt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT "更新日時",
+  PRIMARY KEY (`JobId`),
+  INDEX `idx_status_next_run_at` (`Status`, `NextRunAt`),
+  INDEX `idx_monster_id` (`MonsterId`)
+) CHARSET utf8mb4 COLLATE utf8mb4_bin COMMENT "モンスター生成ジョブ";
@@ -0,0 +1,2 @@
+-- Modify "MonsterGenerationJob" table
+ALTER TABLE `MonsterGenerationJob` ADD COLUMN `Nickname` varchar(50) NOT NULL DEFAULT "" COMMENT "生成するモンスターのニックネーム" AFTER `UserId`, ADD COLUMN `Latitude` decimal(10,8) NULL COMMENT "緯度(-90.0 ~ 90.0)" AFTER `Nickname`, ADD COLUMN `Longitude` decimal(11,8) NULL COMMENT "経度(-180.0 ~ 180.0)" AFTER `Latitude`;
@@ -0,0 +1,2 @@
+-- Modify "MonsterGenerationJob" table
+ALTER TABLE `MonsterGenerationJob` ADD COLUMN `GenerationInput` json NULL COMMENT "画像の生成に使った入力(モデル・プロンプト・正規化した画像のハッシュなど、再現用)" AFTER `InputMimeType`;
@@ -0,0 +1,10 @@
+-- Create "MonsterTrashAnalysis" table
+CREATE TABLE `MonsterTrashAnalysis` (
+  `MonsterId` varchar(36) NOT NULL COMMENT "モンスターID(UUID)",
+  `TrashType` varchar(50) NOT NULL COMMENT "判定したゴミ種別の名前(判定できない場合はunknown)",
+  `Confidence` double NOT NULL COMMENT "判定の確信度(0 ~ 1)",
+  `Result` json NOT NULL COMMENT "ゴミ箱の画像の分析結果(色・マーク・中身・判定理由・ゴミ箱の位置など)",
+  `CreatedAt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT "作成日時",
+  `UpdatedAt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT "更新日時",
+  PRIMARY KEY (`MonsterId`)
+) CHARSET utf8mb4 COLLATE utf8mb4_bin COMMENT "モンスターの元になったゴミ箱の画像の分析結果";
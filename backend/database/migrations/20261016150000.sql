@@ -0,0 +1,54 @@
+-- Fix "MonsterTrashCategory" rows saved with the legacy mapping (1:燃えるゴミ, 2:不燃ごみ, 3:缶・瓶, 4:ペットボトル, 5:紙, unknown:燃えるゴミ)
+-- Updating the rows in place can map two rows of a monster to the same category and violate "idx_monster_trash_unique",
+-- so the fixed rows are collected first, and the legacy rows are deleted and inserted again
+CREATE TEMPORARY TABLE `FixedMonsterTrashCategory` (
+  `MonsterId` varchar(36) NOT NULL,
+  `TrashCategory` TINYINT UNSIGNED NOT NULL,
+  `CreatedAt` datetime NOT NULL,
+  PRIMARY KEY (`MonsterId`, `TrashCategory`)
+);
+-- Monsters with a saved analysis get the single category of the analyzed trash type
+INSERT INTO `FixedMonsterTrashCategory` (`MonsterId`, `TrashCategory`, `CreatedAt`)
+SELECT `mtc`.`MonsterId`,
+  CASE `mta`.`TrashType`
+    WHEN '燃えるゴミ' THEN 1
+    WHEN '不燃ごみ' THEN 2
+    WHEN '缶' THEN 3
+    WHEN '瓶' THEN 4
+    WHEN 'ペットボトル' THEN 5
+    ELSE 0
+  END,
+  MIN(`mtc`.`CreatedAt`)
+FROM `MonsterTrashCategory` AS `mtc`
+  JOIN `MonsterTrashAnalysis` AS `mta` ON `mta`.`MonsterId` = `mtc`.`MonsterId`
+GROUP BY `mtc`.`MonsterId`, `mta`.`TrashType`;
+-- Monsters without an analysis: 4 (ペットボトル) becomes 5 and 5 (紙, no longer supported) becomes 0
+-- 3 (缶・瓶) cannot be told apart and is kept as 缶
+-- Rows where an unknown type fell back to 1 (燃えるゴミ) cannot be told apart from real 燃えるゴミ without an analysis and are kept as they are
+INSERT INTO `FixedMonsterTrashCategory` (`MonsterId`, `TrashCategory`, `CreatedAt`)
+SELECT `mtc`.`MonsterId`,
+  CASE `mtc`.`TrashCategory`
+    WHEN 4 THEN 5
+    ELSE 0
+  END,
+  MIN(`mtc`.`CreatedAt`)
+FROM `MonsterTrashCategory` AS `mtc`
+  LEFT JOIN `MonsterTrashAnalysis` AS `mta` ON `mta`.`MonsterId` = `mtc`.`MonsterId`
+WHERE `mta`.`MonsterId` IS NULL
+  AND `mtc`.`TrashCategory` IN (4, 5)
+GROUP BY `mtc`.`MonsterId`, `mtc`.`TrashCategory`;
+-- Delete the rows replaced above (every row of a monster with an analysis, and 4 and 5 of a monster without one)
+DELETE `mtc` FROM `MonsterTrashCategory` AS `mtc`
+  LEFT JOIN `MonsterTrashAnalysis` AS `mta` ON `mta`.`MonsterId` = `mtc`.`MonsterId`
+WHERE `mta`.`MonsterId` IS NOT NULL
+  OR `mtc`.`TrashCategory` IN (4, 5);
+-- A monster without an analysis may already have a row of the remapped category (e.g. 0 and 5), which is kept
+INSERT INTO `MonsterTrashCategory` (`MonsterTrashCategoryId`, `MonsterId`, `TrashCategory`, `CreatedAt`)
+SELECT UUID(), `fixed`.`MonsterId`, `fixed`.`TrashCategory`, `fixed`.`CreatedAt`
+FROM `FixedMonsterTrashCategory` AS `fixed`
+WHERE NOT EXISTS (
+  SELECT 1 FROM `MonsterTrashCategory` AS `mtc`
+  WHERE `mtc`.`MonsterId` = `fixed`.`MonsterId`
+    AND `mtc`.`TrashCategory` = `fixed`.`TrashCategory`
+);
+DROP TEMPORARY TABLE `FixedMonsterTrashCategory`;
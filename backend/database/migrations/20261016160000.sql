@@ -0,0 +1,25 @@
+-- Backfill "MonsterAttribute" for monsters generated before attributes were saved
+-- The attribute and color come from the trash category because the dominant color of the generated image cannot be extracted in SQL
+INSERT INTO `MonsterAttribute` (`MonsterId`, `AttributeName`, `ColorCode`)
+SELECT `m`.`MonsterId`,
+  CASE COALESCE(MIN(`mtc`.`TrashCategory`), 0)
+    WHEN 1 THEN '炎'
+    WHEN 2 THEN '闇'
+    WHEN 3 THEN '光'
+    WHEN 4 THEN '光'
+    WHEN 5 THEN '水'
+    ELSE '無'
+  END,
+  CASE COALESCE(MIN(`mtc`.`TrashCategory`), 0)
+    WHEN 1 THEN '#E53935'
+    WHEN 2 THEN '#7B1FA2'
+    WHEN 3 THEN '#FDD835'
+    WHEN 4 THEN '#FDD835'
+    WHEN 5 THEN '#1E88E5'
+    ELSE '#9E9E9E'
+  END
+FROM `Monster` AS `m`
+  LEFT JOIN `MonsterTrashCategory` AS `mtc` ON `mtc`.`MonsterId` = `m`.`MonsterId`
+  LEFT JOIN `MonsterAttribute` AS `ma` ON `ma`.`MonsterId` = `m`.`MonsterId`
+WHERE `ma`.`MonsterId` IS NULL
+GROUP BY `m`.`MonsterId`;
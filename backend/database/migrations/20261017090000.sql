@@ -0,0 +1,23 @@
+-- Create "MonsterProfile" table
+CREATE TABLE `MonsterProfile` (
+  `MonsterId` varchar(36) NOT NULL COMMENT "モンスターID(UUID)",
+  `SpeciesName` varchar(50) NOT NULL COMMENT "種族名",
+  `Description` varchar(500) NOT NULL COMMENT "図鑑の説明文",
+  `Provider` varchar(50) NOT NULL COMMENT "種族名・説明文を生成したプロバイダー(生成に失敗した場合はfallback)",
+  `Model` varchar(100) NOT NULL DEFAULT "" COMMENT "種族名・説明文の生成に使ったモデル",
+  `CreatedAt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT "作成日時",
+  `UpdatedAt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT "更新日時",
+  PRIMARY KEY (`MonsterId`)
+) CHARSET utf8mb4 COLLATE utf8mb4_bin COMMENT "モンスターの種族名と図鑑の説明文";
+-- Create "MonsterStats" table
+CREATE TABLE `MonsterStats` (
+  `MonsterId` varchar(36) NOT NULL COMMENT "モンスターID(UUID)",
+  `Hp` smallint unsigned NOT NULL COMMENT "HP",
+  `Attack` smallint unsigned NOT NULL COMMENT "こうげき",
+  `Defense` smallint unsigned NOT NULL COMMENT "ぼうぎょ",
+  `Speed` smallint unsigned NOT NULL COMMENT "すばやさ",
+  `Seed` bigint unsigned NOT NULL COMMENT "能力値の計算に使ったシード(同じ分析結果とシードから同じ能力値を計算できる)",
+  `CreatedAt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT "作成日時",
+  `UpdatedAt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT "更新日時",
+  PRIMARY KEY (`MonsterId`)
+) CHARSET utf8mb4 COLLATE utf8mb4_bin COMMENT "モンスターの能力値";
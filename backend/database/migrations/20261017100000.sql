@@ -0,0 +1,2 @@
+-- Modify "MonsterGenerationJob" table
+ALTER TABLE `MonsterGenerationJob` ADD COLUMN `QuotaKey` varchar(255) NULL COMMENT "ジョブの作成時に数えた1日の利用上限のキー(失敗した場合に戻す)" AFTER `Longitude`;
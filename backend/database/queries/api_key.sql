@@ -0,0 +1,17 @@
+-- name: GetApiKeyByHash :one
+SELECT * FROM ApiKey
+WHERE KeyHash = ? AND RevokedAt IS NULL LIMIT 1;
+
+-- name: ListApiKeysByUserId :many
+SELECT * FROM ApiKey
+WHERE UserId = ?
+ORDER BY CreatedAt DESC;
+
+-- name: CreateApiKey :execresult
+INSERT INTO ApiKey (ApiKeyId, UserId, KeyHash, Scopes, ExpiresAt)
+VALUES (?, ?, ?, ?, ?);
+
+-- name: RevokeApiKey :exec
+UPDATE ApiKey
+SET RevokedAt = CURRENT_TIMESTAMP
+WHERE ApiKeyId = ? AND RevokedAt IS NULL;
@@ -2,51 +2,72 @@
 SELECT * FROM Monster
 WHERE MonsterId = ? LIMIT 1;
 
--- name: GetMonsterDetail :one
-SELECT
-    m.MonsterId,
-    m.Nickname,
-    m.OriginalTrashBinImageUrl,
-    m.GeneratedMonsterImageUrl,
-    m.Latitude,
-    m.Longitude,
-    m.CreatedAt,
-    m.UpdatedAt,
-    ma.AttributeName,
-    ma.ColorCode
-FROM Monster m
-LEFT JOIN MonsterAttribute ma ON m.MonsterId = ma.MonsterId
-WHERE m.MonsterId = ? LIMIT 1;
-
 -- name: ListMonsters :many
 SELECT * FROM Monster
 ORDER BY CreatedAt DESC;
 
--- name: ListMonstersWithAttribute :many
-SELECT
-    m.MonsterId,
-    m.Nickname,
-    m.OriginalTrashBinImageUrl,
-    m.GeneratedMonsterImageUrl,
-    m.Latitude,
-    m.Longitude,
-    m.CreatedAt,
-    m.UpdatedAt,
-    ma.AttributeName,
-    ma.ColorCode
-FROM Monster m
-LEFT JOIN MonsterAttribute ma ON m.MonsterId = ma.MonsterId
-ORDER BY m.CreatedAt DESC;
+-- name: ListMonstersByUserId :many
+SELECT * FROM Monster
+WHERE UserId = ?
+ORDER BY CreatedAt DESC;
+
+-- name: ListMonstersInBounds :many
+SELECT * FROM Monster
+WHERE Latitude BETWEEN sqlc.arg(min_latitude) AND sqlc.arg(max_latitude)
+  AND Longitude BETWEEN sqlc.arg(min_longitude) AND sqlc.arg(max_longitude)
+ORDER BY CreatedAt DESC
+LIMIT sqlc.arg(max_rows);
+
+-- name: ListMonstersInBoundsByTrashCategory :many
+SELECT m.* FROM Monster m
+JOIN MonsterTrashCategory c ON c.MonsterId = m.MonsterId
+WHERE m.Latitude BETWEEN sqlc.arg(min_latitude) AND sqlc.arg(max_latitude)
+  AND m.Longitude BETWEEN sqlc.arg(min_longitude) AND sqlc.arg(max_longitude)
+  AND c.TrashCategory = sqlc.arg(trash_category)
+ORDER BY m.CreatedAt DESC
+LIMIT sqlc.arg(max_rows);
+
+-- name: ListMonstersNearby :many
+SELECT * FROM Monster
+WHERE Latitude BETWEEN sqlc.arg(min_latitude) AND sqlc.arg(max_latitude)
+  AND Longitude BETWEEN sqlc.arg(min_longitude) AND sqlc.arg(max_longitude)
+ORDER BY ST_Distance_Sphere(POINT(Longitude, Latitude), POINT(sqlc.arg(center_longitude), sqlc.arg(center_latitude)))
+LIMIT sqlc.arg(max_rows);
+
+-- name: ListMonstersNearbyByTrashCategory :many
+SELECT m.* FROM Monster m
+JOIN MonsterTrashCategory c ON c.MonsterId = m.MonsterId
+WHERE m.Latitude BETWEEN sqlc.arg(min_latitude) AND sqlc.arg(max_latitude)
+  AND m.Longitude BETWEEN sqlc.arg(min_longitude) AND sqlc.arg(max_longitude)
+  AND c.TrashCategory = sqlc.arg(trash_category)
+ORDER BY ST_Distance_Sphere(POINT(m.Longitude, m.Latitude), POINT(sqlc.arg(center_longitude), sqlc.arg(center_latitude)))
+LIMIT sqlc.arg(max_rows);
+
+-- name: ListIncompleteMonsters :many
+SELECT * FROM Monster
+WHERE CreatedAt < ?
+  AND (GeneratedMonsterImageUrl = '' OR OriginalTrashBinImageUrl = ''
+    OR NOT EXISTS (SELECT 1 FROM MonsterTrashCategory c WHERE c.MonsterId = Monster.MonsterId))
+  AND NOT EXISTS (
+    SELECT 1 FROM MonsterGenerationJob j
+    WHERE j.MonsterId = Monster.MonsterId AND j.Status NOT IN ('done', 'failed')
+  )
+ORDER BY CreatedAt;
 
 -- name: CreateMonster :execresult
-INSERT INTO Monster (MonsterId, Nickname, OriginalTrashBinImageUrl, GeneratedMonsterImageUrl, Latitude, Longitude)
-VALUES (?, ?, ?, ?, ?, ?);
+INSERT INTO Monster (MonsterId, UserId, Nickname, OriginalTrashBinImageUrl, GeneratedMonsterImageUrl, Latitude, Longitude)
+VALUES (?, ?, ?, ?, ?, ?, ?);
 
 -- name: UpdateMonster :execresult
 UPDATE Monster
 SET Nickname = ?, OriginalTrashBinImageUrl = ?, GeneratedMonsterImageUrl = ?, Latitude = ?, Longitude = ?
 WHERE MonsterId = ?;
 
+-- name: UpdateMonsterNickname :execresult
+UPDATE Monster
+SET Nickname = ?
+WHERE MonsterId = ?;
+
 -- name: DeleteMonster :exec
 DELETE FROM Monster
 WHERE MonsterId = ?;
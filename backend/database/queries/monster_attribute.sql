@@ -2,6 +2,11 @@
 SELECT * FROM MonsterAttribute
 WHERE MonsterId = ? LIMIT 1;
 
+-- name: ListMonsterAttributesByMonsterIds :many
+SELECT * FROM MonsterAttribute
+WHERE MonsterId IN (sqlc.slice(monster_ids))
+ORDER BY MonsterId;
+
 -- name: CreateMonsterAttribute :execresult
 INSERT INTO MonsterAttribute (MonsterId, AttributeName, ColorCode)
 VALUES (?, ?, ?);
@@ -0,0 +1,76 @@
+-- name: CreateMonsterGenerationJob :execresult
+INSERT INTO MonsterGenerationJob (JobId, MonsterId, UserId, Nickname, Latitude, Longitude, QuotaKey, Status, MaxAttempts, NextRunAt, InputImageKey, InputMimeType)
+VALUES (?, ?, ?, ?, ?, ?, ?, 'queued', ?, ?, ?, ?);
+
+-- name: GetMonsterGenerationJob :one
+SELECT * FROM MonsterGenerationJob
+WHERE JobId = ? LIMIT 1;
+
+-- name: GetMonsterGenerationJobStatus :one
+SELECT JobId, MonsterId, UserId, Status, Attempts, MaxAttempts, ErrorCode, ErrorMessage, CompletedAt, CreatedAt, UpdatedAt
+FROM MonsterGenerationJob
+WHERE JobId = ? LIMIT 1;
+
+-- name: ListRunnableMonsterGenerationJobIds :many
+SELECT JobId FROM MonsterGenerationJob
+WHERE (Status = 'queued' AND NextRunAt <= sqlc.arg(now))
+   OR (Status IN ('analyzing', 'generating', 'uploading') AND LeaseExpiresAt <= sqlc.arg(now) AND Attempts < MaxAttempts)
+ORDER BY NextRunAt
+LIMIT sqlc.arg(max_jobs);
+
+-- name: ClaimMonsterGenerationJob :execresult
+UPDATE MonsterGenerationJob
+SET Status = 'analyzing', Attempts = Attempts + 1, LeaseOwner = sqlc.arg(lease_owner), LeaseExpiresAt = sqlc.arg(lease_expires_at)
+WHERE JobId = sqlc.arg(job_id)
+  AND ((Status = 'queued' AND NextRunAt <= sqlc.arg(now))
+    OR (Status IN ('analyzing', 'generating', 'uploading') AND LeaseExpiresAt <= sqlc.arg(now) AND Attempts < MaxAttempts));
+
+-- name: HeartbeatMonsterGenerationJob :execresult
+UPDATE MonsterGenerationJob
+SET LeaseExpiresAt = ?
+WHERE JobId = ? AND LeaseOwner = ?;
+
+-- name: UpdateMonsterGenerationJobStatus :execresult
+UPDATE MonsterGenerationJob
+SET Status = ?, LeaseExpiresAt = ?
+WHERE JobId = ? AND LeaseOwner = ?;
+
+-- name: UpdateMonsterGenerationJobGenerationInput :execresult
+UPDATE MonsterGenerationJob
+SET GenerationInput = ?
+WHERE JobId = ?;
+
+-- name: CompleteMonsterGenerationJob :execresult
+UPDATE MonsterGenerationJob
+SET Status = 'done', LeaseOwner = NULL, LeaseExpiresAt = NULL, ErrorCode = NULL, ErrorMessage = NULL, CompletedAt = ?
+WHERE JobId = ? AND LeaseOwner = ?;
+
+-- name: RetryMonsterGenerationJob :execresult
+UPDATE MonsterGenerationJob
+SET Status = 'queued', NextRunAt = ?, ErrorCode = ?, ErrorMessage = ?, LeaseOwner = NULL, LeaseExpiresAt = NULL
+WHERE JobId = ? AND LeaseOwner = ?;
+
+-- name: FailMonsterGenerationJob :execresult
+UPDATE MonsterGenerationJob
+SET Status = 'failed', ErrorCode = ?, ErrorMessage = ?, LeaseOwner = NULL, LeaseExpiresAt = NULL, CompletedAt = ?
+WHERE JobId = ? AND LeaseOwner = ?;
+
+-- name: ListExpiredMonsterGenerationJobs :many
+SELECT JobId, MonsterId, QuotaKey, InputImageKey FROM MonsterGenerationJob
+WHERE Status IN ('analyzing', 'generating', 'uploading') AND LeaseExpiresAt <= sqlc.arg(now) AND Attempts >= MaxAttempts
+ORDER BY LeaseExpiresAt
+LIMIT sqlc.arg(max_jobs);
+
+-- name: FailExpiredMonsterGenerationJob :execresult
+UPDATE MonsterGenerationJob
+SET Status = 'failed', ErrorCode = 'LEASE_EXPIRED', ErrorMessage = 'worker lease expired after the last attempt', LeaseOwner = NULL, LeaseExpiresAt = NULL, CompletedAt = sqlc.arg(now)
+WHERE JobId = sqlc.arg(job_id) AND Status IN ('analyzing', 'generating', 'uploading') AND LeaseExpiresAt <= sqlc.arg(now) AND Attempts >= MaxAttempts;
+
+-- name: CountActiveMonsterGenerationJobs :one
+SELECT COUNT(*) FROM MonsterGenerationJob
+WHERE MonsterId = ? AND Status NOT IN ('done', 'failed')
+FOR UPDATE;
+
+-- name: DeleteMonsterGenerationJobsByMonsterId :exec
+DELETE FROM MonsterGenerationJob
+WHERE MonsterId = ?;
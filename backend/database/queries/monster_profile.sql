@@ -0,0 +1,17 @@
+-- name: GetMonsterProfile :one
+SELECT * FROM MonsterProfile
+WHERE MonsterId = ? LIMIT 1;
+
+-- name: ListMonsterProfilesByMonsterIds :many
+SELECT * FROM MonsterProfile
+WHERE MonsterId IN (sqlc.slice(monster_ids))
+ORDER BY MonsterId;
+
+-- name: UpsertMonsterProfile :exec
+INSERT INTO MonsterProfile (MonsterId, SpeciesName, Description, Provider, Model)
+VALUES (?, ?, ?, ?, ?)
+ON DUPLICATE KEY UPDATE SpeciesName = VALUES(SpeciesName), Description = VALUES(Description), Provider = VALUES(Provider), Model = VALUES(Model);
+
+-- name: DeleteMonsterProfile :exec
+DELETE FROM MonsterProfile
+WHERE MonsterId = ?;
@@ -0,0 +1,17 @@
+-- name: GetMonsterStats :one
+SELECT * FROM MonsterStats
+WHERE MonsterId = ? LIMIT 1;
+
+-- name: ListMonsterStatsByMonsterIds :many
+SELECT * FROM MonsterStats
+WHERE MonsterId IN (sqlc.slice(monster_ids))
+ORDER BY MonsterId;
+
+-- name: UpsertMonsterStats :exec
+INSERT INTO MonsterStats (MonsterId, Hp, Attack, Defense, Speed, Seed)
+VALUES (?, ?, ?, ?, ?, ?)
+ON DUPLICATE KEY UPDATE Hp = VALUES(Hp), Attack = VALUES(Attack), Defense = VALUES(Defense), Speed = VALUES(Speed), Seed = VALUES(Seed);
+
+-- name: DeleteMonsterStats :exec
+DELETE FROM MonsterStats
+WHERE MonsterId = ?;
@@ -0,0 +1,12 @@
+-- name: GetMonsterTrashAnalysis :one
+SELECT * FROM MonsterTrashAnalysis
+WHERE MonsterId = ? LIMIT 1;
+
+-- name: UpsertMonsterTrashAnalysis :exec
+INSERT INTO MonsterTrashAnalysis (MonsterId, TrashType, Confidence, Result)
+VALUES (?, ?, ?, ?)
+ON DUPLICATE KEY UPDATE TrashType = VALUES(TrashType), Confidence = VALUES(Confidence), Result = VALUES(Result);
+
+-- name: DeleteMonsterTrashAnalysis :exec
+DELETE FROM MonsterTrashAnalysis
+WHERE MonsterId = ?;
@@ -7,6 +7,11 @@ SELECT * FROM MonsterTrashCategory
 WHERE MonsterId = ?
 ORDER BY TrashCategory;
 
+-- name: ListMonsterTrashCategoriesByMonsterIds :many
+SELECT * FROM MonsterTrashCategory
+WHERE MonsterId IN (sqlc.slice(monster_ids))
+ORDER BY MonsterId, TrashCategory;
+
 -- name: ListMonstersByTrashCategory :many
 SELECT * FROM MonsterTrashCategory
 WHERE TrashCategory = ?
@@ -0,0 +1,13 @@
+CREATE TABLE `ApiKey` (
+    `ApiKeyId` varchar(36) NOT NULL comment 'APIキーID(UUID)',
+    `UserId` varchar(36) NOT NULL comment 'ユーザーID(UUID)',
+    `KeyHash` char(64) NOT NULL comment 'APIキーのハッシュ(SHA-256の16進数)',
+    `Scopes` varchar(255) NOT NULL default '' comment '許可するスコープ(スペース区切り)',
+    `ExpiresAt` datetime NULL comment '有効期限(NULLの場合は無期限)',
+    `RevokedAt` datetime NULL comment '無効化日時',
+    `CreatedAt` datetime NOT NULL default CURRENT_TIMESTAMP comment '作成日時',
+    `UpdatedAt` datetime NOT NULL default CURRENT_TIMESTAMP on update CURRENT_TIMESTAMP comment '更新日時',
+    PRIMARY KEY (`ApiKeyId`),
+    UNIQUE INDEX `idx_key_hash` (`KeyHash`),
+    INDEX `idx_user_id` (`UserId`)
+) DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin COMMENT 'APIキー';
@@ -1,5 +1,6 @@
 CREATE TABLE `Monster` (
     `MonsterId` varchar(36) NOT NULL comment 'モンスターID(UUID)',
+    `UserId` varchar(36) NULL comment '所有者のユーザーID(UUID、未ログインで作成した場合はNULL)',
     `Nickname` varchar(50) NOT NULL comment 'ニックネーム',
     `OriginalTrashBinImageUrl` TEXT NOT NULL comment '元のゴミ箱の画像URL',
     `GeneratedMonsterImageUrl` TEXT NOT NULL comment '生成したモンスターの画像URL',
@@ -8,5 +9,6 @@ CREATE TABLE `Monster` (
     `CreatedAt` datetime NOT NULL default CURRENT_TIMESTAMP comment '作成日時',
     `UpdatedAt` datetime NOT NULL default CURRENT_TIMESTAMP on update CURRENT_TIMESTAMP comment '更新日時',
     PRIMARY KEY (`MonsterId`),
-    INDEX `idx_location` (`Latitude`, `Longitude`)
+    INDEX `idx_location` (`Latitude`, `Longitude`),
+    INDEX `idx_user_id` (`UserId`)
 ) DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin COMMENT 'モンスターの基本情報';
@@ -0,0 +1,26 @@
+CREATE TABLE `MonsterGenerationJob` (
+    `JobId` varchar(36) NOT NULL comment 'ジョブID(UUID)',
+    `MonsterId` varchar(36) NOT NULL comment '生成するモンスターID(UUID)',
+    `UserId` varchar(36) NULL comment 'ジョブを作成したユーザーID(UUID、未ログインで作成した場合はNULL)',
+    `Nickname` varchar(50) NOT NULL default '' comment '生成するモンスターのニックネーム',
+    `Latitude` DECIMAL(10, 8) NULL comment '緯度(-90.0 ~ 90.0)',
+    `Longitude` DECIMAL(11, 8) NULL comment '経度(-180.0 ~ 180.0)',
+    `QuotaKey` varchar(255) NULL comment 'ジョブの作成時に数えた1日の利用上限のキー(失敗した場合に戻す)',
+    `Status` varchar(16) NOT NULL default 'queued' comment '状態(queued, analyzing, generating, uploading, done, failed)',
+    `Attempts` int NOT NULL default 0 comment '実行回数',
+    `MaxAttempts` int NOT NULL comment '最大実行回数(リトライを含む)',
+    `NextRunAt` datetime(3) NOT NULL comment '次に実行できる日時',
+    `LeaseOwner` varchar(64) NULL comment '実行中のワーカーID',
+    `LeaseExpiresAt` datetime(3) NULL comment 'リースの有効期限(過ぎた場合は他のワーカーが再取得する)',
+    `ErrorCode` varchar(64) NULL comment '失敗理由のコード',
+    `ErrorMessage` varchar(1024) NULL comment '失敗理由の詳細',
+    `InputImageKey` varchar(255) NOT NULL default '' comment 'アップロードされたゴミ箱の画像のストレージのキー(完了後に削除)',
+    `InputMimeType` varchar(64) NOT NULL comment 'アップロードされたゴミ箱の画像のMIMEタイプ',
+    `GenerationInput` JSON NULL comment '画像の生成に使った入力(モデル・プロンプト・正規化した画像のハッシュなど、再現用)',
+    `CompletedAt` datetime NULL comment '完了日時',
+    `CreatedAt` datetime NOT NULL default CURRENT_TIMESTAMP comment '作成日時',
+    `UpdatedAt` datetime NOT NULL default CURRENT_TIMESTAMP on update CURRENT_TIMESTAMP comment '更新日時',
+    PRIMARY KEY (`JobId`),
+    INDEX `idx_status_next_run_at` (`Status`, `NextRunAt`),
+    INDEX `idx_monster_id` (`MonsterId`)
+) DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin COMMENT 'モンスター生成ジョブ';
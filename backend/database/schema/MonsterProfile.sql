@@ -0,0 +1,10 @@
+CREATE TABLE `MonsterProfile` (
+    `MonsterId` varchar(36) NOT NULL comment 'モンスターID(UUID)',
+    `SpeciesName` varchar(50) NOT NULL comment '種族名',
+    `Description` varchar(500) NOT NULL comment '図鑑の説明文',
+    `Provider` varchar(50) NOT NULL comment '種族名・説明文を生成したプロバイダー(生成に失敗した場合はfallback)',
+    `Model` varchar(100) NOT NULL default '' comment '種族名・説明文の生成に使ったモデル',
+    `CreatedAt` datetime NOT NULL default CURRENT_TIMESTAMP comment '作成日時',
+    `UpdatedAt` datetime NOT NULL default CURRENT_TIMESTAMP on update CURRENT_TIMESTAMP comment '更新日時',
+    PRIMARY KEY (`MonsterId`)
+) DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin COMMENT 'モンスターの種族名と図鑑の説明文';
@@ -0,0 +1,11 @@
+CREATE TABLE `MonsterStats` (
+    `MonsterId` varchar(36) NOT NULL comment 'モンスターID(UUID)',
+    `Hp` SMALLINT UNSIGNED NOT NULL comment 'HP',
+    `Attack` SMALLINT UNSIGNED NOT NULL comment 'こうげき',
+    `Defense` SMALLINT UNSIGNED NOT NULL comment 'ぼうぎょ',
+    `Speed` SMALLINT UNSIGNED NOT NULL comment 'すばやさ',
+    `Seed` BIGINT UNSIGNED NOT NULL comment '能力値の計算に使ったシード(同じ分析結果とシードから同じ能力値を計算できる)',
+    `CreatedAt` datetime NOT NULL default CURRENT_TIMESTAMP comment '作成日時',
+    `UpdatedAt` datetime NOT NULL default CURRENT_TIMESTAMP on update CURRENT_TIMESTAMP comment '更新日時',
+    PRIMARY KEY (`MonsterId`)
+) DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin COMMENT 'モンスターの能力値';
@@ -0,0 +1,9 @@
+CREATE TABLE `MonsterTrashAnalysis` (
+    `MonsterId` varchar(36) NOT NULL comment 'モンスターID(UUID)',
+    `TrashType` varchar(50) NOT NULL comment '判定したゴミ種別の名前(判定できない場合はunknown)',
+    `Confidence` DOUBLE NOT NULL comment '判定の確信度(0 ~ 1)',
+    `Result` JSON NOT NULL comment 'ゴミ箱の画像の分析結果(色・マーク・中身・判定理由・ゴミ箱の位置など)',
+    `CreatedAt` datetime NOT NULL default CURRENT_TIMESTAMP comment '作成日時',
+    `UpdatedAt` datetime NOT NULL default CURRENT_TIMESTAMP on update CURRENT_TIMESTAMP comment '更新日時',
+    PRIMARY KEY (`MonsterId`)
+) DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin COMMENT 'モンスターの元になったゴミ箱の画像の分析結果';
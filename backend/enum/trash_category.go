@@ -1,5 +1,10 @@
 package enum
 
+import (
+	"slices"
+	"strings"
+)
+
 type TrashCategory uint8
 
 const (
@@ -17,22 +22,173 @@ const (
 	TrashCategoryPetBottle
 )
 
-// trashTypeStringToUint8 はゴミ種別の文字列をuint8に変換します
-// "燃えるゴミ" -> 1, "不燃ごみ" -> 2, "缶・瓶" -> 3, "ペットボトル" -> 4, "紙" -> 5, その他 -> 1 (デフォルト)
-func StringToTrashCategoryEnum(trashType string) TrashCategory {
-	switch trashType {
-	case "燃えるゴミ":
-		return 1
-	case "不燃ごみ":
-		return 2
-	case "缶・瓶", "缶", "瓶":
-		return 3
-	case "ペットボトル":
-		return 4
-	case "紙":
-		return 5
-	default:
-		// 不明な場合はデフォルトで燃えるゴミとする
-		return 1
+// TrashCategoryInfo はゴミ種別の定義です
+type TrashCategoryInfo struct {
+	// ID はDBに保存する値です（MonsterTrashCategory.TrashCategory）
+	ID TrashCategory
+	// Name は正式な日本語の名前です（APIのレスポンス・AIの分析結果の trash_type に使います）
+	Name string
+	// Slug は英語の識別子です
+	Slug string
+	// Aliases は Name 以外で同じゴミ種別を表す表記です（表記ゆれを吸収します）
+	Aliases []string
+	// Attribute はゴミ種別から生まれるモンスターの属性です（ゴミ種別を判定できない場合は指定なしの属性を使います）
+	Attribute string
+	// ColorName・ColorCode はモンスターの主な色です
+	ColorName string
+	ColorCode string
+}
+
+// trashCategoryRegistry はゴミ種別の一覧です（ID順）
+// DBの値との変換・プロンプト・APIのレスポンスはすべてこの一覧から作成するため、ゴミ種別の追加・変更はここだけで行います
+var trashCategoryRegistry = []TrashCategoryInfo{
+	{
+		ID:        TrashCategoryNone,
+		Name:      "指定なし",
+		Slug:      "none",
+		Attribute: "無",
+	},
+	{
+		ID:        TrashCategoryBurnable,
+		Name:      "燃えるゴミ",
+		Slug:      "burnable",
+		Aliases:   []string{"燃えるごみ", "燃やすごみ", "燃やすゴミ", "可燃ごみ", "可燃ゴミ"},
+		Attribute: "炎",
+		ColorName: "赤",
+		ColorCode: "#E53935",
+	},
+	{
+		ID:        TrashCategoryNonBurnable,
+		Name:      "不燃ごみ",
+		Slug:      "non_burnable",
+		Aliases:   []string{"不燃ゴミ", "燃えないごみ", "燃えないゴミ", "燃やさないごみ", "燃やさないゴミ"},
+		Attribute: "闇",
+		ColorName: "紫",
+		ColorCode: "#7B1FA2",
+	},
+	{
+		ID:        TrashCategoryCan,
+		Name:      "缶",
+		Slug:      "can",
+		Aliases:   []string{"かん", "カン", "空き缶", "缶ごみ"},
+		Attribute: "光",
+		ColorName: "黄",
+		ColorCode: "#FDD835",
+	},
+	{
+		ID:        TrashCategoryGlassBottle,
+		Name:      "瓶",
+		Slug:      "glass_bottle",
+		Aliases:   []string{"びん", "ビン", "空きびん", "空き瓶"},
+		Attribute: "光",
+		ColorName: "黄",
+		ColorCode: "#FDD835",
+	},
+	{
+		ID:        TrashCategoryPetBottle,
+		Name:      "ペットボトル",
+		Slug:      "pet_bottle",
+		Aliases:   []string{"ペット", "PETボトル", "PET"},
+		Attribute: "水",
+		ColorName: "青",
+		ColorCode: "#1E88E5",
+	},
+}
+
+// UnknownTrashCategoryName は登録されていないIDの名前です
+const UnknownTrashCategoryName = "不明"
+
+// Info はゴミ種別の定義を返します（登録されていないIDの場合は false を返します）
+func (c TrashCategory) Info() (TrashCategoryInfo, bool) {
+	if int(c) >= len(trashCategoryRegistry) {
+		return TrashCategoryInfo{}, false
 	}
+	return trashCategoryRegistry[c], true
+}
+
+// String はゴミ種別の名前を返します（登録されていないIDの場合は UnknownTrashCategoryName を返します）
+func (c TrashCategory) String() string {
+	info, ok := c.Info()
+	if !ok {
+		return UnknownTrashCategoryName
+	}
+	return info.Name
+}
+
+// Slug はゴミ種別の英語の識別子を返します（登録されていないIDの場合は空文字を返します）
+func (c TrashCategory) Slug() string {
+	info, _ := c.Info()
+	return info.Slug
+}
+
+// TrashCategories は指定なしを除くゴミ種別の定義をID順に返します
+func TrashCategories() []TrashCategoryInfo {
+	return slices.Clone(trashCategoryRegistry[TrashCategoryBurnable:])
+}
+
+// TrashCategoryNames は指定なしを除くゴミ種別の名前をID順に返します
+func TrashCategoryNames() []string {
+	names := make([]string, 0, len(trashCategoryRegistry)-1)
+	for _, info := range TrashCategories() {
+		names = append(names, info.Name)
+	}
+	return names
+}
+
+// TrashCategoryName はAPIで使うゴミ種別の名前です（指定なしを含みます）
+// EnumValues がとりうる値を返すため、APIのバリデーションとクライアントの型はゴミ種別の一覧から作られます
+type TrashCategoryName string
+
+// EnumValues は指定なしを含むゴミ種別の名前をID順に返します
+func (TrashCategoryName) EnumValues() []string {
+	values := make([]string, 0, len(trashCategoryRegistry))
+	for _, info := range trashCategoryRegistry {
+		values = append(values, info.Name)
+	}
+	return values
+}
+
+// TrashCategorySlug はAPIで使うゴミ種別の英語の識別子です（指定なしを含みます）
+type TrashCategorySlug string
+
+// EnumValues は指定なしを含むゴミ種別の英語の識別子をID順に返します
+func (TrashCategorySlug) EnumValues() []string {
+	values := make([]string, 0, len(trashCategoryRegistry))
+	for _, info := range trashCategoryRegistry {
+		values = append(values, info.Slug)
+	}
+	return values
+}
+
+// TrashCategoryFilterSlug はゴミ種別で絞り込む場合の英語の識別子です（指定なしは含みません）
+type TrashCategoryFilterSlug string
+
+// EnumValues は指定なしを除くゴミ種別の英語の識別子をID順に返します
+func (TrashCategoryFilterSlug) EnumValues() []string {
+	values := make([]string, 0, len(trashCategoryRegistry)-1)
+	for _, info := range TrashCategories() {
+		values = append(values, info.Slug)
+	}
+	return values
+}
+
+// ParseTrashCategory は名前・英語の識別子・別名からゴミ種別を返します（一致しない場合は false を返します）
+func ParseTrashCategory(s string) (TrashCategory, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return TrashCategoryNone, false
+	}
+	for _, info := range trashCategoryRegistry {
+		if s == info.Name || s == info.Slug || slices.Contains(info.Aliases, s) {
+			return info.ID, true
+		}
+	}
+	return TrashCategoryNone, false
+}
+
+// StringToTrashCategoryEnum はゴミ種別の文字列（AIの分析結果の trash_type など）をゴミ種別に変換します
+// 一致しない場合（"unknown" など）は TrashCategoryNone を返します
+func StringToTrashCategoryEnum(trashType string) TrashCategory {
+	c, _ := ParseTrashCategory(trashType)
+	return c
 }
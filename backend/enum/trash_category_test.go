@@ -0,0 +1,62 @@
+package enum
+
+import "testing"
+
+func TestTrashCategoryRegistry(t *testing.T) {
+	seen := map[string]TrashCategory{}
+	for i, info := range trashCategoryRegistry {
+		if info.ID != TrashCategory(i) {
+			t.Errorf("registry[%d].ID = %d, want %d", i, info.ID, i)
+		}
+		if info.ID != TrashCategoryNone && (info.Attribute == "" || info.ColorCode == "") {
+			t.Errorf("%s: attribute and color code are required", info.Name)
+		}
+		for _, s := range append([]string{info.Name, info.Slug}, info.Aliases...) {
+			if other, ok := seen[s]; ok {
+				t.Errorf("%q is used by both %s and %s", s, other, info.ID)
+			}
+			seen[s] = info.ID
+		}
+	}
+}
+
+func TestParseTrashCategory(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		want   TrashCategory
+		wantOK bool
+	}{
+		{name: "名前", input: "ペットボトル", want: TrashCategoryPetBottle, wantOK: true},
+		{name: "英語の識別子", input: "glass_bottle", want: TrashCategoryGlassBottle, wantOK: true},
+		{name: "別名", input: "可燃ごみ", want: TrashCategoryBurnable, wantOK: true},
+		{name: "前後の空白は無視する", input: " 缶 ", want: TrashCategoryCan, wantOK: true},
+		{name: "指定なし", input: "指定なし", want: TrashCategoryNone, wantOK: true},
+		{name: "判定できない場合", input: "unknown", want: TrashCategoryNone},
+		{name: "廃止したゴミ種別", input: "紙", want: TrashCategoryNone},
+		{name: "空文字", input: "", want: TrashCategoryNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseTrashCategory(tt.input)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("ParseTrashCategory(%q) = (%v, %v), want (%v, %v)", tt.input, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestTrashCategory_String(t *testing.T) {
+	for _, info := range TrashCategories() {
+		if got, ok := ParseTrashCategory(info.ID.String()); !ok || got != info.ID {
+			t.Errorf("ParseTrashCategory(%q) = (%v, %v), want (%v, true)", info.ID.String(), got, ok, info.ID)
+		}
+		if got, _ := ParseTrashCategory(info.ID.Slug()); got != info.ID {
+			t.Errorf("ParseTrashCategory(%q) = %v, want %v", info.ID.Slug(), got, info.ID)
+		}
+	}
+	if got := TrashCategory(99).String(); got != UnknownTrashCategoryName {
+		t.Errorf("TrashCategory(99).String() = %q, want %q", got, UnknownTrashCategoryName)
+	}
+}
@@ -0,0 +1,20 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/kinpatsu-everyone/backend-template/pkg/outorouter"
+)
+
+// ハンドラーが返すエラーです
+// ルーター登録時に Errors へ指定することで、生成されるクライアントのエラーコードに含まれます
+var (
+	ErrMonsterNotFound       = outorouter.NotFoundError("MONSTER_NOT_FOUND", "指定されたモンスターが見つかりません")
+	ErrImageNotFound         = outorouter.NotFoundError("IMAGE_NOT_FOUND", "指定された画像が見つかりません")
+	ErrStorageUnavailable    = outorouter.ServiceUnavailableError("STORAGE_UNAVAILABLE", "画像ストレージが設定されていません")
+	ErrNotMonsterOwner       = outorouter.ForbiddenError("NOT_MONSTER_OWNER", "このモンスターを操作する権限がありません")
+	ErrMonsterGenerating     = outorouter.NewHTTPError(http.StatusConflict, "MONSTER_GENERATING", "モンスターの生成が完了していないため削除できません")
+	ErrGenerationJobNotFound = outorouter.NotFoundError("GENERATION_JOB_NOT_FOUND", "指定された生成ジョブが見つかりません")
+	ErrInvalidSignedURL      = outorouter.ForbiddenError("INVALID_SIGNED_URL", "署名付きURLが不正です")
+	ErrSignedURLExpired      = outorouter.ForbiddenError("SIGNED_URL_EXPIRED", "署名付きURLの有効期限が切れています")
+)
@@ -3,9 +3,11 @@ package handler
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
+	"path"
 	"strconv"
 	"strings"
 	"time"
@@ -13,53 +15,55 @@ import (
 	"github.com/google/uuid"
 	"github.com/kinpatsu-everyone/backend-template/config"
 	"github.com/kinpatsu-everyone/backend-template/enum"
-	"github.com/kinpatsu-everyone/backend-template/internal/gcs"
-	"github.com/kinpatsu-everyone/backend-template/internal/gemini"
+	"github.com/kinpatsu-everyone/backend-template/internal/blob"
+	"github.com/kinpatsu-everyone/backend-template/internal/generation"
+	"github.com/kinpatsu-everyone/backend-template/internal/geo"
 	"github.com/kinpatsu-everyone/backend-template/internal/mysql"
 	"github.com/kinpatsu-everyone/backend-template/pkg/outologger"
+	"github.com/kinpatsu-everyone/backend-template/pkg/outorouter"
 )
 
+// GenerationQuotaName は生成エンドポイントで共有する1日の利用上限のレート制限の名前です
+// CreateMonster は生成ジョブが失敗した場合に戻せるように、数えたキーをジョブに記録します
+const GenerationQuotaName = "generation-quota"
+
 // CreateMonsterRequest はMonster登録リクエストです
 type CreateMonsterRequest struct {
-	Nickname  string                `multipart:"nickname"`  // ニックネーム
-	Latitude  float64               `multipart:"latitude"`  // 緯度(-90.0 ~ 90.0)
-	Longitude float64               `multipart:"longitude"` // 経度(-180.0 ~ 180.0)
-	Image     *multipart.FileHeader `multipart:"image"`     // 画像ファイル
+	Nickname  string                `multipart:"nickname" validate:"required,max=50"`                 // ニックネーム
+	Latitude  float64               `multipart:"latitude" validate:"min=-90,max=90"`                  // 緯度(-90.0 ~ 90.0)
+	Longitude float64               `multipart:"longitude" validate:"min=-180,max=180"`               // 経度(-180.0 ~ 180.0)
+	Image     *multipart.FileHeader `multipart:"image" validate:"required,maxsize=20MB,mime=image/*"` // 画像ファイル
 }
 
 // Validate はリクエストのバリデーションを行います
+// 必須・範囲・ファイル形式のチェックはvalidateタグで行います
 func (r CreateMonsterRequest) Validate() error {
-	if r.Nickname == "" {
-		return fmt.Errorf("nickname is required")
-	}
-	if r.Image == nil {
-		return fmt.Errorf("image is required")
-	}
-	// TODO: 緯度・経度の範囲チェック（-90.0 ~ 90.0, -180.0 ~ 180.0）
 	return nil
 }
 
 // CreateMonsterResponse はMonster登録レスポンスです
+// 画像の分析・生成は非同期に行うため、結果は GetMonsterGenerationStatus で確認します（完了するまで GetMonster は404を返します）
 type CreateMonsterResponse struct {
-	MonsterID         string `json:"monsterid"`           // モンスターID(UUID)
-	TrashType         string `json:"trash_type"`          // ごみ種判別結果
-	GeneratedImageURL string `json:"generated_image_url"` // 生成されたモンスター画像のGCS URL
-	OriginalImageURL  string `json:"original_image_url"`  // 元のごみ箱画像のGCS URL
+	MonsterID string `json:"monsterid"` // モンスターID(UUID)
+	JobID     string `json:"job_id"`    // 生成ジョブID(UUID)
+	Status    string `json:"status"`    // 生成ジョブの状態("queued")
 }
 
 // CreateMonster はMonster登録ハンドラーです
 // 処理内容:
-// 1. Monsterの永続化（ニックネーム、緯度、経度を保存）
-// 2. AnalyzeAndGenerateImageMultipartの画像分析・画像生成処理を実行
-// 3. 生成された画像のURLをMonsterのImageurlに保存
-// 4. MonsterのPKをレスポンスで返す
+// 1. アップロードされた画像を読み込み、ストレージに保存
+// 2. 生成ジョブ（ニックネーム、緯度、経度、画像のキー、認証されている場合は所有者）を保存
+// 3. MonsterのPKとジョブIDをすぐに返す（画像の分析・生成・アップロードとMonsterの保存は generation.Pool のワーカーが実行する）
 func CreateMonster(ctx context.Context, req *CreateMonsterRequest) (*CreateMonsterResponse, error) {
 	logger := outologger.GetLogger()
-	queries := mysql.GetQueries()
+	store := blob.GetStore()
+	if store == nil {
+		return nil, ErrStorageUnavailable
+	}
 
-	// 1. Monsterの永続化処理
+	// 1. アップロードされた画像を読み込み、ストレージに保存
 	monsterID := uuid.New().String()
-	monsterTrashCategoryID := uuid.New().String()
+	jobID := uuid.New().String()
 
 	// 画像ファイルを開く
 	file, err := req.Image.Open()
@@ -92,6 +96,12 @@ func CreateMonster(ctx context.Context, req *CreateMonsterRequest) (*CreateMonst
 		}
 	}
 
+	// 画像はMonsterのキー以下に保存し、ジョブの完了・失敗後にワーカーが削除する
+	inputImageKey := blob.GenerateInputImagePath(monsterID, jobID, blob.GetExtensionFromMimeType(mimeType))
+	if err := store.Put(ctx, inputImageKey, imageBytes, mimeType); err != nil {
+		return nil, fmt.Errorf("failed to upload input image: %w", err)
+	}
+
 	// 緯度・経度をsql.NullStringに変換
 	var latitude, longitude sql.NullString
 	if req.Latitude != 0 {
@@ -101,171 +111,194 @@ func CreateMonster(ctx context.Context, req *CreateMonsterRequest) (*CreateMonst
 		longitude = sql.NullString{String: fmt.Sprintf("%f", req.Longitude), Valid: true}
 	}
 
-	// 初期状態で空のURLでMonsterを作成（後で更新）
-	_, err = queries.CreateMonster(ctx, mysql.CreateMonsterParams{
-		Monsterid:                monsterID,
-		Nickname:                 req.Nickname,
-		Originaltrashbinimageurl: "", // GCSアップロードは後で実装
-		Generatedmonsterimageurl: "", // GCSアップロードは後で実装
-		Latitude:                 latitude,
-		Longitude:                longitude,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to create monster: %w", err)
+	// 認証されている場合はMonsterの所有者にする
+	var userID sql.NullString
+	if principal, ok := outorouter.GetPrincipalFromContext(ctx); ok {
+		userID = sql.NullString{String: principal.Subject, Valid: true}
+	}
+	var quotaKey sql.NullString
+	if key, ok := outorouter.GetRateLimitKeyFromContext(ctx, GenerationQuotaName); ok {
+		quotaKey = sql.NullString{String: key, Valid: true}
 	}
 
-	// 2. 画像からゴミ種別を判定
-	// gemini/client.go:149 の AnalyzeTrashBinImage メソッドを利用
-	analysisModel := "gemini-2.5-flash"
-	analysisClient, err := gemini.NewClient(config.GeminiAPIKey, analysisModel)
-	if err != nil {
-		logger.Error(ctx, "failed to create analysis client", map[string]any{
-			"error": err,
-		})
-		return nil, fmt.Errorf("failed to create analysis client: %w", err)
+	// 2. 生成ジョブを保存（Monsterは画像の分析・生成が成功した後にワーカーが保存する）
+	now := outorouter.GetNowUTCFromContext(ctx)
+	if now.IsZero() {
+		now = time.Now().UTC()
+	}
+	if _, err := mysql.GetQueries().CreateMonsterGenerationJob(ctx, mysql.CreateMonsterGenerationJobParams{
+		Jobid:         jobID,
+		Monsterid:     monsterID,
+		Userid:        userID,
+		Nickname:      req.Nickname,
+		Latitude:      latitude,
+		Longitude:     longitude,
+		Quotakey:      quotaKey,
+		Maxattempts:   int32(config.GenerationMaxAttempts),
+		Nextrunat:     now,
+		Inputimagekey: inputImageKey,
+		Inputmimetype: mimeType,
+	}); err != nil {
+		if delErr := store.Delete(ctx, inputImageKey); delErr != nil {
+			logger.Error(ctx, "failed to delete input image", map[string]any{
+				"error": delErr,
+				"key":   inputImageKey,
+			})
+		}
+		return nil, fmt.Errorf("failed to create monster generation job: %w", err)
 	}
 
-	logger.Info(ctx, "analyzing trash bin image", map[string]any{
-		"mime_type": mimeType,
-		"filename":  req.Image.Filename,
-		"size":      len(imageBytes),
+	logger.Info(ctx, "monster generation job queued", map[string]any{
+		"job_id":     jobID,
+		"monster_id": monsterID,
+		"mime_type":  mimeType,
+		"size":       len(imageBytes),
 	})
 
-	// AnalyzeTrashBinImage を呼び出し（gemini/client.go:149）
-	trashType, _, _, err := analysisClient.AnalyzeTrashBinImage(ctx, imageBytes, mimeType)
-	if err != nil {
-		return nil, fmt.Errorf("failed to analyze image: %w", err)
-	}
+	// 3. MonsterのPKとジョブIDを返す
+	return &CreateMonsterResponse{
+		MonsterID: monsterID,
+		JobID:     jobID,
+		Status:    string(generation.StatusQueued),
+	}, nil
+}
 
-	logger.Info(ctx, "trash type determined", map[string]any{
-		"trash_type": trashType,
-	})
+// GetMonsterGenerationStatusRequest はMonster生成ジョブの状態取得リクエストです
+type GetMonsterGenerationStatusRequest struct {
+	JobID string `json:"job_id" validate:"required,max=36"` // 生成ジョブID(UUID、CreateMonsterのレスポンス)
+}
 
-	// ゴミ種別の文字列をuint8に変換
-	trashCategory := enum.StringToTrashCategoryEnum(trashType)
+// Validate はリクエストのバリデーションを行います
+func (r GetMonsterGenerationStatusRequest) Validate() error {
+	return nil
+}
 
-	// 3. ゴミ種別をMonstertrashcategoryに保存
-	_, err = queries.CreateMonsterTrashCategory(ctx, mysql.CreateMonsterTrashCategoryParams{
-		Monstertrashcategoryid: monsterTrashCategoryID,
-		Monsterid:              monsterID,
-		Trashcategory:          uint8(trashCategory),
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to create monster trash category: %w", err)
-	}
+// GetMonsterGenerationStatusResponse はMonster生成ジョブの状態取得レスポンスです
+type GetMonsterGenerationStatusResponse struct {
+	JobID       string `json:"job_id"`       // 生成ジョブID(UUID)
+	MonsterID   string `json:"monsterid"`    // モンスターID(UUID)
+	Status      string `json:"status"`       // 状態("queued", "analyzing", "generating", "uploading", "done", "failed")
+	Attempts    int    `json:"attempts"`     // 実行回数
+	MaxAttempts int    `json:"max_attempts"` // 最大実行回数(リトライを含む)
+	ErrorCode   string `json:"error_code"`   // 失敗理由のコード(リトライ待ち・失敗の場合のみ、"ANALYSIS_FAILED"など)
+	ErrorReason string `json:"error_reason"` // 失敗理由のメッセージ(リトライ待ち・失敗の場合のみ)
+}
 
-	// 4. monsterの画像を生成
-	// gemini/client.go:188 の GenerateMonsterImage メソッドを利用
-	generateModel := "gemini-3-pro-image-preview"
-	generateClient, err := gemini.NewClient(config.GeminiAPIKey, generateModel)
+// GetMonsterGenerationStatus はMonster生成ジョブの状態取得ハンドラーです
+// 認証されたユーザーが作成したジョブは、作成したユーザーのみ取得できます
+func GetMonsterGenerationStatus(ctx context.Context, req *GetMonsterGenerationStatusRequest) (*GetMonsterGenerationStatusResponse, error) {
+	job, err := getGenerationJobStatus(ctx, mysql.GetQueries(), req.JobID)
 	if err != nil {
-		logger.Error(ctx, "failed to create generate client", map[string]any{
-			"error": err,
-		})
-		return nil, fmt.Errorf("failed to create generate client: %w", err)
+		return nil, err
 	}
+	return newGenerationStatusResponse(job), nil
+}
 
-	logger.Info(ctx, "generating monster image", map[string]any{
-		"model":      generateModel,
-		"trash_type": trashType,
-	})
+// WatchMonsterGenerationRequest はMonster生成ジョブの状態を購読するフレームです
+type WatchMonsterGenerationRequest struct {
+	JobID string `json:"job_id" validate:"required,max=36"` // 生成ジョブID(UUID、CreateMonsterのレスポンス)
+}
+
+// Validate はリクエストのバリデーションを行います
+func (r WatchMonsterGenerationRequest) Validate() error {
+	return nil
+}
+
+// WatchMonsterGenerationResponse はMonster生成ジョブの状態が変わるたびに送信するフレームです
+type WatchMonsterGenerationResponse GetMonsterGenerationStatusResponse
+
+// generationWatchInterval はWatchMonsterGenerationがジョブの状態を確認する間隔です
+const generationWatchInterval = time.Second
 
-	// GenerateMonsterImage を呼び出し（gemini/client.go:188）
-	generatedImageData, generatedMimeType, err := generateClient.GenerateMonsterImage(ctx, trashType)
+// WatchMonsterGeneration はMonster生成ジョブの状態をWebSocketで配信するハンドラーです
+// 最初に受信したフレームのジョブについて、状態が変わるたびにフレームを送信し、完了または失敗した時点で接続を閉じます
+// 自分が作成したジョブのみ購読できます
+func WatchMonsterGeneration(ctx context.Context, stream *outorouter.WebSocketStream[WatchMonsterGenerationRequest, WatchMonsterGenerationResponse]) error {
+	req, err := stream.Receive(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate image: %w", err)
+		return err
 	}
+	return watchMonsterGeneration(ctx, mysql.GetQueries(), stream, req.JobID, generationWatchInterval)
+}
 
-	logger.Info(ctx, "monster image generated", map[string]any{
-		"size":      len(generatedImageData),
-		"mime_type": generatedMimeType,
-	})
-
-	// 5. 生成画像のURL保存（GCSにアップロード・公開URL方式）
-	var generatedImagePath string
-	var originalImagePath string
-
-	if config.GCSBucketName != "" {
-		// GCSクライアントを作成
-		// 認証方法:
-		// - GCS_CREDENTIALS_JSONが設定されている場合: サービスアカウントキーを使用
-		// - GCS_CREDENTIALS_JSONが未設定の場合: Application Default Credentials (ADC) を使用
-		//   ADCは以下の順序で認証情報を探します:
-		//   1. 環境変数 GOOGLE_APPLICATION_CREDENTIALS で指定されたJSONファイル
-		//   2. gcloud CLIの認証情報 (gcloud auth application-default login)
-		//   3. GCE/GKEのサービスアカウント（クラウド環境の場合）
-		var credentialsJSON []byte
-		if config.GCSCredentialsJSON != "" {
-			credentialsJSON = []byte(config.GCSCredentialsJSON)
+// watchMonsterGeneration はジョブの状態を interval ごとに確認し、変わった場合にフレームを送信します
+func watchMonsterGeneration(
+	ctx context.Context,
+	queries mysql.Querier,
+	stream *outorouter.WebSocketStream[WatchMonsterGenerationRequest, WatchMonsterGenerationResponse],
+	jobID string,
+	interval time.Duration,
+) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var last WatchMonsterGenerationResponse
+	for {
+		job, err := getGenerationJobStatus(ctx, queries, jobID)
+		if err != nil {
+			return err
+		}
+		// 未認証で作成されたジョブは誰のものか判別できないため購読できない
+		if !job.Userid.Valid {
+			return ErrGenerationJobNotFound
 		}
 
-		gcsClient, err := gcs.NewClient(ctx, config.GCSBucketName, config.GCSBaseURL, credentialsJSON)
-		if err != nil {
-			logger.Error(ctx, "failed to create GCS client", map[string]any{
-				"error": err,
-			})
-			// GCSクライアントの作成に失敗しても処理は続行（空文字列のまま）
-		} else {
-			defer gcsClient.Close()
-
-			// 生成されたモンスター画像をアップロード（パスのみ保存）
-			generatedExtension := gcs.GetExtensionFromMimeType(generatedMimeType)
-			generatedObjectPath := gcs.GenerateGeneratedImagePath(monsterID, generatedExtension)
-			generatedImagePath, err = gcsClient.UploadImageWithPath(ctx, generatedObjectPath, generatedImageData, generatedMimeType)
-			if err != nil {
-				logger.Error(ctx, "failed to upload generated image to GCS", map[string]any{
-					"error":       err,
-					"object_path": generatedObjectPath,
-				})
-				generatedImagePath = ""
-			} else {
-				logger.Info(ctx, "generated image uploaded to GCS", map[string]any{
-					"path":        generatedImagePath,
-					"object_path": generatedObjectPath,
-				})
+		res := WatchMonsterGenerationResponse(*newGenerationStatusResponse(job))
+		if res != last {
+			if err := stream.Send(&res); err != nil {
+				return err
 			}
+			last = res
+		}
 
-			// 元のゴミ箱画像をアップロード（パスのみ保存）
-			originalExtension := gcs.GetExtensionFromMimeType(mimeType)
-			originalObjectPath := gcs.GenerateOriginalImagePath(monsterID, originalExtension)
-			originalImagePath, err = gcsClient.UploadImageWithPath(ctx, originalObjectPath, imageBytes, mimeType)
-			if err != nil {
-				logger.Error(ctx, "failed to upload original image to GCS", map[string]any{
-					"error":       err,
-					"object_path": originalObjectPath,
-				})
-				originalImagePath = ""
-			} else {
-				logger.Info(ctx, "original image uploaded to GCS", map[string]any{
-					"path":        originalImagePath,
-					"object_path": originalObjectPath,
-				})
-			}
+		switch generation.Status(res.Status) {
+		case generation.StatusDone, generation.StatusFailed:
+			return nil
 		}
-	} else {
-		logger.Info(ctx, "GCS bucket name not configured, skipping image upload", nil)
-	}
-
-	// Monsterの画像パスを更新
-	_, err = queries.UpdateMonster(ctx, mysql.UpdateMonsterParams{
-		Nickname:                 req.Nickname,
-		Originaltrashbinimageurl: originalImagePath,
-		Generatedmonsterimageurl: generatedImagePath,
-		Latitude:                 latitude,
-		Longitude:                longitude,
-		Monsterid:                monsterID,
-	})
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// getGenerationJobStatus は生成ジョブの状態を取得します
+// 認証されたユーザーが作成したジョブを他のユーザーが取得した場合は、存在しない場合と同じく ErrGenerationJobNotFound を返します
+func getGenerationJobStatus(ctx context.Context, queries mysql.Querier, jobID string) (mysql.GetMonsterGenerationJobStatusRow, error) {
+	job, err := queries.GetMonsterGenerationJobStatus(ctx, jobID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to update monster with image paths: %w", err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return job, ErrGenerationJobNotFound
+		}
+		return job, fmt.Errorf("failed to get monster generation job: %w", err)
 	}
+	if job.Userid.Valid {
+		// 他のユーザーのジョブは存在を明かさない
+		principal, ok := outorouter.GetPrincipalFromContext(ctx)
+		if !ok || principal.Subject != job.Userid.String {
+			return job, ErrGenerationJobNotFound
+		}
+	}
+	return job, nil
+}
 
-	// 6. MonsterのPKとパスを返す（クライアントには署名付きURLは返さない）
-	return &CreateMonsterResponse{
-		MonsterID:         monsterID,
-		TrashType:         trashType,
-		GeneratedImageURL: generatedImagePath,
-		OriginalImageURL:  originalImagePath,
-	}, nil
+// newGenerationStatusResponse は生成ジョブの状態をレスポンスに変換します
+func newGenerationStatusResponse(job mysql.GetMonsterGenerationJobStatusRow) *GetMonsterGenerationStatusResponse {
+	res := &GetMonsterGenerationStatusResponse{
+		JobID:       job.Jobid,
+		MonsterID:   job.Monsterid,
+		Status:      job.Status,
+		Attempts:    int(job.Attempts),
+		MaxAttempts: int(job.Maxattempts),
+	}
+	// 詳細なエラーはログ用のため、クライアントにはコードとメッセージのみ返す
+	if job.Errorcode.Valid && job.Status != string(generation.StatusDone) {
+		res.ErrorCode = job.Errorcode.String
+		res.ErrorReason = generation.ReasonMessage(job.Errorcode.String)
+	}
+	return res
 }
 
 // GetMonstersRequest はMonster一覧取得リクエストです
@@ -278,12 +311,26 @@ func (r GetMonstersRequest) Validate() error {
 
 // MonsterItem はMonster一覧の各アイテムです
 type MonsterItem struct {
-	ID            string  `json:"id"`             // モンスターID(UUID)
-	Nickname      string  `json:"nickname"`       // ニックネーム
-	Latitude      float64 `json:"latitude"`       // 緯度(-90.0 ~ 90.0, nullable)
-	Longitude     float64 `json:"longitude"`      // 経度(-180.0 ~ 180.0, nullable)
-	TrashCategory string  `json:"trash_category"` // ゴミ種別("指定なし", "燃えるゴミ", "不燃ごみ", "缶", "瓶", "ペットボトル")
-	ImageURL      string  `json:"image_url"`      // 画像のURL (https://images.kinpatsu.fanlav.net/monsters/{uuid}/model.png)
+	ID                string                 `json:"id"`                  // モンスターID(UUID)
+	Nickname          string                 `json:"nickname"`            // ニックネーム
+	Latitude          float64                `json:"latitude"`            // 緯度(-90.0 ~ 90.0, nullable)
+	Longitude         float64                `json:"longitude"`           // 経度(-180.0 ~ 180.0, nullable)
+	TrashCategory     enum.TrashCategoryName `json:"trash_category"`      // ゴミ種別の名前
+	TrashCategorySlug enum.TrashCategorySlug `json:"trash_category_slug"` // ゴミ種別の英語の識別子
+	Attribute         string                 `json:"attribute"`           // 属性(例: "炎")
+	ColorCode         string                 `json:"color_code"`          // 主な色のカラーコード(例: "#E53935")
+	SpeciesName       string                 `json:"species_name"`        // 種族名（生成されていない場合は空）
+	Description       string                 `json:"description"`         // 図鑑の説明文（生成されていない場合は空）
+	Stats             MonsterStats           `json:"stats"`               // 能力値（生成されていない場合はすべて0）
+	ImageURL          string                 `json:"image_url"`           // 画像のURL (https://images.kinpatsu.fanlav.net/monsters/{uuid}/model.png)
+}
+
+// MonsterStats はMonsterの能力値です
+type MonsterStats struct {
+	HP      int `json:"hp"`      // HP
+	Attack  int `json:"attack"`  // こうげき
+	Defense int `json:"defense"` // ぼうぎょ
+	Speed   int `json:"speed"`   // すばやさ
 }
 
 // GetMonstersResponse はMonster一覧取得レスポンスです
@@ -295,10 +342,9 @@ type GetMonstersResponse struct {
 // 処理内容:
 // 1. データベースからMonster一覧を取得
 // 2. 各Monsterの画像URLを生成（署名付きURL）
-// 3. 各Monsterの分類種別を取得
+// 3. 各Monsterの分類種別・属性・種族名・能力値を取得
 // 4. レスポンスとして配列を返す
 func GetMonsters(ctx context.Context, _ *GetMonstersRequest) (*GetMonstersResponse, error) {
-	logger := outologger.GetLogger()
 	queries := mysql.GetQueries()
 
 	// 1. データベースからMonster一覧を取得
@@ -307,86 +353,166 @@ func GetMonsters(ctx context.Context, _ *GetMonstersRequest) (*GetMonstersRespon
 		return nil, fmt.Errorf("failed to list monsters: %w", err)
 	}
 
-	// GCSクライアントを作成（署名付きURL生成用）
-	var gcsClient *gcs.Client
-	if config.GCSBucketName != "" {
-		var credentialsJSON []byte
-		if config.GCSCredentialsJSON != "" {
-			credentialsJSON = []byte(config.GCSCredentialsJSON)
-		}
+	// 2-3. 各Monsterの画像URL・分類種別・属性・種族名・能力値を付与
+	monsterItems, err := buildMonsterItems(ctx, queries, monsters)
+	if err != nil {
+		return nil, err
+	}
 
-		gcsClient, err = gcs.NewClient(ctx, config.GCSBucketName, config.GCSBaseURL, credentialsJSON)
-		if err != nil {
-			logger.Error(ctx, "failed to create GCS client", map[string]any{
-				"error": err,
-			})
-			// GCSクライアントの作成に失敗しても処理は続行
-		} else {
-			defer gcsClient.Close()
-		}
+	// 4. レスポンスとして配列を返す
+	return &GetMonstersResponse{
+		Monsters: monsterItems,
+	}, nil
+}
+
+// buildMonsterItems はMonsterの一覧をレスポンス用のMonsterItemに変換します
+// 生成画像の署名付きURLとゴミ種別・属性・種族名・能力値を付与します
+// ゴミ種別・属性・種族名・能力値はMonsterの数によらずテーブルごとに一度のクエリでまとめて取得します
+func buildMonsterItems(ctx context.Context, queries mysql.Querier, monsters []mysql.Monster) ([]MonsterItem, error) {
+	store := blob.GetStore()
+
+	// 各Monsterの分類種別・属性・種族名・能力値をまとめて取得
+	details, err := listMonsterDetails(ctx, queries, monsterIDs(monsters))
+	if err != nil {
+		return nil, err
 	}
 
 	// レスポンス用のMonsterItem配列を作成
 	monsterItems := make([]MonsterItem, 0, len(monsters))
 
 	for _, monster := range monsters {
+		// 最初のTrashCategoryを使用（なければ指定なし）
+		trashCategory := details.trashCategory(monster.Monsterid)
 
-		// 3. 各Monsterの分類種別を取得（Monstertrashcategoryテーブルから）
-		trashCategories, err := queries.ListMonsterTrashCategories(ctx, monster.Monsterid)
-		if err != nil {
-			return nil, fmt.Errorf("failed to list monster trash categories for monster %s: %w", monster.Monsterid, err)
+		// 属性と主な色（保存されていなければゴミ種別の属性と色）
+		attribute, ok := details.attributes[monster.Monsterid]
+		if !ok {
+			attribute = defaultMonsterAttribute(monster.Monsterid, trashCategory)
 		}
 
-		// 最初のTrashCategoryを使用（なければ空文字列）
-		trashCategoryStr := ""
-		if len(trashCategories) > 0 {
-			trashCategoryStr = mysql.TrashCategoryToString(trashCategories[0].Trashcategory)
-		}
+		// 緯度・経度が保存されていない場合は0を返す
+		point, _ := monsterPoint(monster)
 
-		// LatitudeとLongitudeの処理（sql.NullStringから数値へ）
-		var latitude float64
-		if monster.Latitude.Valid {
-			if lat, err := strconv.ParseFloat(monster.Latitude.String, 64); err == nil {
-				latitude = lat
-			}
-		}
-		var longitude float64
-		if monster.Longitude.Valid {
-			if lon, err := strconv.ParseFloat(monster.Longitude.String, 64); err == nil {
-				longitude = lon
-			}
-		}
+		monsterItems = append(monsterItems, MonsterItem{
+			ID:                monster.Monsterid,
+			Nickname:          monster.Nickname,
+			Latitude:          point.Latitude,
+			Longitude:         point.Longitude,
+			TrashCategory:     enum.TrashCategoryName(trashCategory.String()),
+			TrashCategorySlug: enum.TrashCategorySlug(trashCategory.Slug()),
+			Attribute:         attribute.Attributename,
+			ColorCode:         attribute.Colorcode,
+			SpeciesName:       details.profiles[monster.Monsterid].Speciesname,
+			Description:       details.profiles[monster.Monsterid].Description,
+			Stats:             newMonsterStats(details.stats[monster.Monsterid]),
+			ImageURL:          signedImageURL(ctx, store, monster.Generatedmonsterimageurl), // 生成画像の署名付きURL
+		})
+	}
 
-		// 生成画像の署名付きURLを生成
-		var imageURL string
-		if gcsClient != nil && monster.Generatedmonsterimageurl != "" {
-			signedURL, err := gcsClient.GetSignedURL(ctx, monster.Generatedmonsterimageurl, 24*time.Hour)
-			if err != nil {
-				logger.Error(ctx, "failed to generate signed URL", map[string]any{
-					"error":      err,
-					"monster_id": monster.Monsterid,
-					"path":       monster.Generatedmonsterimageurl,
-				})
-				imageURL = "" // エラー時は空文字列
-			} else {
-				imageURL = signedURL
-			}
+	return monsterItems, nil
+}
+
+// monsterDetails は複数のMonsterの分類種別・属性・種族名・能力値です（キーはモンスターID）
+type monsterDetails struct {
+	trashCategories map[string]enum.TrashCategory
+	attributes      map[string]mysql.Monsterattribute
+	profiles        map[string]mysql.Monsterprofile
+	stats           map[string]mysql.Monsterstat
+}
+
+// trashCategory はMonsterの最初のゴミ種別を返します（保存されていなければ指定なし）
+func (d monsterDetails) trashCategory(monsterID string) enum.TrashCategory {
+	if c, ok := d.trashCategories[monsterID]; ok {
+		return c
+	}
+	return enum.TrashCategoryNone
+}
+
+// listMonsterDetails は複数のMonsterの分類種別・属性・種族名・能力値を WHERE MonsterId IN (...) でまとめて取得します
+func listMonsterDetails(ctx context.Context, queries mysql.Querier, monsterIDs []string) (monsterDetails, error) {
+	trashCategories, err := listFirstTrashCategories(ctx, queries, monsterIDs)
+	if err != nil {
+		return monsterDetails{}, err
+	}
+	details := monsterDetails{
+		trashCategories: trashCategories,
+		attributes:      make(map[string]mysql.Monsterattribute, len(monsterIDs)),
+		profiles:        make(map[string]mysql.Monsterprofile, len(monsterIDs)),
+		stats:           make(map[string]mysql.Monsterstat, len(monsterIDs)),
+	}
+	if len(monsterIDs) == 0 {
+		return details, nil
+	}
+
+	attributes, err := queries.ListMonsterAttributesByMonsterIds(ctx, monsterIDs)
+	if err != nil {
+		return monsterDetails{}, fmt.Errorf("failed to list monster attributes: %w", err)
+	}
+	for _, a := range attributes {
+		details.attributes[a.Monsterid] = a
+	}
+
+	profiles, err := queries.ListMonsterProfilesByMonsterIds(ctx, monsterIDs)
+	if err != nil {
+		return monsterDetails{}, fmt.Errorf("failed to list monster profiles: %w", err)
+	}
+	for _, p := range profiles {
+		details.profiles[p.Monsterid] = p
+	}
+
+	stats, err := queries.ListMonsterStatsByMonsterIds(ctx, monsterIDs)
+	if err != nil {
+		return monsterDetails{}, fmt.Errorf("failed to list monster stats: %w", err)
+	}
+	for _, st := range stats {
+		details.stats[st.Monsterid] = st
+	}
+
+	return details, nil
+}
+
+// listFirstTrashCategories は複数のMonsterの最初のゴミ種別をまとめて取得します（キーはモンスターID）
+func listFirstTrashCategories(ctx context.Context, queries mysql.Querier, monsterIDs []string) (map[string]enum.TrashCategory, error) {
+	firsts := make(map[string]enum.TrashCategory, len(monsterIDs))
+	if len(monsterIDs) == 0 {
+		return firsts, nil
+	}
+	trashCategories, err := queries.ListMonsterTrashCategoriesByMonsterIds(ctx, monsterIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list monster trash categories: %w", err)
+	}
+	// MonsterごとにTrashCategoryの昇順で並んでいるため、最初の行を使う
+	for _, c := range trashCategories {
+		if _, ok := firsts[c.Monsterid]; !ok {
+			firsts[c.Monsterid] = enum.TrashCategory(c.Trashcategory)
 		}
+	}
+	return firsts, nil
+}
 
-		monsterItems = append(monsterItems, MonsterItem{
-			ID:            monster.Monsterid,
-			Nickname:      monster.Nickname,
-			Latitude:      latitude,
-			Longitude:     longitude,
-			TrashCategory: trashCategoryStr,
-			ImageURL:      imageURL,
-		})
+// monsterIDs はMonsterのIDの一覧を返します
+func monsterIDs(monsters []mysql.Monster) []string {
+	ids := make([]string, 0, len(monsters))
+	for _, monster := range monsters {
+		ids = append(ids, monster.Monsterid)
 	}
+	return ids
+}
 
-	// 4. レスポンスとして配列を返す
-	return &GetMonstersResponse{
-		Monsters: monsterItems,
-	}, nil
+// monsterPoint はMonsterの緯度・経度を返します（保存されていない場合や解析できない場合は false を返します）
+func monsterPoint(monster mysql.Monster) (geo.Point, bool) {
+	if !monster.Latitude.Valid || !monster.Longitude.Valid {
+		return geo.Point{}, false
+	}
+	lat, err := strconv.ParseFloat(monster.Latitude.String, 64)
+	if err != nil {
+		return geo.Point{}, false
+	}
+	lng, err := strconv.ParseFloat(monster.Longitude.String, 64)
+	if err != nil {
+		return geo.Point{}, false
+	}
+	return geo.Point{Latitude: lat, Longitude: lng}, true
 }
 
 // GetTrashsRequest はゴミ箱一覧取得リクエストです
@@ -399,12 +525,13 @@ func (r GetTrashsRequest) Validate() error {
 
 // TrashItem はゴミ箱一覧の各アイテムです
 type TrashItem struct {
-	ID            string  `json:"id"`             // モンスターID(UUID)
-	Nickname      string  `json:"nickname"`       // ニックネーム
-	Latitude      float64 `json:"latitude"`       // 緯度(-90.0 ~ 90.0, nullable)
-	Longitude     float64 `json:"longitude"`      // 経度(-180.0 ~ 180.0, nullable)
-	TrashCategory string  `json:"trash_category"` // ゴミ種別
-	ImageURL      string  `json:"image_url"`      // 元のゴミ箱画像の署名付きURL
+	ID                string                 `json:"id"`                  // モンスターID(UUID)
+	Nickname          string                 `json:"nickname"`            // ニックネーム
+	Latitude          float64                `json:"latitude"`            // 緯度(-90.0 ~ 90.0, nullable)
+	Longitude         float64                `json:"longitude"`           // 経度(-180.0 ~ 180.0, nullable)
+	TrashCategory     enum.TrashCategoryName `json:"trash_category"`      // ゴミ種別の名前
+	TrashCategorySlug enum.TrashCategorySlug `json:"trash_category_slug"` // ゴミ種別の英語の識別子
+	ImageURL          string                 `json:"image_url"`           // 元のゴミ箱画像の署名付きURL
 }
 
 // GetTrashsResponse はゴミ箱一覧取得レスポンスです
@@ -419,8 +546,8 @@ type GetTrashsResponse struct {
 // 3. 各Monsterの分類種別を取得
 // 4. レスポンスとして配列を返す
 func GetTrashs(ctx context.Context, _ *GetTrashsRequest) (*GetTrashsResponse, error) {
-	logger := outologger.GetLogger()
 	queries := mysql.GetQueries()
+	store := blob.GetStore()
 
 	// 1. データベースからMonster一覧を取得
 	monsters, err := queries.ListMonsters(ctx)
@@ -428,77 +555,33 @@ func GetTrashs(ctx context.Context, _ *GetTrashsRequest) (*GetTrashsResponse, er
 		return nil, fmt.Errorf("failed to list monsters: %w", err)
 	}
 
-	// GCSクライアントを作成（署名付きURL生成用）
-	var gcsClient *gcs.Client
-	if config.GCSBucketName != "" {
-		var credentialsJSON []byte
-		if config.GCSCredentialsJSON != "" {
-			credentialsJSON = []byte(config.GCSCredentialsJSON)
-		}
-
-		gcsClient, err = gcs.NewClient(ctx, config.GCSBucketName, config.GCSBaseURL, credentialsJSON)
-		if err != nil {
-			logger.Error(ctx, "failed to create GCS client", map[string]any{
-				"error": err,
-			})
-		} else {
-			defer gcsClient.Close()
-		}
+	// 各Monsterの分類種別をまとめて取得（Monstertrashcategoryテーブルから）
+	trashCategories, err := listFirstTrashCategories(ctx, queries, monsterIDs(monsters))
+	if err != nil {
+		return nil, err
 	}
 
 	// レスポンス用のTrashItem配列を作成
 	trashItems := make([]TrashItem, 0, len(monsters))
 
 	for _, monster := range monsters {
-		// 各Monsterの分類種別を取得（Monstertrashcategoryテーブルから）
-		trashCategories, err := queries.ListMonsterTrashCategories(ctx, monster.Monsterid)
-		if err != nil {
-			return nil, fmt.Errorf("failed to list monster trash categories for monster %s: %w", monster.Monsterid, err)
-		}
-
-		// 最初のTrashCategoryを使用（なければ空文字列）
-		trashCategoryStr := ""
-		if len(trashCategories) > 0 {
-			trashCategoryStr = mysql.TrashCategoryToString(trashCategories[0].Trashcategory)
-		}
-
-		// LatitudeとLongitudeの処理（sql.NullStringから数値へ）
-		var latitude float64
-		if monster.Latitude.Valid {
-			if lat, err := strconv.ParseFloat(monster.Latitude.String, 64); err == nil {
-				latitude = lat
-			}
-		}
-		var longitude float64
-		if monster.Longitude.Valid {
-			if lon, err := strconv.ParseFloat(monster.Longitude.String, 64); err == nil {
-				longitude = lon
-			}
+		// 最初のTrashCategoryを使用（なければ指定なし）
+		trashCategory, ok := trashCategories[monster.Monsterid]
+		if !ok {
+			trashCategory = enum.TrashCategoryNone
 		}
 
-		// 元画像（ゴミ箱画像）の署名付きURLを生成
-		var imageURL string
-		if gcsClient != nil && monster.Originaltrashbinimageurl != "" {
-			signedURL, err := gcsClient.GetSignedURL(ctx, monster.Originaltrashbinimageurl, 24*time.Hour)
-			if err != nil {
-				logger.Error(ctx, "failed to generate signed URL for original image", map[string]any{
-					"error":      err,
-					"monster_id": monster.Monsterid,
-					"path":       monster.Originaltrashbinimageurl,
-				})
-				imageURL = ""
-			} else {
-				imageURL = signedURL
-			}
-		}
+		// 緯度・経度が保存されていない場合は0を返す
+		point, _ := monsterPoint(monster)
 
 		trashItems = append(trashItems, TrashItem{
-			ID:            monster.Monsterid,
-			Nickname:      monster.Nickname,
-			Latitude:      latitude,
-			Longitude:     longitude,
-			TrashCategory: trashCategoryStr,
-			ImageURL:      imageURL,
+			ID:                monster.Monsterid,
+			Nickname:          monster.Nickname,
+			Latitude:          point.Latitude,
+			Longitude:         point.Longitude,
+			TrashCategory:     enum.TrashCategoryName(trashCategory.String()),
+			TrashCategorySlug: enum.TrashCategorySlug(trashCategory.Slug()),
+			ImageURL:          signedImageURL(ctx, store, monster.Originaltrashbinimageurl), // 元画像（ゴミ箱画像）の署名付きURL
 		})
 	}
 
@@ -509,14 +592,11 @@ func GetTrashs(ctx context.Context, _ *GetTrashsRequest) (*GetTrashsResponse, er
 
 // GetMonsterRequest はMonster一件取得リクエストです
 type GetMonsterRequest struct {
-	ID string `json:"id"` // モンスターID(UUID)
+	ID string `json:"id" path:"id" validate:"required,max=36"` // モンスターID(UUID)
 }
 
 // Validate はリクエストのバリデーションを行います
 func (r GetMonsterRequest) Validate() error {
-	if r.ID == "" {
-		return fmt.Errorf("id is required")
-	}
 	return nil
 }
 
@@ -531,8 +611,8 @@ type GetMonsterResponse struct {
 // 処理内容:
 // 1. パスパラメータからMonsterのPKを取得
 // 2. データベースからMonsterを取得
-// 3. 保存されたGCSパスから署名付きURLを生成
-// 4. Monsterの分類種別を取得
+// 3. 保存されたパスから署名付きURLを生成
+// 4. Monsterの分類種別・属性・種族名・能力値を取得
 // 5. レスポンスとして返す
 func GetMonster(ctx context.Context, req *GetMonsterRequest) (*GetMonsterResponse, error) {
 	logger := outologger.GetLogger()
@@ -541,6 +621,9 @@ func GetMonster(ctx context.Context, req *GetMonsterRequest) (*GetMonsterRespons
 	// 1. データベースからMonsterを取得
 	monster, err := queries.GetMonster(ctx, req.ID)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrMonsterNotFound
+		}
 		return nil, fmt.Errorf("failed to get monster: %w", err)
 	}
 
@@ -554,82 +637,396 @@ func GetMonster(ctx context.Context, req *GetMonsterRequest) (*GetMonsterRespons
 		// エラーがあっても続行（デフォルト値を使用）
 	}
 
-	trashCategory := "指定なし"
-	if len(trashCategories) > 0 {
-		trashCategory = mysql.TrashCategoryToString(trashCategories[0].Trashcategory)
+	trashCategory := firstTrashCategory(trashCategories)
+
+	// 属性と主な色を取得（MonsterAttributeテーブルから）
+	attribute, err := getMonsterAttribute(ctx, queries, req.ID, trashCategory)
+	if err != nil {
+		logger.Error(ctx, "failed to get monster attribute", map[string]any{
+			"error":      err,
+			"monster_id": req.ID,
+		})
+		// エラーがあっても続行（ゴミ種別の属性と色を使用）
+	}
+
+	// 種族名・説明文と能力値を取得（MonsterProfile・MonsterStatsテーブルから）
+	profile, stats, err := getMonsterProfile(ctx, queries, req.ID)
+	if err != nil {
+		logger.Error(ctx, "failed to get monster profile", map[string]any{
+			"error":      err,
+			"monster_id": req.ID,
+		})
+		// エラーがあっても続行（空の種族名と能力値を使用）
 	}
 
 	// 3. 保存されているパスから署名付きURLを生成
-	var generatedImageURL string
-	var originalImageURL string
+	store := blob.GetStore()
+	generatedImageURL := signedImageURL(ctx, store, monster.Generatedmonsterimageurl)
+	originalImageURL := signedImageURL(ctx, store, monster.Originaltrashbinimageurl)
+
+	// 4. レスポンスを返す
+	// 緯度・経度が保存されていない場合は0を返す
+	point, _ := monsterPoint(monster)
+
+	return &GetMonsterResponse{
+		Monster: MonsterItem{
+			ID:                monster.Monsterid,
+			Nickname:          monster.Nickname,
+			Latitude:          point.Latitude,
+			Longitude:         point.Longitude,
+			TrashCategory:     enum.TrashCategoryName(trashCategory.String()),
+			TrashCategorySlug: enum.TrashCategorySlug(trashCategory.Slug()),
+			Attribute:         attribute.Attributename,
+			ColorCode:         attribute.Colorcode,
+			SpeciesName:       profile.Speciesname,
+			Description:       profile.Description,
+			Stats:             newMonsterStats(stats),
+			ImageURL:          generatedImageURL, // 生成画像の署名付きURL
+		},
+		OriginalImageURL:  originalImageURL,  // 元画像の署名付きURL
+		GeneratedImageURL: generatedImageURL, // 生成画像の署名付きURL
+	}, nil
+}
+
+// DownloadMonsterImageRequest はMonster画像ダウンロードリクエストです
+type DownloadMonsterImageRequest struct {
+	ID   string `json:"id" validate:"required,max=36"`                      // モンスターID(UUID)
+	Type string `json:"type,omitempty" validate:"oneof=generated original"` // 画像種別("generated": 生成画像(デフォルト), "original": 元のゴミ箱画像)
+}
 
-	if config.GCSBucketName != "" && (monster.Generatedmonsterimageurl != "" || monster.Originaltrashbinimageurl != "") {
-		var credentialsJSON []byte
-		if config.GCSCredentialsJSON != "" {
-			credentialsJSON = []byte(config.GCSCredentialsJSON)
+// Validate はリクエストのバリデーションを行います
+func (r DownloadMonsterImageRequest) Validate() error {
+	return nil
+}
+
+// DownloadMonsterImage はMonster画像ダウンロードハンドラーです
+// 署名付きURLが利用できない環境向けに、画像をAPI経由で直接返します
+func DownloadMonsterImage(ctx context.Context, req *DownloadMonsterImageRequest) (*outorouter.FileDownloadResponseObject, error) {
+	store, objectPath, err := monsterImageObject(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	data, info, err := store.Get(ctx, objectPath)
+	if err != nil {
+		if errors.Is(err, blob.ErrNotFound) {
+			return nil, ErrImageNotFound
 		}
+		return nil, fmt.Errorf("failed to download image: %w", err)
+	}
 
-		gcsClient, err := gcs.NewClient(ctx, config.GCSBucketName, config.GCSBaseURL, credentialsJSON)
-		if err != nil {
-			logger.Error(ctx, "failed to create GCS client", map[string]any{
-				"error": err,
-			})
-		} else {
-			defer gcsClient.Close()
-
-			// 生成画像の署名付きURLを取得
-			if monster.Generatedmonsterimageurl != "" {
-				generatedImageURL, err = gcsClient.GetSignedURL(ctx, monster.Generatedmonsterimageurl, 24*time.Hour)
-				if err != nil {
-					logger.Error(ctx, "failed to generate signed URL for generated image", map[string]any{
-						"error": err,
-						"path":  monster.Generatedmonsterimageurl,
-					})
-					generatedImageURL = ""
-				} else {
-					logger.Info(ctx, "generated signed URL for generated image", map[string]any{
-						"path": monster.Generatedmonsterimageurl,
-					})
-				}
-			}
+	return &outorouter.FileDownloadResponseObject{
+		Filename:    path.Base(objectPath),
+		ContentType: info.ContentType,
+		Content:     data,
+		Inline:      true,
+		ETag:        objectETag(info),
+		ModTime:     info.ModTime,
+	}, nil
+}
 
-			// 元画像の署名付きURLを取得
-			if monster.Originaltrashbinimageurl != "" {
-				originalImageURL, err = gcsClient.GetSignedURL(ctx, monster.Originaltrashbinimageurl, 24*time.Hour)
-				if err != nil {
-					logger.Error(ctx, "failed to generate signed URL for original image", map[string]any{
-						"error": err,
-						"path":  monster.Originaltrashbinimageurl,
-					})
-					originalImageURL = ""
-				} else {
-					logger.Info(ctx, "generated signed URL for original image", map[string]any{
-						"path": monster.Originaltrashbinimageurl,
-					})
-				}
-			}
+// DownloadMonsterImageETag は画像を取得せずに DownloadMonsterImage のETagを返します
+// If-None-Match が一致する場合は画像を取得せずに304を返すために使います
+func DownloadMonsterImageETag(ctx context.Context, req *DownloadMonsterImageRequest) (string, error) {
+	store, objectPath, err := monsterImageObject(ctx, req)
+	if err != nil {
+		return "", err
+	}
+
+	info, err := store.Stat(ctx, objectPath)
+	if err != nil {
+		if errors.Is(err, blob.ErrNotFound) {
+			return "", ErrImageNotFound
 		}
+		return "", fmt.Errorf("failed to stat image: %w", err)
 	}
+	return objectETag(info), nil
+}
 
-	// 4. レスポンスを返す
-	var latitude, longitude float64
-	if monster.Latitude.Valid {
-		latitude, _ = strconv.ParseFloat(monster.Latitude.String, 64)
+// monsterImageObject はリクエストされたMonster画像のストレージとキーを返します
+func monsterImageObject(ctx context.Context, req *DownloadMonsterImageRequest) (blob.Store, string, error) {
+	queries := mysql.GetQueries()
+
+	monster, err := queries.GetMonster(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, "", ErrMonsterNotFound
+		}
+		return nil, "", fmt.Errorf("failed to get monster: %w", err)
 	}
-	if monster.Longitude.Valid {
-		longitude, _ = strconv.ParseFloat(monster.Longitude.String, 64)
+
+	imageType := req.Type
+	if imageType == "" {
+		imageType = "generated"
 	}
 
-	return &GetMonsterResponse{
-		Monster: MonsterItem{
-			ID:            monster.Monsterid,
-			Nickname:      monster.Nickname,
-			Latitude:      latitude,
-			Longitude:     longitude,
-			TrashCategory: trashCategory,
-			ImageURL:      generatedImageURL, // 生成画像の署名付きURL
+	objectPath := monster.Generatedmonsterimageurl
+	if imageType == "original" {
+		objectPath = monster.Originaltrashbinimageurl
+	}
+	if objectPath == "" {
+		return nil, "", ErrImageNotFound
+	}
+
+	store := blob.GetStore()
+	if store == nil {
+		return nil, "", ErrStorageUnavailable
+	}
+	return store, objectPath, nil
+}
+
+// signedURLExpiry はレスポンスに含める画像の署名付きURLの有効期限です
+const signedURLExpiry = 24 * time.Hour
+
+// signedImageURL は保存されているパスから画像の署名付きURLを生成します
+// パスが空の場合、ストレージが設定されていない場合、生成に失敗した場合は空文字列を返します
+func signedImageURL(ctx context.Context, store blob.Store, objectPath string) string {
+	if store == nil || objectPath == "" {
+		return ""
+	}
+	signedURL, err := store.SignedURL(ctx, objectPath, signedURLExpiry)
+	if err != nil {
+		outologger.GetLogger().Error(ctx, "failed to generate signed URL", map[string]any{
+			"error": err,
+			"path":  objectPath,
+		})
+		return ""
+	}
+	return signedURL
+}
+
+// getMonsterAttribute はMonsterの属性と主な色を取得します
+// 属性が保存されていない場合（属性を保存する前に生成したMonster）や取得に失敗した場合は、ゴミ種別の属性と色を返します
+func getMonsterAttribute(ctx context.Context, queries mysql.Querier, monsterID string, trashCategory enum.TrashCategory) (mysql.Monsterattribute, error) {
+	attribute, err := queries.GetMonsterAttribute(ctx, monsterID)
+	if err == nil {
+		return attribute, nil
+	}
+
+	fallback := defaultMonsterAttribute(monsterID, trashCategory)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fallback, nil
+	}
+	return fallback, err
+}
+
+// defaultMonsterAttribute は属性が保存されていないMonsterの属性と主な色（ゴミ種別の属性と色）を返します
+func defaultMonsterAttribute(monsterID string, trashCategory enum.TrashCategory) mysql.Monsterattribute {
+	info, _ := trashCategory.Info()
+	return mysql.Monsterattribute{
+		Monsterid:     monsterID,
+		Attributename: info.Attribute,
+		Colorcode:     info.ColorCode,
+	}
+}
+
+// getMonsterProfile はMonsterの種族名・説明文と能力値を取得します
+// 保存されていない場合（種族名を保存する前に生成したMonster）は空の値を返します
+func getMonsterProfile(ctx context.Context, queries mysql.Querier, monsterID string) (mysql.Monsterprofile, mysql.Monsterstat, error) {
+	profile, err := queries.GetMonsterProfile(ctx, monsterID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return mysql.Monsterprofile{}, mysql.Monsterstat{}, err
+	}
+	stats, err := queries.GetMonsterStats(ctx, monsterID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return profile, mysql.Monsterstat{}, err
+	}
+	return profile, stats, nil
+}
+
+// newMonsterStats は保存された能力値をレスポンス用のMonsterStatsに変換します
+func newMonsterStats(stats mysql.Monsterstat) MonsterStats {
+	return MonsterStats{
+		HP:      int(stats.Hp),
+		Attack:  int(stats.Attack),
+		Defense: int(stats.Defense),
+		Speed:   int(stats.Speed),
+	}
+}
+
+// authorizeMonsterOwner は認証されたユーザーがMonsterの所有者であることを確認します
+// 所有者のいないMonster（未ログインで作成したもの）は誰も操作できません
+func authorizeMonsterOwner(ctx context.Context, monster mysql.Monster) error {
+	principal, ok := outorouter.GetPrincipalFromContext(ctx)
+	if !ok || !monster.Userid.Valid || monster.Userid.String != principal.Subject {
+		return ErrNotMonsterOwner
+	}
+	return nil
+}
+
+// getOwnedMonster はMonsterを取得し、認証されたユーザーが所有者であることを確認します
+func getOwnedMonster(ctx context.Context, queries mysql.Querier, monsterID string) (mysql.Monster, error) {
+	monster, err := queries.GetMonster(ctx, monsterID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return mysql.Monster{}, ErrMonsterNotFound
+		}
+		return mysql.Monster{}, fmt.Errorf("failed to get monster: %w", err)
+	}
+	if err := authorizeMonsterOwner(ctx, monster); err != nil {
+		return mysql.Monster{}, err
+	}
+	return monster, nil
+}
+
+// GetMyMonstersRequest は自分のMonster一覧取得リクエストです
+type GetMyMonstersRequest struct{}
+
+// Validate はリクエストのバリデーションを行います
+func (r GetMyMonstersRequest) Validate() error {
+	return nil
+}
+
+// GetMyMonstersResponse は自分のMonster一覧取得レスポンスです
+type GetMyMonstersResponse struct {
+	Monsters []MonsterItem `json:"monsters"` // 認証されたユーザーが所有するMonsterの配列
+}
+
+// GetMyMonsters は認証されたユーザーが所有するMonster一覧取得ハンドラーです
+func GetMyMonsters(ctx context.Context, _ *GetMyMonstersRequest) (*GetMyMonstersResponse, error) {
+	queries := mysql.GetQueries()
+
+	principal, ok := outorouter.GetPrincipalFromContext(ctx)
+	if !ok {
+		return nil, outorouter.UnauthorizedError(outorouter.ErrorCodeUnauthorized, "認証が必要です")
+	}
+
+	monsters, err := queries.ListMonstersByUserId(ctx, sql.NullString{String: principal.Subject, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list monsters by user: %w", err)
+	}
+
+	monsterItems, err := buildMonsterItems(ctx, queries, monsters)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetMyMonstersResponse{
+		Monsters: monsterItems,
+	}, nil
+}
+
+// RenameMonsterRequest はMonsterのニックネーム変更リクエストです
+type RenameMonsterRequest struct {
+	ID       string `json:"id" validate:"required,max=36"`       // モンスターID(UUID)
+	Nickname string `json:"nickname" validate:"required,max=50"` // 新しいニックネーム
+}
+
+// Validate はリクエストのバリデーションを行います
+func (r RenameMonsterRequest) Validate() error {
+	return nil
+}
+
+// RenameMonsterResponse はMonsterのニックネーム変更レスポンスです
+type RenameMonsterResponse struct {
+	ID       string `json:"id"`       // モンスターID(UUID)
+	Nickname string `json:"nickname"` // 変更後のニックネーム
+}
+
+// RenameMonster はMonsterのニックネーム変更ハンドラーです
+// 所有者のみ変更できます
+func RenameMonster(ctx context.Context, req *RenameMonsterRequest) (*RenameMonsterResponse, error) {
+	queries := mysql.GetQueries()
+
+	if _, err := getOwnedMonster(ctx, queries, req.ID); err != nil {
+		return nil, err
+	}
+
+	if _, err := queries.UpdateMonsterNickname(ctx, mysql.UpdateMonsterNicknameParams{
+		Nickname:  req.Nickname,
+		Monsterid: req.ID,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to update monster nickname: %w", err)
+	}
+
+	return &RenameMonsterResponse{
+		ID:       req.ID,
+		Nickname: req.Nickname,
+	}, nil
+}
+
+// DeleteMonsterRequest はMonster削除リクエストです
+type DeleteMonsterRequest struct {
+	ID string `json:"id" validate:"required,max=36"` // モンスターID(UUID)
+}
+
+// Validate はリクエストのバリデーションを行います
+func (r DeleteMonsterRequest) Validate() error {
+	return nil
+}
+
+// DeleteMonsterResponse はMonster削除レスポンスです
+type DeleteMonsterResponse struct {
+	ID string `json:"id"` // 削除したモンスターID(UUID)
+}
+
+// DeleteMonster はMonster削除ハンドラーです
+// 所有者のみ削除できます。ゴミ種別・属性・分析結果・種族名・能力値・生成ジョブ・画像もあわせて削除します
+func DeleteMonster(ctx context.Context, req *DeleteMonsterRequest) (*DeleteMonsterResponse, error) {
+	monster, err := getOwnedMonster(ctx, mysql.GetQueries(), req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := generation.DeleteMonster(ctx, blob.GetStore(), monster); err != nil {
+		if errors.Is(err, generation.ErrMonsterGenerating) {
+			return nil, ErrMonsterGenerating
+		}
+		return nil, err
+	}
+
+	return &DeleteMonsterResponse{
+		ID: req.ID,
+	}, nil
+}
+
+// RegenerateMonsterProfileRequest はMonsterの種族名・説明文の再生成リクエストです
+type RegenerateMonsterProfileRequest struct {
+	ID          string `json:"id" validate:"required,max=36"` // モンスターID(UUID)
+	RerollStats bool   `json:"reroll_stats"`                  // 能力値を新しいシードで振り直すかどうか(falseの場合は保存済みのシードで計算し直す)
+}
+
+// Validate はリクエストのバリデーションを行います
+func (r RegenerateMonsterProfileRequest) Validate() error {
+	return nil
+}
+
+// RegenerateMonsterProfileResponse はMonsterの種族名・説明文の再生成レスポンスです
+type RegenerateMonsterProfileResponse struct {
+	ID          string       `json:"id"`           // モンスターID(UUID)
+	SpeciesName string       `json:"species_name"` // 種族名
+	Description string       `json:"description"`  // 図鑑の説明文
+	Stats       MonsterStats `json:"stats"`        // 能力値
+}
+
+// RegenerateMonsterProfile はMonsterの種族名・説明文の再生成ハンドラーです（管理者用）
+// 画像は生成し直さず、保存済みの生成画像と分析結果から種族名と説明文を生成し直し、能力値を計算し直します
+func RegenerateMonsterProfile(ctx context.Context, req *RegenerateMonsterProfileRequest) (*RegenerateMonsterProfileResponse, error) {
+	result, err := generation.RegenerateMonsterProfile(ctx, req.ID, req.RerollStats)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrMonsterNotFound
+		}
+		if errors.Is(err, blob.ErrNotFound) {
+			return nil, ErrImageNotFound
+		}
+		return nil, fmt.Errorf("failed to regenerate monster profile: %w", err)
+	}
+
+	outologger.GetLogger().Info(ctx, "monster profile regenerated", map[string]any{
+		"monster_id":   req.ID,
+		"provider":     result.Profile.Provider,
+		"reroll_stats": req.RerollStats,
+	})
+	return &RegenerateMonsterProfileResponse{
+		ID:          req.ID,
+		SpeciesName: result.Profile.SpeciesName,
+		Description: result.Profile.Description,
+		Stats: MonsterStats{
+			HP:      result.Stats.HP,
+			Attack:  result.Stats.Attack,
+			Defense: result.Stats.Defense,
+			Speed:   result.Stats.Speed,
 		},
-		OriginalImageURL:  originalImageURL,  // 元画像の署名付きURL
-		GeneratedImageURL: generatedImageURL, // 生成画像の署名付きURL
 	}, nil
 }
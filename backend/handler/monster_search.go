@@ -0,0 +1,289 @@
+package handler
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/kinpatsu-everyone/backend-template/enum"
+	"github.com/kinpatsu-everyone/backend-template/internal/geo"
+	"github.com/kinpatsu-everyone/backend-template/internal/mysql"
+	"github.com/kinpatsu-everyone/backend-template/pkg/outorouter"
+)
+
+const (
+	// defaultNearbyLimit は SearchMonstersNearby の limit を省略した場合の件数です
+	defaultNearbyLimit = 50
+	// defaultBoundsLimit は GetMonstersInBounds の limit を省略した場合の件数です
+	defaultBoundsLimit = 200
+)
+
+// SearchMonstersNearbyRequest は指定した地点の周辺のMonster検索リクエストです
+type SearchMonstersNearbyRequest struct {
+	Latitude      float64                      `json:"latitude" query:"latitude" validate:"min=-90,max=90"`           // 中心の緯度(-90.0 ~ 90.0)
+	Longitude     float64                      `json:"longitude" query:"longitude" validate:"min=-180,max=180"`       // 中心の経度(-180.0 ~ 180.0)
+	RadiusM       int                          `json:"radius_m" query:"radius_m" validate:"required,min=1,max=50000"` // 検索する半径(メートル)
+	Limit         int                          `json:"limit" query:"limit" validate:"min=1,max=100"`                  // 最大件数(省略した場合は50)
+	TrashCategory enum.TrashCategoryFilterSlug `json:"trash_category" query:"trash_category"`                         // ゴミ種別の英語の識別子で絞り込む(省略した場合はすべて)
+}
+
+// Validate はリクエストのバリデーションを行います
+func (r SearchMonstersNearbyRequest) Validate() error {
+	return nil
+}
+
+// NearbyMonsterItem は周辺のMonster検索結果の各アイテムです
+type NearbyMonsterItem struct {
+	Monster   MonsterItem `json:"monster"`    // Monster
+	DistanceM float64     `json:"distance_m"` // 中心からの距離(メートル)
+}
+
+// SearchMonstersNearbyResponse は指定した地点の周辺のMonster検索レスポンスです
+type SearchMonstersNearbyResponse struct {
+	Monsters []NearbyMonsterItem `json:"monsters"` // 中心から近い順のMonsterの配列
+}
+
+// SearchMonstersNearby は指定した地点の周辺のMonster検索ハンドラーです
+// 処理内容:
+// 1. 検索する円を囲む範囲のMonsterを、ゴミ種別で絞り込み中心から近い順に最大件数までデータベースから取得（idx_location を使う）
+// 2. ハーバーサインの公式で中心からの距離を求め、半径の外のMonsterを除く
+// 3. 近い順のMonsterに画像URL・分類種別・属性・種族名・能力値を付与して返す
+func SearchMonstersNearby(ctx context.Context, req *SearchMonstersNearbyRequest) (*SearchMonstersNearbyResponse, error) {
+	queries := mysql.GetQueries()
+	center := geo.Point{Latitude: req.Latitude, Longitude: req.Longitude}
+	radius := float64(req.RadiusM)
+	limit := cmp.Or(req.Limit, defaultNearbyLimit)
+
+	category, err := parseTrashCategoryFilter(string(req.TrashCategory))
+	if err != nil {
+		return nil, err
+	}
+
+	// 1. 検索する円を囲む範囲のMonsterを近い順に取得
+	monsters, err := listMonstersNearby(ctx, queries, center, radius, category, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2-3. 半径の内側のMonsterを近い順に並べる
+	nearby := nearbyMonsters(monsters, center, radius, limit)
+	found := make([]mysql.Monster, 0, len(nearby))
+	for _, n := range nearby {
+		found = append(found, n.monster)
+	}
+	monsterItems, err := buildMonsterItems(ctx, queries, found)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]NearbyMonsterItem, 0, len(nearby))
+	for i, n := range nearby {
+		items = append(items, NearbyMonsterItem{
+			Monster:   monsterItems[i],
+			DistanceM: n.distance,
+		})
+	}
+	return &SearchMonstersNearbyResponse{
+		Monsters: items,
+	}, nil
+}
+
+// GetMonstersInBoundsRequest は地図に表示している範囲のMonster取得リクエストです
+// クエリ文字列で受け取るため、範囲の南西・北東の地点は緯度・経度のフィールドに分けています
+type GetMonstersInBoundsRequest struct {
+	SouthWestLatitude  float64                      `json:"sw_latitude" query:"sw_latitude" validate:"min=-90,max=90"`     // 範囲の南西の緯度(-90.0 ~ 90.0)
+	SouthWestLongitude float64                      `json:"sw_longitude" query:"sw_longitude" validate:"min=-180,max=180"` // 範囲の南西の経度(-180.0 ~ 180.0)
+	NorthEastLatitude  float64                      `json:"ne_latitude" query:"ne_latitude" validate:"min=-90,max=90"`     // 範囲の北東の緯度(-90.0 ~ 90.0)
+	NorthEastLongitude float64                      `json:"ne_longitude" query:"ne_longitude" validate:"min=-180,max=180"` // 範囲の北東の経度(-180.0 ~ 180.0、南西より小さい場合は経度180度の線をまたぐ範囲)
+	Limit              int                          `json:"limit" query:"limit" validate:"min=1,max=500"`                  // 最大件数(省略した場合は200)
+	TrashCategory      enum.TrashCategoryFilterSlug `json:"trash_category" query:"trash_category"`                         // ゴミ種別の英語の識別子で絞り込む(省略した場合はすべて)
+}
+
+// Validate はリクエストのバリデーションを行います
+// 緯度・経度の範囲はvalidateタグで、南西と北東の位置関係はここでチェックします
+func (r GetMonstersInBoundsRequest) Validate() error {
+	if r.SouthWestLatitude > r.NorthEastLatitude {
+		return outorouter.ValidationFailedError(outorouter.FieldError{
+			Field:   "sw_latitude",
+			Code:    "bounds",
+			Message: "北東の緯度以下である必要があります",
+		})
+	}
+	return nil
+}
+
+// GetMonstersInBoundsResponse は地図に表示している範囲のMonster取得レスポンスです
+type GetMonstersInBoundsResponse struct {
+	Monsters []MonsterItem `json:"monsters"` // 新しい順のMonsterの配列
+}
+
+// GetMonstersInBounds は地図に表示している範囲のMonster取得ハンドラーです
+// 処理内容:
+// 1. 範囲内のMonsterを、ゴミ種別で絞り込み新しい順に最大件数までデータベースから取得（idx_location を使う）
+// 2. Monsterに画像URL・分類種別・属性・種族名・能力値を付与して返す
+func GetMonstersInBounds(ctx context.Context, req *GetMonstersInBoundsRequest) (*GetMonstersInBoundsResponse, error) {
+	queries := mysql.GetQueries()
+	bounds := geo.Bounds{
+		SouthWest: geo.Point{Latitude: req.SouthWestLatitude, Longitude: req.SouthWestLongitude},
+		NorthEast: geo.Point{Latitude: req.NorthEastLatitude, Longitude: req.NorthEastLongitude},
+	}
+
+	category, err := parseTrashCategoryFilter(string(req.TrashCategory))
+	if err != nil {
+		return nil, err
+	}
+
+	// 1. 範囲内のMonsterを取得
+	monsters, err := listMonstersInBounds(ctx, queries, bounds, category, cmp.Or(req.Limit, defaultBoundsLimit))
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. 画像URL・分類種別・属性・種族名・能力値を付与
+	monsterItems, err := buildMonsterItems(ctx, queries, monsters)
+	if err != nil {
+		return nil, err
+	}
+	return &GetMonstersInBoundsResponse{
+		Monsters: monsterItems,
+	}, nil
+}
+
+// parseTrashCategoryFilter は絞り込むゴミ種別の英語の識別子を解析します（空の場合は指定なしを返します）
+func parseTrashCategoryFilter(slug string) (enum.TrashCategory, error) {
+	if slug == "" {
+		return enum.TrashCategoryNone, nil
+	}
+	category, ok := enum.ParseTrashCategory(slug)
+	if !ok {
+		return enum.TrashCategoryNone, fmt.Errorf("unknown trash category %q", slug)
+	}
+	return category, nil
+}
+
+// listMonstersInBounds は範囲内のMonsterを新しい順に最大 limit 件取得します
+// ゴミ種別が指定なし以外の場合は、そのゴミ種別のMonsterだけを取得します
+func listMonstersInBounds(ctx context.Context, queries mysql.Querier, bounds geo.Bounds, category enum.TrashCategory, limit int) ([]mysql.Monster, error) {
+	monsters, err := listMonstersInLongitudeRanges(bounds, func(lng geo.LongitudeRange) ([]mysql.Monster, error) {
+		if category == enum.TrashCategoryNone {
+			return queries.ListMonstersInBounds(ctx, mysql.ListMonstersInBoundsParams{
+				MinLatitude:  decimalParam(bounds.SouthWest.Latitude),
+				MaxLatitude:  decimalParam(bounds.NorthEast.Latitude),
+				MinLongitude: decimalParam(lng.Min),
+				MaxLongitude: decimalParam(lng.Max),
+				MaxRows:      int32(limit),
+			})
+		}
+		return queries.ListMonstersInBoundsByTrashCategory(ctx, mysql.ListMonstersInBoundsByTrashCategoryParams{
+			MinLatitude:   decimalParam(bounds.SouthWest.Latitude),
+			MaxLatitude:   decimalParam(bounds.NorthEast.Latitude),
+			MinLongitude:  decimalParam(lng.Min),
+			MaxLongitude:  decimalParam(lng.Max),
+			TrashCategory: uint8(category),
+			MaxRows:       int32(limit),
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list monsters in bounds: %w", err)
+	}
+
+	slices.SortStableFunc(monsters, func(a, b mysql.Monster) int {
+		return b.Createdat.Compare(a.Createdat)
+	})
+	if len(monsters) > limit {
+		monsters = monsters[:limit]
+	}
+	return monsters, nil
+}
+
+// listMonstersNearby は中心から半径 radiusM メートルの円を囲む範囲のMonsterを、中心から近い順に最大 limit 件取得します
+// 近い順に取得するため、半径の内側のMonsterは近いものから limit 件まで必ず含まれます（半径の外の範囲の隅のMonsterも含まれます）
+// ゴミ種別が指定なし以外の場合は、そのゴミ種別のMonsterだけを取得します
+func listMonstersNearby(ctx context.Context, queries mysql.Querier, center geo.Point, radiusM float64, category enum.TrashCategory, limit int) ([]mysql.Monster, error) {
+	bounds := geo.BoundsAround(center, radiusM)
+	monsters, err := listMonstersInLongitudeRanges(bounds, func(lng geo.LongitudeRange) ([]mysql.Monster, error) {
+		if category == enum.TrashCategoryNone {
+			return queries.ListMonstersNearby(ctx, mysql.ListMonstersNearbyParams{
+				MinLatitude:     decimalParam(bounds.SouthWest.Latitude),
+				MaxLatitude:     decimalParam(bounds.NorthEast.Latitude),
+				MinLongitude:    decimalParam(lng.Min),
+				MaxLongitude:    decimalParam(lng.Max),
+				CenterLongitude: center.Longitude,
+				CenterLatitude:  center.Latitude,
+				MaxRows:         int32(limit),
+			})
+		}
+		return queries.ListMonstersNearbyByTrashCategory(ctx, mysql.ListMonstersNearbyByTrashCategoryParams{
+			MinLatitude:     decimalParam(bounds.SouthWest.Latitude),
+			MaxLatitude:     decimalParam(bounds.NorthEast.Latitude),
+			MinLongitude:    decimalParam(lng.Min),
+			MaxLongitude:    decimalParam(lng.Max),
+			TrashCategory:   uint8(category),
+			CenterLongitude: center.Longitude,
+			CenterLatitude:  center.Latitude,
+			MaxRows:         int32(limit),
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list monsters nearby: %w", err)
+	}
+	return monsters, nil
+}
+
+// listMonstersInLongitudeRanges は範囲の経度ごとに list で取得したMonsterを重複を除いてまとめます
+// 経度180度の線をまたぐ範囲は、線の東側と西側に分けて取得します
+func listMonstersInLongitudeRanges(bounds geo.Bounds, list func(lng geo.LongitudeRange) ([]mysql.Monster, error)) ([]mysql.Monster, error) {
+	var monsters []mysql.Monster
+	seen := make(map[string]bool)
+	for _, lng := range bounds.LongitudeRanges() {
+		found, err := list(lng)
+		if err != nil {
+			return nil, err
+		}
+		for _, monster := range found {
+			// 経度180度と-180度の線上のMonsterは両方の範囲に含まれる
+			if !seen[monster.Monsterid] {
+				seen[monster.Monsterid] = true
+				monsters = append(monsters, monster)
+			}
+		}
+	}
+	return monsters, nil
+}
+
+// nearbyMonster は中心からの距離を求めたMonsterです
+type nearbyMonster struct {
+	monster  mysql.Monster
+	distance float64
+}
+
+// nearbyMonsters は中心から半径 radiusM メートル以内のMonsterを近い順に最大 limit 件返します
+// 緯度・経度が保存されていないMonsterは除きます
+func nearbyMonsters(monsters []mysql.Monster, center geo.Point, radiusM float64, limit int) []nearbyMonster {
+	var nearby []nearbyMonster
+	for _, monster := range monsters {
+		point, ok := monsterPoint(monster)
+		if !ok {
+			continue
+		}
+		if d := geo.Distance(center, point); d <= radiusM {
+			nearby = append(nearby, nearbyMonster{monster: monster, distance: d})
+		}
+	}
+
+	slices.SortStableFunc(nearby, func(a, b nearbyMonster) int {
+		return cmp.Compare(a.distance, b.distance)
+	})
+	if len(nearby) > limit {
+		nearby = nearby[:limit]
+	}
+	return nearby
+}
+
+// decimalParam は緯度・経度をDECIMAL型の列と比較するクエリのパラメーターに変換します
+func decimalParam(v float64) sql.NullString {
+	return sql.NullString{String: strconv.FormatFloat(v, 'f', 8, 64), Valid: true}
+}
@@ -0,0 +1,332 @@
+package handler
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/kinpatsu-everyone/backend-template/enum"
+	"github.com/kinpatsu-everyone/backend-template/internal/geo"
+	"github.com/kinpatsu-everyone/backend-template/internal/mysql"
+	"github.com/kinpatsu-everyone/backend-template/pkg/outorouter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// locationQuerier は ListMonstersInBounds・ListMonstersNearby とゴミ種別で絞り込む版のみ実装した mysql.Querier です
+// データベースと同じく範囲内（とゴミ種別）で絞り込み、並べ替えて最大件数まで返し、受け取った最大件数を記録します
+type locationQuerier struct {
+	mysql.Querier
+	monsters   []mysql.Monster
+	categories map[string]enum.TrashCategory
+	maxRows    []int32
+}
+
+func (q *locationQuerier) ListMonstersInBounds(_ context.Context, arg mysql.ListMonstersInBoundsParams) ([]mysql.Monster, error) {
+	items := q.inBounds(arg.MinLatitude, arg.MaxLatitude, arg.MinLongitude, arg.MaxLongitude, nil)
+	return q.newestFirst(items, arg.MaxRows), nil
+}
+
+func (q *locationQuerier) ListMonstersInBoundsByTrashCategory(_ context.Context, arg mysql.ListMonstersInBoundsByTrashCategoryParams) ([]mysql.Monster, error) {
+	items := q.inBounds(arg.MinLatitude, arg.MaxLatitude, arg.MinLongitude, arg.MaxLongitude, &arg.TrashCategory)
+	return q.newestFirst(items, arg.MaxRows), nil
+}
+
+func (q *locationQuerier) ListMonstersNearby(_ context.Context, arg mysql.ListMonstersNearbyParams) ([]mysql.Monster, error) {
+	items := q.inBounds(arg.MinLatitude, arg.MaxLatitude, arg.MinLongitude, arg.MaxLongitude, nil)
+	return q.nearestFirst(items, arg.CenterLatitude, arg.CenterLongitude, arg.MaxRows), nil
+}
+
+func (q *locationQuerier) ListMonstersNearbyByTrashCategory(_ context.Context, arg mysql.ListMonstersNearbyByTrashCategoryParams) ([]mysql.Monster, error) {
+	items := q.inBounds(arg.MinLatitude, arg.MaxLatitude, arg.MinLongitude, arg.MaxLongitude, &arg.TrashCategory)
+	return q.nearestFirst(items, arg.CenterLatitude, arg.CenterLongitude, arg.MaxRows), nil
+}
+
+// inBounds は範囲内のMonsterを返します（category が nil 以外の場合はそのゴミ種別のMonsterだけを返します）
+func (q *locationQuerier) inBounds(minLat, maxLat, minLng, maxLng sql.NullString, category *uint8) []mysql.Monster {
+	items := []mysql.Monster{}
+	for _, m := range q.monsters {
+		if category != nil && uint8(q.categories[m.Monsterid]) != *category {
+			continue
+		}
+		p, ok := monsterPoint(m)
+		if !ok {
+			continue
+		}
+		if p.Latitude >= parseDecimal(minLat) && p.Latitude <= parseDecimal(maxLat) && p.Longitude >= parseDecimal(minLng) && p.Longitude <= parseDecimal(maxLng) {
+			items = append(items, m)
+		}
+	}
+	return items
+}
+
+// newestFirst は ORDER BY CreatedAt DESC LIMIT maxRows と同じく新しい順に最大件数まで返します
+func (q *locationQuerier) newestFirst(items []mysql.Monster, maxRows int32) []mysql.Monster {
+	q.maxRows = append(q.maxRows, maxRows)
+	slices.SortStableFunc(items, func(a, b mysql.Monster) int {
+		return b.Createdat.Compare(a.Createdat)
+	})
+	return items[:min(len(items), int(maxRows))]
+}
+
+// nearestFirst は ORDER BY ST_Distance_Sphere(...) LIMIT maxRows と同じく中心から近い順に最大件数まで返します
+func (q *locationQuerier) nearestFirst(items []mysql.Monster, lat, lng any, maxRows int32) []mysql.Monster {
+	q.maxRows = append(q.maxRows, maxRows)
+	center := geo.Point{Latitude: lat.(float64), Longitude: lng.(float64)}
+	distance := func(m mysql.Monster) float64 {
+		p, _ := monsterPoint(m)
+		return geo.Distance(center, p)
+	}
+	slices.SortStableFunc(items, func(a, b mysql.Monster) int {
+		return cmp.Compare(distance(a), distance(b))
+	})
+	return items[:min(len(items), int(maxRows))]
+}
+
+func parseDecimal(s sql.NullString) float64 {
+	v, _ := strconv.ParseFloat(s.String, 64)
+	return v
+}
+
+// testMonster は指定した位置・作成日時のMonsterを作成します（位置が空の場合は保存されていないMonster）
+func testMonster(id, lat, lng string, createdAt time.Time) mysql.Monster {
+	return mysql.Monster{
+		Monsterid: id,
+		Latitude:  sql.NullString{String: lat, Valid: lat != ""},
+		Longitude: sql.NullString{String: lng, Valid: lng != ""},
+		Createdat: createdAt,
+	}
+}
+
+func TestListMonstersInBounds(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	monsters := []mysql.Monster{
+		testMonster("tokyo", "35.68123600", "139.76712500", now.Add(-2*time.Hour)),
+		testMonster("shinjuku", "35.69092100", "139.70025800", now),
+		testMonster("osaka", "34.70248500", "135.49595100", now.Add(-time.Hour)),
+		testMonster("fiji-east", "-17.70000000", "179.90000000", now.Add(-3*time.Hour)),
+		testMonster("fiji-west", "-17.70000000", "-179.90000000", now.Add(-time.Minute)),
+		testMonster("antimeridian", "-17.70000000", "180.00000000", now.Add(-4*time.Hour)),
+		testMonster("no-location", "", "", now),
+	}
+	categories := map[string]enum.TrashCategory{
+		"tokyo":     enum.TrashCategoryBurnable,
+		"shinjuku":  enum.TrashCategoryPetBottle,
+		"osaka":     enum.TrashCategoryBurnable,
+		"fiji-east": enum.TrashCategoryCan,
+	}
+	japan := geo.Bounds{SouthWest: geo.Point{Latitude: 34, Longitude: 135}, NorthEast: geo.Point{Latitude: 36, Longitude: 140}}
+
+	tests := []struct {
+		name            string
+		bounds          geo.Bounds
+		category        enum.TrashCategory
+		limit           int
+		expectedIDs     []string
+		expectedMaxRows []int32
+	}{
+		{
+			name:            "範囲内のMonsterを新しい順に返す",
+			bounds:          japan,
+			limit:           10,
+			expectedIDs:     []string{"shinjuku", "osaka", "tokyo"},
+			expectedMaxRows: []int32{10},
+		},
+		{
+			name:            "範囲外のMonsterは返さない",
+			bounds:          geo.Bounds{SouthWest: geo.Point{Latitude: 35.6, Longitude: 139.74}, NorthEast: geo.Point{Latitude: 35.7, Longitude: 139.8}},
+			limit:           10,
+			expectedIDs:     []string{"tokyo"},
+			expectedMaxRows: []int32{10},
+		},
+		{
+			name:            "最大件数をデータベースに渡して取得する",
+			bounds:          japan,
+			limit:           2,
+			expectedIDs:     []string{"shinjuku", "osaka"},
+			expectedMaxRows: []int32{2},
+		},
+		{
+			name:            "ゴミ種別が一致するMonsterだけを返す",
+			bounds:          japan,
+			category:        enum.TrashCategoryBurnable,
+			limit:           10,
+			expectedIDs:     []string{"osaka", "tokyo"},
+			expectedMaxRows: []int32{10},
+		},
+		{
+			name:            "ゴミ種別が一致するMonsterがない場合は空",
+			bounds:          japan,
+			category:        enum.TrashCategoryGlassBottle,
+			limit:           10,
+			expectedIDs:     []string{},
+			expectedMaxRows: []int32{10},
+		},
+		{
+			name:            "経度180度の線をまたぐ範囲は2回に分けて取得し重複を除く",
+			bounds:          geo.Bounds{SouthWest: geo.Point{Latitude: -20, Longitude: 179}, NorthEast: geo.Point{Latitude: -15, Longitude: -179}},
+			limit:           10,
+			expectedIDs:     []string{"fiji-west", "fiji-east", "antimeridian"},
+			expectedMaxRows: []int32{10, 10},
+		},
+		{
+			name:            "経度180度の線をまたぐ範囲は合わせて最大件数まで返す",
+			bounds:          geo.Bounds{SouthWest: geo.Point{Latitude: -20, Longitude: 179}, NorthEast: geo.Point{Latitude: -15, Longitude: -179}},
+			limit:           2,
+			expectedIDs:     []string{"fiji-west", "fiji-east"},
+			expectedMaxRows: []int32{2, 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := &locationQuerier{monsters: monsters, categories: categories}
+
+			got, err := listMonstersInBounds(context.Background(), q, tt.bounds, tt.category, tt.limit)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedIDs, monsterIDs(got))
+			assert.Equal(t, tt.expectedMaxRows, q.maxRows)
+		})
+	}
+}
+
+func TestListMonstersNearby(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	tokyo := geo.Point{Latitude: 35.681236, Longitude: 139.767125}
+	monsters := []mysql.Monster{
+		testMonster("shinjuku", "35.69092100", "139.70025800", now),
+		testMonster("tokyo", "35.68123600", "139.76712500", now.Add(-time.Hour)),
+		testMonster("yurakucho", "35.67500000", "139.76300000", now.Add(-2*time.Hour)),
+		testMonster("osaka", "34.70248500", "135.49595100", now),
+		testMonster("no-location", "", "", now),
+	}
+	categories := map[string]enum.TrashCategory{
+		"shinjuku":  enum.TrashCategoryBurnable,
+		"tokyo":     enum.TrashCategoryPetBottle,
+		"yurakucho": enum.TrashCategoryBurnable,
+	}
+
+	tests := []struct {
+		name        string
+		category    enum.TrashCategory
+		limit       int
+		expectedIDs []string
+	}{
+		{name: "円を囲む範囲のMonsterを近い順に返す", limit: 10, expectedIDs: []string{"tokyo", "yurakucho", "shinjuku"}},
+		{name: "作成日時によらず近いMonsterから最大件数まで返す", limit: 2, expectedIDs: []string{"tokyo", "yurakucho"}},
+		{name: "ゴミ種別が一致するMonsterだけを返す", category: enum.TrashCategoryBurnable, limit: 10, expectedIDs: []string{"yurakucho", "shinjuku"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := &locationQuerier{monsters: monsters, categories: categories}
+
+			got, err := listMonstersNearby(context.Background(), q, tokyo, 10000, tt.category, tt.limit)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedIDs, monsterIDs(got))
+			assert.Equal(t, []int32{int32(tt.limit)}, q.maxRows)
+		})
+	}
+}
+
+func TestParseTrashCategoryFilter(t *testing.T) {
+	tests := []struct {
+		name        string
+		slug        string
+		expected    enum.TrashCategory
+		expectedErr bool
+	}{
+		{name: "指定しない場合は指定なし", slug: "", expected: enum.TrashCategoryNone},
+		{name: "英語の識別子のゴミ種別", slug: "pet_bottle", expected: enum.TrashCategoryPetBottle},
+		{name: "不明な識別子はエラー", slug: "unknown", expectedErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseTrashCategoryFilter(tt.slug)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestNearbyMonsters(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	tokyo := geo.Point{Latitude: 35.681236, Longitude: 139.767125}
+	monsters := []mysql.Monster{
+		testMonster("shinjuku", "35.69092100", "139.70025800", now),
+		testMonster("tokyo", "35.68123600", "139.76712500", now),
+		testMonster("yurakucho", "35.67500000", "139.76300000", now),
+		testMonster("no-location", "", "", now),
+	}
+
+	tests := []struct {
+		name        string
+		radiusM     float64
+		limit       int
+		expectedIDs []string
+	}{
+		{name: "半径の内側のMonsterを近い順に返す", radiusM: 10000, limit: 10, expectedIDs: []string{"tokyo", "yurakucho", "shinjuku"}},
+		{name: "半径の外のMonsterは返さない", radiusM: 1000, limit: 10, expectedIDs: []string{"tokyo", "yurakucho"}},
+		{name: "最大件数まで返す", radiusM: 10000, limit: 2, expectedIDs: []string{"tokyo", "yurakucho"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := nearbyMonsters(monsters, tokyo, tt.radiusM, tt.limit)
+
+			ids := make([]string, 0, len(got))
+			for i, n := range got {
+				ids = append(ids, n.monster.Monsterid)
+				assert.LessOrEqual(t, n.distance, tt.radiusM)
+				if i > 0 {
+					assert.GreaterOrEqual(t, n.distance, got[i-1].distance)
+				}
+			}
+			assert.Equal(t, tt.expectedIDs, ids)
+		})
+	}
+}
+
+func TestGetMonstersInBoundsRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name        string
+		req         GetMonstersInBoundsRequest
+		expectedErr bool
+	}{
+		{
+			name: "南西の緯度が北東以下",
+			req:  GetMonstersInBoundsRequest{SouthWestLatitude: 34, SouthWestLongitude: 135, NorthEastLatitude: 36, NorthEastLongitude: 140},
+		},
+		{
+			name: "経度180度の線をまたぐ範囲",
+			req:  GetMonstersInBoundsRequest{SouthWestLatitude: -20, SouthWestLongitude: 179, NorthEastLatitude: -15, NorthEastLongitude: -179},
+		},
+		{
+			name:        "南西の緯度が北東より大きい",
+			req:         GetMonstersInBoundsRequest{SouthWestLatitude: 36, SouthWestLongitude: 135, NorthEastLatitude: 34, NorthEastLongitude: 140},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if !tt.expectedErr {
+				assert.NoError(t, err)
+				return
+			}
+			var httpErr outorouter.HTTPError
+			require.ErrorAs(t, err, &httpErr)
+			assert.Equal(t, outorouter.ErrorCodeValidationFailed, httpErr.Code())
+		})
+	}
+}
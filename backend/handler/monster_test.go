@@ -0,0 +1,363 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/kinpatsu-everyone/backend-template/enum"
+	"github.com/kinpatsu-everyone/backend-template/internal/geo"
+	"github.com/kinpatsu-everyone/backend-template/internal/mysql"
+	"github.com/kinpatsu-everyone/backend-template/pkg/outorouter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthorizeMonsterOwner(t *testing.T) {
+	owned := mysql.Monster{Monsterid: "monster-1", Userid: sql.NullString{String: "user-1", Valid: true}}
+	unowned := mysql.Monster{Monsterid: "monster-2"}
+
+	tests := []struct {
+		name        string
+		principal   *outorouter.Principal
+		monster     mysql.Monster
+		expectedErr error
+	}{
+		{
+			name:      "所有者は操作できる",
+			principal: &outorouter.Principal{Subject: "user-1"},
+			monster:   owned,
+		},
+		{
+			name:        "所有者以外は操作できない",
+			principal:   &outorouter.Principal{Subject: "user-2"},
+			monster:     owned,
+			expectedErr: ErrNotMonsterOwner,
+		},
+		{
+			name:        "未認証の場合は操作できない",
+			monster:     owned,
+			expectedErr: ErrNotMonsterOwner,
+		},
+		{
+			name:        "所有者のいないMonsterは誰も操作できない",
+			principal:   &outorouter.Principal{Subject: "user-1"},
+			monster:     unowned,
+			expectedErr: ErrNotMonsterOwner,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.principal != nil {
+				ctx = outorouter.WithPrincipal(ctx, tt.principal)
+			}
+
+			err := authorizeMonsterOwner(ctx, tt.monster)
+			assert.Equal(t, tt.expectedErr, err)
+		})
+	}
+}
+
+// attributeQuerier は GetMonsterAttribute のみ実装した mysql.Querier です
+type attributeQuerier struct {
+	mysql.Querier
+	attribute mysql.Monsterattribute
+	err       error
+}
+
+func (q attributeQuerier) GetMonsterAttribute(_ context.Context, _ string) (mysql.Monsterattribute, error) {
+	return q.attribute, q.err
+}
+
+func TestMonsterPoint(t *testing.T) {
+	valid := func(s string) sql.NullString { return sql.NullString{String: s, Valid: true} }
+
+	tests := []struct {
+		name      string
+		monster   mysql.Monster
+		wantPoint geo.Point
+		wantOK    bool
+	}{
+		{
+			name:      "緯度・経度を返す",
+			monster:   mysql.Monster{Latitude: valid("35.68120000"), Longitude: valid("139.76710000")},
+			wantPoint: geo.Point{Latitude: 35.6812, Longitude: 139.7671},
+			wantOK:    true,
+		},
+		{
+			name:    "経度が保存されていない場合はfalse",
+			monster: mysql.Monster{Latitude: valid("35.68120000")},
+		},
+		{
+			name:    "解析できない場合はfalse",
+			monster: mysql.Monster{Latitude: valid("north"), Longitude: valid("139.76710000")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			point, ok := monsterPoint(tt.monster)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantPoint, point)
+		})
+	}
+}
+
+func TestGetMonsterAttribute(t *testing.T) {
+	saved := mysql.Monsterattribute{Monsterid: "monster-1", Attributename: "炎", Colorcode: "#D84315"}
+	dbErr := errors.New("connection refused")
+
+	tests := []struct {
+		name          string
+		querier       attributeQuerier
+		trashCategory enum.TrashCategory
+		expected      mysql.Monsterattribute
+		expectedErr   error
+	}{
+		{
+			name:          "保存された属性と色を返す",
+			querier:       attributeQuerier{attribute: saved},
+			trashCategory: enum.TrashCategoryPetBottle,
+			expected:      saved,
+		},
+		{
+			name:          "属性が保存されていない場合はゴミ種別の属性と色を返す",
+			querier:       attributeQuerier{err: sql.ErrNoRows},
+			trashCategory: enum.TrashCategoryPetBottle,
+			expected:      mysql.Monsterattribute{Monsterid: "monster-1", Attributename: "水", Colorcode: "#1E88E5"},
+		},
+		{
+			name:          "取得に失敗した場合はゴミ種別の属性と色とエラーを返す",
+			querier:       attributeQuerier{err: dbErr},
+			trashCategory: enum.TrashCategoryBurnable,
+			expected:      mysql.Monsterattribute{Monsterid: "monster-1", Attributename: "炎", Colorcode: "#E53935"},
+			expectedErr:   dbErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attribute, err := getMonsterAttribute(context.Background(), tt.querier, "monster-1", tt.trashCategory)
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expected, attribute)
+		})
+	}
+}
+
+// detailsQuerier は ListMonster*ByMonsterIds のみ実装した mysql.Querier です
+// Monsterごとに取得するメソッド（GetMonsterAttribute など）を呼ぶと nil の mysql.Querier を参照して panic します
+type detailsQuerier struct {
+	mysql.Querier
+	categories []mysql.Monstertrashcategory
+	attributes []mysql.Monsterattribute
+	profiles   []mysql.Monsterprofile
+	stats      []mysql.Monsterstat
+	calls      int
+}
+
+func (q *detailsQuerier) ListMonsterTrashCategoriesByMonsterIds(_ context.Context, _ []string) ([]mysql.Monstertrashcategory, error) {
+	q.calls++
+	return q.categories, nil
+}
+
+func (q *detailsQuerier) ListMonsterAttributesByMonsterIds(_ context.Context, _ []string) ([]mysql.Monsterattribute, error) {
+	q.calls++
+	return q.attributes, nil
+}
+
+func (q *detailsQuerier) ListMonsterProfilesByMonsterIds(_ context.Context, _ []string) ([]mysql.Monsterprofile, error) {
+	q.calls++
+	return q.profiles, nil
+}
+
+func (q *detailsQuerier) ListMonsterStatsByMonsterIds(_ context.Context, _ []string) ([]mysql.Monsterstat, error) {
+	q.calls++
+	return q.stats, nil
+}
+
+func TestBuildMonsterItems(t *testing.T) {
+	tests := []struct {
+		name          string
+		monsters      []mysql.Monster
+		querier       *detailsQuerier
+		expected      []MonsterItem
+		expectedCalls int
+	}{
+		{
+			name: "Monsterの数によらずテーブルごとに一度だけ取得する",
+			monsters: []mysql.Monster{
+				{Monsterid: "monster-1", Nickname: "もえるん", Latitude: sql.NullString{String: "35.68123600", Valid: true}, Longitude: sql.NullString{String: "139.76712500", Valid: true}},
+				{Monsterid: "monster-2", Nickname: "ぺっとん"},
+			},
+			querier: &detailsQuerier{
+				categories: []mysql.Monstertrashcategory{
+					{Monsterid: "monster-1", Trashcategory: uint8(enum.TrashCategoryBurnable)},
+					{Monsterid: "monster-1", Trashcategory: uint8(enum.TrashCategoryCan)},
+					{Monsterid: "monster-2", Trashcategory: uint8(enum.TrashCategoryPetBottle)},
+				},
+				attributes: []mysql.Monsterattribute{{Monsterid: "monster-1", Attributename: "雷", Colorcode: "#FDD835"}},
+				profiles:   []mysql.Monsterprofile{{Monsterid: "monster-1", Speciesname: "モエルン", Description: "よく燃える"}},
+				stats:      []mysql.Monsterstat{{Monsterid: "monster-1", Hp: 50, Attack: 40, Defense: 30, Speed: 20}},
+			},
+			expected: []MonsterItem{
+				{
+					ID: "monster-1", Nickname: "もえるん", Latitude: 35.681236, Longitude: 139.767125,
+					TrashCategory: "燃えるゴミ", TrashCategorySlug: "burnable",
+					Attribute: "雷", ColorCode: "#FDD835",
+					SpeciesName: "モエルン", Description: "よく燃える",
+					Stats: MonsterStats{HP: 50, Attack: 40, Defense: 30, Speed: 20},
+				},
+				{
+					ID: "monster-2", Nickname: "ぺっとん",
+					TrashCategory: "ペットボトル", TrashCategorySlug: "pet_bottle",
+					Attribute: "水", ColorCode: "#1E88E5",
+				},
+			},
+			expectedCalls: 4,
+		},
+		{
+			name:          "Monsterがない場合は取得しない",
+			querier:       &detailsQuerier{},
+			expected:      []MonsterItem{},
+			expectedCalls: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := buildMonsterItems(context.Background(), tt.querier, tt.monsters)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+			assert.Equal(t, tt.expectedCalls, tt.querier.calls)
+		})
+	}
+}
+
+// jobStatusQuerier は GetMonsterGenerationJobStatus のみ実装した mysql.Querier です
+// 呼び出されるたびに statuses を先頭から順に返し、最後の状態を返し続けます
+type jobStatusQuerier struct {
+	mysql.Querier
+	userID   sql.NullString
+	statuses []string
+	err      error
+
+	mu    sync.Mutex
+	calls int
+}
+
+func (q *jobStatusQuerier) GetMonsterGenerationJobStatus(_ context.Context, jobID string) (mysql.GetMonsterGenerationJobStatusRow, error) {
+	if q.err != nil {
+		return mysql.GetMonsterGenerationJobStatusRow{}, q.err
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	status := q.statuses[min(q.calls, len(q.statuses)-1)]
+	q.calls++
+	return mysql.GetMonsterGenerationJobStatusRow{
+		Jobid:       jobID,
+		Monsterid:   "monster-1",
+		Userid:      q.userID,
+		Status:      status,
+		Attempts:    1,
+		Maxattempts: 3,
+	}, nil
+}
+
+// subjectVerifier はトークンをそのまま Subject とする Verifier です
+type subjectVerifier struct{}
+
+func (subjectVerifier) Verify(_ context.Context, token string) (*outorouter.Principal, error) {
+	return &outorouter.Principal{Subject: token}, nil
+}
+
+func TestWatchMonsterGeneration(t *testing.T) {
+	owner := sql.NullString{String: "user-1", Valid: true}
+
+	tests := []struct {
+		name             string
+		querier          *jobStatusQuerier
+		expectedStatuses []string
+		expectedCode     string
+	}{
+		{
+			name:             "状態が変わるたびに送信し、完了したら閉じる",
+			querier:          &jobStatusQuerier{userID: owner, statuses: []string{"queued", "queued", "generating", "done"}},
+			expectedStatuses: []string{"queued", "generating", "done"},
+		},
+		{
+			name:             "失敗したら閉じる",
+			querier:          &jobStatusQuerier{userID: owner, statuses: []string{"analyzing", "failed"}},
+			expectedStatuses: []string{"analyzing", "failed"},
+		},
+		{
+			name:         "他のユーザーのジョブは購読できない",
+			querier:      &jobStatusQuerier{userID: sql.NullString{String: "user-2", Valid: true}, statuses: []string{"queued"}},
+			expectedCode: "GENERATION_JOB_NOT_FOUND",
+		},
+		{
+			name:         "未認証で作成されたジョブは購読できない",
+			querier:      &jobStatusQuerier{statuses: []string{"queued"}},
+			expectedCode: "GENERATION_JOB_NOT_FOUND",
+		},
+		{
+			name:         "存在しないジョブは購読できない",
+			querier:      &jobStatusQuerier{err: sql.ErrNoRows},
+			expectedCode: "GENERATION_JOB_NOT_FOUND",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := outorouter.New(outorouter.WithVerifiers(subjectVerifier{}))
+			outorouter.RegisterWebSocketEndpoint(r, outorouter.WebSocketEndpoint[WatchMonsterGenerationRequest, WatchMonsterGenerationResponse]{
+				Domain:     "monster",
+				Version:    1,
+				MethodName: "WatchMonsterGeneration",
+				Auth:       outorouter.AuthRequired,
+				Handler: func(ctx context.Context, stream *outorouter.WebSocketStream[WatchMonsterGenerationRequest, WatchMonsterGenerationResponse]) error {
+					req, err := stream.Receive(ctx)
+					if err != nil {
+						return err
+					}
+					return watchMonsterGeneration(ctx, tt.querier, stream, req.JobID, time.Millisecond)
+				},
+			})
+			srv := httptest.NewServer(r.Handler())
+			t.Cleanup(srv.Close)
+
+			dialer := websocket.Dialer{Subprotocols: []string{"bearer", "user-1"}}
+			conn, _, err := dialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http")+"/monster/v1/WatchMonsterGeneration", nil)
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = conn.Close() })
+			require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+			require.NoError(t, conn.WriteJSON(WatchMonsterGenerationRequest{JobID: "job-1"}))
+
+			if tt.expectedCode != "" {
+				var errRes outorouter.ErrorResponse
+				require.NoError(t, conn.ReadJSON(&errRes))
+				assert.Equal(t, tt.expectedCode, errRes.Error.Code)
+				return
+			}
+
+			var statuses []string
+			for {
+				var res WatchMonsterGenerationResponse
+				if err := conn.ReadJSON(&res); err != nil {
+					assert.True(t, websocket.IsCloseError(err, websocket.CloseNormalClosure), err)
+					break
+				}
+				assert.Equal(t, "job-1", res.JobID)
+				statuses = append(statuses, res.Status)
+			}
+			assert.Equal(t, tt.expectedStatuses, statuses)
+		})
+	}
+}
@@ -1,53 +0,0 @@
-package handler
-
-// AnalyzeTrashBinPrompt はゴミ箱の写真から分別種類を判定するためのプロンプトです
-const AnalyzeTrashBinPrompt = `この画像に写っているゴミ箱を分析してください。
-
-以下の点を確認して、JSON形式で回答してください：
-
-【ゴミ箱の外観から判断】
-1. ゴミ箱の色（燃やすゴミ、燃やさないゴミ、資源ゴミ、有害ゴミなど）
-2. ゴミ箱に書かれている文字やマーク
-3. ゴミ箱の形状や特徴
-
-【ゴミ箱の中身から判断】
-4. ゴミ箱の中に入っているゴミの種類（見える範囲で）
-5. 中身のゴミから推測できる分別種類
-
-【総合判定】
-6. 外観と中身の両方の情報を総合して、最も適切な分別種類を判定してください
-7. 外観と中身で判定が異なる場合は、より確実な方を優先してください
-
-判定できる分別種類の例：
-- 燃やすゴミ（可燃ごみ）
-- 燃やさないゴミ（不燃ごみ）
-- プラスチック
-- 紙
-- 缶
-- びん
-- ペットボトル
-- 有害ゴミ
-- その他
-
-回答は以下のJSON形式で返してください：
-{
-  "trash_type": "判定された分別種類",
-  "color": "ゴミ箱の色",
-  "markings": "ゴミ箱に書かれている文字やマーク",
-  "contents": "ゴミ箱の中身の説明（見える範囲で）",
-  "reasoning": "外観と中身のどちらから判定したか、その理由",
-  "description": "ゴミ箱の詳細な説明（外観と中身を含む）",
-  "confidence": "判定の確信度（high/medium/low）"
-}
-
-もしゴミ箱が写っていない、または判定できない場合は、trash_typeを"unknown"としてください。`
-
-// GenerateTrashMonsterPromptTemplate は分別種をテーマにしたモンスターキャラクター生成用のプロンプトテンプレートです
-// %s には分別種が入ります
-const GenerateTrashMonsterPromptTemplate = `この写真のゴミ箱をリアルなモンスターにしてください。
-
-以下の条件を守ってください：
-・日本文化をモチーフにした動物や妖怪・幻獣など
-・分別種「%s」をテーマにしたデザイン
-・全く新しい画像ではなく、既存の写真のゴミ箱をそのままモンスターに生まれ変わらせること。
-`
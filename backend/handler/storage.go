@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/kinpatsu-everyone/backend-template/internal/blob"
+	"github.com/kinpatsu-everyone/backend-template/pkg/outorouter"
+)
+
+// GetStorageObjectRequest は署名付きURLによる画像取得リクエストです
+type GetStorageObjectRequest struct {
+	Key       string `json:"key" validate:"required,max=1024"` // オブジェクトのキー（例: "monsters/{uuid}/generated.png"）
+	Expires   int64  `json:"expires" validate:"required"`      // 署名付きURLの有効期限（UNIX時間）
+	Signature string `json:"signature" validate:"required"`    // 署名付きURLの署名
+}
+
+// Validate はリクエストのバリデーションを行います
+func (r GetStorageObjectRequest) Validate() error {
+	return nil
+}
+
+// GetStorageObject は署名付きURLによる画像取得ハンドラーです
+// ローカル・メモリのストレージが発行した署名付きURLを検証し、画像を返します
+// GCSのようにストレージ自身が署名付きURLを配信する場合は利用できません
+func GetStorageObject(ctx context.Context, req *GetStorageObjectRequest) (*outorouter.FileDownloadResponseObject, error) {
+	store, err := verifyStorageObjectRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	data, info, err := store.Get(ctx, req.Key)
+	if err != nil {
+		if errors.Is(err, blob.ErrNotFound) {
+			return nil, ErrImageNotFound
+		}
+		return nil, fmt.Errorf("failed to get object: %w", err)
+	}
+
+	return &outorouter.FileDownloadResponseObject{
+		Filename:    path.Base(req.Key),
+		ContentType: info.ContentType,
+		Content:     data,
+		Inline:      true,
+		ETag:        objectETag(info),
+		ModTime:     info.ModTime,
+	}, nil
+}
+
+// GetStorageObjectETag は画像を取得せずに GetStorageObject のETagを返します
+// If-None-Match が一致する場合は画像を取得せずに304を返すために使います
+func GetStorageObjectETag(ctx context.Context, req *GetStorageObjectRequest) (string, error) {
+	store, err := verifyStorageObjectRequest(ctx, req)
+	if err != nil {
+		return "", err
+	}
+
+	info, err := store.Stat(ctx, req.Key)
+	if err != nil {
+		if errors.Is(err, blob.ErrNotFound) {
+			return "", ErrImageNotFound
+		}
+		return "", fmt.Errorf("failed to stat object: %w", err)
+	}
+	return objectETag(info), nil
+}
+
+// verifyStorageObjectRequest は署名付きURLを検証し、画像を取得するストレージを返します
+func verifyStorageObjectRequest(ctx context.Context, req *GetStorageObjectRequest) (blob.Store, error) {
+	store := blob.GetStore()
+	if store == nil {
+		return nil, ErrStorageUnavailable
+	}
+	verifier, ok := store.(blob.URLVerifier)
+	if !ok {
+		return nil, ErrInvalidSignedURL
+	}
+
+	now := outorouter.GetNowUTCFromContext(ctx)
+	if now.IsZero() {
+		now = time.Now().UTC()
+	}
+	if err := verifier.VerifySignedURL(req.Key, req.Expires, req.Signature, now); err != nil {
+		if errors.Is(err, blob.ErrURLExpired) {
+			return nil, ErrSignedURLExpired
+		}
+		return nil, ErrInvalidSignedURL
+	}
+	return store, nil
+}
+
+// objectETag はオブジェクトの更新日時とサイズから条件付きリクエスト用のETagを生成します
+// オブジェクトの内容を取得せずに Stat だけで求められます
+func objectETag(info blob.ObjectInfo) string {
+	return fmt.Sprintf("%x-%x", info.ModTime.UnixNano(), info.Size)
+}
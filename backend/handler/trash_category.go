@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/kinpatsu-everyone/backend-template/enum"
+	"github.com/kinpatsu-everyone/backend-template/internal/mysql"
+)
+
+// GetTrashCategoriesRequest はゴミ種別一覧取得リクエストです
+type GetTrashCategoriesRequest struct{}
+
+// Validate はリクエストのバリデーションを行います
+func (r GetTrashCategoriesRequest) Validate() error {
+	return nil
+}
+
+// TrashCategoryItem はゴミ種別の定義です
+// Name・Slug の oneof は enum のゴミ種別の一覧と一致させます（TypeScriptクライアントのユニオン型になります）
+type TrashCategoryItem struct {
+	ID        int                    `json:"id"`         // ゴミ種別ID
+	Name      enum.TrashCategoryName `json:"name"`       // ゴミ種別の名前
+	Slug      enum.TrashCategorySlug `json:"slug"`       // ゴミ種別の英語の識別子
+	Aliases   []string               `json:"aliases"`    // ゴミ種別の別名（表記ゆれ）
+	Attribute string                 `json:"attribute"`  // モンスターの属性(例: "炎")
+	ColorCode string                 `json:"color_code"` // モンスターの主な色のカラーコード(例: "#E53935")
+}
+
+// GetTrashCategoriesResponse はゴミ種別一覧取得レスポンスです
+type GetTrashCategoriesResponse struct {
+	TrashCategories []TrashCategoryItem `json:"trash_categories"` // 指定なしを除くゴミ種別の配列（ID順）
+}
+
+// GetTrashCategories はゴミ種別一覧取得ハンドラーです
+func GetTrashCategories(_ context.Context, _ *GetTrashCategoriesRequest) (*GetTrashCategoriesResponse, error) {
+	categories := enum.TrashCategories()
+	items := make([]TrashCategoryItem, 0, len(categories))
+	for _, c := range categories {
+		aliases := c.Aliases
+		if aliases == nil {
+			aliases = []string{}
+		}
+		items = append(items, TrashCategoryItem{
+			ID:        int(c.ID),
+			Name:      enum.TrashCategoryName(c.Name),
+			Slug:      enum.TrashCategorySlug(c.Slug),
+			Aliases:   aliases,
+			Attribute: c.Attribute,
+			ColorCode: c.ColorCode,
+		})
+	}
+	return &GetTrashCategoriesResponse{
+		TrashCategories: items,
+	}, nil
+}
+
+// firstTrashCategory はMonsterのゴミ種別のうち最初のものを返します（ゴミ種別がない場合は指定なしを返します）
+func firstTrashCategory(trashCategories []mysql.Monstertrashcategory) enum.TrashCategory {
+	if len(trashCategories) == 0 {
+		return enum.TrashCategoryNone
+	}
+	return enum.TrashCategory(trashCategories[0].Trashcategory)
+}
@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/kinpatsu-everyone/backend-template/enum"
+	"github.com/kinpatsu-everyone/backend-template/pkg/outorouter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrashCategoryEnumValues(t *testing.T) {
+	var names, slugs []string
+	for _, info := range append([]enum.TrashCategoryInfo{mustInfo(t, enum.TrashCategoryNone)}, enum.TrashCategories()...) {
+		names = append(names, info.Name)
+		slugs = append(slugs, info.Slug)
+	}
+	// 絞り込みには指定なしを使わない
+	filterSlugs := slugs[1:]
+
+	tests := []struct {
+		name  string
+		typ   reflect.Type
+		field string
+		want  []string
+	}{
+		{name: "MonsterItemの名前", typ: reflect.TypeOf(MonsterItem{}), field: "TrashCategory", want: names},
+		{name: "MonsterItemの英語の識別子", typ: reflect.TypeOf(MonsterItem{}), field: "TrashCategorySlug", want: slugs},
+		{name: "TrashItemの名前", typ: reflect.TypeOf(TrashItem{}), field: "TrashCategory", want: names},
+		{name: "TrashItemの英語の識別子", typ: reflect.TypeOf(TrashItem{}), field: "TrashCategorySlug", want: slugs},
+		{name: "TrashCategoryItemの名前", typ: reflect.TypeOf(TrashCategoryItem{}), field: "Name", want: names},
+		{name: "TrashCategoryItemの英語の識別子", typ: reflect.TypeOf(TrashCategoryItem{}), field: "Slug", want: slugs},
+		{name: "SearchMonstersNearbyRequestの絞り込み", typ: reflect.TypeOf(SearchMonstersNearbyRequest{}), field: "TrashCategory", want: filterSlugs},
+		{name: "GetMonstersInBoundsRequestの絞り込み", typ: reflect.TypeOf(GetMonstersInBoundsRequest{}), field: "TrashCategory", want: filterSlugs},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, ok := tt.typ.FieldByName(tt.field)
+			require.True(t, ok)
+			// 列挙値はフィールドの型から作るため、validateタグに oneof を書かない
+			assert.NotContains(t, f.Tag.Get("validate"), "oneof")
+			e, ok := reflect.Zero(f.Type).Interface().(outorouter.Enum)
+			require.True(t, ok, "%s does not implement outorouter.Enum", f.Type)
+			assert.Equal(t, tt.want, e.EnumValues())
+		})
+	}
+}
+
+func TestGetTrashCategories(t *testing.T) {
+	resp, err := GetTrashCategories(context.Background(), &GetTrashCategoriesRequest{})
+	require.NoError(t, err)
+
+	categories := enum.TrashCategories()
+	require.Len(t, resp.TrashCategories, len(categories))
+	for i, item := range resp.TrashCategories {
+		assert.Equal(t, int(categories[i].ID), item.ID)
+		assert.Equal(t, categories[i].Name, string(item.Name))
+		assert.Equal(t, categories[i].Slug, string(item.Slug))
+		assert.NotNil(t, item.Aliases)
+		assert.Equal(t, categories[i].Attribute, item.Attribute)
+		assert.Equal(t, categories[i].ColorCode, item.ColorCode)
+	}
+}
+
+func mustInfo(t *testing.T, c enum.TrashCategory) enum.TrashCategoryInfo {
+	t.Helper()
+	info, ok := c.Info()
+	require.True(t, ok)
+	return info
+}
@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/kinpatsu-everyone/backend-template/config"
+	"github.com/kinpatsu-everyone/backend-template/internal/mysql"
+	"github.com/kinpatsu-everyone/backend-template/pkg/outorouter"
+)
+
+// defaultUserNickname は端末登録時にニックネームが指定されなかった場合のニックネームです
+const defaultUserNickname = "ゲスト"
+
+// RegisterDeviceRequest は端末登録リクエストです
+type RegisterDeviceRequest struct {
+	Nickname string `json:"nickname,omitempty" validate:"max=50"` // ニックネーム(省略時は"ゲスト")
+}
+
+// Validate はリクエストのバリデーションを行います
+func (r RegisterDeviceRequest) Validate() error {
+	return nil
+}
+
+// RegisterDeviceResponse は端末登録レスポンスです
+type RegisterDeviceResponse struct {
+	UserID string `json:"user_id"` // ユーザーID(UUID)
+	Token  string `json:"token"`   // 認証トークン(Authorization: Bearer ヘッダーに指定する、再発行不可)
+}
+
+// RegisterDevice は端末登録ハンドラーです
+// 処理内容:
+// 1. 匿名のユーザーを作成
+// 2. ユーザーに紐づくAPIキーを発行（DBにはハッシュのみ保存）
+// 3. ユーザーIDとトークンを返す
+func RegisterDevice(ctx context.Context, req *RegisterDeviceRequest) (*RegisterDeviceResponse, error) {
+	userID := uuid.New().String()
+	nickname := req.Nickname
+	if nickname == "" {
+		nickname = defaultUserNickname
+	}
+
+	token, err := newAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	err = mysql.WithQueriesTx(ctx, func(q *mysql.Queries) error {
+		if _, err := q.CreateUser(ctx, mysql.CreateUserParams{
+			Userid:   userID,
+			Nickname: nickname,
+		}); err != nil {
+			return fmt.Errorf("failed to create user: %w", err)
+		}
+		if _, err := q.CreateApiKey(ctx, mysql.CreateApiKeyParams{
+			Apikeyid: uuid.New().String(),
+			Userid:   userID,
+			Keyhash:  outorouter.HashAPIKey(token),
+		}); err != nil {
+			return fmt.Errorf("failed to create api key: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &RegisterDeviceResponse{
+		UserID: userID,
+		Token:  token,
+	}, nil
+}
+
+// newAPIKey は推測できないランダムなAPIキーを生成します
+func newAPIKey() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+	return config.AuthConfig.APIKeyPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kinpatsu-everyone/backend-template/config"
+	"github.com/kinpatsu-everyone/backend-template/internal/mysql"
+	"github.com/kinpatsu-everyone/backend-template/pkg/outorouter"
+)
+
+// ScopeAdmin は管理者用のエンドポイントに必要なスコープです（ApiKey.Scopes・JWTの scope クレームで付与します）
+const ScopeAdmin = "admin"
+
+// APIKeyStore はMySQLの ApiKey テーブルからAPIキーを検索します
+type APIKeyStore struct {
+	queries mysql.Querier
+}
+
+// NewAPIKeyStore は新しい APIKeyStore を作成します
+func NewAPIKeyStore(queries mysql.Querier) *APIKeyStore {
+	return &APIKeyStore{queries: queries}
+}
+
+// LookupAPIKey はハッシュ化したAPIキーから Principal を取得します
+// 見つからない・無効化済み・期限切れの場合は outorouter.ErrInvalidToken を返します
+func (s *APIKeyStore) LookupAPIKey(ctx context.Context, keyHash string) (*outorouter.Principal, error) {
+	key, err := s.queries.GetApiKeyByHash(ctx, keyHash)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("api key is not found: %w", outorouter.ErrInvalidToken)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+
+	now := outorouter.GetNowUTCFromContext(ctx)
+	if now.IsZero() {
+		now = time.Now()
+	}
+	if key.Expiresat.Valid && !now.Before(key.Expiresat.Time) {
+		return nil, fmt.Errorf("api key is expired: %w", outorouter.ErrInvalidToken)
+	}
+
+	return &outorouter.Principal{
+		Subject: key.Userid,
+		Method:  "api_key",
+		Scopes:  strings.Fields(key.Scopes),
+	}, nil
+}
+
+// NewVerifiers は設定からJWT・APIキーの Verifier を作成します
+// JWTの鍵が設定されていない場合はAPIキーのみを検証します
+func NewVerifiers(queries mysql.Querier) (outorouter.Verifiers, error) {
+	verifiers := outorouter.Verifiers{
+		outorouter.APIKeyVerifier{Store: NewAPIKeyStore(queries), Prefix: config.AuthConfig.APIKeyPrefix},
+	}
+
+	jwt := outorouter.JWTVerifier{
+		HMACSecret: []byte(config.AuthConfig.JWTHS256Secret),
+		Issuer:     config.AuthConfig.JWTIssuer,
+		Audience:   config.AuthConfig.JWTAudience,
+		Leeway:     config.AuthConfig.JWTLeeway,
+	}
+	if config.AuthConfig.JWKSFile != "" {
+		keys, err := outorouter.LoadJWKSFile(config.AuthConfig.JWKSFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load jwks: %w", err)
+		}
+		jwt.RSAKeys = keys
+	}
+	if len(jwt.HMACSecret) > 0 || len(jwt.RSAKeys) > 0 {
+		verifiers = append(verifiers, jwt)
+	}
+	return verifiers, nil
+}
@@ -0,0 +1,103 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+)
+
+// ErrNotFound はオブジェクトが存在しない場合のエラーです
+var ErrNotFound = errors.New("blob: object not found")
+
+// ObjectInfo はオブジェクトの情報です
+type ObjectInfo struct {
+	Key         string
+	ContentType string
+	Size        int64
+	ModTime     time.Time
+}
+
+// Store は画像などのオブジェクトを保存するストレージです
+// キーは "/" 区切りのパスです（例: "monsters/{uuid}/original.jpg"）
+type Store interface {
+	// Put はオブジェクトを保存します（同じキーのオブジェクトは上書きします）
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	// Get はオブジェクトを取得します（存在しない場合は ErrNotFound を返します）
+	Get(ctx context.Context, key string) ([]byte, ObjectInfo, error)
+	// Delete はオブジェクトを削除します（存在しない場合は何もしません）
+	Delete(ctx context.Context, key string) error
+	// Stat はオブジェクトの情報を取得します（存在しない場合は ErrNotFound を返します）
+	Stat(ctx context.Context, key string) (ObjectInfo, error)
+	// SignedURL はオブジェクトを expiry の間だけ取得できる署名付きURLを返します
+	SignedURL(ctx context.Context, key string, expiry time.Duration) (string, error)
+	// List は prefix で始まるキーのオブジェクトをキーの昇順で返します
+	List(ctx context.Context, prefix string) ([]ObjectInfo, error)
+	// Close はストレージの接続を閉じます
+	Close() error
+}
+
+var globalStore Store
+
+// SetStore はグローバルなストレージを設定します
+func SetStore(store Store) {
+	globalStore = store
+}
+
+// GetStore はグローバルなストレージを取得します（設定されていない場合は nil を返します）
+func GetStore() Store {
+	return globalStore
+}
+
+// GenerateObjectPath はモンスターIDと画像タイプからオブジェクトのキーを生成します
+// monsterID: モンスターID（UUID）
+// imageType: 画像タイプ（"original" または "generated"）
+// extension: ファイル拡張子（例: "jpg", "png"）
+// 戻り値: オブジェクトのキー
+func GenerateObjectPath(monsterID, imageType, extension string) string {
+	// 拡張子にドットがない場合は追加
+	if extension != "" && extension[0] != '.' {
+		extension = "." + extension
+	}
+	return filepath.ToSlash(filepath.Join("monsters", monsterID, fmt.Sprintf("%s%s", imageType, extension)))
+}
+
+// MonsterObjectPrefix はモンスターのオブジェクトのキーの接頭辞を返します（例: "monsters/{uuid}/"）
+func MonsterObjectPrefix(monsterID string) string {
+	return filepath.ToSlash(filepath.Join("monsters", monsterID)) + "/"
+}
+
+// GenerateOriginalImagePath はモンスターIDから元画像のキーを生成します
+// 戻り値: オブジェクトのキー（例: "monsters/{uuid}/original.jpg"）
+func GenerateOriginalImagePath(monsterID, extension string) string {
+	return GenerateObjectPath(monsterID, "original", extension)
+}
+
+// GenerateGeneratedImagePath はモンスターIDから生成画像のキーを生成します
+// 戻り値: オブジェクトのキー（例: "monsters/{uuid}/generated.png"）
+func GenerateGeneratedImagePath(monsterID, extension string) string {
+	return GenerateObjectPath(monsterID, "generated", extension)
+}
+
+// GenerateInputImagePath はモンスターIDと生成ジョブIDから、生成ジョブに渡すアップロードされた画像のキーを生成します
+// 戻り値: オブジェクトのキー（例: "monsters/{uuid}/input-{jobID}.jpg"）
+func GenerateInputImagePath(monsterID, jobID, extension string) string {
+	return GenerateObjectPath(monsterID, "input-"+jobID, extension)
+}
+
+// GetExtensionFromMimeType はMIMEタイプからファイル拡張子を取得します
+func GetExtensionFromMimeType(mimeType string) string {
+	switch mimeType {
+	case "image/jpeg", "image/jpg":
+		return "jpg"
+	case "image/png":
+		return "png"
+	case "image/gif":
+		return "gif"
+	case "image/webp":
+		return "webp"
+	default:
+		return "jpg" // デフォルト
+	}
+}
@@ -0,0 +1,181 @@
+package blob
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/url"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func newTestStores(t *testing.T) map[string]Store {
+	t.Helper()
+	signer := &URLSigner{BaseURL: "http://localhost:8080", Secret: []byte("secret")}
+	local, err := NewLocalStore(t.TempDir(), signer)
+	if err != nil {
+		t.Fatalf("NewLocalStore() error = %v", err)
+	}
+	return map[string]Store{
+		"memory": NewMemoryStore(signer),
+		"local":  local,
+	}
+}
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+
+	for name, store := range newTestStores(t) {
+		t.Run(name, func(t *testing.T) {
+			objects := []struct {
+				key         string
+				data        []byte
+				contentType string
+			}{
+				{key: "monsters/b/generated.png", data: []byte("png"), contentType: "image/png"},
+				{key: "monsters/a/original.jpg", data: []byte("jpeg"), contentType: "image/jpeg"},
+				{key: "monsters/a/generated.png", data: []byte("generated"), contentType: "image/png"},
+				{key: "other/a.png", data: []byte("other"), contentType: "image/png"},
+			}
+			for _, o := range objects {
+				if err := store.Put(ctx, o.key, o.data, o.contentType); err != nil {
+					t.Fatalf("Put(%q) error = %v", o.key, err)
+				}
+			}
+
+			// 同じキーは上書きする
+			if err := store.Put(ctx, "monsters/a/generated.png", []byte("overwritten"), "image/png"); err != nil {
+				t.Fatalf("Put() error = %v", err)
+			}
+			data, info, err := store.Get(ctx, "monsters/a/generated.png")
+			if err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+			if !bytes.Equal(data, []byte("overwritten")) || info.ContentType != "image/png" || info.Size != int64(len("overwritten")) {
+				t.Errorf("Get() = %q, %+v", data, info)
+			}
+
+			listTests := []struct {
+				prefix string
+				want   []string
+			}{
+				{prefix: "monsters/", want: []string{"monsters/a/generated.png", "monsters/a/original.jpg", "monsters/b/generated.png"}},
+				{prefix: "monsters/a", want: []string{"monsters/a/generated.png", "monsters/a/original.jpg"}},
+				{prefix: "monsters/a/orig", want: []string{"monsters/a/original.jpg"}},
+				{prefix: "missing/", want: nil},
+				{prefix: "", want: []string{"monsters/a/generated.png", "monsters/a/original.jpg", "monsters/b/generated.png", "other/a.png"}},
+			}
+			for _, tt := range listTests {
+				infos, err := store.List(ctx, tt.prefix)
+				if err != nil {
+					t.Fatalf("List(%q) error = %v", tt.prefix, err)
+				}
+				var keys []string
+				for _, info := range infos {
+					keys = append(keys, info.Key)
+				}
+				if !slices.Equal(keys, tt.want) {
+					t.Errorf("List(%q) = %v, want %v", tt.prefix, keys, tt.want)
+				}
+			}
+
+			if err := store.Delete(ctx, "monsters/a/original.jpg"); err != nil {
+				t.Fatalf("Delete() error = %v", err)
+			}
+			// 存在しないオブジェクトの削除はエラーにしない
+			if err := store.Delete(ctx, "monsters/a/original.jpg"); err != nil {
+				t.Fatalf("Delete() of missing object error = %v", err)
+			}
+			if _, err := store.Stat(ctx, "monsters/a/original.jpg"); !errors.Is(err, ErrNotFound) {
+				t.Errorf("Stat() of deleted object error = %v, want ErrNotFound", err)
+			}
+			if _, _, err := store.Get(ctx, "monsters/a/original.jpg"); !errors.Is(err, ErrNotFound) {
+				t.Errorf("Get() of deleted object error = %v, want ErrNotFound", err)
+			}
+			// ディレクトリに相当するキーはオブジェクトとして扱わない
+			if _, err := store.Stat(ctx, "monsters/a"); !errors.Is(err, ErrNotFound) {
+				t.Errorf("Stat() of directory error = %v, want ErrNotFound", err)
+			}
+
+			signedURL, err := store.SignedURL(ctx, "monsters/b/generated.png", time.Hour)
+			if err != nil {
+				t.Fatalf("SignedURL() error = %v", err)
+			}
+			u, err := url.Parse(signedURL)
+			if err != nil {
+				t.Fatalf("url.Parse() error = %v", err)
+			}
+			expires, _ := strconv.ParseInt(u.Query().Get("expires"), 10, 64)
+			verifier := store.(URLVerifier)
+			if err := verifier.VerifySignedURL(u.Query().Get("key"), expires, u.Query().Get("signature"), time.Now()); err != nil {
+				t.Errorf("VerifySignedURL() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestLocalStore_不正なキーはエラーになる(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewLocalStore(t.TempDir(), &URLSigner{Secret: []byte("secret")})
+	if err != nil {
+		t.Fatalf("NewLocalStore() error = %v", err)
+	}
+
+	for _, key := range []string{"", "../outside.png", "monsters/../../outside.png", "/etc/passwd"} {
+		if err := store.Put(ctx, key, []byte("data"), "image/png"); err == nil {
+			t.Errorf("Put(%q) error = nil, want error", key)
+		}
+		if _, _, err := store.Get(ctx, key); err == nil || errors.Is(err, ErrNotFound) {
+			t.Errorf("Get(%q) error = %v, want invalid key error", key, err)
+		}
+	}
+	if _, err := store.List(ctx, "../"); err == nil {
+		t.Errorf("List(%q) error = nil, want error", "../")
+	}
+}
+
+func TestURLSigner(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	signer := &URLSigner{BaseURL: "http://localhost:8080/", Secret: []byte("secret"), Now: func() time.Time { return now }}
+
+	signedURL := signer.Sign("monsters/1/generated.png", time.Hour)
+	u, err := url.Parse(signedURL)
+	if err != nil {
+		t.Fatalf("url.Parse() error = %v", err)
+	}
+	if got := u.Scheme + "://" + u.Host + u.Path; got != "http://localhost:8080"+ObjectPath {
+		t.Errorf("Sign() URL = %q, want path %q", signedURL, ObjectPath)
+	}
+	key := u.Query().Get("key")
+	signature := u.Query().Get("signature")
+	expires, err := strconv.ParseInt(u.Query().Get("expires"), 10, 64)
+	if err != nil {
+		t.Fatalf("expires = %q", u.Query().Get("expires"))
+	}
+
+	tests := []struct {
+		name      string
+		key       string
+		expires   int64
+		signature string
+		signer    *URLSigner
+		now       time.Time
+		want      error
+	}{
+		{name: "有効期限内の署名付きURLは検証に成功する", key: key, expires: expires, signature: signature, signer: signer, now: now.Add(59 * time.Minute)},
+		{name: "有効期限が切れた署名付きURLはエラー", key: key, expires: expires, signature: signature, signer: signer, now: now.Add(61 * time.Minute), want: ErrURLExpired},
+		{name: "キーを変更した署名付きURLはエラー", key: "monsters/2/generated.png", expires: expires, signature: signature, signer: signer, now: now, want: ErrInvalidSignature},
+		{name: "有効期限を延長した署名付きURLはエラー", key: key, expires: expires + 3600, signature: signature, signer: signer, now: now, want: ErrInvalidSignature},
+		{name: "署名鍵が異なる場合はエラー", key: key, expires: expires, signature: signature, signer: &URLSigner{Secret: []byte("other")}, now: now, want: ErrInvalidSignature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.signer.Verify(tt.key, tt.expires, tt.signature, tt.now); !errors.Is(err, tt.want) {
+				t.Errorf("Verify() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
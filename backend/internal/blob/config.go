@@ -0,0 +1,51 @@
+package blob
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+
+	"github.com/kinpatsu-everyone/backend-template/config"
+)
+
+// NewFromConfig は config.BlobStore で指定されたストレージを作成します
+func NewFromConfig(ctx context.Context) (Store, error) {
+	switch config.BlobStore {
+	case "gcs":
+		if config.GCSBucketName == "" {
+			return nil, fmt.Errorf("blob: GCS_BUCKET_NAME is required for the gcs store")
+		}
+		var credentialsJSON []byte
+		if config.GCSCredentialsJSON != "" {
+			credentialsJSON = []byte(config.GCSCredentialsJSON)
+		}
+		return NewGCSStore(ctx, config.GCSBucketName, config.GCSBaseURL, credentialsJSON)
+	case "local":
+		signer, err := signerFromConfig()
+		if err != nil {
+			return nil, err
+		}
+		return NewLocalStore(config.BlobLocalDir, signer)
+	case "memory":
+		signer, err := signerFromConfig()
+		if err != nil {
+			return nil, err
+		}
+		return NewMemoryStore(signer), nil
+	default:
+		return nil, fmt.Errorf("blob: unknown store %q", config.BlobStore)
+	}
+}
+
+// signerFromConfig は署名付きURLの URLSigner を作成します
+// 署名鍵が設定されていない場合はランダムに生成します（再起動すると発行済みのURLは無効になります）
+func signerFromConfig() (*URLSigner, error) {
+	secret := []byte(config.BlobSigningSecret)
+	if len(secret) == 0 {
+		secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, fmt.Errorf("failed to generate signing secret: %w", err)
+		}
+	}
+	return &URLSigner{BaseURL: config.BlobPublicBaseURL, Secret: secret}, nil
+}
@@ -0,0 +1,95 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/storage"
+
+	"github.com/kinpatsu-everyone/backend-template/internal/gcs"
+)
+
+// GCSStore はオブジェクトをGCSのバケットに保存するストレージです
+// 署名付きURLはGCSが発行・配信します
+type GCSStore struct {
+	client *gcs.Client
+}
+
+// NewGCSStore は新しい GCSStore を作成します
+// credentialsJSONが空の場合、Application Default Credentials (ADC) を使用します
+func NewGCSStore(ctx context.Context, bucketName, baseURL string, credentialsJSON []byte) (*GCSStore, error) {
+	client, err := gcs.NewClient(ctx, bucketName, baseURL, credentialsJSON)
+	if err != nil {
+		return nil, err
+	}
+	return &GCSStore{client: client}, nil
+}
+
+func (s *GCSStore) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	_, err := s.client.UploadImageWithPath(ctx, key, data, contentType)
+	return err
+}
+
+func (s *GCSStore) Get(ctx context.Context, key string) ([]byte, ObjectInfo, error) {
+	data, contentType, modTime, err := s.client.DownloadImage(ctx, key)
+	if err != nil {
+		return nil, ObjectInfo{}, gcsError(err)
+	}
+	return data, ObjectInfo{
+		Key:         key,
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		ModTime:     modTime,
+	}, nil
+}
+
+func (s *GCSStore) Delete(ctx context.Context, key string) error {
+	return s.client.DeleteObject(ctx, key)
+}
+
+func (s *GCSStore) Stat(ctx context.Context, key string) (ObjectInfo, error) {
+	attrs, err := s.client.StatObject(ctx, key)
+	if err != nil {
+		return ObjectInfo{}, gcsError(err)
+	}
+	return gcsObjectInfo(attrs), nil
+}
+
+func (s *GCSStore) SignedURL(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	return s.client.GetSignedURL(ctx, key, expiry)
+}
+
+func (s *GCSStore) List(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	attrs, err := s.client.ListObjects(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+	infos := make([]ObjectInfo, 0, len(attrs))
+	for _, a := range attrs {
+		infos = append(infos, gcsObjectInfo(a))
+	}
+	return infos, nil
+}
+
+func (s *GCSStore) Close() error {
+	return s.client.Close()
+}
+
+// gcsError はオブジェクトが存在しない場合のエラーを ErrNotFound に変換します
+func gcsError(err error) error {
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return fmt.Errorf("%w: %w", ErrNotFound, err)
+	}
+	return err
+}
+
+func gcsObjectInfo(attrs *storage.ObjectAttrs) ObjectInfo {
+	return ObjectInfo{
+		Key:         attrs.Name,
+		ContentType: attrs.ContentType,
+		Size:        attrs.Size,
+		ModTime:     attrs.Updated,
+	}
+}
@@ -0,0 +1,192 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// tempFilePrefix は書き込み中の一時ファイルの接頭辞です（List の対象外です）
+const tempFilePrefix = ".tmp-"
+
+// LocalStore はオブジェクトをローカルのファイルシステムに保存するストレージです（ローカル開発用）
+// オブジェクトは dir 配下のキーと同じパスに保存します
+// Content-Type はキーの拡張子から判定します（拡張子から判定できない場合はデータから判定します）
+type LocalStore struct {
+	dir    string
+	signer *URLSigner
+}
+
+// NewLocalStore は新しい LocalStore を作成します（dir が存在しない場合は作成します）
+// 署名付きURLは signer で発行し、ObjectPath のAPIから配信します
+func NewLocalStore(dir string, signer *URLSigner) (*LocalStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create blob directory: %w", err)
+	}
+	return &LocalStore{dir: dir, signer: signer}, nil
+}
+
+// filePath はキーに対応するファイルパスを返します
+// dir の外を指すキー（"../" や絶対パス）はエラーにします
+func (s *LocalStore) filePath(key string) (string, error) {
+	p := filepath.FromSlash(key)
+	if key == "" || !filepath.IsLocal(p) {
+		return "", fmt.Errorf("blob: invalid key %q", key)
+	}
+	return filepath.Join(s.dir, p), nil
+}
+
+func (s *LocalStore) Put(_ context.Context, key string, data []byte, _ string) error {
+	p, err := s.filePath(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	// 読み込み中のオブジェクトが壊れないように、一時ファイルに書き込んでから置き換える
+	tmp, err := os.CreateTemp(filepath.Dir(p), tempFilePrefix+"*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write object: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close temp file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), p); err != nil {
+		return fmt.Errorf("failed to rename temp file: %w", err)
+	}
+	return nil
+}
+
+func (s *LocalStore) Get(ctx context.Context, key string) ([]byte, ObjectInfo, error) {
+	p, err := s.filePath(key)
+	if err != nil {
+		return nil, ObjectInfo{}, err
+	}
+	data, err := os.ReadFile(p)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ObjectInfo{}, ErrNotFound
+		}
+		return nil, ObjectInfo{}, fmt.Errorf("failed to read object: %w", err)
+	}
+
+	info, err := s.Stat(ctx, key)
+	if err != nil {
+		return nil, ObjectInfo{}, err
+	}
+	if info.ContentType == "" {
+		info.ContentType = http.DetectContentType(data)
+	}
+	return data, info, nil
+}
+
+func (s *LocalStore) Delete(_ context.Context, key string) error {
+	p, err := s.filePath(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(p); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete object: %w", err)
+	}
+	return nil
+}
+
+func (s *LocalStore) Stat(_ context.Context, key string) (ObjectInfo, error) {
+	p, err := s.filePath(key)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	fi, err := os.Stat(p)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return ObjectInfo{}, ErrNotFound
+		}
+		return ObjectInfo{}, fmt.Errorf("failed to stat object: %w", err)
+	}
+	if fi.IsDir() {
+		return ObjectInfo{}, ErrNotFound
+	}
+	return localObjectInfo(key, fi), nil
+}
+
+func (s *LocalStore) SignedURL(_ context.Context, key string, expiry time.Duration) (string, error) {
+	if _, err := s.filePath(key); err != nil {
+		return "", err
+	}
+	return s.signer.Sign(key, expiry), nil
+}
+
+func (s *LocalStore) VerifySignedURL(key string, expires int64, signature string, now time.Time) error {
+	return s.signer.Verify(key, expires, signature, now)
+}
+
+func (s *LocalStore) List(_ context.Context, prefix string) ([]ObjectInfo, error) {
+	// prefix を含むディレクトリから探索する（"monsters/ab" の場合は "monsters"、"monsters/ab/" の場合は "monsters/ab"）
+	dir := filepath.FromSlash(path.Dir(prefix + "x"))
+	if dir != "." && !filepath.IsLocal(dir) {
+		return nil, fmt.Errorf("blob: invalid prefix %q", prefix)
+	}
+	root := filepath.Join(s.dir, dir)
+
+	var infos []ObjectInfo
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() || strings.HasPrefix(d.Name(), tempFilePrefix) {
+			return nil
+		}
+		rel, err := filepath.Rel(s.dir, p)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+		fi, err := d.Info()
+		if err != nil {
+			return err
+		}
+		infos = append(infos, localObjectInfo(key, fi))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list objects: %w", err)
+	}
+	slices.SortFunc(infos, func(a, b ObjectInfo) int { return strings.Compare(a.Key, b.Key) })
+	return infos, nil
+}
+
+func (s *LocalStore) Close() error {
+	return nil
+}
+
+func localObjectInfo(key string, fi fs.FileInfo) ObjectInfo {
+	return ObjectInfo{
+		Key:         key,
+		ContentType: mime.TypeByExtension(path.Ext(key)),
+		Size:        fi.Size(),
+		ModTime:     fi.ModTime().UTC(),
+	}
+}
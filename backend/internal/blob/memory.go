@@ -0,0 +1,104 @@
+package blob
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MemoryStore はオブジェクトをメモリに保存するストレージです（テスト用）
+// プロセスを再起動するとオブジェクトは失われます
+type MemoryStore struct {
+	signer *URLSigner
+
+	mu      sync.RWMutex
+	objects map[string]memoryObject
+}
+
+type memoryObject struct {
+	data []byte
+	info ObjectInfo
+}
+
+// NewMemoryStore は新しい MemoryStore を作成します
+// 署名付きURLは signer で発行し、ObjectPath のAPIから配信します
+func NewMemoryStore(signer *URLSigner) *MemoryStore {
+	return &MemoryStore{
+		signer:  signer,
+		objects: make(map[string]memoryObject),
+	}
+}
+
+func (s *MemoryStore) Put(_ context.Context, key string, data []byte, contentType string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.objects[key] = memoryObject{
+		data: slices.Clone(data),
+		info: ObjectInfo{
+			Key:         key,
+			ContentType: contentType,
+			Size:        int64(len(data)),
+			ModTime:     time.Now().UTC(),
+		},
+	}
+	return nil
+}
+
+func (s *MemoryStore) Get(_ context.Context, key string) ([]byte, ObjectInfo, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	obj, ok := s.objects[key]
+	if !ok {
+		return nil, ObjectInfo{}, ErrNotFound
+	}
+	return slices.Clone(obj.data), obj.info, nil
+}
+
+func (s *MemoryStore) Delete(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.objects, key)
+	return nil
+}
+
+func (s *MemoryStore) Stat(_ context.Context, key string) (ObjectInfo, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	obj, ok := s.objects[key]
+	if !ok {
+		return ObjectInfo{}, ErrNotFound
+	}
+	return obj.info, nil
+}
+
+func (s *MemoryStore) SignedURL(_ context.Context, key string, expiry time.Duration) (string, error) {
+	return s.signer.Sign(key, expiry), nil
+}
+
+func (s *MemoryStore) VerifySignedURL(key string, expires int64, signature string, now time.Time) error {
+	return s.signer.Verify(key, expires, signature, now)
+}
+
+func (s *MemoryStore) List(_ context.Context, prefix string) ([]ObjectInfo, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var infos []ObjectInfo
+	for key, obj := range s.objects {
+		if strings.HasPrefix(key, prefix) {
+			infos = append(infos, obj.info)
+		}
+	}
+	slices.SortFunc(infos, func(a, b ObjectInfo) int { return strings.Compare(a.Key, b.Key) })
+	return infos, nil
+}
+
+func (s *MemoryStore) Close() error {
+	return nil
+}
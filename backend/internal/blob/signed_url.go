@@ -0,0 +1,69 @@
+package blob
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ObjectPath は署名付きURLでオブジェクトを配信するAPIのパスです
+// ルーターの storage ドメインの GetStorageObject エンドポイントと一致させます
+const ObjectPath = "/storage/v1/GetStorageObject"
+
+var (
+	// ErrInvalidSignature は署名付きURLの署名が一致しない場合のエラーです
+	ErrInvalidSignature = errors.New("blob: invalid signature")
+	// ErrURLExpired は署名付きURLの有効期限が切れている場合のエラーです
+	ErrURLExpired = errors.New("blob: signed url expired")
+)
+
+// URLVerifier はAPI経由で配信する署名付きURLを検証できるストレージです
+// GCSのようにストレージ自身が署名付きURLを配信する場合は実装しません
+type URLVerifier interface {
+	VerifySignedURL(key string, expires int64, signature string, now time.Time) error
+}
+
+// URLSigner はHMAC-SHA256で署名した、有効期限付きのURLを発行・検証します
+type URLSigner struct {
+	// BaseURL はAPIサーバーのURLです（例: "http://localhost:8080"）
+	BaseURL string
+	Secret  []byte
+	Now     func() time.Time
+}
+
+// Sign はキーを expiry の間だけ ObjectPath から取得できる署名付きURLを返します
+func (s *URLSigner) Sign(key string, expiry time.Duration) string {
+	now := time.Now
+	if s.Now != nil {
+		now = s.Now
+	}
+	expires := now().Add(expiry).Unix()
+
+	query := url.Values{}
+	query.Set("key", key)
+	query.Set("expires", strconv.FormatInt(expires, 10))
+	query.Set("signature", s.signature(key, expires))
+	return strings.TrimSuffix(s.BaseURL, "/") + ObjectPath + "?" + query.Encode()
+}
+
+// Verify は署名付きURLの署名と有効期限を検証します
+func (s *URLSigner) Verify(key string, expires int64, signature string, now time.Time) error {
+	if !hmac.Equal([]byte(signature), []byte(s.signature(key, expires))) {
+		return ErrInvalidSignature
+	}
+	if now.Unix() > expires {
+		return ErrURLExpired
+	}
+	return nil
+}
+
+func (s *URLSigner) signature(key string, expires int64) string {
+	mac := hmac.New(sha256.New, s.Secret)
+	mac.Write([]byte(key + "\n" + strconv.FormatInt(expires, 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -2,12 +2,13 @@ package gcs
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
-	"path/filepath"
 	"time"
 
 	"cloud.google.com/go/storage"
+	"google.golang.org/api/iterator"
 	"google.golang.org/api/option"
 )
 
@@ -193,33 +194,77 @@ func (c *Client) UploadImageFromReader(ctx context.Context, objectPath string, r
 	return signedURL, nil
 }
 
-// GenerateObjectPath はモンスターIDと画像タイプからGCSオブジェクトパスを生成します
-// monsterID: モンスターID（UUID）
-// imageType: 画像タイプ（"original" または "generated"）
-// extension: ファイル拡張子（例: "jpg", "png"）
-// 戻り値: GCSオブジェクトパス
-func GenerateObjectPath(monsterID, imageType, extension string) string {
-	// 拡張子にドットがない場合は追加
-	if extension != "" && extension[0] != '.' {
-		extension = "." + extension
+// DownloadImage はGCSから画像データを取得します
+// objectPath: GCS内のオブジェクトパス
+// 戻り値: 画像データ、MIMEタイプ、最終更新日時
+func (c *Client) DownloadImage(ctx context.Context, objectPath string) ([]byte, string, time.Time, error) {
+	if c.bucketName == "" {
+		return nil, "", time.Time{}, fmt.Errorf("bucket name is required")
+	}
+
+	obj := c.client.Bucket(c.bucketName).Object(objectPath)
+
+	reader, err := obj.NewReader(ctx)
+	if err != nil {
+		return nil, "", time.Time{}, fmt.Errorf("failed to open object: %w", err)
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, "", time.Time{}, fmt.Errorf("failed to read object: %w", err)
 	}
-	return filepath.Join("monsters", monsterID, fmt.Sprintf("%s%s", imageType, extension))
+
+	return data, reader.Attrs.ContentType, reader.Attrs.LastModified, nil
 }
 
-// GetExtensionFromMimeType はMIMEタイプからファイル拡張子を取得します
-func GetExtensionFromMimeType(mimeType string) string {
-	switch mimeType {
-	case "image/jpeg", "image/jpg":
-		return "jpg"
-	case "image/png":
-		return "png"
-	case "image/gif":
-		return "gif"
-	case "image/webp":
-		return "webp"
-	default:
-		return "jpg" // デフォルト
+// DeleteObject はGCSのオブジェクトを削除します
+// オブジェクトが存在しない場合は何もしません
+func (c *Client) DeleteObject(ctx context.Context, objectPath string) error {
+	if c.bucketName == "" {
+		return fmt.Errorf("bucket name is required")
+	}
+
+	err := c.client.Bucket(c.bucketName).Object(objectPath).Delete(ctx)
+	if err != nil && !errors.Is(err, storage.ErrObjectNotExist) {
+		return fmt.Errorf("failed to delete object: %w", err)
 	}
+	return nil
+}
+
+// StatObject はGCSのオブジェクトの属性を取得します
+// オブジェクトが存在しない場合は storage.ErrObjectNotExist をラップしたエラーを返します
+func (c *Client) StatObject(ctx context.Context, objectPath string) (*storage.ObjectAttrs, error) {
+	if c.bucketName == "" {
+		return nil, fmt.Errorf("bucket name is required")
+	}
+
+	attrs, err := c.client.Bucket(c.bucketName).Object(objectPath).Attrs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object attrs: %w", err)
+	}
+	return attrs, nil
+}
+
+// ListObjects は prefix で始まるGCSのオブジェクトの属性を返します
+func (c *Client) ListObjects(ctx context.Context, prefix string) ([]*storage.ObjectAttrs, error) {
+	if c.bucketName == "" {
+		return nil, fmt.Errorf("bucket name is required")
+	}
+
+	var result []*storage.ObjectAttrs
+	it := c.client.Bucket(c.bucketName).Objects(ctx, &storage.Query{Prefix: prefix})
+	for {
+		attrs, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list objects: %w", err)
+		}
+		result = append(result, attrs)
+	}
+	return result, nil
 }
 
 // Close はGCSクライアントを閉じます
@@ -260,19 +305,3 @@ func (c *Client) GetSignedURL(ctx context.Context, objectPath string, expiration
 
 	return url, nil
 }
-
-// GenerateOriginalImagePath はモンスターIDから元画像のGCSオブジェクトパスを生成します
-// monsterID: モンスターID（UUID）
-// extension: ファイル拡張子（例: "jpg", "png"）
-// 戻り値: GCSオブジェクトパス（例: "monsters/{uuid}/original.jpg"）
-func GenerateOriginalImagePath(monsterID, extension string) string {
-	return GenerateObjectPath(monsterID, "original", extension)
-}
-
-// GenerateGeneratedImagePath はモンスターIDから生成画像のGCSオブジェクトパスを生成します
-// monsterID: モンスターID（UUID）
-// extension: ファイル拡張子（例: "jpg", "png"）
-// 戻り値: GCSオブジェクトパス（例: "monsters/{uuid}/generated.jpg"）
-func GenerateGeneratedImagePath(monsterID, extension string) string {
-	return GenerateObjectPath(monsterID, "generated", extension)
-}
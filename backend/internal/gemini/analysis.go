@@ -0,0 +1,176 @@
+package gemini
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"google.golang.org/genai"
+
+	"github.com/kinpatsu-everyone/backend-template/enum"
+)
+
+// TrashTypeUnknown は分別種類を判定できない場合の trash_type です
+const TrashTypeUnknown = "unknown"
+
+// AnalyzeTrashBinPrompt はゴミ箱の写真から分別種類を判定するためのプロンプトです
+// 回答の形式は TrashAnalysisSchema で指定します
+var AnalyzeTrashBinPrompt = `この画像に写っているゴミ箱を分析してください。
+ゴミ箱の色、マーク、中身、その他の特徴を観察し、このゴミ箱がどの分別種類（` + strings.Join(enum.TrashCategoryNames(), "、") + `）に該当するかを判定してください。
+
+以下の項目をJSON形式で回答してください：
+- trash_type: 分別種類（上記のいずれか。判定が難しい場合は "` + TrashTypeUnknown + `"）
+- color: ゴミ箱の主な色
+- markings: ゴミ箱に書かれているマークや文字
+- contents: ゴミ箱の中身の説明（見える範囲で）
+- reasoning: 判定理由の説明
+- description: ゴミ箱の全体的な説明
+- confidence: 判定の確信度（0.0〜1.0の数値）
+- bounding_box: 画像内のゴミ箱の位置（画像の左上を0、右下を1とした x_min, y_min, x_max, y_max。ゴミ箱が写っていない場合はすべて0）`
+
+// trashAnalysisFields は分析結果の項目です（すべて必須です）
+var trashAnalysisFields = []string{"trash_type", "color", "markings", "contents", "reasoning", "description", "confidence", "bounding_box"}
+
+// boundingBoxFields はゴミ箱の位置の項目です（すべて必須です）
+var boundingBoxFields = []string{"x_min", "y_min", "x_max", "y_max"}
+
+// BoundingBox は画像内のゴミ箱の位置です（画像の左上を0、右下を1とした座標）
+type BoundingBox struct {
+	XMin float64 `json:"x_min"`
+	YMin float64 `json:"y_min"`
+	XMax float64 `json:"x_max"`
+	YMax float64 `json:"y_max"`
+}
+
+// TrashAnalysisResult は画像分析結果のJSON構造です
+type TrashAnalysisResult struct {
+	TrashType   string      `json:"trash_type"`
+	Color       string      `json:"color"`
+	Markings    string      `json:"markings"`
+	Contents    string      `json:"contents"`
+	Reasoning   string      `json:"reasoning"`
+	Description string      `json:"description"`
+	Confidence  float64     `json:"confidence"`
+	BoundingBox BoundingBox `json:"bounding_box"`
+}
+
+// TrashAnalysisSchema は分析結果のJSONスキーマです
+// trash_type は enum のゴミ種別の名前と TrashTypeUnknown に制限します
+func TrashAnalysisSchema() *genai.Schema {
+	text := func(description string) *genai.Schema {
+		return &genai.Schema{Type: genai.TypeString, Description: description}
+	}
+	unit := func(description string) *genai.Schema {
+		return &genai.Schema{Type: genai.TypeNumber, Description: description, Minimum: genai.Ptr(0.0), Maximum: genai.Ptr(1.0)}
+	}
+
+	return &genai.Schema{
+		Type: genai.TypeObject,
+		Properties: map[string]*genai.Schema{
+			"trash_type": {
+				Type:        genai.TypeString,
+				Format:      "enum",
+				Enum:        append(enum.TrashCategoryNames(), TrashTypeUnknown),
+				Description: "分別種類",
+			},
+			"color":       text("ゴミ箱の主な色"),
+			"markings":    text("ゴミ箱に書かれているマークや文字"),
+			"contents":    text("ゴミ箱の中身の説明"),
+			"reasoning":   text("判定理由の説明"),
+			"description": text("ゴミ箱の全体的な説明"),
+			"confidence":  unit("判定の確信度"),
+			"bounding_box": {
+				Type:        genai.TypeObject,
+				Description: "画像内のゴミ箱の位置",
+				Properties: map[string]*genai.Schema{
+					"x_min": unit("左端"),
+					"y_min": unit("上端"),
+					"x_max": unit("右端"),
+					"y_max": unit("下端"),
+				},
+				Required:         boundingBoxFields,
+				PropertyOrdering: boundingBoxFields,
+			},
+		},
+		Required:         trashAnalysisFields,
+		PropertyOrdering: trashAnalysisFields,
+	}
+}
+
+// DecodeTrashAnalysis はモデルが返したJSONを厳密に読み込みます
+// 項目の過不足・型の誤り・範囲外の値・JSON以外のテキストはすべてエラーにします
+func DecodeTrashAnalysis(text string) (TrashAnalysisResult, error) {
+	data := []byte(strings.TrimSpace(text))
+	if len(data) == 0 {
+		return TrashAnalysisResult{}, ErrEmptyResponse
+	}
+
+	var fields map[string]json.RawMessage
+	if err := decodeStrict(data, &fields); err != nil {
+		return TrashAnalysisResult{}, &InvalidResponseError{Reason: "response is not a JSON object", Err: err}
+	}
+	if err := checkFields("", fields, trashAnalysisFields); err != nil {
+		return TrashAnalysisResult{}, err
+	}
+	var boxFields map[string]json.RawMessage
+	if err := decodeStrict(fields["bounding_box"], &boxFields); err != nil {
+		return TrashAnalysisResult{}, &InvalidResponseError{Field: "bounding_box", Reason: "must be an object", Err: err}
+	}
+	if err := checkFields("bounding_box.", boxFields, boundingBoxFields); err != nil {
+		return TrashAnalysisResult{}, err
+	}
+
+	var result TrashAnalysisResult
+	if err := decodeStrict(data, &result); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			return TrashAnalysisResult{}, &InvalidResponseError{Field: typeErr.Field, Reason: "must be " + typeErr.Type.String(), Err: err}
+		}
+		return TrashAnalysisResult{}, &InvalidResponseError{Reason: err.Error(), Err: err}
+	}
+
+	if result.TrashType != TrashTypeUnknown && !slices.Contains(enum.TrashCategoryNames(), result.TrashType) {
+		return TrashAnalysisResult{}, &InvalidResponseError{Field: "trash_type", Reason: fmt.Sprintf("unknown trash type %q", result.TrashType)}
+	}
+	box := result.BoundingBox
+	for _, v := range []struct {
+		field string
+		value float64
+	}{
+		{"confidence", result.Confidence},
+		{"bounding_box.x_min", box.XMin},
+		{"bounding_box.y_min", box.YMin},
+		{"bounding_box.x_max", box.XMax},
+		{"bounding_box.y_max", box.YMax},
+	} {
+		if v.value < 0 || v.value > 1 {
+			return TrashAnalysisResult{}, &InvalidResponseError{Field: v.field, Reason: fmt.Sprintf("must be between 0 and 1, got %g", v.value)}
+		}
+	}
+	if box.XMin > box.XMax || box.YMin > box.YMax {
+		return TrashAnalysisResult{}, &InvalidResponseError{Field: "bounding_box", Reason: "min must not be greater than max"}
+	}
+	return result, nil
+}
+
+// AnalyzeTrashBinImage はゴミ箱の画像を分析して分別種類を判定します
+// 回答の形式は TrashAnalysisSchema で制限し、DecodeTrashAnalysis で読み込みます
+// 回答がスキーマに合わない場合は、理由を伝えて1回だけ再回答させます（再回答も合わない場合は InvalidResponseError を返します）
+// 戻り値: 解析された分析結果、分析結果のテキスト（再回答した場合は再回答のテキスト）
+func (c *Client) AnalyzeTrashBinImage(ctx context.Context, imageData []byte, mimeType string) (result TrashAnalysisResult, analysisText string, err error) {
+	if len(imageData) == 0 {
+		return TrashAnalysisResult{}, "", fmt.Errorf("image data is required")
+	}
+	if mimeType == "" {
+		mimeType = "image/jpeg"
+	}
+
+	result, analysisText, err = generateStructured(ctx, c, contentsWithImage(AnalyzeTrashBinPrompt, imageData, mimeType), TrashAnalysisSchema(), DecodeTrashAnalysis)
+	if err != nil {
+		return TrashAnalysisResult{}, analysisText, fmt.Errorf("failed to analyze image: %w", err)
+	}
+	return result, analysisText, nil
+}
@@ -0,0 +1,155 @@
+package gemini
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+const validAnalysis = `{
+  "trash_type": "ペットボトル",
+  "color": "青",
+  "markings": "ペットボトル",
+  "contents": "空のペットボトル",
+  "reasoning": "「ペットボトル」と書かれているため",
+  "description": "コンビニの前にある回収ボックス",
+  "confidence": 0.9,
+  "bounding_box": {"x_min": 0.1, "y_min": 0.2, "x_max": 0.8, "y_max": 0.95}
+}`
+
+func TestDecodeTrashAnalysis(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		want      TrashAnalysisResult
+		wantErr   bool
+		wantEmpty bool
+		wantField string
+	}{
+		{
+			name: "スキーマに合う場合は読み込める",
+			text: validAnalysis,
+			want: TrashAnalysisResult{
+				TrashType:   "ペットボトル",
+				Color:       "青",
+				Markings:    "ペットボトル",
+				Contents:    "空のペットボトル",
+				Reasoning:   "「ペットボトル」と書かれているため",
+				Description: "コンビニの前にある回収ボックス",
+				Confidence:  0.9,
+				BoundingBox: BoundingBox{XMin: 0.1, YMin: 0.2, XMax: 0.8, YMax: 0.95},
+			},
+		},
+		{
+			name: "判定できない場合はunknownを読み込める",
+			text: `{"trash_type":"unknown","color":"","markings":"","contents":"","reasoning":"ゴミ箱が写っていない","description":"","confidence":0,"bounding_box":{"x_min":0,"y_min":0,"x_max":0,"y_max":0}}`,
+			want: TrashAnalysisResult{TrashType: "unknown", Reasoning: "ゴミ箱が写っていない"},
+		},
+		{
+			name:      "空の場合はErrEmptyResponse",
+			text:      " \n",
+			wantErr:   true,
+			wantEmpty: true,
+		},
+		{
+			name:      "JSONの前後にテキストがある場合はエラー",
+			text:      "分析結果です\n" + validAnalysis,
+			wantErr:   true,
+			wantField: "",
+		},
+		{
+			name:      "JSONの後ろにテキストがある場合はエラー",
+			text:      validAnalysis + "\n以上です",
+			wantErr:   true,
+			wantField: "",
+		},
+		{
+			name:      "項目が不足している場合はエラー",
+			text:      `{"trash_type":"缶","color":"","markings":"","contents":"","reasoning":"","description":"","confidence":0.5}`,
+			wantErr:   true,
+			wantField: "bounding_box",
+		},
+		{
+			name:      "未知の項目がある場合はエラー",
+			text:      `{"trash_type":"缶","color":"","markings":"","contents":"","reasoning":"","description":"","confidence":0.5,"bounding_box":{"x_min":0,"y_min":0,"x_max":1,"y_max":1},"extra":1}`,
+			wantErr:   true,
+			wantField: "extra",
+		},
+		{
+			name:      "ゴミ種別がenumにない場合はエラー",
+			text:      `{"trash_type":"紙","color":"","markings":"","contents":"","reasoning":"","description":"","confidence":0.5,"bounding_box":{"x_min":0,"y_min":0,"x_max":1,"y_max":1}}`,
+			wantErr:   true,
+			wantField: "trash_type",
+		},
+		{
+			name:      "確信度が文字列の場合はエラー",
+			text:      `{"trash_type":"缶","color":"","markings":"","contents":"","reasoning":"","description":"","confidence":"high","bounding_box":{"x_min":0,"y_min":0,"x_max":1,"y_max":1}}`,
+			wantErr:   true,
+			wantField: "confidence",
+		},
+		{
+			name:      "確信度が1を超える場合はエラー",
+			text:      `{"trash_type":"缶","color":"","markings":"","contents":"","reasoning":"","description":"","confidence":1.5,"bounding_box":{"x_min":0,"y_min":0,"x_max":1,"y_max":1}}`,
+			wantErr:   true,
+			wantField: "confidence",
+		},
+		{
+			name:      "位置の項目が不足している場合はエラー",
+			text:      `{"trash_type":"缶","color":"","markings":"","contents":"","reasoning":"","description":"","confidence":0.5,"bounding_box":{"x_min":0,"y_min":0,"x_max":1}}`,
+			wantErr:   true,
+			wantField: "bounding_box.y_max",
+		},
+		{
+			name:      "位置の最小値が最大値より大きい場合はエラー",
+			text:      `{"trash_type":"缶","color":"","markings":"","contents":"","reasoning":"","description":"","confidence":0.5,"bounding_box":{"x_min":0.9,"y_min":0,"x_max":0.1,"y_max":1}}`,
+			wantErr:   true,
+			wantField: "bounding_box",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeTrashAnalysis(tt.text)
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("DecodeTrashAnalysis() error = %v", err)
+				}
+				if got != tt.want {
+					t.Errorf("DecodeTrashAnalysis() = %+v, want %+v", got, tt.want)
+				}
+				return
+			}
+
+			if tt.wantEmpty {
+				if !errors.Is(err, ErrEmptyResponse) {
+					t.Errorf("DecodeTrashAnalysis() error = %v, want ErrEmptyResponse", err)
+				}
+				return
+			}
+			var invalid *InvalidResponseError
+			if !errors.As(err, &invalid) {
+				t.Fatalf("DecodeTrashAnalysis() error = %v, want InvalidResponseError", err)
+			}
+			if invalid.Field != tt.wantField {
+				t.Errorf("InvalidResponseError.Field = %q, want %q", invalid.Field, tt.wantField)
+			}
+		})
+	}
+}
+
+func TestTrashAnalysisSchema(t *testing.T) {
+	schema := TrashAnalysisSchema()
+
+	if !slices.Equal(schema.Required, trashAnalysisFields) {
+		t.Errorf("Required = %v, want %v", schema.Required, trashAnalysisFields)
+	}
+	for _, f := range trashAnalysisFields {
+		if schema.Properties[f] == nil {
+			t.Errorf("Properties[%q] is missing", f)
+		}
+	}
+	want := []string{"燃えるゴミ", "不燃ごみ", "缶", "瓶", "ペットボトル", "unknown"}
+	if got := schema.Properties["trash_type"].Enum; !slices.Equal(got, want) {
+		t.Errorf("trash_type Enum = %v, want %v", got, want)
+	}
+}
@@ -3,52 +3,35 @@ package gemini
 import (
 	"context"
 	"encoding/base64"
-	"encoding/json"
 	"fmt"
 	"strings"
 
 	"google.golang.org/genai"
-)
-
-// AnalyzeTrashBinPrompt はゴミ箱の写真から分別種類を判定するためのプロンプトです
-const AnalyzeTrashBinPrompt = `この画像に写っているゴミ箱を分析してください。
-ゴミ箱の色、マーク、中身、その他の特徴を観察し、このゴミ箱がどの分別種類（燃えるゴミ、不燃ごみ、缶、瓶、ペットボトルなど）に該当するかを判定してください。
-
-以下のJSON形式で回答してください：
-{
-  "trash_type": "分別種類（例: 燃えるゴミ、不燃ごみ、缶、瓶、ペットボトルなど）",
-  "color": "ゴミ箱の主な色",
-  "markings": "ゴミ箱に書かれているマークや文字",
-  "contents": "ゴミ箱の中身の説明（見える範囲で）",
-  "reasoning": "判定理由の説明",
-  "description": "ゴミ箱の全体的な説明",
-  "confidence": "判定の確信度（high, medium, low）"
-}
 
-判定が難しい場合は、"trash_type"を"unknown"としてください。`
+	"github.com/kinpatsu-everyone/backend-template/enum"
+)
 
 // GenerateTrashMonsterPromptTemplate は分別種をテーマにしたモンスターキャラクター生成用のプロンプトテンプレートです
-// プレースホルダー: %s = trashType (2箇所)
-const GenerateTrashMonsterPromptTemplate = `[アップロードされたゴミ箱の画像]をベースに、その形や色を活かしたモンスターのイラストを生成してください。
+// 元のゴミ箱の画像と一緒に送信します。属性と色の表は enum のゴミ種別から作成します
+// プレースホルダー: %[1]s = trashType, %[2]s = color, %[3]s = markings, %[4]s = contents
+var GenerateTrashMonsterPromptTemplate = `[アップロードされたゴミ箱の画像]をベースに、その形や色を活かしたモンスターのイラストを生成してください。
 **1. モンスター基本情報**
 
 * **ゴミの分類**:
 
-    %s
+    %[1]s
+
+* **ゴミ箱の特徴**（アップロードされたゴミ箱の画像の分析結果）:
+    * 色: %[2]s
+    * マーク・文字: %[3]s
+    * 中身: %[4]s
 
 **2. 属性と特徴**
 
 * **テーマとなる動物**: [以下の項目に当てはまる動物を選択してください]
 動物園や水族館にいる動物をランダムに選択。
 
-| ゴミの分類 | 属性 | モンスターの具体的な特徴 |
-| :--- | :--- | :--- |
-| **燃えるゴミ** | 炎属性 | primary color: 赤系の色、secondary color: ゴミ箱の色 |
-| **プラ・その他** | 闇属性 | primary color: 紫系の色、secondary color: ゴミ箱の色 |
-| **ペットボトル** | 水属性 | primary color: 青系の色、secondary color: ゴミ箱の色 |
-| **びん・缶** | 光属性 | primary color: 黄色系の色、secondary color: ゴミ箱の色 |
-| **新聞紙** | 木属性 | primary color: 緑系の色、secondary color: ゴミ箱の色 |
-
+` + trashCategoryAttributeTable() + `
 色は[以下のいずれかを選択して入力してください]の特徴を持つ質感にする
 - ちょっとグラデーションあり
 - ちょっと光沢あり
@@ -77,15 +60,48 @@ const GenerateTrashMonsterPromptTemplate = `[アップロードされたゴミ
 ---
 `
 
-// TrashAnalysisResult は画像分析結果のJSON構造です
-type TrashAnalysisResult struct {
-	TrashType   string `json:"trash_type"`
-	Color       string `json:"color"`
-	Markings    string `json:"markings"`
-	Contents    string `json:"contents"`
-	Reasoning   string `json:"reasoning"`
-	Description string `json:"description"`
-	Confidence  string `json:"confidence"`
+// trashCategoryAttributeTable はゴミ種別ごとのモンスターの属性と色の表（Markdown）を返します
+func trashCategoryAttributeTable() string {
+	var b strings.Builder
+	b.WriteString("| ゴミの分類 | 属性 | モンスターの具体的な特徴 |\n")
+	b.WriteString("| :--- | :--- | :--- |\n")
+	for _, c := range enum.TrashCategories() {
+		fmt.Fprintf(&b, "| **%s** | %s属性 | primary color: %s系の色（%s）、secondary color: ゴミ箱の色 |\n", c.Name, c.Attribute, c.ColorName, c.ColorCode)
+	}
+	return b.String()
+}
+
+// MonsterPromptParams はモンスターの画像を生成するプロンプトに埋め込む分析結果です
+type MonsterPromptParams struct {
+	TrashType string
+	Color     string
+	Markings  string
+	Contents  string
+}
+
+// maxPromptFieldLength はプロンプトに埋め込む分析結果の1項目あたりの最大文字数です
+const maxPromptFieldLength = 200
+
+// BuildGenerateMonsterPrompt は分析結果を GenerateTrashMonsterPromptTemplate に埋め込んだプロンプトを返します
+// 空の項目は「不明」とし、長すぎる項目は maxPromptFieldLength 文字で切り詰めます
+func BuildGenerateMonsterPrompt(params MonsterPromptParams) string {
+	return fmt.Sprintf(GenerateTrashMonsterPromptTemplate,
+		promptField(params.TrashType),
+		promptField(params.Color),
+		promptField(params.Markings),
+		promptField(params.Contents),
+	)
+}
+
+func promptField(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if s == "" {
+		return "不明"
+	}
+	if r := []rune(s); len(r) > maxPromptFieldLength {
+		return string(r[:maxPromptFieldLength])
+	}
+	return s
 }
 
 // Client はGemini APIクライアントです
@@ -95,9 +111,10 @@ type Client struct {
 }
 
 // NewClient は新しいGemini APIクライアントを作成します
+// model は config.GeminiAnalysisModel・config.GeminiImageModel などで指定します
 func NewClient(apiKey, model string) (*Client, error) {
 	if model == "" {
-		model = "gemini-3-pro-image-preview"
+		return nil, fmt.Errorf("model is required")
 	}
 
 	ctx := context.Background()
@@ -117,14 +134,12 @@ func NewClient(apiKey, model string) (*Client, error) {
 	}, nil
 }
 
-// GenerateContent は画像生成リクエストを送信します
+// GenerateContent はテキストのプロンプトを送信し、モデルの回答（画像を含む）を返します
 func (c *Client) GenerateContent(ctx context.Context, prompt string) (*genai.GenerateContentResponse, error) {
-	contents := genai.Text(prompt)
-
 	result, err := c.client.Models.GenerateContent(
 		ctx,
 		c.model,
-		contents,
+		genai.Text(prompt),
 		nil,
 	)
 	if err != nil {
@@ -134,99 +149,39 @@ func (c *Client) GenerateContent(ctx context.Context, prompt string) (*genai.Gen
 	return result, nil
 }
 
-// AnalyzeImage は画像データとテキストプロンプトを送信して、分析結果をテキストで返します
-func (c *Client) AnalyzeImage(ctx context.Context, prompt string, imageData []byte, mimeType string) (*genai.GenerateContentResponse, error) {
-	// 画像データが空の場合はエラー
-	if len(imageData) == 0 {
-		return nil, fmt.Errorf("image data is required")
-	}
-
-	// MIMEタイプが指定されていない場合はデフォルトを設定
-	if mimeType == "" {
-		mimeType = "image/jpeg"
-	}
-
-	// テキストと画像データを含むPartsを作成
+// contentsWithImage はテキストプロンプトと画像データを含むContentを作成します
+func contentsWithImage(prompt string, imageData []byte, mimeType string) []*genai.Content {
 	parts := []*genai.Part{
 		{Text: prompt},
-	}
-
-	// 画像データを追加
-	parts = append(parts, &genai.Part{
-		InlineData: &genai.Blob{
-			Data:     imageData,
-			MIMEType: mimeType,
+		{
+			InlineData: &genai.Blob{
+				Data:     imageData,
+				MIMEType: mimeType,
+			},
 		},
-	})
-
-	// Contentを作成
-	contents := []*genai.Content{
+	}
+	return []*genai.Content{
 		{
 			Parts: parts,
 			Role:  genai.RoleUser,
 		},
 	}
-
-	result, err := c.client.Models.GenerateContent(
-		ctx,
-		c.model,
-		contents,
-		nil,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to analyze image: %w", err)
-	}
-
-	return result, nil
-}
-
-// AnalyzeTrashBinImage はゴミ箱の画像を分析して分別種類を判定します
-// 戻り値: 分別種類（trash_type）、分析結果のテキスト、解析されたJSON構造
-func (c *Client) AnalyzeTrashBinImage(ctx context.Context, imageData []byte, mimeType string) (trashType string, analysisText string, result TrashAnalysisResult, err error) {
-	// 画像分析を実行
-	analysisResp, err := c.AnalyzeImage(ctx, AnalyzeTrashBinPrompt, imageData, mimeType)
-	if err != nil {
-		return "", "", TrashAnalysisResult{}, fmt.Errorf("failed to analyze image: %w", err)
-	}
-
-	// 分析結果のテキストを抽出
-	analysisText = ""
-	if len(analysisResp.Candidates) > 0 && analysisResp.Candidates[0].Content != nil {
-		for _, part := range analysisResp.Candidates[0].Content.Parts {
-			if part.Text != "" {
-				analysisText += part.Text
-			}
-		}
-	}
-
-	// 分析結果から分別種を抽出
-	trashType = "unknown"
-	var analysisResult TrashAnalysisResult
-
-	// JSONをパース（テキスト内にJSONが含まれている可能性があるため、抽出を試みる）
-	jsonStart := strings.Index(analysisText, "{")
-	jsonEnd := strings.LastIndex(analysisText, "}")
-	if jsonStart >= 0 && jsonEnd > jsonStart {
-		jsonStr := analysisText[jsonStart : jsonEnd+1]
-		if err := json.Unmarshal([]byte(jsonStr), &analysisResult); err == nil {
-			trashType = analysisResult.TrashType
-			if trashType == "" {
-				trashType = "unknown"
-			}
-		}
-	}
-
-	return trashType, analysisText, analysisResult, nil
 }
 
-// GenerateMonsterImage は分別種をテーマにしたモンスターキャラクターの画像を生成します
+// GenerateMonsterImage は元のゴミ箱の画像とプロンプト（BuildGenerateMonsterPrompt で作成）からモンスターキャラクターの画像を生成します
 // 戻り値: 生成された画像データ（バイナリ）、MIMEタイプ
-func (c *Client) GenerateMonsterImage(ctx context.Context, trashType string) (imageData []byte, mimeType string, err error) {
-	// 分別種をテーマにした画像生成プロンプトを作成
-	generatePrompt := fmt.Sprintf(GenerateTrashMonsterPromptTemplate, trashType)
+func (c *Client) GenerateMonsterImage(ctx context.Context, prompt string, trashBinImage []byte, trashBinMimeType string) (imageData []byte, mimeType string, err error) {
+	if len(trashBinImage) == 0 {
+		return nil, "", fmt.Errorf("image data is required")
+	}
 
 	// 画像生成を実行
-	generateResp, err := c.GenerateContent(ctx, generatePrompt)
+	generateResp, err := c.client.Models.GenerateContent(
+		ctx,
+		c.model,
+		contentsWithImage(prompt, trashBinImage, trashBinMimeType),
+		nil,
+	)
 	if err != nil {
 		return nil, "", fmt.Errorf("failed to generate image: %w", err)
 	}
@@ -258,30 +213,6 @@ func (c *Client) GenerateMonsterImage(ctx context.Context, trashType string) (im
 	return imageData, mimeType, nil
 }
 
-// AnalyzeAndGenerateMonsterImage はゴミ箱の画像を分析し、分別種をテーマにしたモンスターキャラクターの画像を生成します
-// generateClient: 画像生成用のクライアント（分析用とは別のモデルを使用する場合）
-// 戻り値: 分別種類、分析結果、生成された画像データ（バイナリ）、MIMEタイプ
-func (c *Client) AnalyzeAndGenerateMonsterImage(ctx context.Context, imageData []byte, mimeType string, generateClient *Client) (string, TrashAnalysisResult, []byte, string, error) {
-	// Step 1: 画像分析（現在のクライアントを使用）
-	trashType, _, analysisResult, err := c.AnalyzeTrashBinImage(ctx, imageData, mimeType)
-	if err != nil {
-		return "", TrashAnalysisResult{}, nil, "", fmt.Errorf("failed to analyze image: %w", err)
-	}
-
-	// Step 2: 画像生成（指定されたクライアントを使用、nilの場合は現在のクライアントを使用）
-	genClient := generateClient
-	if genClient == nil {
-		genClient = c
-	}
-
-	generatedImageData, generatedMimeType, err := genClient.GenerateMonsterImage(ctx, trashType)
-	if err != nil {
-		return trashType, analysisResult, nil, "", fmt.Errorf("failed to generate image: %w", err)
-	}
-
-	return trashType, analysisResult, generatedImageData, generatedMimeType, nil
-}
-
 // DecodeBase64Image はbase64エンコードされた画像データをデコードします
 func DecodeBase64Image(base64Data string) ([]byte, error) {
 	return base64.StdEncoding.DecodeString(base64Data)
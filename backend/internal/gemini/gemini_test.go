@@ -0,0 +1,54 @@
+package gemini
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuildGenerateMonsterPrompt(t *testing.T) {
+	tests := []struct {
+		name     string
+		params   MonsterPromptParams
+		contains []string
+		excludes []string
+	}{
+		{
+			name: "分析結果の色・マーク・中身をプロンプトに含める",
+			params: MonsterPromptParams{
+				TrashType: "ペットボトル",
+				Color:     "青",
+				Markings:  "ペットボトル専用",
+				Contents:  "空のペットボトル",
+			},
+			contains: []string{"    ペットボトル\n", "色: 青\n", "マーク・文字: ペットボトル専用\n", "中身: 空のペットボトル\n"},
+			excludes: []string{"%!", "不明"},
+		},
+		{
+			name:     "空の項目は不明にする",
+			params:   MonsterPromptParams{TrashType: "缶", Color: " \n "},
+			contains: []string{"色: 不明\n", "マーク・文字: 不明\n", "中身: 不明\n"},
+		},
+		{
+			name:     "改行を含む項目は1行にまとめ、長すぎる項目は切り詰める",
+			params:   MonsterPromptParams{TrashType: "瓶", Markings: "びん\n\nかん", Contents: strings.Repeat("あ", maxPromptFieldLength+10)},
+			contains: []string{"マーク・文字: びん かん\n", "中身: " + strings.Repeat("あ", maxPromptFieldLength) + "\n"},
+			excludes: []string{strings.Repeat("あ", maxPromptFieldLength+1)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := BuildGenerateMonsterPrompt(tt.params)
+			for _, s := range tt.contains {
+				if !strings.Contains(got, s) {
+					t.Errorf("BuildGenerateMonsterPrompt() does not contain %q", s)
+				}
+			}
+			for _, s := range tt.excludes {
+				if strings.Contains(got, s) {
+					t.Errorf("BuildGenerateMonsterPrompt() contains %q", s)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package gemini
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"google.golang.org/genai"
+)
+
+const (
+	// MaxSpeciesNameLength はモンスターの種族名の最大文字数です
+	MaxSpeciesNameLength = 20
+	// MaxMonsterDescriptionLength はモンスターの説明文の最大文字数です
+	MaxMonsterDescriptionLength = 200
+)
+
+// GenerateMonsterProfilePromptTemplate は生成したモンスターの画像から種族名と説明文を考えるためのプロンプトです
+// 回答の形式は MonsterProfileSchema で指定します
+// プレースホルダー: %[1]s = trashType, %[2]s = attribute, %[3]s = color, %[4]s = markings, %[5]s = contents
+var GenerateMonsterProfilePromptTemplate = `この画像は、街中のゴミ箱の写真をもとに生成したモンスターのイラストです。
+このモンスターの図鑑に載せる種族名と説明文を考えてください。
+
+* **ゴミの分類**: %[1]s
+* **属性**: %[2]s
+* **元になったゴミ箱の特徴**:
+    * 色: %[3]s
+    * マーク・文字: %[4]s
+    * 中身: %[5]s
+
+以下の項目をJSON形式で回答してください：
+- species_name: モンスターの種族名（イラストのモチーフの動物とゴミの分類を組み合わせた、カタカナを中心とした` + fmt.Sprint(MaxSpeciesNameLength) + `文字以内の名前）
+- description: 図鑑の説明文（見た目・性格・住んでいるゴミ箱の様子がわかる、です・ます調の` + fmt.Sprint(MaxMonsterDescriptionLength) + `文字以内の文章）`
+
+// MonsterProfileParams はモンスターの種族名と説明文を考えるプロンプトに埋め込む情報です
+type MonsterProfileParams struct {
+	TrashType string
+	Attribute string
+	Color     string
+	Markings  string
+	Contents  string
+}
+
+// BuildMonsterProfilePrompt は情報を GenerateMonsterProfilePromptTemplate に埋め込んだプロンプトを返します
+func BuildMonsterProfilePrompt(params MonsterProfileParams) string {
+	return fmt.Sprintf(GenerateMonsterProfilePromptTemplate,
+		promptField(params.TrashType),
+		promptField(params.Attribute),
+		promptField(params.Color),
+		promptField(params.Markings),
+		promptField(params.Contents),
+	)
+}
+
+// monsterProfileFields はモンスターの種族名と説明文の項目です（すべて必須です）
+var monsterProfileFields = []string{"species_name", "description"}
+
+// MonsterProfile はモンスターの種族名と説明文のJSON構造です
+type MonsterProfile struct {
+	SpeciesName string `json:"species_name"`
+	Description string `json:"description"`
+}
+
+// MonsterProfileSchema はモンスターの種族名と説明文のJSONスキーマです
+func MonsterProfileSchema() *genai.Schema {
+	return &genai.Schema{
+		Type: genai.TypeObject,
+		Properties: map[string]*genai.Schema{
+			"species_name": {Type: genai.TypeString, Description: "モンスターの種族名", MinLength: genai.Ptr[int64](1), MaxLength: genai.Ptr[int64](MaxSpeciesNameLength)},
+			"description":  {Type: genai.TypeString, Description: "図鑑の説明文", MinLength: genai.Ptr[int64](1), MaxLength: genai.Ptr[int64](MaxMonsterDescriptionLength)},
+		},
+		Required:         monsterProfileFields,
+		PropertyOrdering: monsterProfileFields,
+	}
+}
+
+// DecodeMonsterProfile はモデルが返したJSONを厳密に読み込みます
+// 項目の過不足・型の誤り・空や長すぎる文字列・JSON以外のテキストはすべてエラーにします
+func DecodeMonsterProfile(text string) (MonsterProfile, error) {
+	data := []byte(strings.TrimSpace(text))
+	if len(data) == 0 {
+		return MonsterProfile{}, ErrEmptyResponse
+	}
+
+	var fields map[string]json.RawMessage
+	if err := decodeStrict(data, &fields); err != nil {
+		return MonsterProfile{}, &InvalidResponseError{Reason: "response is not a JSON object", Err: err}
+	}
+	if err := checkFields("", fields, monsterProfileFields); err != nil {
+		return MonsterProfile{}, err
+	}
+
+	var profile MonsterProfile
+	if err := decodeStrict(data, &profile); err != nil {
+		return MonsterProfile{}, &InvalidResponseError{Reason: err.Error(), Err: err}
+	}
+	profile.SpeciesName = strings.TrimSpace(profile.SpeciesName)
+	profile.Description = strings.TrimSpace(profile.Description)
+	for _, v := range []struct {
+		field  string
+		value  string
+		maxLen int
+	}{
+		{"species_name", profile.SpeciesName, MaxSpeciesNameLength},
+		{"description", profile.Description, MaxMonsterDescriptionLength},
+	} {
+		if n := utf8.RuneCountInString(v.value); n == 0 || n > v.maxLen {
+			return MonsterProfile{}, &InvalidResponseError{Field: v.field, Reason: fmt.Sprintf("must be 1 to %d characters, got %d", v.maxLen, n)}
+		}
+	}
+	return profile, nil
+}
+
+// GenerateMonsterProfile は生成したモンスターの画像から種族名と説明文を生成します
+// 回答の形式は MonsterProfileSchema で制限し、DecodeMonsterProfile で読み込みます（合わない場合は1回だけ再回答させます）
+// 戻り値: 種族名と説明文、回答のテキスト
+func (c *Client) GenerateMonsterProfile(ctx context.Context, prompt string, monsterImage []byte, mimeType string) (MonsterProfile, string, error) {
+	if len(monsterImage) == 0 {
+		return MonsterProfile{}, "", fmt.Errorf("image data is required")
+	}
+	if mimeType == "" {
+		mimeType = "image/png"
+	}
+
+	profile, text, err := generateStructured(ctx, c, contentsWithImage(prompt, monsterImage, mimeType), MonsterProfileSchema(), DecodeMonsterProfile)
+	if err != nil {
+		return MonsterProfile{}, text, fmt.Errorf("failed to generate monster profile: %w", err)
+	}
+	return profile, text, nil
+}
@@ -0,0 +1,100 @@
+package gemini
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDecodeMonsterProfile(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		want      MonsterProfile
+		wantErr   bool
+		wantEmpty bool
+		wantField string
+	}{
+		{
+			name: "スキーマに合う場合は読み込める",
+			text: `{"species_name": "ペコペコペンギン", "description": "空のペットボトルを集めるのが大好きなモンスターです。"}`,
+			want: MonsterProfile{SpeciesName: "ペコペコペンギン", Description: "空のペットボトルを集めるのが大好きなモンスターです。"},
+		},
+		{
+			name: "前後の空白は取り除く",
+			text: `{"species_name": " ボウボウラッコ\n", "description": "燃えるゴミのゴミ箱に住んでいます。 "}`,
+			want: MonsterProfile{SpeciesName: "ボウボウラッコ", Description: "燃えるゴミのゴミ箱に住んでいます。"},
+		},
+		{
+			name:      "空の場合はErrEmptyResponse",
+			text:      "",
+			wantErr:   true,
+			wantEmpty: true,
+		},
+		{
+			name:      "項目が足りない場合はエラー",
+			text:      `{"species_name": "ボウボウラッコ"}`,
+			wantErr:   true,
+			wantField: "description",
+		},
+		{
+			name:      "未知の項目がある場合はエラー",
+			text:      `{"species_name": "ボウボウラッコ", "description": "説明", "hp": 100}`,
+			wantErr:   true,
+			wantField: "hp",
+		},
+		{
+			name:      "空白だけの種族名はエラー",
+			text:      `{"species_name": "  ", "description": "説明"}`,
+			wantErr:   true,
+			wantField: "species_name",
+		},
+		{
+			name:      "長すぎる説明文はエラー",
+			text:      `{"species_name": "ボウボウラッコ", "description": "` + strings.Repeat("あ", MaxMonsterDescriptionLength+1) + `"}`,
+			wantErr:   true,
+			wantField: "description",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeMonsterProfile(tt.text)
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("DecodeMonsterProfile() error = %v", err)
+				}
+				if got != tt.want {
+					t.Errorf("DecodeMonsterProfile() = %+v, want %+v", got, tt.want)
+				}
+				return
+			}
+
+			if tt.wantEmpty {
+				if !errors.Is(err, ErrEmptyResponse) {
+					t.Errorf("DecodeMonsterProfile() error = %v, want ErrEmptyResponse", err)
+				}
+				return
+			}
+			var invalid *InvalidResponseError
+			if !errors.As(err, &invalid) {
+				t.Fatalf("DecodeMonsterProfile() error = %v, want InvalidResponseError", err)
+			}
+			if invalid.Field != tt.wantField {
+				t.Errorf("InvalidResponseError.Field = %q, want %q", invalid.Field, tt.wantField)
+			}
+		})
+	}
+}
+
+func TestBuildMonsterProfilePrompt(t *testing.T) {
+	got := BuildMonsterProfilePrompt(MonsterProfileParams{TrashType: "缶", Attribute: "光", Color: "黄色"})
+	for _, s := range []string{"ゴミの分類**: 缶\n", "属性**: 光\n", "色: 黄色\n", "マーク・文字: 不明\n"} {
+		if !strings.Contains(got, s) {
+			t.Errorf("BuildMonsterProfilePrompt() does not contain %q", s)
+		}
+	}
+	if strings.Contains(got, "%!") {
+		t.Errorf("BuildMonsterProfilePrompt() has a formatting error: %q", got)
+	}
+}
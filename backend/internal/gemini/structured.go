@@ -0,0 +1,120 @@
+package gemini
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"google.golang.org/genai"
+)
+
+// repairPromptTemplate は回答がスキーマに合わない場合に再回答させるプロンプトです
+// プレースホルダー: %s = スキーマに合わない理由
+const repairPromptTemplate = `前の回答は次の理由で指定した形式に合っていません: %s
+指定した形式のJSONのみで、もう一度回答してください。`
+
+// ErrEmptyResponse はモデルが回答を返さなかった場合のエラーです
+var ErrEmptyResponse = errors.New("gemini: empty response")
+
+// InvalidResponseError はモデルの回答がスキーマに合わない場合のエラーです
+type InvalidResponseError struct {
+	// Field はスキーマに合わない項目です（JSONとして読み込めない場合は空）
+	Field  string
+	Reason string
+	Err    error
+}
+
+func (e *InvalidResponseError) Error() string {
+	if e.Field == "" {
+		return "gemini: invalid response: " + e.Reason
+	}
+	return fmt.Sprintf("gemini: invalid response: %s: %s", e.Field, e.Reason)
+}
+
+func (e *InvalidResponseError) Unwrap() error {
+	return e.Err
+}
+
+// generateStructured は schema で形式を制限した回答を生成し、decode で読み込みます
+// 回答がスキーマに合わない場合は、前の回答と理由を伝えて1回だけ再回答させます
+// 戻り値: 読み込んだ回答、回答のテキスト（再回答した場合は再回答のテキスト）
+func generateStructured[T any](ctx context.Context, c *Client, contents []*genai.Content, schema *genai.Schema, decode func(string) (T, error)) (T, string, error) {
+	var zero T
+	config := &genai.GenerateContentConfig{
+		ResponseMIMEType: "application/json",
+		ResponseSchema:   schema,
+	}
+
+	text, err := c.generateText(ctx, contents, config)
+	if err != nil {
+		return zero, "", err
+	}
+	result, decodeErr := decode(text)
+	if decodeErr == nil {
+		return result, text, nil
+	}
+
+	// スキーマに合わない場合は、前の回答と理由を伝えて再回答させる
+	if text != "" {
+		contents = append(contents, genai.NewContentFromText(text, genai.RoleModel))
+	}
+	contents = append(contents, genai.NewContentFromText(fmt.Sprintf(repairPromptTemplate, decodeErr), genai.RoleUser))
+
+	text, err = c.generateText(ctx, contents, config)
+	if err != nil {
+		return zero, "", fmt.Errorf("failed to repair response: %w", err)
+	}
+	result, err = decode(text)
+	if err != nil {
+		return zero, text, err
+	}
+	return result, text, nil
+}
+
+// decodeStrict は未知の項目やJSONの後ろのテキストを許可せずに読み込みます
+func decodeStrict(data []byte, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return fmt.Errorf("unexpected data after JSON")
+	}
+	return nil
+}
+
+// checkFields は required の項目がすべて存在し、それ以外の項目が存在しないことを確認します
+func checkFields(prefix string, fields map[string]json.RawMessage, required []string) error {
+	for _, f := range required {
+		if v, ok := fields[f]; !ok || string(v) == "null" {
+			return &InvalidResponseError{Field: prefix + f, Reason: "is required"}
+		}
+	}
+	for f := range fields {
+		if !slices.Contains(required, f) {
+			return &InvalidResponseError{Field: prefix + f, Reason: "is not allowed"}
+		}
+	}
+	return nil
+}
+
+// generateText はリクエストを送信し、最初の候補のテキストを返します
+func (c *Client) generateText(ctx context.Context, contents []*genai.Content, config *genai.GenerateContentConfig) (string, error) {
+	resp, err := c.client.Models.GenerateContent(ctx, c.model, contents, config)
+	if err != nil {
+		return "", err
+	}
+
+	text := ""
+	if len(resp.Candidates) > 0 && resp.Candidates[0].Content != nil {
+		for _, part := range resp.Candidates[0].Content.Parts {
+			text += part.Text
+		}
+	}
+	return text, nil
+}
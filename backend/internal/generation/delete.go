@@ -0,0 +1,102 @@
+package generation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/kinpatsu-everyone/backend-template/internal/blob"
+	"github.com/kinpatsu-everyone/backend-template/internal/mysql"
+	"github.com/kinpatsu-everyone/backend-template/pkg/outologger"
+)
+
+// ErrMonsterGenerating は生成ジョブが完了していないMonsterを削除しようとした場合のエラーです
+var ErrMonsterGenerating = errors.New("generation: monster has active generation jobs")
+
+// DeleteMonster はMonsterと関連する行・生成ジョブを1つのトランザクションで削除し、コミット後にストレージの画像を削除します
+// 完了していない生成ジョブがある場合は、削除後に画像がアップロードされないように ErrMonsterGenerating を返します
+// 画像の削除に失敗した場合はログに記録し、エラーは返しません
+func DeleteMonster(ctx context.Context, store blob.Store, monster mysql.Monster) error {
+	err := mysql.WithQueriesTx(ctx, func(q *mysql.Queries) error {
+		active, err := q.CountActiveMonsterGenerationJobs(ctx, monster.Monsterid)
+		if err != nil {
+			return fmt.Errorf("failed to count active monster generation jobs: %w", err)
+		}
+		if active > 0 {
+			return ErrMonsterGenerating
+		}
+
+		if err := q.DeleteMonsterGenerationJobsByMonsterId(ctx, monster.Monsterid); err != nil {
+			return fmt.Errorf("failed to delete monster generation jobs: %w", err)
+		}
+		if err := q.DeleteMonsterTrashCategoriesByMonsterId(ctx, monster.Monsterid); err != nil {
+			return fmt.Errorf("failed to delete monster trash categories: %w", err)
+		}
+		if err := q.DeleteMonsterAttribute(ctx, monster.Monsterid); err != nil {
+			return fmt.Errorf("failed to delete monster attribute: %w", err)
+		}
+		if err := q.DeleteMonsterTrashAnalysis(ctx, monster.Monsterid); err != nil {
+			return fmt.Errorf("failed to delete monster trash analysis: %w", err)
+		}
+		if err := q.DeleteMonsterProfile(ctx, monster.Monsterid); err != nil {
+			return fmt.Errorf("failed to delete monster profile: %w", err)
+		}
+		if err := q.DeleteMonsterStats(ctx, monster.Monsterid); err != nil {
+			return fmt.Errorf("failed to delete monster stats: %w", err)
+		}
+		if err := q.DeleteMonster(ctx, monster.Monsterid); err != nil {
+			return fmt.Errorf("failed to delete monster: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if store != nil {
+		deleteMonsterObjects(ctx, store, monster)
+	}
+	return nil
+}
+
+// deleteMonsterObjects は削除したMonsterの画像をストレージから削除します
+// Monsterが参照している画像に加えて、"monsters/{id}/" 以下に残っているオブジェクトも削除します
+func deleteMonsterObjects(ctx context.Context, store blob.Store, monster mysql.Monster) {
+	logger := outologger.GetLogger()
+
+	// リクエストがキャンセルされても削除できるように、キャンセルを引き継がない
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 30*time.Second)
+	defer cancel()
+
+	var paths []string
+	for _, p := range []string{monster.Generatedmonsterimageurl, monster.Originaltrashbinimageurl} {
+		if p != "" {
+			paths = append(paths, p)
+		}
+	}
+	objects, err := store.List(ctx, blob.MonsterObjectPrefix(monster.Monsterid))
+	if err != nil {
+		// 参照している画像だけは削除する
+		logger.Error(ctx, "failed to list monster objects", map[string]any{
+			"error":      err,
+			"monster_id": monster.Monsterid,
+		})
+	}
+	for _, o := range objects {
+		if !slices.Contains(paths, o.Key) {
+			paths = append(paths, o.Key)
+		}
+	}
+
+	for _, p := range paths {
+		if err := store.Delete(ctx, p); err != nil {
+			logger.Error(ctx, "failed to delete monster object", map[string]any{
+				"error":      err,
+				"monster_id": monster.Monsterid,
+				"path":       p,
+			})
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package generation
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/kinpatsu-everyone/backend-template/internal/blob"
+	"github.com/kinpatsu-everyone/backend-template/internal/mysql"
+)
+
+func TestDeleteMonsterObjects(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		monster mysql.Monster
+		want    []string
+	}{
+		{
+			name: "参照している画像とMonsterのキー以下のオブジェクトを削除する",
+			monster: mysql.Monster{
+				Monsterid:                "1",
+				Generatedmonsterimageurl: "monsters/1/generated.png",
+				Originaltrashbinimageurl: "monsters/1/original.jpg",
+			},
+			want: []string{"legacy/1.png", "monsters/10/generated.png", "monsters/2/generated.png"},
+		},
+		{
+			name: "キー以下にない参照している画像も削除する",
+			monster: mysql.Monster{
+				Monsterid:                "1",
+				Generatedmonsterimageurl: "legacy/1.png",
+			},
+			want: []string{"monsters/10/generated.png", "monsters/2/generated.png"},
+		},
+		{
+			name:    "画像のパスが保存されていない場合もキー以下のオブジェクトを削除する",
+			monster: mysql.Monster{Monsterid: "1"},
+			want:    []string{"legacy/1.png", "monsters/10/generated.png", "monsters/2/generated.png"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := blob.NewMemoryStore(&blob.URLSigner{BaseURL: "http://localhost:8080", Secret: []byte("secret")})
+			for _, key := range []string{
+				"legacy/1.png",
+				"monsters/1/generated.png",
+				"monsters/1/original.jpg",
+				"monsters/1/generated.webp",
+				"monsters/10/generated.png",
+				"monsters/2/generated.png",
+			} {
+				if err := store.Put(ctx, key, []byte("image"), "image/png"); err != nil {
+					t.Fatalf("Put() error = %v", err)
+				}
+			}
+
+			deleteMonsterObjects(ctx, store, tt.monster)
+
+			objects, err := store.List(ctx, "")
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+			var got []string
+			for _, o := range objects {
+				got = append(got, o.Key)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("remaining objects = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package generation
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+	"unicode/utf8"
+)
+
+// Status はモンスター生成ジョブの状態です
+type Status string
+
+const (
+	StatusQueued     Status = "queued"
+	StatusAnalyzing  Status = "analyzing"
+	StatusGenerating Status = "generating"
+	StatusUploading  Status = "uploading"
+	StatusDone       Status = "done"
+	StatusFailed     Status = "failed"
+)
+
+// 失敗理由のコードです
+const (
+	ErrorCodeAnalysisFailed   = "ANALYSIS_FAILED"
+	ErrorCodeGenerationFailed = "GENERATION_FAILED"
+	ErrorCodeUploadFailed     = "UPLOAD_FAILED"
+	ErrorCodeLeaseExpired     = "LEASE_EXPIRED"
+	ErrorCodeInterrupted      = "INTERRUPTED"
+)
+
+// reasonMessages は失敗理由のコードごとのユーザー向けメッセージです
+var reasonMessages = map[string]string{
+	ErrorCodeAnalysisFailed:   "ゴミ箱の画像の分析に失敗しました",
+	ErrorCodeGenerationFailed: "モンスターの画像の生成に失敗しました",
+	ErrorCodeUploadFailed:     "画像の保存に失敗しました",
+	ErrorCodeLeaseExpired:     "生成処理が時間内に完了しませんでした",
+	ErrorCodeInterrupted:      "サーバーの停止により生成処理が中断されました",
+}
+
+// ReasonMessage は失敗理由のコードに対応するユーザー向けメッセージを返します
+func ReasonMessage(code string) string {
+	if msg, ok := reasonMessages[code]; ok {
+		return msg
+	}
+	return "モンスターの生成に失敗しました"
+}
+
+// stageErrorCodes は失敗した時点の状態ごとの失敗理由のコードです
+var stageErrorCodes = map[Status]string{
+	StatusAnalyzing:  ErrorCodeAnalysisFailed,
+	StatusGenerating: ErrorCodeGenerationFailed,
+	StatusUploading:  ErrorCodeUploadFailed,
+}
+
+// maxErrorMessageLength は保存する失敗理由の詳細の最大バイト数です（ErrorMessage カラムの長さ）
+const maxErrorMessageLength = 1024
+
+// Reason はジョブの失敗理由です
+type Reason struct {
+	Code    string
+	Message string
+}
+
+// reasonFor は失敗した時点の状態とエラーから失敗理由を作成します
+func reasonFor(status Status, err error) Reason {
+	code, ok := stageErrorCodes[status]
+	if !ok {
+		code = ErrorCodeAnalysisFailed
+	}
+	msg := err.Error()
+	if len(msg) > maxErrorMessageLength {
+		msg = msg[:maxErrorMessageLength]
+		for !utf8.ValidString(msg) {
+			msg = msg[:len(msg)-1]
+		}
+	}
+	return Reason{Code: code, Message: msg}
+}
+
+// Job は実行するモンスター生成ジョブです
+type Job struct {
+	ID        string
+	MonsterID string
+	// UserID はジョブを作成したユーザーIDです（未ログインの場合は空）
+	UserID string
+	// Nickname・Latitude・Longitude はジョブの完了時に作成するMonsterの情報です
+	Nickname  string
+	Latitude  sql.NullString
+	Longitude sql.NullString
+	// QuotaKey はジョブの作成時に数えた1日の利用上限のキーです（失敗した場合に戻します、数えていない場合は空）
+	QuotaKey string
+	// Attempts は今回の実行を含む実行回数です
+	Attempts    int
+	MaxAttempts int
+	// InputImageKey はアップロードされたゴミ箱の画像のストレージのキーです（完了・失敗後に削除します）
+	InputImageKey string
+	MimeType      string
+}
+
+// ErrLeaseLost はリースの有効期限が切れ、他のワーカーにジョブが再取得された場合のエラーです
+var ErrLeaseLost = errors.New("generation: job lease lost")
+
+// Store はジョブを保存し、ワーカー間で排他的に取得するためのストアです
+// ジョブを操作するメソッドはリースを持つワーカーのみが成功し、それ以外は ErrLeaseLost を返します
+type Store interface {
+	// Claim は実行できるジョブを1件取得してリースします（ジョブがない場合は nil を返します）
+	// 待機中のジョブに加えて、リースの有効期限が切れた実行中のジョブも再取得します
+	Claim(ctx context.Context, workerID string, now, leaseExpiresAt time.Time) (*Job, error)
+	// Heartbeat はリースの有効期限を延長します
+	Heartbeat(ctx context.Context, jobID, workerID string, leaseExpiresAt time.Time) error
+	// SetStatus は実行中のジョブの状態を更新し、リースの有効期限を延長します
+	SetStatus(ctx context.Context, jobID, workerID string, status Status, leaseExpiresAt time.Time) error
+	// Complete はジョブを完了にします
+	Complete(ctx context.Context, jobID, workerID string, now time.Time) error
+	// Retry はジョブを nextRunAt 以降に再実行する待機状態に戻します
+	Retry(ctx context.Context, jobID, workerID string, nextRunAt time.Time, reason Reason) error
+	// Fail はジョブを失敗にします
+	Fail(ctx context.Context, jobID, workerID string, now time.Time, reason Reason) error
+	// FailExpired は最大実行回数に達したままリースの有効期限が切れたジョブを失敗にし、失敗にしたジョブを返します
+	FailExpired(ctx context.Context, now time.Time) ([]*Job, error)
+}
+
+// Pipeline はジョブを実行する処理です
+// 処理の段階が進むごとに progress で状態を報告します
+type Pipeline func(ctx context.Context, job *Job, progress func(Status) error) error
+
+// permanentError はリトライしても成功しないエラーです
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent はリトライせずにジョブを失敗にするエラーを返します
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsPermanent はエラーがリトライしても成功しないエラーかどうかを返します
+func IsPermanent(err error) bool {
+	var p *permanentError
+	return errors.As(err, &p)
+}
@@ -0,0 +1,173 @@
+package generation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/kinpatsu-everyone/backend-template/internal/mysql"
+)
+
+// claimCandidates は1回の Claim で取得を試みるジョブの候補数です
+// 他のワーカーと同じジョブを取り合った場合に、次の候補を試します
+const claimCandidates = 5
+
+// expireBatchSize は1回の FailExpired で失敗にするジョブの最大数です
+const expireBatchSize = 100
+
+// MySQLStore はMySQLの MonsterGenerationJob テーブルにジョブを保存します
+type MySQLStore struct {
+	queries mysql.Querier
+}
+
+// NewMySQLStore は新しい MySQLStore を作成します
+func NewMySQLStore(queries mysql.Querier) *MySQLStore {
+	return &MySQLStore{queries: queries}
+}
+
+func (s *MySQLStore) Claim(ctx context.Context, workerID string, now, leaseExpiresAt time.Time) (*Job, error) {
+	ids, err := s.queries.ListRunnableMonsterGenerationJobIds(ctx, mysql.ListRunnableMonsterGenerationJobIdsParams{
+		Now:     now,
+		MaxJobs: claimCandidates,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list runnable jobs: %w", err)
+	}
+
+	for _, id := range ids {
+		// 条件付きUPDATEで取得し、他のワーカーが先に取得した場合は次の候補を試す
+		result, err := s.queries.ClaimMonsterGenerationJob(ctx, mysql.ClaimMonsterGenerationJobParams{
+			LeaseOwner:     sql.NullString{String: workerID, Valid: true},
+			LeaseExpiresAt: sql.NullTime{Time: leaseExpiresAt, Valid: true},
+			JobID:          id,
+			Now:            now,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to claim job: %w", err)
+		}
+		if n, err := result.RowsAffected(); err != nil {
+			return nil, fmt.Errorf("failed to get rows affected: %w", err)
+		} else if n == 0 {
+			continue
+		}
+
+		row, err := s.queries.GetMonsterGenerationJob(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get job: %w", err)
+		}
+		return &Job{
+			ID:            row.Jobid,
+			MonsterID:     row.Monsterid,
+			UserID:        row.Userid.String,
+			Nickname:      row.Nickname,
+			Latitude:      row.Latitude,
+			Longitude:     row.Longitude,
+			QuotaKey:      row.Quotakey.String,
+			Attempts:      int(row.Attempts),
+			MaxAttempts:   int(row.Maxattempts),
+			InputImageKey: row.Inputimagekey,
+			MimeType:      row.Inputmimetype,
+		}, nil
+	}
+	return nil, nil
+}
+
+func (s *MySQLStore) Heartbeat(ctx context.Context, jobID, workerID string, leaseExpiresAt time.Time) error {
+	result, err := s.queries.HeartbeatMonsterGenerationJob(ctx, mysql.HeartbeatMonsterGenerationJobParams{
+		Leaseexpiresat: sql.NullTime{Time: leaseExpiresAt, Valid: true},
+		Jobid:          jobID,
+		Leaseowner:     sql.NullString{String: workerID, Valid: true},
+	})
+	return checkLeased(result, err)
+}
+
+func (s *MySQLStore) SetStatus(ctx context.Context, jobID, workerID string, status Status, leaseExpiresAt time.Time) error {
+	result, err := s.queries.UpdateMonsterGenerationJobStatus(ctx, mysql.UpdateMonsterGenerationJobStatusParams{
+		Status:         string(status),
+		Leaseexpiresat: sql.NullTime{Time: leaseExpiresAt, Valid: true},
+		Jobid:          jobID,
+		Leaseowner:     sql.NullString{String: workerID, Valid: true},
+	})
+	return checkLeased(result, err)
+}
+
+func (s *MySQLStore) Complete(ctx context.Context, jobID, workerID string, now time.Time) error {
+	result, err := s.queries.CompleteMonsterGenerationJob(ctx, mysql.CompleteMonsterGenerationJobParams{
+		Completedat: sql.NullTime{Time: now, Valid: true},
+		Jobid:       jobID,
+		Leaseowner:  sql.NullString{String: workerID, Valid: true},
+	})
+	return checkLeased(result, err)
+}
+
+func (s *MySQLStore) Retry(ctx context.Context, jobID, workerID string, nextRunAt time.Time, reason Reason) error {
+	result, err := s.queries.RetryMonsterGenerationJob(ctx, mysql.RetryMonsterGenerationJobParams{
+		Nextrunat:    nextRunAt,
+		Errorcode:    sql.NullString{String: reason.Code, Valid: true},
+		Errormessage: sql.NullString{String: reason.Message, Valid: true},
+		Jobid:        jobID,
+		Leaseowner:   sql.NullString{String: workerID, Valid: true},
+	})
+	return checkLeased(result, err)
+}
+
+func (s *MySQLStore) Fail(ctx context.Context, jobID, workerID string, now time.Time, reason Reason) error {
+	result, err := s.queries.FailMonsterGenerationJob(ctx, mysql.FailMonsterGenerationJobParams{
+		Errorcode:    sql.NullString{String: reason.Code, Valid: true},
+		Errormessage: sql.NullString{String: reason.Message, Valid: true},
+		Completedat:  sql.NullTime{Time: now, Valid: true},
+		Jobid:        jobID,
+		Leaseowner:   sql.NullString{String: workerID, Valid: true},
+	})
+	return checkLeased(result, err)
+}
+
+func (s *MySQLStore) FailExpired(ctx context.Context, now time.Time) ([]*Job, error) {
+	rows, err := s.queries.ListExpiredMonsterGenerationJobs(ctx, mysql.ListExpiredMonsterGenerationJobsParams{
+		Now:     sql.NullTime{Time: now, Valid: true},
+		MaxJobs: expireBatchSize,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list expired jobs: %w", err)
+	}
+
+	var jobs []*Job
+	for _, row := range rows {
+		// 条件付きUPDATEで失敗にし、他のワーカーが先に失敗にした場合は含めない
+		result, err := s.queries.FailExpiredMonsterGenerationJob(ctx, mysql.FailExpiredMonsterGenerationJobParams{
+			Now:   sql.NullTime{Time: now, Valid: true},
+			JobID: row.Jobid,
+		})
+		if err != nil {
+			return jobs, fmt.Errorf("failed to fail expired job: %w", err)
+		}
+		if n, err := result.RowsAffected(); err != nil {
+			return jobs, fmt.Errorf("failed to get rows affected: %w", err)
+		} else if n == 0 {
+			continue
+		}
+		jobs = append(jobs, &Job{
+			ID:            row.Jobid,
+			MonsterID:     row.Monsterid,
+			QuotaKey:      row.Quotakey.String,
+			InputImageKey: row.Inputimagekey,
+		})
+	}
+	return jobs, nil
+}
+
+// checkLeased は更新した行がない場合（リースを失っている場合）に ErrLeaseLost を返します
+func checkLeased(result sql.Result, err error) error {
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if n == 0 {
+		return ErrLeaseLost
+	}
+	return nil
+}
@@ -0,0 +1,319 @@
+package generation
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/kinpatsu-everyone/backend-template/enum"
+	"github.com/kinpatsu-everyone/backend-template/internal/blob"
+	"github.com/kinpatsu-everyone/backend-template/internal/monsterai"
+	"github.com/kinpatsu-everyone/backend-template/internal/mysql"
+	"github.com/kinpatsu-everyone/backend-template/pkg/outologger"
+)
+
+// RunMonsterGeneration はモンスター生成ジョブを実行する Pipeline です
+// 外部サービスの処理がすべて成功してから、Monsterを1つのトランザクションで保存します
+// 処理内容:
+// 1. ストレージからアップロードされたゴミ箱の画像を取得し、ゴミ種別を判定（analyzing）
+// 2. 元画像と分析結果からモンスターの画像を生成し、属性・種族名・説明文・能力値を決める（generating）
+// 3. 生成画像と元画像をストレージにアップロード（uploading）
+// 4. Monster・ゴミ種別・属性・種族名・能力値・分析結果・生成に使った入力を保存（失敗した場合はアップロードした画像を削除する）
+func RunMonsterGeneration(ctx context.Context, job *Job, progress func(Status) error) (err error) {
+	logger := outologger.GetLogger()
+
+	ai := monsterai.GetMonsterAI()
+	if ai == nil {
+		return Permanent(fmt.Errorf("monster AI is not configured"))
+	}
+	store := blob.GetStore()
+	if store == nil {
+		return Permanent(fmt.Errorf("blob store is not configured"))
+	}
+
+	// 1. アップロードされた画像を取得し、ゴミ種別を判定
+	image, _, err := store.Get(ctx, job.InputImageKey)
+	if errors.Is(err, blob.ErrNotFound) {
+		return Permanent(fmt.Errorf("input image is not found: %w", err))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to download input image: %w", err)
+	}
+	// デコードに大量のメモリを使う画像は、分析を呼び出す前に失敗にする（リトライしない）
+	if err := monsterai.CheckInputImageSize(image); err != nil {
+		return Permanent(err)
+	}
+	analysis, err := ai.AnalyzeTrashBin(ctx, image, job.MimeType)
+	if err != nil {
+		return fmt.Errorf("failed to analyze image: %w", err)
+	}
+	logger.Info(ctx, "trash type determined", map[string]any{
+		"job_id":     job.ID,
+		"trash_type": analysis.TrashType,
+		"confidence": analysis.Confidence,
+	})
+
+	// 2. モンスターの画像を生成
+	if err := progress(StatusGenerating); err != nil {
+		return err
+	}
+	generated, err := ai.GenerateMonster(ctx, monsterai.GenerateInput{
+		Analysis: *analysis,
+		Image:    image,
+		MimeType: job.MimeType,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to generate image: %w", err)
+	}
+	logger.Info(ctx, "monster image generated", map[string]any{
+		"job_id":             job.ID,
+		"provider":           generated.Input.Provider,
+		"model":              generated.Input.Model,
+		"input_image_sha256": generated.Input.InputImageSHA256,
+	})
+
+	// 属性はゴミ種別から、色は生成画像の主な色から決める
+	attribute, colorErr := newMonsterAttribute(analysis.TrashType, generated.Data)
+	if colorErr != nil {
+		logger.Warn(ctx, "failed to extract dominant color, using trash category color", map[string]any{
+			"job_id":     job.ID,
+			"error":      colorErr,
+			"color_code": attribute.ColorCode,
+		})
+	}
+
+	// 種族名と説明文を生成する（失敗した場合は画像を生成し直さずに仮の種族名と説明文を保存し、管理者が再生成する）
+	profile, profileErr := ai.DescribeMonster(ctx, monsterai.ProfileInput{
+		Analysis:  *analysis,
+		Attribute: attribute.Name,
+		Image:     generated.Data,
+		MimeType:  generated.MimeType,
+	})
+	if profileErr != nil {
+		profile = fallbackProfile(*analysis, attribute)
+		logger.Warn(ctx, "failed to describe monster, using fallback profile", map[string]any{
+			"job_id": job.ID,
+			"error":  profileErr,
+		})
+	}
+
+	// 能力値は分析結果とMonsterIDのシードから計算する
+	statsSeed := StatsSeed(job.MonsterID)
+
+	// 3. 生成画像と元画像をアップロード（パスのみ保存）
+	if err := progress(StatusUploading); err != nil {
+		return err
+	}
+	// 以降の処理に失敗した場合は、アップロードした画像を削除する
+	// err は戻り値を参照する（deferで失敗を判定するため、シャドーイングしない）
+	var uploaded []string
+	defer func() {
+		if err != nil && len(uploaded) > 0 {
+			cleanupUploadedObjects(ctx, store, job.MonsterID, uploaded)
+		}
+	}()
+
+	generatedImagePath := blob.GenerateGeneratedImagePath(job.MonsterID, blob.GetExtensionFromMimeType(generated.MimeType))
+	if err = store.Put(ctx, generatedImagePath, generated.Data, generated.MimeType); err != nil {
+		return fmt.Errorf("failed to upload generated image: %w", err)
+	}
+	uploaded = append(uploaded, generatedImagePath)
+
+	originalImagePath := blob.GenerateOriginalImagePath(job.MonsterID, blob.GetExtensionFromMimeType(job.MimeType))
+	if err = store.Put(ctx, originalImagePath, image, job.MimeType); err != nil {
+		return fmt.Errorf("failed to upload original image: %w", err)
+	}
+	uploaded = append(uploaded, originalImagePath)
+
+	// 4. Monster・ゴミ種別・属性・種族名・能力値・分析結果・生成に使った入力を保存
+	return saveGeneratedMonster(ctx, job, generatedMonster{
+		Analysis:           analysis,
+		Attribute:          attribute,
+		Profile:            *profile,
+		Stats:              ComputeStats(*analysis, statsSeed),
+		StatsSeed:          statsSeed,
+		Input:              generated.Input,
+		OriginalImagePath:  originalImagePath,
+		GeneratedImagePath: generatedImagePath,
+	})
+}
+
+// generatedMonster はジョブで生成し、保存するモンスターの情報です
+type generatedMonster struct {
+	Analysis  *monsterai.Analysis
+	Attribute monsterAttribute
+	Profile   monsterai.Profile
+	Stats     Stats
+	StatsSeed uint64
+	// Input は画像の生成に使った入力です（ジョブに記録します）
+	Input              monsterai.GenerationRecord
+	OriginalImagePath  string
+	GeneratedImagePath string
+}
+
+// defaultMonsterColorCode は生成画像の主な色を抽出できず、ゴミ種別の色もない場合のカラーコードです
+const defaultMonsterColorCode = "#9E9E9E"
+
+// monsterAttribute はモンスターの属性と主な色です（MonsterAttribute テーブルに保存します）
+type monsterAttribute struct {
+	Name      string
+	ColorCode string
+}
+
+// newMonsterAttribute はゴミ種別と生成画像からモンスターの属性と主な色を決めます
+// 属性はゴミ種別の属性（判定できない場合は指定なしの属性）です
+// 主な色を抽出できない場合はゴミ種別の色を使い、抽出できなかった理由をエラーとして返します
+func newMonsterAttribute(trashType string, generatedImage []byte) (monsterAttribute, error) {
+	info, _ := enum.StringToTrashCategoryEnum(trashType).Info()
+	attribute := monsterAttribute{Name: info.Attribute}
+
+	colorCode, err := monsterai.DominantColor(generatedImage)
+	if err != nil {
+		colorCode = info.ColorCode
+		if colorCode == "" {
+			colorCode = defaultMonsterColorCode
+		}
+	}
+	attribute.ColorCode = colorCode
+	return attribute, err
+}
+
+// saveGeneratedMonster は生成結果のMonster・ゴミ種別・属性・種族名・能力値・分析結果と、生成に使った入力（ジョブに記録）を1つのトランザクションで保存します
+// Monsterが既に存在する場合（前回の実行で保存済み、または reconcile で再生成する場合）は画像・ゴミ種別・属性・種族名・能力値・分析結果のみ置き換えます
+func saveGeneratedMonster(ctx context.Context, job *Job, m generatedMonster) error {
+	analysis, attribute := m.Analysis, m.Attribute
+	originalImagePath, generatedImagePath := m.OriginalImagePath, m.GeneratedImagePath
+	analysisResult, err := json.Marshal(analysis)
+	if err != nil {
+		return fmt.Errorf("failed to marshal analysis: %w", err)
+	}
+	generationInput, err := json.Marshal(m.Input)
+	if err != nil {
+		return fmt.Errorf("failed to marshal generation input: %w", err)
+	}
+
+	return mysql.WithQueriesTx(ctx, func(q *mysql.Queries) error {
+		monster, err := q.GetMonster(ctx, job.MonsterID)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			if _, err := q.CreateMonster(ctx, mysql.CreateMonsterParams{
+				Monsterid:                job.MonsterID,
+				Userid:                   sql.NullString{String: job.UserID, Valid: job.UserID != ""},
+				Nickname:                 job.Nickname,
+				Originaltrashbinimageurl: originalImagePath,
+				Generatedmonsterimageurl: generatedImagePath,
+				Latitude:                 job.Latitude,
+				Longitude:                job.Longitude,
+			}); err != nil {
+				return fmt.Errorf("failed to create monster: %w", err)
+			}
+		case err != nil:
+			return fmt.Errorf("failed to get monster: %w", err)
+		default:
+			if _, err := q.UpdateMonster(ctx, mysql.UpdateMonsterParams{
+				Nickname:                 monster.Nickname,
+				Originaltrashbinimageurl: originalImagePath,
+				Generatedmonsterimageurl: generatedImagePath,
+				Latitude:                 monster.Latitude,
+				Longitude:                monster.Longitude,
+				Monsterid:                job.MonsterID,
+			}); err != nil {
+				return fmt.Errorf("failed to update monster with image paths: %w", err)
+			}
+		}
+
+		if err := q.DeleteMonsterTrashCategoriesByMonsterId(ctx, job.MonsterID); err != nil {
+			return fmt.Errorf("failed to delete monster trash categories: %w", err)
+		}
+		if _, err := q.CreateMonsterTrashCategory(ctx, mysql.CreateMonsterTrashCategoryParams{
+			Monstertrashcategoryid: uuid.New().String(),
+			Monsterid:              job.MonsterID,
+			Trashcategory:          uint8(enum.StringToTrashCategoryEnum(analysis.TrashType)),
+		}); err != nil {
+			return fmt.Errorf("failed to create monster trash category: %w", err)
+		}
+		if err := q.DeleteMonsterAttribute(ctx, job.MonsterID); err != nil {
+			return fmt.Errorf("failed to delete monster attribute: %w", err)
+		}
+		if _, err := q.CreateMonsterAttribute(ctx, mysql.CreateMonsterAttributeParams{
+			Monsterid:     job.MonsterID,
+			Attributename: attribute.Name,
+			Colorcode:     attribute.ColorCode,
+		}); err != nil {
+			return fmt.Errorf("failed to create monster attribute: %w", err)
+		}
+		if err := saveMonsterProfile(ctx, q, job.MonsterID, m.Profile, m.Stats, m.StatsSeed); err != nil {
+			return err
+		}
+		if err := q.UpsertMonsterTrashAnalysis(ctx, mysql.UpsertMonsterTrashAnalysisParams{
+			Monsterid:  job.MonsterID,
+			Trashtype:  analysis.TrashType,
+			Confidence: analysis.Confidence,
+			Result:     analysisResult,
+		}); err != nil {
+			return fmt.Errorf("failed to save trash analysis: %w", err)
+		}
+
+		if _, err := q.UpdateMonsterGenerationJobGenerationInput(ctx, mysql.UpdateMonsterGenerationJobGenerationInputParams{
+			Generationinput: generationInput,
+			Jobid:           job.ID,
+		}); err != nil {
+			return fmt.Errorf("failed to record generation input: %w", err)
+		}
+		return nil
+	})
+}
+
+// cleanupUploadedObjects は保存に失敗したジョブがアップロードした画像を削除します
+// 画像は同じパスに上書きするため、保存済みのMonsterが参照している画像は削除しません
+func cleanupUploadedObjects(ctx context.Context, store blob.Store, monsterID string, paths []string) {
+	logger := outologger.GetLogger()
+
+	// ジョブが中断された場合でも削除できるように、キャンセルを引き継がない
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 30*time.Second)
+	defer cancel()
+
+	var monster *mysql.Monster
+	m, err := mysql.GetQueries().GetMonster(ctx, monsterID)
+	switch {
+	case err == nil:
+		monster = &m
+	case !errors.Is(err, sql.ErrNoRows):
+		// 参照されているか確認できない場合は、保存済みの画像を誤って削除しないように削除しない
+		logger.Error(ctx, "failed to get monster for cleanup", map[string]any{
+			"error":      err,
+			"monster_id": monsterID,
+		})
+		return
+	}
+
+	for _, p := range unreferencedPaths(paths, monster) {
+		if err := store.Delete(ctx, p); err != nil {
+			logger.Error(ctx, "failed to delete orphaned object", map[string]any{
+				"error": err,
+				"path":  p,
+			})
+			continue
+		}
+		logger.Info(ctx, "orphaned object deleted", map[string]any{
+			"path": p,
+		})
+	}
+}
+
+// unreferencedPaths は monster（nilの場合は存在しない）が参照していないパスを返します
+func unreferencedPaths(paths []string, monster *mysql.Monster) []string {
+	var result []string
+	for _, p := range paths {
+		if monster != nil && (p == monster.Originaltrashbinimageurl || p == monster.Generatedmonsterimageurl) {
+			continue
+		}
+		result = append(result, p)
+	}
+	return result
+}
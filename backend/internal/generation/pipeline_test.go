@@ -0,0 +1,140 @@
+package generation
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/png"
+	"reflect"
+	"testing"
+
+	"github.com/kinpatsu-everyone/backend-template/internal/blob"
+	"github.com/kinpatsu-everyone/backend-template/internal/monsterai"
+	"github.com/kinpatsu-everyone/backend-template/internal/mysql"
+)
+
+func TestUnreferencedPaths(t *testing.T) {
+	paths := []string{"monsters/1/generated.png", "monsters/1/original.jpg"}
+
+	tests := []struct {
+		name    string
+		monster *mysql.Monster
+		want    []string
+	}{
+		{
+			name:    "Monsterが保存されていない場合はすべて削除する",
+			monster: nil,
+			want:    paths,
+		},
+		{
+			name:    "保存済みのMonsterが参照している画像は削除しない",
+			monster: &mysql.Monster{Generatedmonsterimageurl: "monsters/1/generated.png"},
+			want:    []string{"monsters/1/original.jpg"},
+		},
+		{
+			name:    "すべて参照されている場合は何も削除しない",
+			monster: &mysql.Monster{Generatedmonsterimageurl: "monsters/1/generated.png", Originaltrashbinimageurl: "monsters/1/original.jpg"},
+			want:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := unreferencedPaths(paths, tt.monster); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("unreferencedPaths() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewMonsterAttribute(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 8, 8))
+	for y := range 8 {
+		for x := range 8 {
+			img.Set(x, y, color.RGBA{R: 0x12, G: 0x34, B: 0xd6, A: 0xff})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("png.Encode() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		trashType string
+		image     []byte
+		want      monsterAttribute
+		wantErr   bool
+	}{
+		{
+			name:      "属性はゴミ種別から、色は生成画像から決める",
+			trashType: "燃えるゴミ",
+			image:     buf.Bytes(),
+			want:      monsterAttribute{Name: "炎", ColorCode: "#1234D6"},
+		},
+		{
+			name:      "色を抽出できない場合はゴミ種別の色を使う",
+			trashType: "ペットボトル",
+			image:     []byte("not an image"),
+			want:      monsterAttribute{Name: "水", ColorCode: "#1E88E5"},
+			wantErr:   true,
+		},
+		{
+			name:      "ゴミ種別を判定できない場合は指定なしの属性とデフォルトの色を使う",
+			trashType: "unknown",
+			image:     nil,
+			want:      monsterAttribute{Name: "無", ColorCode: defaultMonsterColorCode},
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newMonsterAttribute(tt.trashType, tt.image)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("newMonsterAttribute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("newMonsterAttribute() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunMonsterGeneration_ピクセル数が上限を超える画像はリトライせずに失敗する(t *testing.T) {
+	ctx := context.Background()
+	store := blob.NewMemoryStore(&blob.URLSigner{BaseURL: "http://example.com", Secret: []byte("secret")})
+	blob.SetStore(store)
+	t.Cleanup(func() { blob.SetStore(nil) })
+	monsterai.SetMonsterAI(monsterai.NewFake())
+	t.Cleanup(func() { monsterai.SetMonsterAI(nil) })
+
+	// 100000×100000 のPNGのヘッダー（デコードすると約40GBになる）
+	ihdr := make([]byte, 17)
+	copy(ihdr, "IHDR")
+	binary.BigEndian.PutUint32(ihdr[4:], 100000)
+	binary.BigEndian.PutUint32(ihdr[8:], 100000)
+	ihdr[12], ihdr[13] = 8, 2
+	var header bytes.Buffer
+	header.WriteString("\x89PNG\r\n\x1a\n")
+	binary.Write(&header, binary.BigEndian, uint32(len(ihdr)-4))
+	header.Write(ihdr)
+	binary.Write(&header, binary.BigEndian, crc32.ChecksumIEEE(ihdr))
+
+	job := &Job{ID: "job-1", MonsterID: "monster-1", InputImageKey: "inputs/monster-1/job-1.png", MimeType: "image/png", Attempts: 1, MaxAttempts: 3}
+	if err := store.Put(ctx, job.InputImageKey, header.Bytes(), job.MimeType); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	err := RunMonsterGeneration(ctx, job, func(Status) error { return nil })
+	if !IsPermanent(err) {
+		t.Errorf("RunMonsterGeneration() error = %v, want permanent error", err)
+	}
+	if !errors.Is(err, monsterai.ErrInputImageTooLarge) {
+		t.Errorf("RunMonsterGeneration() error = %v, want %v", err, monsterai.ErrInputImageTooLarge)
+	}
+}
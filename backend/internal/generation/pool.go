@@ -0,0 +1,300 @@
+package generation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/kinpatsu-everyone/backend-template/internal/blob"
+	"github.com/kinpatsu-everyone/backend-template/pkg/outologger"
+)
+
+// PoolConfig はワーカープールの設定です
+type PoolConfig struct {
+	// WorkerID はリースの所有者として記録するIDです（デフォルトはホスト名とランダムな値）
+	WorkerID string
+	// Concurrency は並列に実行するジョブ数です（デフォルトは1）
+	Concurrency int
+	// LeaseDuration はリースの有効期間です（デフォルトは2分）
+	// ワーカーが停止した場合、この期間が過ぎると他のワーカーがジョブを再取得します
+	LeaseDuration time.Duration
+	// HeartbeatInterval はリースを延長する間隔です（デフォルトは LeaseDuration の1/3）
+	HeartbeatInterval time.Duration
+	// PollInterval は実行できるジョブがない場合に待機する時間です（デフォルトは2秒）
+	PollInterval time.Duration
+	// BaseBackoff は1回目のリトライまでの待機時間です（以降は2倍ずつ増やします、デフォルトは10秒）
+	BaseBackoff time.Duration
+	// MaxBackoff はリトライまでの待機時間の上限です（デフォルトは5分）
+	MaxBackoff time.Duration
+	Logger     outologger.Logger
+	// RefundQuota は失敗したジョブの作成時に数えた1日の利用上限を戻します（nilの場合は戻しません）
+	RefundQuota func(ctx context.Context, key string) error
+	// Storage は完了・失敗したジョブの入力画像を削除するストレージです（nilの場合は削除しません）
+	Storage blob.Store
+	// Now は現在時刻を返します（テスト用、デフォルトは time.Now）
+	Now func() time.Time
+}
+
+// Pool はモンスター生成ジョブを並列に実行するワーカープールです
+type Pool struct {
+	store    Store
+	pipeline Pipeline
+	cfg      PoolConfig
+}
+
+// NewPool は新しいワーカープールを作成します
+func NewPool(store Store, pipeline Pipeline, cfg PoolConfig) *Pool {
+	if cfg.WorkerID == "" {
+		host, _ := os.Hostname()
+		cfg.WorkerID = fmt.Sprintf("%s-%s", host, uuid.New().String()[:8])
+	}
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = 1
+	}
+	if cfg.LeaseDuration <= 0 {
+		cfg.LeaseDuration = 2 * time.Minute
+	}
+	if cfg.HeartbeatInterval <= 0 {
+		cfg.HeartbeatInterval = cfg.LeaseDuration / 3
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = 2 * time.Second
+	}
+	if cfg.BaseBackoff <= 0 {
+		cfg.BaseBackoff = 10 * time.Second
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = 5 * time.Minute
+	}
+	if cfg.Now == nil {
+		cfg.Now = time.Now
+	}
+	return &Pool{store: store, pipeline: pipeline, cfg: cfg}
+}
+
+// Run はワーカーを起動し、ctx がキャンセルされて実行中のジョブが終わるまでブロックします
+func (p *Pool) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for range p.cfg.Concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.loop(ctx)
+		}()
+	}
+	wg.Wait()
+}
+
+func (p *Pool) loop(ctx context.Context) {
+	for ctx.Err() == nil {
+		ran, err := p.RunOnce(ctx)
+		if err != nil && ctx.Err() == nil {
+			p.log(ctx, "error", "failed to run generation job", map[string]any{
+				"error": err,
+			})
+		}
+		if ran && err == nil {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(p.cfg.PollInterval):
+		}
+	}
+}
+
+// RunOnce は実行できるジョブを1件取得して実行します
+// 実行できるジョブがない場合は false を返します
+func (p *Pool) RunOnce(ctx context.Context) (bool, error) {
+	now := p.cfg.Now()
+	expired, err := p.store.FailExpired(ctx, now)
+	for _, job := range expired {
+		p.log(ctx, "warn", "generation job failed by lease expiration", map[string]any{
+			"job_id":     job.ID,
+			"monster_id": job.MonsterID,
+		})
+		p.refundQuota(ctx, job)
+		p.deleteInputImage(ctx, job)
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to fail expired jobs: %w", err)
+	}
+
+	job, err := p.store.Claim(ctx, p.cfg.WorkerID, now, now.Add(p.cfg.LeaseDuration))
+	if err != nil {
+		return false, fmt.Errorf("failed to claim job: %w", err)
+	}
+	if job == nil {
+		return false, nil
+	}
+
+	return true, p.execute(ctx, job)
+}
+
+// execute はジョブを実行し、結果に応じて完了・リトライ・失敗にします
+func (p *Pool) execute(ctx context.Context, job *Job) error {
+	jobCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	// リースを定期的に延長する（延長できない場合は他のワーカーに再取得されているため中断する）
+	stopHeartbeat := make(chan struct{})
+	heartbeatDone := make(chan struct{})
+	go func() {
+		defer close(heartbeatDone)
+		ticker := time.NewTicker(p.cfg.HeartbeatInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stopHeartbeat:
+				return
+			case <-jobCtx.Done():
+				return
+			case <-ticker.C:
+				err := p.store.Heartbeat(jobCtx, job.ID, p.cfg.WorkerID, p.cfg.Now().Add(p.cfg.LeaseDuration))
+				if errors.Is(err, ErrLeaseLost) {
+					cancel(ErrLeaseLost)
+					return
+				}
+				if err != nil {
+					p.log(jobCtx, "warn", "failed to extend generation job lease", map[string]any{
+						"error":  err,
+						"job_id": job.ID,
+					})
+				}
+			}
+		}
+	}()
+
+	status := StatusAnalyzing
+	progress := func(s Status) error {
+		if err := p.store.SetStatus(jobCtx, job.ID, p.cfg.WorkerID, s, p.cfg.Now().Add(p.cfg.LeaseDuration)); err != nil {
+			if errors.Is(err, ErrLeaseLost) {
+				cancel(ErrLeaseLost)
+			}
+			return fmt.Errorf("failed to update job status: %w", err)
+		}
+		status = s
+		return nil
+	}
+
+	p.log(ctx, "info", "generation job started", map[string]any{
+		"job_id":     job.ID,
+		"monster_id": job.MonsterID,
+		"attempt":    job.Attempts,
+	})
+	runErr := p.pipeline(jobCtx, job, progress)
+	close(stopHeartbeat)
+	<-heartbeatDone
+
+	if errors.Is(context.Cause(jobCtx), ErrLeaseLost) {
+		p.log(ctx, "warn", "generation job lease lost", map[string]any{
+			"job_id": job.ID,
+		})
+		return nil
+	}
+
+	// 停止中に中断したジョブは、他のワーカーがすぐに再実行できるように待機状態に戻す
+	if runErr != nil && ctx.Err() != nil {
+		releaseCtx, releaseCancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+		defer releaseCancel()
+		reason := Reason{Code: ErrorCodeInterrupted, Message: reasonFor(status, runErr).Message}
+		if err := p.store.Retry(releaseCtx, job.ID, p.cfg.WorkerID, p.cfg.Now(), reason); err != nil {
+			return fmt.Errorf("failed to release interrupted job: %w", err)
+		}
+		return nil
+	}
+
+	now := p.cfg.Now()
+	switch {
+	case runErr == nil:
+		if err := p.store.Complete(ctx, job.ID, p.cfg.WorkerID, now); err != nil {
+			return fmt.Errorf("failed to complete job: %w", err)
+		}
+		p.log(ctx, "info", "generation job completed", map[string]any{
+			"job_id":     job.ID,
+			"monster_id": job.MonsterID,
+		})
+		p.deleteInputImage(ctx, job)
+	case IsPermanent(runErr) || job.Attempts >= job.MaxAttempts:
+		if err := p.store.Fail(ctx, job.ID, p.cfg.WorkerID, now, reasonFor(status, runErr)); err != nil {
+			return fmt.Errorf("failed to fail job: %w", err)
+		}
+		p.log(ctx, "error", "generation job failed", map[string]any{
+			"error":   runErr,
+			"job_id":  job.ID,
+			"status":  status,
+			"attempt": job.Attempts,
+		})
+		p.refundQuota(ctx, job)
+		p.deleteInputImage(ctx, job)
+	default:
+		nextRunAt := now.Add(p.backoff(job.Attempts))
+		if err := p.store.Retry(ctx, job.ID, p.cfg.WorkerID, nextRunAt, reasonFor(status, runErr)); err != nil {
+			return fmt.Errorf("failed to retry job: %w", err)
+		}
+		p.log(ctx, "warn", "generation job will be retried", map[string]any{
+			"error":       runErr,
+			"job_id":      job.ID,
+			"status":      status,
+			"attempt":     job.Attempts,
+			"next_run_at": nextRunAt,
+		})
+	}
+	return nil
+}
+
+// refundQuota は失敗したジョブの作成時に数えた1日の利用上限を戻します
+func (p *Pool) refundQuota(ctx context.Context, job *Job) {
+	if p.cfg.RefundQuota == nil || job.QuotaKey == "" {
+		return
+	}
+	if err := p.cfg.RefundQuota(ctx, job.QuotaKey); err != nil {
+		p.log(ctx, "error", "failed to refund generation quota", map[string]any{
+			"error":  err,
+			"job_id": job.ID,
+		})
+	}
+}
+
+// deleteInputImage は完了・失敗したジョブの入力画像をストレージから削除します
+func (p *Pool) deleteInputImage(ctx context.Context, job *Job) {
+	if p.cfg.Storage == nil || job.InputImageKey == "" {
+		return
+	}
+	if err := p.cfg.Storage.Delete(ctx, job.InputImageKey); err != nil {
+		p.log(ctx, "error", "failed to delete generation job input image", map[string]any{
+			"error":  err,
+			"job_id": job.ID,
+			"key":    job.InputImageKey,
+		})
+	}
+}
+
+// backoff は attempts 回目の実行に失敗した後、次の実行までの待機時間を返します
+func (p *Pool) backoff(attempts int) time.Duration {
+	d := p.cfg.BaseBackoff
+	for i := 1; i < attempts && d < p.cfg.MaxBackoff; i++ {
+		d *= 2
+	}
+	return min(d, p.cfg.MaxBackoff)
+}
+
+func (p *Pool) log(ctx context.Context, level, msg string, kv map[string]any) {
+	if p.cfg.Logger == nil {
+		return
+	}
+	switch level {
+	case "error":
+		p.cfg.Logger.Error(ctx, msg, kv)
+	case "warn":
+		p.cfg.Logger.Warn(ctx, msg, kv)
+	default:
+		p.cfg.Logger.Info(ctx, msg, kv)
+	}
+}
@@ -0,0 +1,343 @@
+package generation
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kinpatsu-everyone/backend-template/internal/blob"
+)
+
+// fakeStore は1件のジョブを保持し、呼び出しを記録するストアです
+type fakeStore struct {
+	mu       sync.Mutex
+	job      *Job
+	claimed  bool
+	statuses []Status
+	result   string
+	reason   Reason
+	retryAt  time.Time
+	// leaseLost がtrueの場合、ジョブの更新は ErrLeaseLost を返します
+	leaseLost bool
+	// expired は FailExpired で失敗にするジョブです
+	expired []*Job
+}
+
+func (s *fakeStore) Claim(ctx context.Context, workerID string, now, leaseExpiresAt time.Time) (*Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.job == nil || s.claimed {
+		return nil, nil
+	}
+	s.claimed = true
+	s.job.Attempts++
+	job := *s.job
+	return &job, nil
+}
+
+func (s *fakeStore) Heartbeat(ctx context.Context, jobID, workerID string, leaseExpiresAt time.Time) error {
+	return s.check()
+}
+
+func (s *fakeStore) SetStatus(ctx context.Context, jobID, workerID string, status Status, leaseExpiresAt time.Time) error {
+	if err := s.check(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.statuses = append(s.statuses, status)
+	return nil
+}
+
+func (s *fakeStore) Complete(ctx context.Context, jobID, workerID string, now time.Time) error {
+	return s.finish("done", Reason{}, time.Time{})
+}
+
+func (s *fakeStore) Retry(ctx context.Context, jobID, workerID string, nextRunAt time.Time, reason Reason) error {
+	return s.finish("retry", reason, nextRunAt)
+}
+
+func (s *fakeStore) Fail(ctx context.Context, jobID, workerID string, now time.Time, reason Reason) error {
+	return s.finish("failed", reason, time.Time{})
+}
+
+func (s *fakeStore) FailExpired(ctx context.Context, now time.Time) ([]*Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	expired := s.expired
+	s.expired = nil
+	return expired, nil
+}
+
+func (s *fakeStore) check() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.leaseLost {
+		return ErrLeaseLost
+	}
+	return nil
+}
+
+func (s *fakeStore) finish(result string, reason Reason, retryAt time.Time) error {
+	if err := s.check(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.result, s.reason, s.retryAt = result, reason, retryAt
+	return nil
+}
+
+const inputImageKey = "monsters/monster-1/input-job-1.jpg"
+
+// newInputImageStorage はジョブの入力画像を保存したストレージを作成します
+func newInputImageStorage(t *testing.T) blob.Store {
+	t.Helper()
+	storage := blob.NewMemoryStore(&blob.URLSigner{BaseURL: "http://localhost:8080", Secret: []byte("secret")})
+	if err := storage.Put(context.Background(), inputImageKey, []byte("image"), "image/jpeg"); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	return storage
+}
+
+func TestPool_RunOnce(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	errGemini := errors.New("gemini: 503 service unavailable")
+
+	tests := []struct {
+		name         string
+		attempts     int
+		leaseLost    bool
+		pipeline     Pipeline
+		wantStatuses []Status
+		wantResult   string
+		wantCode     string
+		wantRetryAt  time.Time
+		// wantRefunded は1日の利用上限を戻すかどうかです（失敗にした場合のみ戻す）
+		wantRefunded bool
+		// wantInputDeleted は入力画像を削除するかどうかです（完了・失敗にした場合のみ削除する）
+		wantInputDeleted bool
+	}{
+		{
+			name: "すべての段階が成功すると完了にする",
+			pipeline: func(ctx context.Context, job *Job, progress func(Status) error) error {
+				if err := progress(StatusGenerating); err != nil {
+					return err
+				}
+				return progress(StatusUploading)
+			},
+			wantStatuses:     []Status{StatusGenerating, StatusUploading},
+			wantResult:       "done",
+			wantInputDeleted: true,
+		},
+		{
+			name: "失敗した段階のコードでリトライする",
+			pipeline: func(ctx context.Context, job *Job, progress func(Status) error) error {
+				if err := progress(StatusGenerating); err != nil {
+					return err
+				}
+				return errGemini
+			},
+			wantStatuses: []Status{StatusGenerating},
+			wantResult:   "retry",
+			wantCode:     ErrorCodeGenerationFailed,
+			wantRetryAt:  now.Add(10 * time.Second),
+		},
+		{
+			name:     "リトライの待機時間は実行回数ごとに2倍にする",
+			attempts: 1,
+			pipeline: func(ctx context.Context, job *Job, progress func(Status) error) error {
+				return errGemini
+			},
+			wantResult:  "retry",
+			wantCode:    ErrorCodeAnalysisFailed,
+			wantRetryAt: now.Add(20 * time.Second),
+		},
+		{
+			name:     "最大実行回数に達すると失敗にする",
+			attempts: 2,
+			pipeline: func(ctx context.Context, job *Job, progress func(Status) error) error {
+				return errGemini
+			},
+			wantResult:       "failed",
+			wantCode:         ErrorCodeAnalysisFailed,
+			wantRefunded:     true,
+			wantInputDeleted: true,
+		},
+		{
+			name: "Permanentなエラーはリトライしない",
+			pipeline: func(ctx context.Context, job *Job, progress func(Status) error) error {
+				if err := progress(StatusUploading); err != nil {
+					return err
+				}
+				return Permanent(errors.New("monster is not found"))
+			},
+			wantStatuses:     []Status{StatusUploading},
+			wantResult:       "failed",
+			wantCode:         ErrorCodeUploadFailed,
+			wantRefunded:     true,
+			wantInputDeleted: true,
+		},
+		{
+			name:      "リースを失った場合はジョブを更新しない",
+			leaseLost: true,
+			pipeline: func(ctx context.Context, job *Job, progress func(Status) error) error {
+				if err := progress(StatusGenerating); err != nil {
+					return err
+				}
+				t.Error("pipeline should stop after the lease is lost")
+				return nil
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &fakeStore{
+				job:       &Job{ID: "job-1", MonsterID: "monster-1", QuotaKey: "generation-quota:user:1", InputImageKey: inputImageKey, Attempts: tt.attempts, MaxAttempts: 3},
+				leaseLost: tt.leaseLost,
+			}
+			storage := newInputImageStorage(t)
+			var refunded []string
+			pool := NewPool(store, tt.pipeline, PoolConfig{
+				WorkerID: "worker-1",
+				RefundQuota: func(ctx context.Context, key string) error {
+					refunded = append(refunded, key)
+					return nil
+				},
+				Storage: storage,
+				Now:     func() time.Time { return now },
+			})
+
+			ran, err := pool.RunOnce(context.Background())
+			if err != nil {
+				t.Fatalf("RunOnce failed: %v", err)
+			}
+			if !ran {
+				t.Fatal("RunOnce did not run the job")
+			}
+
+			if !reflect.DeepEqual(store.statuses, tt.wantStatuses) {
+				t.Errorf("statuses = %v, want %v", store.statuses, tt.wantStatuses)
+			}
+			if store.result != tt.wantResult {
+				t.Errorf("result = %q, want %q", store.result, tt.wantResult)
+			}
+			if store.reason.Code != tt.wantCode {
+				t.Errorf("reason code = %q, want %q", store.reason.Code, tt.wantCode)
+			}
+			if !store.retryAt.Equal(tt.wantRetryAt) {
+				t.Errorf("retry at = %v, want %v", store.retryAt, tt.wantRetryAt)
+			}
+			var wantRefunded []string
+			if tt.wantRefunded {
+				wantRefunded = []string{"generation-quota:user:1"}
+			}
+			if !reflect.DeepEqual(refunded, wantRefunded) {
+				t.Errorf("refunded = %v, want %v", refunded, wantRefunded)
+			}
+			_, err = storage.Stat(context.Background(), inputImageKey)
+			if deleted := errors.Is(err, blob.ErrNotFound); deleted != tt.wantInputDeleted {
+				t.Errorf("input image deleted = %v, want %v", deleted, tt.wantInputDeleted)
+			}
+
+			ran, err = pool.RunOnce(context.Background())
+			if err != nil || ran {
+				t.Errorf("RunOnce without jobs = %v, %v; want false, nil", ran, err)
+			}
+		})
+	}
+}
+
+func TestPool_RunOnceはリースの有効期限が切れたジョブの利用上限を戻し入力画像を削除する(t *testing.T) {
+	store := &fakeStore{expired: []*Job{
+		{ID: "job-1", MonsterID: "monster-1", QuotaKey: "generation-quota:user:1", InputImageKey: inputImageKey},
+	}}
+	storage := newInputImageStorage(t)
+	var refunded []string
+	pool := NewPool(store, nil, PoolConfig{
+		RefundQuota: func(ctx context.Context, key string) error {
+			refunded = append(refunded, key)
+			return nil
+		},
+		Storage: storage,
+	})
+
+	ran, err := pool.RunOnce(context.Background())
+	if err != nil || ran {
+		t.Fatalf("RunOnce = %v, %v; want false, nil", ran, err)
+	}
+	if want := []string{"generation-quota:user:1"}; !reflect.DeepEqual(refunded, want) {
+		t.Errorf("refunded = %v, want %v", refunded, want)
+	}
+	if _, err := storage.Stat(context.Background(), inputImageKey); !errors.Is(err, blob.ErrNotFound) {
+		t.Errorf("input image was not deleted: %v", err)
+	}
+}
+
+func TestPool_Runは停止時に中断したジョブを待機状態に戻す(t *testing.T) {
+	store := &fakeStore{job: &Job{ID: "job-1", MaxAttempts: 3}}
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := NewPool(store, func(ctx context.Context, job *Job, progress func(Status) error) error {
+		cancel()
+		<-ctx.Done()
+		return ctx.Err()
+	}, PoolConfig{Concurrency: 2})
+
+	done := make(chan struct{})
+	go func() {
+		pool.Run(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not return after cancel")
+	}
+	if store.result != "retry" || store.reason.Code != ErrorCodeInterrupted {
+		t.Errorf("result = %q (%q), want retry (%q)", store.result, store.reason.Code, ErrorCodeInterrupted)
+	}
+}
+
+func TestPool_backoff(t *testing.T) {
+	pool := NewPool(&fakeStore{}, nil, PoolConfig{BaseBackoff: time.Second, MaxBackoff: 5 * time.Second})
+
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: time.Second},
+		{attempts: 2, want: 2 * time.Second},
+		{attempts: 3, want: 4 * time.Second},
+		{attempts: 4, want: 5 * time.Second},
+		{attempts: 100, want: 5 * time.Second},
+	}
+
+	for _, tt := range tests {
+		if got := pool.backoff(tt.attempts); got != tt.want {
+			t.Errorf("backoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestReasonFor(t *testing.T) {
+	long := make([]rune, maxErrorMessageLength)
+	for i := range long {
+		long[i] = 'あ'
+	}
+
+	reason := reasonFor(StatusGenerating, errors.New(string(long)))
+	if reason.Code != ErrorCodeGenerationFailed {
+		t.Errorf("code = %q, want %q", reason.Code, ErrorCodeGenerationFailed)
+	}
+	if len(reason.Message) > maxErrorMessageLength {
+		t.Errorf("message length = %d, want <= %d", len(reason.Message), maxErrorMessageLength)
+	}
+	if len(reason.Message)%3 != 0 {
+		t.Errorf("message was cut in the middle of a rune: %d bytes", len(reason.Message))
+	}
+}
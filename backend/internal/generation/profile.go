@@ -0,0 +1,153 @@
+package generation
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/kinpatsu-everyone/backend-template/enum"
+	"github.com/kinpatsu-everyone/backend-template/internal/blob"
+	"github.com/kinpatsu-everyone/backend-template/internal/monsterai"
+	"github.com/kinpatsu-everyone/backend-template/internal/mysql"
+)
+
+// fallbackProvider は種族名と説明文の生成に失敗した場合に保存する MonsterProfile.Provider です
+const fallbackProvider = "fallback"
+
+// fallbackProfile は種族名と説明文の生成に失敗した場合の仮の種族名と説明文を返します
+// Provider が fallbackProvider のMonsterは RegenerateMonsterProfile で再生成します
+func fallbackProfile(analysis monsterai.Analysis, attribute monsterAttribute) *monsterai.Profile {
+	category := enum.StringToTrashCategoryEnum(analysis.TrashType)
+	return &monsterai.Profile{
+		SpeciesName: "ナゾノモンスター",
+		Description: fmt.Sprintf("%sのゴミ箱から生まれた%s属性のモンスターです。くわしい生態はまだわかっていません。", category.String(), attribute.Name),
+		Provider:    fallbackProvider,
+	}
+}
+
+// saveMonsterProfile は種族名・説明文と能力値を保存します（既に保存されている場合は置き換えます）
+func saveMonsterProfile(ctx context.Context, q mysql.Querier, monsterID string, profile monsterai.Profile, stats Stats, seed uint64) error {
+	if err := q.UpsertMonsterProfile(ctx, mysql.UpsertMonsterProfileParams{
+		Monsterid:   monsterID,
+		Speciesname: profile.SpeciesName,
+		Description: profile.Description,
+		Provider:    profile.Provider,
+		Model:       profile.Model,
+	}); err != nil {
+		return fmt.Errorf("failed to save monster profile: %w", err)
+	}
+	if err := q.UpsertMonsterStats(ctx, mysql.UpsertMonsterStatsParams{
+		Monsterid: monsterID,
+		Hp:        uint16(stats.HP),
+		Attack:    uint16(stats.Attack),
+		Defense:   uint16(stats.Defense),
+		Speed:     uint16(stats.Speed),
+		Seed:      seed,
+	}); err != nil {
+		return fmt.Errorf("failed to save monster stats: %w", err)
+	}
+	return nil
+}
+
+// RegeneratedProfile は RegenerateMonsterProfile で保存した種族名・説明文と能力値です
+type RegeneratedProfile struct {
+	Profile   monsterai.Profile
+	Stats     Stats
+	StatsSeed uint64
+}
+
+// RegenerateMonsterProfile は保存済みのMonsterの種族名と説明文を生成し直し、能力値を計算し直します
+// 画像は生成し直さず、保存済みの生成画像と分析結果（分析結果がない場合はゴミ種別）を使います
+// rerollStats が true の場合は新しいシードで能力値を振り直し、false の場合は保存済みのシードを使います
+// Monsterが存在しない場合は sql.ErrNoRows をラップしたエラーを返します
+func RegenerateMonsterProfile(ctx context.Context, monsterID string, rerollStats bool) (*RegeneratedProfile, error) {
+	ai := monsterai.GetMonsterAI()
+	if ai == nil {
+		return nil, fmt.Errorf("monster AI is not configured")
+	}
+	store := blob.GetStore()
+	if store == nil {
+		return nil, fmt.Errorf("blob store is not configured")
+	}
+	queries := mysql.GetQueries()
+
+	monster, err := queries.GetMonster(ctx, monsterID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get monster: %w", err)
+	}
+	if monster.Generatedmonsterimageurl == "" {
+		return nil, fmt.Errorf("monster %s has no generated image: %w", monsterID, blob.ErrNotFound)
+	}
+	analysis, err := loadAnalysis(ctx, queries, monsterID)
+	if err != nil {
+		return nil, err
+	}
+	image, object, err := store.Get(ctx, monster.Generatedmonsterimageurl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get generated image: %w", err)
+	}
+	// 属性は保存済みの属性（保存されていない場合はゴミ種別の属性）を使う
+	info, _ := enum.StringToTrashCategoryEnum(analysis.TrashType).Info()
+	attributeName := info.Attribute
+	if saved, err := queries.GetMonsterAttribute(ctx, monsterID); err == nil {
+		attributeName = saved.Attributename
+	}
+
+	profile, err := ai.DescribeMonster(ctx, monsterai.ProfileInput{
+		Analysis:  analysis,
+		Attribute: attributeName,
+		Image:     image,
+		MimeType:  object.ContentType,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe monster: %w", err)
+	}
+
+	seed := StatsSeed(monsterID)
+	if saved, err := queries.GetMonsterStats(ctx, monsterID); err == nil {
+		seed = saved.Seed
+	}
+	if rerollStats {
+		seed = RandomStatsSeed()
+	}
+	result := &RegeneratedProfile{
+		Profile:   *profile,
+		Stats:     ComputeStats(analysis, seed),
+		StatsSeed: seed,
+	}
+
+	if err := mysql.WithQueriesTx(ctx, func(q *mysql.Queries) error {
+		return saveMonsterProfile(ctx, q, monsterID, result.Profile, result.Stats, result.StatsSeed)
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// loadAnalysis は保存済みの分析結果を読み込みます
+// 分析結果を保存する前に生成したMonsterの場合は、保存済みのゴミ種別だけの分析結果を返します
+func loadAnalysis(ctx context.Context, queries mysql.Querier, monsterID string) (monsterai.Analysis, error) {
+	saved, err := queries.GetMonsterTrashAnalysis(ctx, monsterID)
+	if err == nil {
+		var analysis monsterai.Analysis
+		if err := json.Unmarshal(saved.Result, &analysis); err != nil {
+			return monsterai.Analysis{}, fmt.Errorf("failed to unmarshal trash analysis: %w", err)
+		}
+		return analysis, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return monsterai.Analysis{}, fmt.Errorf("failed to get trash analysis: %w", err)
+	}
+
+	categories, err := queries.ListMonsterTrashCategories(ctx, monsterID)
+	if err != nil {
+		return monsterai.Analysis{}, fmt.Errorf("failed to list monster trash categories: %w", err)
+	}
+	analysis := monsterai.Analysis{TrashType: monsterai.TrashTypeUnknown}
+	if len(categories) > 0 && enum.TrashCategory(categories[0].Trashcategory) != enum.TrashCategoryNone {
+		analysis.TrashType = enum.TrashCategory(categories[0].Trashcategory).String()
+	}
+	return analysis, nil
+}
@@ -0,0 +1,72 @@
+package generation
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+
+	"github.com/kinpatsu-everyone/backend-template/enum"
+	"github.com/kinpatsu-everyone/backend-template/internal/monsterai"
+	"github.com/kinpatsu-everyone/backend-template/internal/mysql"
+)
+
+// analysisQuerier は分析結果とゴミ種別の取得のみ実装した mysql.Querier です
+type analysisQuerier struct {
+	mysql.Querier
+	analysis   *mysql.Monstertrashanalysis
+	categories []mysql.Monstertrashcategory
+}
+
+func (q analysisQuerier) GetMonsterTrashAnalysis(_ context.Context, _ string) (mysql.Monstertrashanalysis, error) {
+	if q.analysis == nil {
+		return mysql.Monstertrashanalysis{}, sql.ErrNoRows
+	}
+	return *q.analysis, nil
+}
+
+func (q analysisQuerier) ListMonsterTrashCategories(_ context.Context, _ string) ([]mysql.Monstertrashcategory, error) {
+	return q.categories, nil
+}
+
+func TestLoadAnalysis(t *testing.T) {
+	saved := monsterai.Analysis{TrashType: "缶", Color: "黄色", Confidence: 0.8, BoundingBox: monsterai.BoundingBox{XMax: 0.5, YMax: 0.5}}
+	result, err := json.Marshal(saved)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		querier analysisQuerier
+		want    monsterai.Analysis
+	}{
+		{
+			name:    "保存済みの分析結果を読み込む",
+			querier: analysisQuerier{analysis: &mysql.Monstertrashanalysis{Result: result}},
+			want:    saved,
+		},
+		{
+			name:    "分析結果がない場合はゴミ種別を使う",
+			querier: analysisQuerier{categories: []mysql.Monstertrashcategory{{Trashcategory: uint8(enum.TrashCategoryPetBottle)}}},
+			want:    monsterai.Analysis{TrashType: "ペットボトル"},
+		},
+		{
+			name:    "ゴミ種別もない場合は判定できなかったものとする",
+			querier: analysisQuerier{categories: []mysql.Monstertrashcategory{{Trashcategory: uint8(enum.TrashCategoryNone)}}},
+			want:    monsterai.Analysis{TrashType: monsterai.TrashTypeUnknown},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := loadAnalysis(context.Background(), tt.querier, "monster-1")
+			if err != nil {
+				t.Fatalf("loadAnalysis() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("loadAnalysis() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package generation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/kinpatsu-everyone/backend-template/internal/blob"
+	"github.com/kinpatsu-everyone/backend-template/internal/mysql"
+)
+
+// ReconcileAction は作成途中のMonsterに対する修復方法です
+type ReconcileAction string
+
+const (
+	// ReconcileRequeue は保存済みの元画像から生成ジョブを作成し、画像とゴミ種別を再生成します
+	ReconcileRequeue ReconcileAction = "requeue"
+	// ReconcileDelete は元画像が残っていないMonsterを削除します
+	ReconcileDelete ReconcileAction = "delete"
+)
+
+// ReconcileResult は1件のMonsterの修復結果です
+type ReconcileResult struct {
+	MonsterID string
+	Action    ReconcileAction
+	Reason    string
+	// JobID は ReconcileRequeue で作成した生成ジョブのIDです
+	JobID string
+	Err   error
+}
+
+// Reconciler は作成途中のMonster（画像やゴミ種別がないもの）を探して修復・削除します
+// 非同期生成の導入前に同期処理の途中で失敗したMonsterを対象にします
+type Reconciler struct {
+	Queries *mysql.Queries
+	Storage blob.Store
+	// MaxAttempts は再生成する生成ジョブの最大実行回数です
+	MaxAttempts int
+	// Apply がfalseの場合は修復方法を判定するだけで、変更しません（ドライラン）
+	Apply bool
+	Now   func() time.Time
+}
+
+// planReconcile は作成途中のMonsterの修復方法を判定します
+func planReconcile(monster mysql.Monster, trashCategories int) (ReconcileAction, string) {
+	var reason string
+	switch {
+	case monster.Generatedmonsterimageurl == "":
+		reason = "generated image is missing"
+	case monster.Originaltrashbinimageurl == "":
+		reason = "original image is missing"
+	case trashCategories == 0:
+		reason = "trash category is missing"
+	}
+	if monster.Originaltrashbinimageurl == "" {
+		return ReconcileDelete, reason
+	}
+	return ReconcileRequeue, reason
+}
+
+// Run は olderThan より前に作成された作成途中のMonsterを修復します
+// 実行中の生成ジョブがあるMonsterは対象にしません
+func (r *Reconciler) Run(ctx context.Context, olderThan time.Duration) ([]ReconcileResult, error) {
+	now := time.Now
+	if r.Now != nil {
+		now = r.Now
+	}
+
+	monsters, err := r.Queries.ListIncompleteMonsters(ctx, now().Add(-olderThan))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list incomplete monsters: %w", err)
+	}
+
+	results := make([]ReconcileResult, 0, len(monsters))
+	for _, monster := range monsters {
+		categories, err := r.Queries.ListMonsterTrashCategories(ctx, monster.Monsterid)
+		if err != nil {
+			return results, fmt.Errorf("failed to list monster trash categories: %w", err)
+		}
+
+		action, reason := planReconcile(monster, len(categories))
+		result := ReconcileResult{MonsterID: monster.Monsterid, Action: action, Reason: reason}
+		if r.Apply {
+			switch action {
+			case ReconcileRequeue:
+				result.JobID, result.Err = r.requeue(ctx, monster, now())
+			case ReconcileDelete:
+				result.Err = r.delete(ctx, monster)
+			}
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// requeue は保存済みの元画像から生成ジョブを作成します
+// 元画像はジョブの完了時に上書きされるため、ジョブの入力画像として別のキーにコピーします
+// ジョブが完了すると、既存のMonsterの画像とゴミ種別が置き換えられます
+func (r *Reconciler) requeue(ctx context.Context, monster mysql.Monster, now time.Time) (string, error) {
+	image, info, err := r.Storage.Get(ctx, monster.Originaltrashbinimageurl)
+	if err != nil {
+		return "", fmt.Errorf("failed to download original image: %w", err)
+	}
+
+	jobID := uuid.New().String()
+	inputImageKey := blob.GenerateInputImagePath(monster.Monsterid, jobID, blob.GetExtensionFromMimeType(info.ContentType))
+	if err := r.Storage.Put(ctx, inputImageKey, image, info.ContentType); err != nil {
+		return "", fmt.Errorf("failed to upload input image: %w", err)
+	}
+
+	if _, err := r.Queries.CreateMonsterGenerationJob(ctx, mysql.CreateMonsterGenerationJobParams{
+		Jobid:         jobID,
+		Monsterid:     monster.Monsterid,
+		Userid:        monster.Userid,
+		Nickname:      monster.Nickname,
+		Latitude:      monster.Latitude,
+		Longitude:     monster.Longitude,
+		Maxattempts:   int32(r.MaxAttempts),
+		Nextrunat:     now,
+		Inputimagekey: inputImageKey,
+		Inputmimetype: info.ContentType,
+	}); err != nil {
+		err = fmt.Errorf("failed to create monster generation job: %w", err)
+		if delErr := r.Storage.Delete(ctx, inputImageKey); delErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to delete input image: %w", delErr))
+		}
+		return "", err
+	}
+	return jobID, nil
+}
+
+// delete はMonsterと関連する行・生成ジョブを削除し、残っている画像も削除します
+func (r *Reconciler) delete(ctx context.Context, monster mysql.Monster) error {
+	return DeleteMonster(ctx, r.Storage, monster)
+}
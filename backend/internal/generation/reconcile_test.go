@@ -0,0 +1,54 @@
+package generation
+
+import (
+	"testing"
+
+	"github.com/kinpatsu-everyone/backend-template/internal/mysql"
+)
+
+func TestPlanReconcile(t *testing.T) {
+	tests := []struct {
+		name            string
+		monster         mysql.Monster
+		trashCategories int
+		wantAction      ReconcileAction
+		wantReason      string
+	}{
+		{
+			name:            "生成画像がない場合は元画像から再生成する",
+			monster:         mysql.Monster{Originaltrashbinimageurl: "monsters/1/original.jpg"},
+			trashCategories: 1,
+			wantAction:      ReconcileRequeue,
+			wantReason:      "generated image is missing",
+		},
+		{
+			name:            "ゴミ種別がない場合は元画像から再生成する",
+			monster:         mysql.Monster{Originaltrashbinimageurl: "monsters/1/original.jpg", Generatedmonsterimageurl: "monsters/1/generated.png"},
+			trashCategories: 0,
+			wantAction:      ReconcileRequeue,
+			wantReason:      "trash category is missing",
+		},
+		{
+			name:            "元画像がない場合は削除する",
+			monster:         mysql.Monster{Generatedmonsterimageurl: "monsters/1/generated.png"},
+			trashCategories: 1,
+			wantAction:      ReconcileDelete,
+			wantReason:      "original image is missing",
+		},
+		{
+			name:       "画像がどちらもない場合は削除する",
+			monster:    mysql.Monster{},
+			wantAction: ReconcileDelete,
+			wantReason: "generated image is missing",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			action, reason := planReconcile(tt.monster, tt.trashCategories)
+			if action != tt.wantAction || reason != tt.wantReason {
+				t.Errorf("planReconcile() = %q, %q; want %q, %q", action, reason, tt.wantAction, tt.wantReason)
+			}
+		})
+	}
+}
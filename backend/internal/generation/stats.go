@@ -0,0 +1,87 @@
+package generation
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"math"
+
+	"github.com/kinpatsu-everyone/backend-template/enum"
+	"github.com/kinpatsu-everyone/backend-template/internal/monsterai"
+)
+
+const (
+	// MinStat・MaxStat は能力値の範囲です
+	MinStat = 1
+	MaxStat = 255
+	// statVariance はシードによる能力値のばらつき（基本値に対する割合）です
+	statVariance = 0.1
+)
+
+// Stats はモンスターの能力値です
+type Stats struct {
+	HP      int
+	Attack  int
+	Defense int
+	Speed   int
+}
+
+// baseStats はゴミ種別ごとの能力値の基本値です（登録されていないゴミ種別は指定なしの基本値を使います）
+// 合計はすべて300にそろえ、属性ごとに得意な能力値を変えます
+var baseStats = map[enum.TrashCategory]Stats{
+	enum.TrashCategoryNone:        {HP: 75, Attack: 75, Defense: 75, Speed: 75},
+	enum.TrashCategoryBurnable:    {HP: 70, Attack: 100, Defense: 60, Speed: 70},
+	enum.TrashCategoryNonBurnable: {HP: 80, Attack: 70, Defense: 100, Speed: 50},
+	enum.TrashCategoryCan:         {HP: 60, Attack: 75, Defense: 65, Speed: 100},
+	enum.TrashCategoryGlassBottle: {HP: 65, Attack: 70, Defense: 90, Speed: 75},
+	enum.TrashCategoryPetBottle:   {HP: 100, Attack: 60, Defense: 70, Speed: 70},
+}
+
+// StatsSeed はMonsterIDから能力値のシードを作成します（同じMonsterを再生成しても能力値が変わらないようにします）
+func StatsSeed(monsterID string) uint64 {
+	sum := sha256.Sum256([]byte(monsterID))
+	return binary.BigEndian.Uint64(sum[:8])
+}
+
+// RandomStatsSeed は能力値を振り直すためのランダムなシードを作成します
+func RandomStatsSeed() uint64 {
+	var b [8]byte
+	_, _ = rand.Read(b[:])
+	return binary.BigEndian.Uint64(b[:])
+}
+
+// ComputeStats は分析結果とシードから能力値を計算します（同じ分析結果とシードからは常に同じ能力値になります）
+//   - 基本値はゴミ種別ごとに決まります
+//   - 判定の確信度が高いほど、すべての能力値が高くなります（0.9倍〜1.1倍）
+//   - 画像内のゴミ箱が大きいほど、HPとぼうぎょが高くなります
+//   - シードで能力値ごとに基本値の±10%のばらつきを加えます
+func ComputeStats(analysis monsterai.Analysis, seed uint64) Stats {
+	base, ok := baseStats[enum.StringToTrashCategoryEnum(analysis.TrashType)]
+	if !ok {
+		base = baseStats[enum.TrashCategoryNone]
+	}
+
+	confidence := min(max(analysis.Confidence, 0), 1)
+	box := analysis.BoundingBox
+	area := min(max((box.XMax-box.XMin)*(box.YMax-box.YMin), 0), 1)
+
+	rng := seed
+	stat := func(base, bonus float64) int {
+		// splitmix64 でシードから能力値ごとの乱数を作る
+		rng += 0x9e3779b97f4a7c15
+		z := rng
+		z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+		z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+		z ^= z >> 31
+		variance := (float64(z>>11)/(1<<53)*2 - 1) * statVariance
+
+		v := math.Round(base*(0.9+0.2*confidence)*(1+variance) + bonus)
+		return int(min(max(v, MinStat), MaxStat))
+	}
+	return Stats{
+		HP:      stat(float64(base.HP), 40*area),
+		Attack:  stat(float64(base.Attack), 0),
+		Defense: stat(float64(base.Defense), 20*area),
+		Speed:   stat(float64(base.Speed), 0),
+	}
+}
@@ -0,0 +1,66 @@
+package generation
+
+import (
+	"testing"
+
+	"github.com/kinpatsu-everyone/backend-template/internal/monsterai"
+)
+
+func TestComputeStats(t *testing.T) {
+	analysis := monsterai.Analysis{
+		TrashType:   "燃えるゴミ",
+		Confidence:  0.9,
+		BoundingBox: monsterai.BoundingBox{XMin: 0.2, YMin: 0.1, XMax: 0.8, YMax: 0.9},
+	}
+
+	t.Run("同じ分析結果とシードからは同じ能力値になる", func(t *testing.T) {
+		seed := StatsSeed("monster-1")
+		if a, b := ComputeStats(analysis, seed), ComputeStats(analysis, seed); a != b {
+			t.Errorf("ComputeStats() = %+v, then %+v", a, b)
+		}
+	})
+
+	t.Run("シードが違うと能力値が変わる", func(t *testing.T) {
+		if a, b := ComputeStats(analysis, StatsSeed("monster-1")), ComputeStats(analysis, StatsSeed("monster-2")); a == b {
+			t.Errorf("ComputeStats() = %+v for both seeds", a)
+		}
+	})
+
+	t.Run("ゴミ種別ごとに得意な能力値が高くなる", func(t *testing.T) {
+		tests := []struct {
+			trashType string
+			best      func(Stats) int
+		}{
+			{"燃えるゴミ", func(s Stats) int { return s.Attack }},
+			{"不燃ごみ", func(s Stats) int { return s.Defense }},
+			{"缶", func(s Stats) int { return s.Speed }},
+			{"ペットボトル", func(s Stats) int { return s.HP }},
+		}
+		for _, tt := range tests {
+			// 画像内のゴミ箱の大きさによる補正を除いて比べる
+			s := ComputeStats(monsterai.Analysis{TrashType: tt.trashType, Confidence: 1}, 0)
+			if best := tt.best(s); best < max(s.HP, s.Attack, s.Defense, s.Speed) {
+				t.Errorf("%s: ComputeStats() = %+v", tt.trashType, s)
+			}
+		}
+	})
+
+	t.Run("確信度が高いほど能力値が高くなる", func(t *testing.T) {
+		low := ComputeStats(monsterai.Analysis{TrashType: "缶", Confidence: 0}, 1)
+		high := ComputeStats(monsterai.Analysis{TrashType: "缶", Confidence: 1}, 1)
+		if high.HP <= low.HP || high.Attack <= low.Attack || high.Defense <= low.Defense || high.Speed <= low.Speed {
+			t.Errorf("ComputeStats() = %+v (confidence 1), %+v (confidence 0)", high, low)
+		}
+	})
+
+	t.Run("判定できない場合や範囲外の値でも能力値は範囲内になる", func(t *testing.T) {
+		for seed := range uint64(100) {
+			s := ComputeStats(monsterai.Analysis{TrashType: "unknown", Confidence: 5, BoundingBox: monsterai.BoundingBox{XMax: 3, YMax: 3}}, seed)
+			for _, v := range []int{s.HP, s.Attack, s.Defense, s.Speed} {
+				if v < MinStat || v > MaxStat {
+					t.Fatalf("ComputeStats() = %+v, want between %d and %d", s, MinStat, MaxStat)
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,106 @@
+package geo
+
+import "math"
+
+// EarthRadiusM は地球の平均半径（メートル）です
+const EarthRadiusM = 6371008.8
+
+// Point は緯度・経度で表した地点です
+type Point struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// Bounds は南西と北東の地点で表した範囲です
+// SouthWest の経度が NorthEast の経度より大きい場合は、経度180度の線をまたぐ範囲として扱います
+type Bounds struct {
+	SouthWest Point
+	NorthEast Point
+}
+
+// LongitudeRange は経度の範囲です（Min <= Max）
+type LongitudeRange struct {
+	Min float64
+	Max float64
+}
+
+// Distance は2地点間の大円距離（メートル）をハーバーサインの公式で求めます
+func Distance(a, b Point) float64 {
+	lat1, lat2 := radians(a.Latitude), radians(b.Latitude)
+	dLat := lat2 - lat1
+	dLng := radians(b.Longitude - a.Longitude)
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * EarthRadiusM * math.Asin(math.Sqrt(min(1, h)))
+}
+
+// BoundsAround は center から半径 radiusM メートルの円を囲む範囲を返します
+// 円が極を含む場合は、すべての経度を含む範囲を返します
+func BoundsAround(center Point, radiusM float64) Bounds {
+	angle := radiusM / EarthRadiusM
+	dLat := degrees(angle)
+	minLat, maxLat := center.Latitude-dLat, center.Latitude+dLat
+	if minLat <= -90 || maxLat >= 90 || angle >= math.Pi/2 {
+		return Bounds{
+			SouthWest: Point{Latitude: max(minLat, -90), Longitude: -180},
+			NorthEast: Point{Latitude: min(maxLat, 90), Longitude: 180},
+		}
+	}
+
+	// 緯度が高いほど経度1度あたりの距離が短くなるため、経度の幅を広げる
+	dLng := degrees(math.Asin(math.Sin(angle) / math.Cos(radians(center.Latitude))))
+	return Bounds{
+		SouthWest: Point{Latitude: minLat, Longitude: normalizeLongitude(center.Longitude - dLng)},
+		NorthEast: Point{Latitude: maxLat, Longitude: normalizeLongitude(center.Longitude + dLng)},
+	}
+}
+
+// CrossesAntimeridian は範囲が経度180度の線をまたぐかどうかを返します
+func (b Bounds) CrossesAntimeridian() bool {
+	return b.SouthWest.Longitude > b.NorthEast.Longitude
+}
+
+// LongitudeRanges は範囲の経度を Min <= Max の範囲で返します
+// 経度180度の線をまたぐ範囲は、線の東側と西側の2つに分けます
+func (b Bounds) LongitudeRanges() []LongitudeRange {
+	if b.CrossesAntimeridian() {
+		return []LongitudeRange{
+			{Min: b.SouthWest.Longitude, Max: 180},
+			{Min: -180, Max: b.NorthEast.Longitude},
+		}
+	}
+	return []LongitudeRange{{Min: b.SouthWest.Longitude, Max: b.NorthEast.Longitude}}
+}
+
+// Contains は地点が範囲に含まれるかどうかを返します（境界を含みます）
+func (b Bounds) Contains(p Point) bool {
+	if p.Latitude < b.SouthWest.Latitude || p.Latitude > b.NorthEast.Latitude {
+		return false
+	}
+	for _, r := range b.LongitudeRanges() {
+		if p.Longitude >= r.Min && p.Longitude <= r.Max {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeLongitude は経度を -180〜180 の範囲に収めます
+func normalizeLongitude(lng float64) float64 {
+	switch {
+	case lng < -180:
+		return lng + 360
+	case lng > 180:
+		return lng - 360
+	default:
+		return lng
+	}
+}
+
+func radians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+func degrees(rad float64) float64 {
+	return rad * 180 / math.Pi
+}
@@ -0,0 +1,115 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+var (
+	tokyoStation = Point{Latitude: 35.681236, Longitude: 139.767125}
+	shinjuku     = Point{Latitude: 35.690921, Longitude: 139.700258}
+	osakaStation = Point{Latitude: 34.702485, Longitude: 135.495951}
+)
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     Point
+		expected float64
+		delta    float64
+	}{
+		{name: "同じ地点", a: tokyoStation, b: tokyoStation, expected: 0, delta: 0.001},
+		{name: "東京駅と新宿駅", a: tokyoStation, b: shinjuku, expected: 6150, delta: 50},
+		{name: "東京駅と大阪駅", a: tokyoStation, b: osakaStation, expected: 403000, delta: 1000},
+		{name: "経度180度の線をまたぐ", a: Point{Latitude: 0, Longitude: 179.999}, b: Point{Latitude: 0, Longitude: -179.999}, expected: 222, delta: 1},
+		{name: "北極と南極", a: Point{Latitude: 90}, b: Point{Latitude: -90}, expected: math.Pi * EarthRadiusM, delta: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Distance(tt.a, tt.b)
+			if math.Abs(got-tt.expected) > tt.delta {
+				t.Errorf("Distance() = %f, want %f±%f", got, tt.expected, tt.delta)
+			}
+			if reverse := Distance(tt.b, tt.a); math.Abs(reverse-got) > 1e-6 {
+				t.Errorf("Distance() is not symmetric: %f, %f", got, reverse)
+			}
+		})
+	}
+}
+
+func TestBoundsAround(t *testing.T) {
+	tests := []struct {
+		name         string
+		center       Point
+		radiusM      float64
+		crosses      bool
+		allLongitude bool
+	}{
+		{name: "東京駅から1km", center: tokyoStation, radiusM: 1000},
+		{name: "東京駅から100km", center: tokyoStation, radiusM: 100000},
+		{name: "経度180度の線の近く", center: Point{Latitude: -17.7, Longitude: 179.9}, radiusM: 50000, crosses: true},
+		{name: "経度-180度の線の近く", center: Point{Latitude: 51.9, Longitude: -179.95}, radiusM: 20000, crosses: true},
+		{name: "極を含む", center: Point{Latitude: 89.9, Longitude: 10}, radiusM: 50000, allLongitude: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := BoundsAround(tt.center, tt.radiusM)
+			if got := b.CrossesAntimeridian(); got != tt.crosses {
+				t.Errorf("CrossesAntimeridian() = %v, want %v", got, tt.crosses)
+			}
+			if tt.allLongitude && (b.SouthWest.Longitude != -180 || b.NorthEast.Longitude != 180) {
+				t.Errorf("longitude = %f..%f, want -180..180", b.SouthWest.Longitude, b.NorthEast.Longitude)
+			}
+			if !b.Contains(tt.center) {
+				t.Errorf("bounds %+v does not contain center", b)
+			}
+
+			// 円周上の地点はすべて範囲に含まれる
+			for deg := 0; deg < 360; deg += 15 {
+				p := destination(tt.center, float64(deg), tt.radiusM*0.999)
+				if !b.Contains(p) {
+					t.Errorf("bounds %+v does not contain %+v (bearing %d)", b, p, deg)
+				}
+			}
+		})
+	}
+}
+
+func TestBounds_Contains(t *testing.T) {
+	japan := Bounds{SouthWest: Point{Latitude: 24, Longitude: 122}, NorthEast: Point{Latitude: 46, Longitude: 154}}
+	pacific := Bounds{SouthWest: Point{Latitude: -30, Longitude: 170}, NorthEast: Point{Latitude: 10, Longitude: -170}}
+
+	tests := []struct {
+		name     string
+		bounds   Bounds
+		point    Point
+		expected bool
+	}{
+		{name: "範囲内", bounds: japan, point: tokyoStation, expected: true},
+		{name: "境界上", bounds: japan, point: Point{Latitude: 24, Longitude: 154}, expected: true},
+		{name: "緯度が範囲外", bounds: japan, point: Point{Latitude: 50, Longitude: 140}, expected: false},
+		{name: "経度が範囲外", bounds: japan, point: Point{Latitude: 35, Longitude: 120}, expected: false},
+		{name: "経度180度の線の東側", bounds: pacific, point: Point{Latitude: -17, Longitude: 178}, expected: true},
+		{name: "経度180度の線の西側", bounds: pacific, point: Point{Latitude: -17, Longitude: -175}, expected: true},
+		{name: "経度180度の線をまたぐ範囲の外", bounds: pacific, point: Point{Latitude: -17, Longitude: 0}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.bounds.Contains(tt.point); got != tt.expected {
+				t.Errorf("Contains() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+// destination は start から方位 bearing 度の方向に distanceM メートル進んだ地点を返します
+func destination(start Point, bearing, distanceM float64) Point {
+	angle := distanceM / EarthRadiusM
+	lat1, lng1, theta := radians(start.Latitude), radians(start.Longitude), radians(bearing)
+	lat2 := math.Asin(math.Sin(lat1)*math.Cos(angle) + math.Cos(lat1)*math.Sin(angle)*math.Cos(theta))
+	lng2 := lng1 + math.Atan2(math.Sin(theta)*math.Sin(angle)*math.Cos(lat1), math.Cos(angle)-math.Sin(lat1)*math.Sin(lat2))
+	return Point{Latitude: degrees(lat2), Longitude: normalizeLongitude(degrees(lng2))}
+}
@@ -0,0 +1,77 @@
+package monsterai
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+)
+
+const (
+	// maxColorSamples は主な色の抽出に使う1辺あたりの最大ピクセル数です（大きい画像は間引いて数えます）
+	maxColorSamples = 128
+	// minColorChroma は色味のある色として数える最小の彩度（RGBの最大値と最小値の差）です
+	// これより小さい色（白・黒・灰色）は、色味のある色がない場合のみ使います
+	minColorChroma = 48
+)
+
+// ErrNoDominantColor は画像に不透明なピクセルがなく主な色を決められない場合のエラーです
+var ErrNoDominantColor = errors.New("monsterai: no opaque pixels")
+
+// DominantColor は画像の主な色を "#RRGGBB" 形式で返します
+// 色を各チャンネル16段階にまとめて数え、最も多い色の平均を返します
+// モンスターは画像の中央に描かれることが多いため、中央のピクセルは2倍に数えます
+// 太い輪郭線や背景の白・灰色を選ばないように、色味のある色を優先します
+func DominantColor(data []byte) (string, error) {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return "", fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	type bucket struct {
+		r, g, b, n uint64
+	}
+	var chromatic, achromatic [16 * 16 * 16]bucket
+
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	stepX, stepY := max(1, w/maxColorSamples), max(1, h/maxColorSamples)
+	for y := 0; y < h; y += stepY {
+		for x := 0; x < w; x += stepX {
+			cr, cg, cb, ca := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+			if ca < 0x8000 {
+				continue
+			}
+			// アルファ乗算済みの値を元に戻して8bitにする
+			r, g, b := uint8(cr*0xffff/ca>>8), uint8(cg*0xffff/ca>>8), uint8(cb*0xffff/ca>>8)
+
+			weight := uint64(1)
+			if x > w/5 && x < w*4/5 && y > h/5 && y < h*4/5 {
+				weight = 2
+			}
+			buckets := &achromatic
+			if max(r, g, b)-min(r, g, b) >= minColorChroma {
+				buckets = &chromatic
+			}
+			k := int(r>>4)<<8 | int(g>>4)<<4 | int(b>>4)
+			buckets[k].r += uint64(r) * weight
+			buckets[k].g += uint64(g) * weight
+			buckets[k].b += uint64(b) * weight
+			buckets[k].n += weight
+		}
+	}
+
+	for _, buckets := range []*[16 * 16 * 16]bucket{&chromatic, &achromatic} {
+		best := -1
+		for k := range buckets {
+			if buckets[k].n > 0 && (best < 0 || buckets[k].n > buckets[best].n) {
+				best = k
+			}
+		}
+		if best >= 0 {
+			c := buckets[best]
+			return fmt.Sprintf("#%02X%02X%02X", c.r/c.n, c.g/c.n, c.b/c.n), nil
+		}
+	}
+	return "", ErrNoDominantColor
+}
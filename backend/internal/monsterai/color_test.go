@@ -0,0 +1,103 @@
+package monsterai
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+// encodeFramedPNG は外側を frame、中央を center で塗った画像を作成します
+func encodeFramedPNG(t *testing.T, size int, frame, center color.Color) []byte {
+	t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, size, size))
+	for y := range size {
+		for x := range size {
+			c := frame
+			if x >= size/4 && x < size*3/4 && y >= size/4 && y < size*3/4 {
+				c = center
+			}
+			img.Set(x, y, c)
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("png.Encode() error = %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestDominantColor(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		want    string
+		wantErr error
+	}{
+		{
+			name: "単色の画像はその色を返す",
+			data: encodePNG(t, 64, 64, color.RGBA{R: 0xe5, G: 0x39, B: 0x35, A: 0xff}),
+			want: "#E53935",
+		},
+		{
+			name: "白や灰色より色味のある色を優先する",
+			data: encodeFramedPNG(t, 64, color.RGBA{R: 0xf0, G: 0xf0, B: 0xf0, A: 0xff}, color.RGBA{R: 0x1e, G: 0x88, B: 0xe5, A: 0xff}),
+			want: "#1E88E5",
+		},
+		{
+			name: "色味のある色がない場合は最も多い色を返す",
+			data: encodeFramedPNG(t, 64, color.RGBA{A: 0xff}, color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}),
+			want: "#000000",
+		},
+		{
+			name: "透明なピクセルは数えない",
+			data: encodeFramedPNG(t, 64, color.NRGBA{R: 0xff, A: 0x10}, color.NRGBA{R: 0x7b, G: 0x1f, B: 0xa2, A: 0xff}),
+			want: "#7B1FA2",
+		},
+		{
+			name:    "すべて透明な場合はエラーを返す",
+			data:    encodePNG(t, 16, 16, color.NRGBA{}),
+			wantErr: ErrNoDominantColor,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DominantColor(tt.data)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("DominantColor() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("DominantColor() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDominantColor_Fake(t *testing.T) {
+	// Fake はゴミ種別の色で体を塗るため、主な色はゴミ種別の色になる
+	img, err := NewFake().GenerateMonster(context.Background(), GenerateInput{
+		Analysis: Analysis{TrashType: "ペットボトル"},
+		Image:    encodePNG(t, 32, 32, color.RGBA{B: 0xff, A: 0xff}),
+		MimeType: "image/png",
+	})
+	if err != nil {
+		t.Fatalf("GenerateMonster() error = %v", err)
+	}
+	got, err := DominantColor(img.Data)
+	if err != nil {
+		t.Fatalf("DominantColor() error = %v", err)
+	}
+	if want := "#1E88E5"; got != want {
+		t.Errorf("DominantColor() = %q, want %q", got, want)
+	}
+}
+
+func TestDominantColor_InvalidImage(t *testing.T) {
+	if _, err := DominantColor([]byte("not an image")); err == nil {
+		t.Error("DominantColor() error = nil, want error")
+	}
+}
@@ -0,0 +1,286 @@
+package monsterai
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+
+	"github.com/kinpatsu-everyone/backend-template/enum"
+)
+
+// fakeFixtures は Fake が返す分析結果です
+var fakeFixtures = []Analysis{
+	{
+		TrashType:   "燃えるゴミ",
+		Color:       "緑",
+		Markings:    "燃やすごみ",
+		Contents:    "紙くずとお弁当の容器",
+		Reasoning:   "ゴミ箱に「燃やすごみ」と書かれており、中身も紙くずが多いため",
+		Description: "駅のホームにある緑色のゴミ箱",
+		Confidence:  0.92,
+		BoundingBox: BoundingBox{XMin: 0.22, YMin: 0.18, XMax: 0.78, YMax: 0.95},
+	},
+	{
+		TrashType:   "不燃ごみ",
+		Color:       "灰色",
+		Markings:    "燃やさないごみ",
+		Contents:    "割れた傘と陶器のかけら",
+		Reasoning:   "ゴミ箱に「燃やさないごみ」と書かれているため",
+		Description: "公園の入口にある灰色のゴミ箱",
+		Confidence:  0.64,
+		BoundingBox: BoundingBox{XMin: 0.30, YMin: 0.25, XMax: 0.70, YMax: 0.90},
+	},
+	{
+		TrashType:   "缶",
+		Color:       "黄色",
+		Markings:    "かん",
+		Contents:    "アルミ缶",
+		Reasoning:   "投入口が丸く、「かん」と書かれているため",
+		Description: "自動販売機の横にある黄色の回収ボックス",
+		Confidence:  0.88,
+		BoundingBox: BoundingBox{XMin: 0.15, YMin: 0.10, XMax: 0.85, YMax: 0.98},
+	},
+	{
+		TrashType:   "瓶",
+		Color:       "茶色",
+		Markings:    "びん",
+		Contents:    "ガラス瓶",
+		Reasoning:   "「びん」と書かれたラベルがあり、中にガラス瓶が見えるため",
+		Description: "商店街にある茶色のゴミ箱",
+		Confidence:  0.71,
+		BoundingBox: BoundingBox{XMin: 0.28, YMin: 0.20, XMax: 0.72, YMax: 0.93},
+	},
+	{
+		TrashType:   "ペットボトル",
+		Color:       "青",
+		Markings:    "ペットボトル",
+		Contents:    "空のペットボトル",
+		Reasoning:   "「ペットボトル」と書かれており、中身もペットボトルのみのため",
+		Description: "コンビニの前にある青色の回収ボックス",
+		Confidence:  0.95,
+		BoundingBox: BoundingBox{XMin: 0.20, YMin: 0.12, XMax: 0.80, YMax: 0.96},
+	},
+}
+
+// fakeSpeciesPrefixes・fakeSpeciesSuffixes は Fake が種族名に使う属性ごとの接頭語と動物の名前です
+var (
+	fakeSpeciesPrefixes = map[string]string{
+		"炎": "ボウボウ",
+		"闇": "カチコチ",
+		"光": "キラリ",
+		"水": "ペコペコ",
+	}
+	fakeSpeciesSuffixes = []string{"ペンギン", "カピバラ", "アルマジロ", "ハリネズミ", "カメレオン", "ラッコ"}
+)
+
+// fakeImageSize は Fake が生成する画像の一辺のピクセル数です
+const fakeImageSize = 256
+
+// Fake はAPIを呼び出さずに決まった結果を返す MonsterAI です（ローカル開発・CI用）
+// 同じ入力には常に同じ結果を返します
+//   - AnalyzeTrashBin は画像のハッシュから fakeFixtures のいずれかを返します
+//   - GenerateMonster はゴミ種別と正規化した画像のハッシュから描画したPNG画像を返します
+//   - DescribeMonster は属性と生成した画像のハッシュから種族名と説明文を返します
+//   - GenerateImage はプロンプトのハッシュから描画したPNG画像を返します
+type Fake struct{}
+
+// NewFake は新しい Fake を作成します
+func NewFake() *Fake {
+	return &Fake{}
+}
+
+func (f *Fake) AnalyzeTrashBin(ctx context.Context, image []byte, _ string) (*Analysis, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if len(image) == 0 {
+		return nil, fmt.Errorf("image data is required")
+	}
+
+	sum := sha256.Sum256(image)
+	analysis := fakeFixtures[binary.BigEndian.Uint32(sum[:4])%uint32(len(fakeFixtures))]
+	text, err := json.Marshal(analysis)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal analysis: %w", err)
+	}
+	analysis.RawText = string(text)
+	return &analysis, nil
+}
+
+func (f *Fake) GenerateMonster(ctx context.Context, input GenerateInput) (*Image, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Gemini と同じく正規化した画像を入力とする
+	trashBin, err := NormalizeInputImage(input.Image, input.MimeType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to normalize image: %w", err)
+	}
+
+	h := sha256.New()
+	h.Write([]byte(input.Analysis.TrashType + "\n"))
+	h.Write(trashBin.Data)
+	var seed [sha256.Size]byte
+	copy(seed[:], h.Sum(nil))
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, renderFakeMonster(input.Analysis.TrashType, seed)); err != nil {
+		return nil, fmt.Errorf("failed to encode image: %w", err)
+	}
+	return &Image{
+		Data:     buf.Bytes(),
+		MimeType: "image/png",
+		Input:    newGenerationRecord("fake", "", "", input.Analysis, trashBin),
+	}, nil
+}
+
+func (f *Fake) DescribeMonster(ctx context.Context, input ProfileInput) (*Profile, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if len(input.Image) == 0 {
+		return nil, fmt.Errorf("image data is required")
+	}
+
+	sum := sha256.Sum256(input.Image)
+	prefix, ok := fakeSpeciesPrefixes[input.Attribute]
+	if !ok {
+		prefix = "ナゾノ"
+	}
+	animal := fakeSpeciesSuffixes[binary.BigEndian.Uint32(sum[:4])%uint32(len(fakeSpeciesSuffixes))]
+	return &Profile{
+		SpeciesName: prefix + animal,
+		Description: fmt.Sprintf("%sのゴミ箱に住みついた%sのモンスターです。ゴミ箱を見つけると、すぐに中にもぐりこみます。", trashTypeLabel(input.Analysis.TrashType), animal),
+		Provider:    "fake",
+	}, nil
+}
+
+func (f *Fake) GenerateImage(ctx context.Context, input PromptInput) ([]Candidate, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(input.Prompt) == "" {
+		return nil, fmt.Errorf("prompt is required")
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, renderFakeMonster(TrashTypeUnknown, sha256.Sum256([]byte(input.Prompt)))); err != nil {
+		return nil, fmt.Errorf("failed to encode image: %w", err)
+	}
+	return []Candidate{{
+		Role: "model",
+		Parts: []Part{
+			{Text: "fake image for the prompt"},
+			{Data: buf.Bytes(), MimeType: "image/png"},
+		},
+		FinishReason: "STOP",
+	}}, nil
+}
+
+// trashTypeLabel は説明文に使うゴミ種別の名前を返します（判定できない場合は「正体不明」）
+func trashTypeLabel(trashType string) string {
+	if trashType == "" || trashType == TrashTypeUnknown {
+		return "正体不明"
+	}
+	return trashType
+}
+
+// renderFakeMonster は seed から体の形・目の位置・模様を決めてモンスターを描画します
+func renderFakeMonster(trashType string, seed [sha256.Size]byte) *image.RGBA {
+	// 体の色はゴミ種別の属性の色（判定できない場合は緑）
+	body := color.RGBA{R: 0x43, G: 0xa0, B: 0x47, A: 0xff}
+	if info, ok := enum.StringToTrashCategoryEnum(trashType).Info(); ok && info.ColorCode != "" {
+		if c, err := parseColorCode(info.ColorCode); err == nil {
+			body = c
+		}
+	}
+	background := color.RGBA{R: 0xe0 + seed[0]%0x20, G: 0xe0 + seed[1]%0x20, B: 0xe0 + seed[2]%0x20, A: 0xff}
+	spot := color.RGBA{R: body.R / 2, G: body.G / 2, B: body.B / 2, A: 0xff}
+	outline := color.RGBA{A: 0xff}
+	white := color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+
+	const size = float64(fakeImageSize)
+	cx, cy := size/2, size*0.58
+	rx := size*0.30 + float64(seed[3]%32)
+	ry := size*0.26 + float64(seed[4]%32)
+	eyeDX := size*0.10 + float64(seed[5]%12)
+	eyeY := cy - ry*0.35
+	eyeR := size*0.06 + float64(seed[6]%8)
+	pupilDX := float64(int(seed[7]%9) - 4)
+	pupilDY := float64(int(seed[8]%9) - 4)
+
+	type circle struct{ x, y, r float64 }
+	spots := make([]circle, 2+int(seed[9]%4))
+	for i := range spots {
+		spots[i] = circle{
+			x: cx + (float64(seed[10+i*3])/255-0.5)*rx*1.2,
+			y: cy + (float64(seed[11+i*3])/255-0.1)*ry*0.8,
+			r: size*0.03 + float64(seed[12+i*3]%10),
+		}
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, fakeImageSize, fakeImageSize))
+	for y := range fakeImageSize {
+		for x := range fakeImageSize {
+			px, py := float64(x)+0.5, float64(y)+0.5
+			c := background
+
+			// 体（太い輪郭線で囲む）
+			if d := ellipse(px, py, cx, cy, rx, ry); d <= 1 {
+				c = body
+				for _, s := range spots {
+					if ellipse(px, py, s.x, s.y, s.r, s.r) <= 1 {
+						c = spot
+					}
+				}
+				if d > 0.92 {
+					c = outline
+				}
+			}
+
+			// 目と瞳
+			for _, ex := range []float64{cx - eyeDX, cx + eyeDX} {
+				if d := ellipse(px, py, ex, eyeY, eyeR, eyeR); d <= 1 {
+					c = white
+					if d > 0.8 {
+						c = outline
+					}
+					if ellipse(px, py, ex+pupilDX, eyeY+pupilDY, eyeR*0.45, eyeR*0.45) <= 1 {
+						c = outline
+					}
+				}
+			}
+
+			// 口
+			if py > cy+ry*0.15 && py < cy+ry*0.15+3 && px > cx-rx*0.35 && px < cx+rx*0.35 {
+				c = outline
+			}
+
+			img.SetRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+// ellipse は点 (x, y) の楕円の中心からの正規化した距離の2乗を返します（1以下の場合は楕円の内側です）
+func ellipse(x, y, cx, cy, rx, ry float64) float64 {
+	dx, dy := (x-cx)/rx, (y-cy)/ry
+	return dx*dx + dy*dy
+}
+
+// parseColorCode は "#RRGGBB" 形式のカラーコードを色に変換します
+func parseColorCode(code string) (color.RGBA, error) {
+	var r, g, b uint8
+	if _, err := fmt.Sscanf(code, "#%02x%02x%02x", &r, &g, &b); err != nil {
+		return color.RGBA{}, fmt.Errorf("invalid color code %q: %w", code, err)
+	}
+	return color.RGBA{R: r, G: g, B: b, A: 0xff}, nil
+}
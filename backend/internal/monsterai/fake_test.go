@@ -0,0 +1,201 @@
+package monsterai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func TestFake_AnalyzeTrashBin(t *testing.T) {
+	ctx := context.Background()
+	fake := NewFake()
+
+	tests := []struct {
+		name    string
+		image   []byte
+		wantErr bool
+	}{
+		{name: "画像から分析結果を返す", image: []byte("trash-bin-1")},
+		{name: "別の画像でも分析結果を返す", image: []byte("trash-bin-2")},
+		{name: "画像が空の場合はエラー", image: nil, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := fake.AnalyzeTrashBin(ctx, tt.image, "image/jpeg")
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("AnalyzeTrashBin() error = nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("AnalyzeTrashBin() error = %v", err)
+			}
+			if got.TrashType == "" || got.TrashType == TrashTypeUnknown {
+				t.Errorf("AnalyzeTrashBin() TrashType = %q", got.TrashType)
+			}
+			var raw Analysis
+			if err := json.Unmarshal([]byte(got.RawText), &raw); err != nil || raw.TrashType != got.TrashType {
+				t.Errorf("AnalyzeTrashBin() RawText = %q", got.RawText)
+			}
+
+			// 同じ画像には同じ結果を返す
+			again, err := fake.AnalyzeTrashBin(ctx, tt.image, "image/jpeg")
+			if err != nil {
+				t.Fatalf("AnalyzeTrashBin() error = %v", err)
+			}
+			if *again != *got {
+				t.Errorf("AnalyzeTrashBin() = %+v, then %+v", got, again)
+			}
+		})
+	}
+}
+
+func TestFake_GenerateMonster(t *testing.T) {
+	ctx := context.Background()
+	fake := NewFake()
+
+	generate := func(t *testing.T, trashType string, image []byte) []byte {
+		t.Helper()
+		got, err := fake.GenerateMonster(ctx, GenerateInput{Analysis: Analysis{TrashType: trashType}, Image: image})
+		if err != nil {
+			t.Fatalf("GenerateMonster() error = %v", err)
+		}
+		if got.MimeType != "image/png" {
+			t.Errorf("GenerateMonster() MimeType = %q", got.MimeType)
+		}
+		if got.Input.Provider != "fake" || got.Input.TrashType != trashType || len(got.Input.InputImageSHA256) != 64 {
+			t.Errorf("GenerateMonster() Input = %+v", got.Input)
+		}
+		img, err := png.Decode(bytes.NewReader(got.Data))
+		if err != nil {
+			t.Fatalf("png.Decode() error = %v", err)
+		}
+		if b := img.Bounds(); b.Dx() != fakeImageSize || b.Dy() != fakeImageSize {
+			t.Errorf("GenerateMonster() size = %v", b)
+		}
+		return got.Data
+	}
+
+	base := generate(t, "燃えるゴミ", []byte("trash-bin-1"))
+
+	tests := []struct {
+		name      string
+		trashType string
+		image     []byte
+		wantSame  bool
+	}{
+		{name: "同じ入力には同じ画像を返す", trashType: "燃えるゴミ", image: []byte("trash-bin-1"), wantSame: true},
+		{name: "画像が異なる場合は異なる画像を返す", trashType: "燃えるゴミ", image: []byte("trash-bin-2")},
+		{name: "ゴミ種別が異なる場合は異なる画像を返す", trashType: "ペットボトル", image: []byte("trash-bin-1")},
+		{name: "不明なゴミ種別でも画像を返す", trashType: "unknown", image: []byte("trash-bin-1")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := generate(t, tt.trashType, tt.image)
+			if same := bytes.Equal(got, base); same != tt.wantSame {
+				t.Errorf("GenerateMonster() same as base = %v, want %v", same, tt.wantSame)
+			}
+		})
+	}
+}
+
+func TestFake_DescribeMonster(t *testing.T) {
+	ctx := context.Background()
+	fake := NewFake()
+
+	tests := []struct {
+		name        string
+		input       ProfileInput
+		wantPrefix  string
+		wantContain string
+		wantErr     bool
+	}{
+		{
+			name:        "属性から種族名の接頭語を決める",
+			input:       ProfileInput{Analysis: Analysis{TrashType: "燃えるゴミ"}, Attribute: "炎", Image: []byte("monster-1")},
+			wantPrefix:  "ボウボウ",
+			wantContain: "燃えるゴミのゴミ箱",
+		},
+		{
+			name:        "判定できない場合も種族名と説明文を返す",
+			input:       ProfileInput{Analysis: Analysis{TrashType: TrashTypeUnknown}, Attribute: "無", Image: []byte("monster-2")},
+			wantPrefix:  "ナゾノ",
+			wantContain: "正体不明のゴミ箱",
+		},
+		{
+			name:    "画像が空の場合はエラー",
+			input:   ProfileInput{Analysis: Analysis{TrashType: "缶"}, Attribute: "光"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := fake.DescribeMonster(ctx, tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("DescribeMonster() error = nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DescribeMonster() error = %v", err)
+			}
+			if !strings.HasPrefix(got.SpeciesName, tt.wantPrefix) || !strings.Contains(got.Description, tt.wantContain) || got.Provider != "fake" {
+				t.Errorf("DescribeMonster() = %+v", got)
+			}
+
+			// 同じ入力には同じ結果を返す
+			again, err := fake.DescribeMonster(ctx, tt.input)
+			if err != nil {
+				t.Fatalf("DescribeMonster() error = %v", err)
+			}
+			if *again != *got {
+				t.Errorf("DescribeMonster() = %+v, then %+v", got, again)
+			}
+		})
+	}
+}
+
+func TestFake_GenerateImage(t *testing.T) {
+	ctx := context.Background()
+	fake := NewFake()
+
+	image := func(t *testing.T, prompt string) []byte {
+		t.Helper()
+		got, err := fake.GenerateImage(ctx, PromptInput{Prompt: prompt})
+		if err != nil {
+			t.Fatalf("GenerateImage() error = %v", err)
+		}
+		if len(got) != 1 || got[0].Role != "model" || got[0].FinishReason != "STOP" {
+			t.Fatalf("GenerateImage() = %+v", got)
+		}
+		for _, part := range got[0].Parts {
+			if part.MimeType == "image/png" {
+				if _, err := png.Decode(bytes.NewReader(part.Data)); err != nil {
+					t.Fatalf("png.Decode() error = %v", err)
+				}
+				return part.Data
+			}
+		}
+		t.Fatal("GenerateImage() returned no image part")
+		return nil
+	}
+
+	base := image(t, "ゴミ箱に住むペンギン")
+	if !bytes.Equal(image(t, "ゴミ箱に住むペンギン"), base) {
+		t.Error("GenerateImage() returned different images for the same prompt")
+	}
+	if bytes.Equal(image(t, "ゴミ箱に住むカピバラ"), base) {
+		t.Error("GenerateImage() returned the same image for different prompts")
+	}
+	if _, err := fake.GenerateImage(ctx, PromptInput{Prompt: " "}); err == nil {
+		t.Error("GenerateImage() error = nil for an empty prompt")
+	}
+}
@@ -0,0 +1,157 @@
+package monsterai
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kinpatsu-everyone/backend-template/internal/gemini"
+)
+
+// Gemini はGoogle Gemini APIで分析・生成する MonsterAI です
+type Gemini struct {
+	apiKey        string
+	analysisModel string
+	imageModel    string
+	analysis      *gemini.Client
+	generate      *gemini.Client
+}
+
+// NewGemini は新しい Gemini を作成します
+// analysisModel は画像の分析と種族名・説明文の生成、imageModel は画像の生成に使うモデルです
+func NewGemini(apiKey, analysisModel, imageModel string) (*Gemini, error) {
+	analysis, err := gemini.NewClient(apiKey, analysisModel)
+	if err != nil {
+		return nil, err
+	}
+	generate, err := gemini.NewClient(apiKey, imageModel)
+	if err != nil {
+		return nil, err
+	}
+	return &Gemini{
+		apiKey:        apiKey,
+		analysisModel: analysisModel,
+		imageModel:    imageModel,
+		analysis:      analysis,
+		generate:      generate,
+	}, nil
+}
+
+// AnalyzeTrashBin はスキーマで形式を制限した回答からゴミ箱の画像を分析します
+// 回答がスキーマに合わない場合は gemini.InvalidResponseError を返します
+func (g *Gemini) AnalyzeTrashBin(ctx context.Context, image []byte, mimeType string) (*Analysis, error) {
+	result, text, err := g.analysis.AnalyzeTrashBinImage(ctx, image, mimeType)
+	if err != nil {
+		return nil, err
+	}
+	return &Analysis{
+		TrashType:   result.TrashType,
+		Color:       result.Color,
+		Markings:    result.Markings,
+		Contents:    result.Contents,
+		Reasoning:   result.Reasoning,
+		Description: result.Description,
+		Confidence:  result.Confidence,
+		BoundingBox: BoundingBox(result.BoundingBox),
+		RawText:     text,
+	}, nil
+}
+
+// imageClient は画像の生成に使うクライアントとモデルを返します（model が空の場合はデフォルトの画像のモデル）
+func (g *Gemini) imageClient(model string) (*gemini.Client, string, error) {
+	if model == "" || model == g.imageModel {
+		return g.generate, g.imageModel, nil
+	}
+	client, err := gemini.NewClient(g.apiKey, model)
+	if err != nil {
+		return nil, "", err
+	}
+	return client, model, nil
+}
+
+// GenerateMonster は元のゴミ箱の画像（正規化したもの）と分析結果の色・マーク・中身をプロンプトに含めてモンスターの画像を生成します
+func (g *Gemini) GenerateMonster(ctx context.Context, input GenerateInput) (*Image, error) {
+	client, model, err := g.imageClient(input.Model)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(input.Image) == 0 {
+		return nil, fmt.Errorf("image data is required")
+	}
+	trashBin, err := NormalizeInputImage(input.Image, input.MimeType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to normalize image: %w", err)
+	}
+	prompt := gemini.BuildGenerateMonsterPrompt(gemini.MonsterPromptParams{
+		TrashType: input.Analysis.TrashType,
+		Color:     input.Analysis.Color,
+		Markings:  input.Analysis.Markings,
+		Contents:  input.Analysis.Contents,
+	})
+
+	data, mimeType, err := client.GenerateMonsterImage(ctx, prompt, trashBin.Data, trashBin.MimeType)
+	if err != nil {
+		return nil, err
+	}
+	return &Image{
+		Data:     data,
+		MimeType: mimeType,
+		Input:    newGenerationRecord("gemini", model, prompt, input.Analysis, trashBin),
+	}, nil
+}
+
+// DescribeMonster は分析のモデルで、生成したモンスターの画像から種族名と説明文を生成します
+// 回答がスキーマに合わない場合は gemini.InvalidResponseError を返します
+func (g *Gemini) DescribeMonster(ctx context.Context, input ProfileInput) (*Profile, error) {
+	prompt := gemini.BuildMonsterProfilePrompt(gemini.MonsterProfileParams{
+		TrashType: input.Analysis.TrashType,
+		Attribute: input.Attribute,
+		Color:     input.Analysis.Color,
+		Markings:  input.Analysis.Markings,
+		Contents:  input.Analysis.Contents,
+	})
+
+	profile, _, err := g.analysis.GenerateMonsterProfile(ctx, prompt, input.Image, input.MimeType)
+	if err != nil {
+		return nil, err
+	}
+	return &Profile{
+		SpeciesName: profile.SpeciesName,
+		Description: profile.Description,
+		Provider:    "gemini",
+		Model:       g.analysisModel,
+	}, nil
+}
+
+// GenerateImage は画像のモデルにテキストのプロンプトを送信し、返された候補をそのまま返します
+func (g *Gemini) GenerateImage(ctx context.Context, input PromptInput) ([]Candidate, error) {
+	client, _, err := g.imageClient(input.Model)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.GenerateContent(ctx, input.Prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]Candidate, 0, len(resp.Candidates))
+	for _, cand := range resp.Candidates {
+		candidate := Candidate{FinishReason: string(cand.FinishReason)}
+		if cand.Content != nil {
+			candidate.Role = cand.Content.Role
+			for _, part := range cand.Content.Parts {
+				p := Part{Text: part.Text}
+				if part.InlineData != nil {
+					p.Data, p.MimeType = part.InlineData.Data, part.InlineData.MIMEType
+				}
+				if part.FileData != nil {
+					p.FileURI, p.FileMimeType = part.FileData.FileURI, part.FileData.MIMEType
+				}
+				candidate.Parts = append(candidate.Parts, p)
+			}
+		}
+		candidates = append(candidates, candidate)
+	}
+	return candidates, nil
+}
@@ -0,0 +1,172 @@
+package monsterai
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/kinpatsu-everyone/backend-template/config"
+)
+
+// Analysis はゴミ箱の画像の分析結果です
+type Analysis struct {
+	// TrashType は enum のゴミ種別の名前、または判定できない場合の TrashTypeUnknown です
+	TrashType   string `json:"trash_type"`
+	Color       string `json:"color"`
+	Markings    string `json:"markings"`
+	Contents    string `json:"contents"`
+	Reasoning   string `json:"reasoning"`
+	Description string `json:"description"`
+	// Confidence は判定の確信度（0〜1）です
+	Confidence  float64     `json:"confidence"`
+	BoundingBox BoundingBox `json:"bounding_box"`
+
+	// RawText はモデルが返した分析結果のテキストです
+	RawText string `json:"-"`
+}
+
+// BoundingBox は画像内のゴミ箱の位置です（画像の左上を0、右下を1とした座標）
+type BoundingBox struct {
+	XMin float64 `json:"x_min"`
+	YMin float64 `json:"y_min"`
+	XMax float64 `json:"x_max"`
+	YMax float64 `json:"y_max"`
+}
+
+// TrashTypeUnknown はゴミ種別を判定できない場合の Analysis.TrashType です
+const TrashTypeUnknown = "unknown"
+
+// GenerateInput はモンスターの画像の生成に使う情報です
+type GenerateInput struct {
+	Analysis Analysis
+	// Image・MimeType は元のゴミ箱の画像です
+	Image    []byte
+	MimeType string
+	// Model は画像の生成に使うモデルです（空の場合はプロバイダーのデフォルトを使用します）
+	Model string
+}
+
+// Image は生成された画像です
+type Image struct {
+	Data     []byte
+	MimeType string
+	// Input は画像の生成に使った入力です
+	Input GenerationRecord
+}
+
+// GenerationRecord は画像の生成に使った入力の記録です（同じ条件で再生成できるようにジョブに保存します）
+// 元のゴミ箱の画像そのものは保存せず、モデルに渡した正規化後の画像のハッシュを記録します
+type GenerationRecord struct {
+	Provider  string `json:"provider"`
+	Model     string `json:"model,omitempty"`
+	Prompt    string `json:"prompt,omitempty"`
+	TrashType string `json:"trash_type"`
+	Color     string `json:"color"`
+	Markings  string `json:"markings"`
+	Contents  string `json:"contents"`
+	// InputImageSHA256 はモデルに渡した画像のSHA-256（16進数）です
+	InputImageSHA256   string `json:"input_image_sha256"`
+	InputImageMimeType string `json:"input_image_mime_type"`
+	InputImageWidth    int    `json:"input_image_width,omitempty"`
+	InputImageHeight   int    `json:"input_image_height,omitempty"`
+}
+
+// newGenerationRecord は分析結果と正規化した画像から GenerationRecord を作成します
+func newGenerationRecord(provider, model, prompt string, analysis Analysis, image *NormalizedImage) GenerationRecord {
+	sum := sha256.Sum256(image.Data)
+	return GenerationRecord{
+		Provider:           provider,
+		Model:              model,
+		Prompt:             prompt,
+		TrashType:          analysis.TrashType,
+		Color:              analysis.Color,
+		Markings:           analysis.Markings,
+		Contents:           analysis.Contents,
+		InputImageSHA256:   hex.EncodeToString(sum[:]),
+		InputImageMimeType: image.MimeType,
+		InputImageWidth:    image.Width,
+		InputImageHeight:   image.Height,
+	}
+}
+
+// ProfileInput はモンスターの種族名と説明文の生成に使う情報です
+type ProfileInput struct {
+	Analysis Analysis
+	// Attribute はモンスターの属性です
+	Attribute string
+	// Image・MimeType は生成したモンスターの画像です
+	Image    []byte
+	MimeType string
+}
+
+// Profile はモンスターの種族名と図鑑の説明文です
+type Profile struct {
+	SpeciesName string
+	Description string
+	// Provider・Model は生成に使ったプロバイダーとモデルです
+	Provider string
+	Model    string
+}
+
+// PromptInput はテキストのプロンプトから画像を生成するための入力です
+type PromptInput struct {
+	Prompt string
+	// Model は画像の生成に使うモデルです（空の場合はプロバイダーのデフォルトを使用します）
+	Model string
+}
+
+// Candidate はプロンプトから生成された候補です
+type Candidate struct {
+	Role         string
+	Parts        []Part
+	FinishReason string
+}
+
+// Part は候補に含まれるテキスト・インラインの画像・ファイルのいずれかです
+type Part struct {
+	Text string
+	// Data・MimeType はインラインの画像データです
+	Data     []byte
+	MimeType string
+	// FileURI・FileMimeType はモデルが返したファイルの参照です
+	FileURI      string
+	FileMimeType string
+}
+
+// MonsterAI はゴミ箱の画像を分析し、モンスターの画像・種族名・説明文を生成するAIです
+type MonsterAI interface {
+	// AnalyzeTrashBin はゴミ箱の画像を分析してゴミ種別を判定します
+	// 判定できない場合、TrashType は TrashTypeUnknown になります
+	AnalyzeTrashBin(ctx context.Context, image []byte, mimeType string) (*Analysis, error)
+	// GenerateMonster は元のゴミ箱の画像と分析結果をもとにモンスターの画像を生成します
+	GenerateMonster(ctx context.Context, input GenerateInput) (*Image, error)
+	// DescribeMonster は生成したモンスターの画像と分析結果から種族名と説明文を生成します
+	DescribeMonster(ctx context.Context, input ProfileInput) (*Profile, error)
+	// GenerateImage はテキストのプロンプトから画像を生成します（画像生成の動作確認用）
+	GenerateImage(ctx context.Context, input PromptInput) ([]Candidate, error)
+}
+
+var globalMonsterAI MonsterAI
+
+// SetMonsterAI はグローバルな MonsterAI を設定します
+func SetMonsterAI(ai MonsterAI) {
+	globalMonsterAI = ai
+}
+
+// GetMonsterAI はグローバルな MonsterAI を取得します（設定されていない場合は nil を返します）
+func GetMonsterAI() MonsterAI {
+	return globalMonsterAI
+}
+
+// NewFromConfig は config.MonsterAIProvider で指定された MonsterAI を作成します
+func NewFromConfig() (MonsterAI, error) {
+	switch config.MonsterAIProvider {
+	case "gemini":
+		return NewGemini(config.GeminiAPIKey, config.GeminiAnalysisModel, config.GeminiImageModel)
+	case "fake":
+		return NewFake(), nil
+	default:
+		return nil, fmt.Errorf("monsterai: unknown provider %q", config.MonsterAIProvider)
+	}
+}
@@ -0,0 +1,114 @@
+package monsterai
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+
+	// 元のゴミ箱の画像としてアップロードされる形式のデコーダーを登録する
+	_ "image/gif"
+	_ "image/png"
+)
+
+const (
+	// MaxInputImageSize は生成モデルに渡す元のゴミ箱の画像の長辺の最大ピクセル数です
+	MaxInputImageSize = 1024
+	// MaxInputImagePixels はデコードする元のゴミ箱の画像の最大ピクセル数（8192×8192）です
+	// 圧縮率の高い画像はファイルサイズが小さくてもデコードに数GBのメモリを使うため、デコードする前に拒否します
+	MaxInputImagePixels = 8192 * 8192
+	// inputImageQuality は正規化した画像のJPEGの品質です
+	inputImageQuality = 90
+)
+
+// ErrInputImageTooLarge は元のゴミ箱の画像のピクセル数が MaxInputImagePixels を超える場合のエラーです
+// 同じ画像でリトライしても成功しません
+var ErrInputImageTooLarge = errors.New("monsterai: input image is too large")
+
+// NormalizedImage は生成モデルに渡すために正規化した元のゴミ箱の画像です
+type NormalizedImage struct {
+	Data     []byte
+	MimeType string
+	// Width・Height は正規化後の画像のサイズです（デコードできなかった場合は0）
+	Width  int
+	Height int
+}
+
+// NormalizeInputImage は元のゴミ箱の画像を生成モデルに渡す形式に正規化します
+// 長辺が MaxInputImageSize を超える場合は縮小し、透過部分を白で塗りつぶしたJPEGに変換します
+// 標準ライブラリでデコードできない形式（WebPなど）の場合は、元の画像をそのまま返します
+// ピクセル数が MaxInputImagePixels を超える場合は、デコードせずに ErrInputImageTooLarge を返します
+func NormalizeInputImage(data []byte, mimeType string) (*NormalizedImage, error) {
+	if err := CheckInputImageSize(data); err != nil {
+		return nil, err
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return &NormalizedImage{Data: data, MimeType: mimeType}, nil
+	}
+
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if longest := max(w, h); longest > MaxInputImageSize {
+		w = max(1, w*MaxInputImageSize/longest)
+		h = max(1, h*MaxInputImageSize/longest)
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, resizeImage(src, w, h), &jpeg.Options{Quality: inputImageQuality}); err != nil {
+		return nil, fmt.Errorf("failed to encode image: %w", err)
+	}
+	return &NormalizedImage{Data: buf.Bytes(), MimeType: "image/jpeg", Width: w, Height: h}, nil
+}
+
+// CheckInputImageSize は画像のヘッダーだけを読み、ピクセル数が MaxInputImagePixels を超える場合は ErrInputImageTooLarge を返します
+// 標準ライブラリでデコードできない形式の場合は nil を返します
+func CheckInputImageSize(data []byte) error {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil
+	}
+	if int64(cfg.Width)*int64(cfg.Height) > MaxInputImagePixels {
+		return fmt.Errorf("%w: %dx%d", ErrInputImageTooLarge, cfg.Width, cfg.Height)
+	}
+	return nil
+}
+
+// resizeImage は src を w×h に縮小した画像を返します（縮小先の1ピクセルに対応する範囲の平均色を使います）
+// 透過部分は白を背景として合成します
+func resizeImage(src image.Image, w, h int) *image.RGBA {
+	b := src.Bounds()
+	sw, sh := b.Dx(), b.Dy()
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+
+	for y := range h {
+		y0 := b.Min.Y + y*sh/h
+		y1 := max(y0+1, b.Min.Y+(y+1)*sh/h)
+		for x := range w {
+			x0 := b.Min.X + x*sw/w
+			x1 := max(x0+1, b.Min.X+(x+1)*sw/w)
+
+			var r, g, bl, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					// RGBA はアルファ乗算済みの値を返すため、白との合成は (1 - a) を足すだけでよい
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r += uint64(cr + 0xffff - ca)
+					g += uint64(cg + 0xffff - ca)
+					bl += uint64(cb + 0xffff - ca)
+					n++
+				}
+			}
+			dst.SetRGBA(x, y, color.RGBA{
+				R: uint8(r / n >> 8),
+				G: uint8(g / n >> 8),
+				B: uint8(bl / n >> 8),
+				A: 0xff,
+			})
+		}
+	}
+	return dst
+}
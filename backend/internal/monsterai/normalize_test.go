@@ -0,0 +1,165 @@
+package monsterai
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+func encodePNG(t *testing.T, w, h int, c color.Color) []byte {
+	t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := range h {
+		for x := range w {
+			img.Set(x, y, c)
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("png.Encode() error = %v", err)
+	}
+	return buf.Bytes()
+}
+
+// pngHeader は幅と高さだけを指定したPNGのシグネチャとIHDRチャンクを返します（画像のデータは含みません）
+func pngHeader(w, h uint32) []byte {
+	ihdr := make([]byte, 17)
+	copy(ihdr, "IHDR")
+	binary.BigEndian.PutUint32(ihdr[4:], w)
+	binary.BigEndian.PutUint32(ihdr[8:], h)
+	ihdr[12] = 8 // ビット深度
+	ihdr[13] = 2 // RGB
+
+	var buf bytes.Buffer
+	buf.WriteString("\x89PNG\r\n\x1a\n")
+	binary.Write(&buf, binary.BigEndian, uint32(len(ihdr)-4))
+	buf.Write(ihdr)
+	binary.Write(&buf, binary.BigEndian, crc32.ChecksumIEEE(ihdr))
+	return buf.Bytes()
+}
+
+func TestNormalizeInputImage(t *testing.T) {
+	tests := []struct {
+		name         string
+		data         []byte
+		mimeType     string
+		wantMimeType string
+		wantWidth    int
+		wantHeight   int
+		wantColor    color.RGBA
+	}{
+		{
+			name:         "長辺が上限を超える場合は縦横比を保って縮小する",
+			data:         encodePNG(t, 2048, 1536, color.RGBA{R: 0xff, A: 0xff}),
+			mimeType:     "image/png",
+			wantMimeType: "image/jpeg",
+			wantWidth:    1024,
+			wantHeight:   768,
+			wantColor:    color.RGBA{R: 0xff, A: 0xff},
+		},
+		{
+			name:         "上限以下の場合はサイズを変えずにJPEGに変換する",
+			data:         encodePNG(t, 300, 400, color.RGBA{B: 0xff, A: 0xff}),
+			mimeType:     "image/png",
+			wantMimeType: "image/jpeg",
+			wantWidth:    300,
+			wantHeight:   400,
+			wantColor:    color.RGBA{B: 0xff, A: 0xff},
+		},
+		{
+			name:         "透過部分は白で塗りつぶす",
+			data:         encodePNG(t, 64, 64, color.NRGBA{}),
+			mimeType:     "image/png",
+			wantMimeType: "image/jpeg",
+			wantWidth:    64,
+			wantHeight:   64,
+			wantColor:    color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+		},
+		{
+			name:         "デコードできない場合は元の画像をそのまま返す",
+			data:         []byte("RIFF....WEBPVP8 "),
+			mimeType:     "image/webp",
+			wantMimeType: "image/webp",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeInputImage(tt.data, tt.mimeType)
+			if err != nil {
+				t.Fatalf("NormalizeInputImage() error = %v", err)
+			}
+			if got.MimeType != tt.wantMimeType {
+				t.Errorf("NormalizeInputImage() MimeType = %q, want %q", got.MimeType, tt.wantMimeType)
+			}
+			if got.Width != tt.wantWidth || got.Height != tt.wantHeight {
+				t.Errorf("NormalizeInputImage() size = %dx%d, want %dx%d", got.Width, got.Height, tt.wantWidth, tt.wantHeight)
+			}
+			if tt.wantWidth == 0 {
+				if !bytes.Equal(got.Data, tt.data) {
+					t.Errorf("NormalizeInputImage() Data = %q, want original", got.Data)
+				}
+				return
+			}
+
+			img, err := jpeg.Decode(bytes.NewReader(got.Data))
+			if err != nil {
+				t.Fatalf("jpeg.Decode() error = %v", err)
+			}
+			if b := img.Bounds(); b.Dx() != tt.wantWidth || b.Dy() != tt.wantHeight {
+				t.Errorf("decoded size = %v, want %dx%d", b, tt.wantWidth, tt.wantHeight)
+			}
+			r, g, b, _ := img.At(tt.wantWidth/2, tt.wantHeight/2).RGBA()
+			want := tt.wantColor
+			if diff(r>>8, want.R) > 8 || diff(g>>8, want.G) > 8 || diff(b>>8, want.B) > 8 {
+				t.Errorf("center color = (%d, %d, %d), want %v", r>>8, g>>8, b>>8, want)
+			}
+		})
+	}
+}
+
+func TestNormalizeInputImage_ピクセル数が上限を超える場合はデコードせずにエラーを返す(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr bool
+	}{
+		{name: "上限を超えるヘッダー", data: pngHeader(100000, 100000), wantErr: true},
+		{name: "辺は上限以下でもピクセル数が上限を超える", data: pngHeader(8192, 8193), wantErr: true},
+		{name: "上限ちょうどはデコードする（データがないためデコードできず元の画像を返す）", data: pngHeader(8192, 8192)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeInputImage(tt.data, "image/png")
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("NormalizeInputImage() error = %v", err)
+				}
+				if !bytes.Equal(got.Data, tt.data) {
+					t.Errorf("NormalizeInputImage() Data = %q, want original", got.Data)
+				}
+				return
+			}
+			if !errors.Is(err, ErrInputImageTooLarge) {
+				t.Fatalf("NormalizeInputImage() error = %v, want %v", err, ErrInputImageTooLarge)
+			}
+			if err := CheckInputImageSize(tt.data); !errors.Is(err, ErrInputImageTooLarge) {
+				t.Errorf("CheckInputImageSize() error = %v, want %v", err, ErrInputImageTooLarge)
+			}
+		})
+	}
+}
+
+func diff(a uint32, b uint8) uint32 {
+	if a > uint32(b) {
+		return a - uint32(b)
+	}
+	return uint32(b) - a
+}
@@ -6,13 +6,36 @@ package mysql
 
 import (
 	"database/sql"
+	"encoding/json"
 	"time"
 )
 
+// APIキー
+type Apikey struct {
+	// APIキーID(UUID)
+	Apikeyid string `json:"apikeyid"`
+	// ユーザーID(UUID)
+	Userid string `json:"userid"`
+	// APIキーのハッシュ(SHA-256の16進数)
+	Keyhash string `json:"keyhash"`
+	// 許可するスコープ(スペース区切り)
+	Scopes string `json:"scopes"`
+	// 有効期限(NULLの場合は無期限)
+	Expiresat sql.NullTime `json:"expiresat"`
+	// 無効化日時
+	Revokedat sql.NullTime `json:"revokedat"`
+	// 作成日時
+	Createdat time.Time `json:"createdat"`
+	// 更新日時
+	Updatedat time.Time `json:"updatedat"`
+}
+
 // モンスターの基本情報
 type Monster struct {
 	// モンスターID(UUID)
 	Monsterid string `json:"monsterid"`
+	// 所有者のユーザーID(UUID、未ログインで作成した場合はNULL)
+	Userid sql.NullString `json:"userid"`
 	// ニックネーム
 	Nickname string `json:"nickname"`
 	// 元のゴミ箱の画像URL
@@ -43,6 +66,106 @@ type Monsterattribute struct {
 	Updatedat time.Time `json:"updatedat"`
 }
 
+// モンスター生成ジョブ
+type Monstergenerationjob struct {
+	// ジョブID(UUID)
+	Jobid string `json:"jobid"`
+	// 生成するモンスターID(UUID)
+	Monsterid string `json:"monsterid"`
+	// ジョブを作成したユーザーID(UUID、未ログインで作成した場合はNULL)
+	Userid sql.NullString `json:"userid"`
+	// 生成するモンスターのニックネーム
+	Nickname string `json:"nickname"`
+	// 緯度(-90.0 ~ 90.0)
+	Latitude sql.NullString `json:"latitude"`
+	// 経度(-180.0 ~ 180.0)
+	Longitude sql.NullString `json:"longitude"`
+	// ジョブの作成時に数えた1日の利用上限のキー(失敗した場合に戻す)
+	Quotakey sql.NullString `json:"quotakey"`
+	// 状態(queued, analyzing, generating, uploading, done, failed)
+	Status string `json:"status"`
+	// 実行回数
+	Attempts int32 `json:"attempts"`
+	// 最大実行回数(リトライを含む)
+	Maxattempts int32 `json:"maxattempts"`
+	// 次に実行できる日時
+	Nextrunat time.Time `json:"nextrunat"`
+	// 実行中のワーカーID
+	Leaseowner sql.NullString `json:"leaseowner"`
+	// リースの有効期限(過ぎた場合は他のワーカーが再取得する)
+	Leaseexpiresat sql.NullTime `json:"leaseexpiresat"`
+	// 失敗理由のコード
+	Errorcode sql.NullString `json:"errorcode"`
+	// 失敗理由の詳細
+	Errormessage sql.NullString `json:"errormessage"`
+	// アップロードされたゴミ箱の画像のストレージのキー(完了後に削除)
+	Inputimagekey string `json:"inputimagekey"`
+	// アップロードされたゴミ箱の画像のMIMEタイプ
+	Inputmimetype string `json:"inputmimetype"`
+	// 画像の生成に使った入力(モデル・プロンプト・正規化した画像のハッシュなど、再現用)
+	Generationinput json.RawMessage `json:"generationinput"`
+	// 完了日時
+	Completedat sql.NullTime `json:"completedat"`
+	// 作成日時
+	Createdat time.Time `json:"createdat"`
+	// 更新日時
+	Updatedat time.Time `json:"updatedat"`
+}
+
+// モンスターの種族名と図鑑の説明文
+type Monsterprofile struct {
+	// モンスターID(UUID)
+	Monsterid string `json:"monsterid"`
+	// 種族名
+	Speciesname string `json:"speciesname"`
+	// 図鑑の説明文
+	Description string `json:"description"`
+	// 種族名・説明文を生成したプロバイダー(生成に失敗した場合はfallback)
+	Provider string `json:"provider"`
+	// 種族名・説明文の生成に使ったモデル
+	Model string `json:"model"`
+	// 作成日時
+	Createdat time.Time `json:"createdat"`
+	// 更新日時
+	Updatedat time.Time `json:"updatedat"`
+}
+
+// モンスターの能力値
+type Monsterstat struct {
+	// モンスターID(UUID)
+	Monsterid string `json:"monsterid"`
+	// HP
+	Hp uint16 `json:"hp"`
+	// こうげき
+	Attack uint16 `json:"attack"`
+	// ぼうぎょ
+	Defense uint16 `json:"defense"`
+	// すばやさ
+	Speed uint16 `json:"speed"`
+	// 能力値の計算に使ったシード(同じ分析結果とシードから同じ能力値を計算できる)
+	Seed uint64 `json:"seed"`
+	// 作成日時
+	Createdat time.Time `json:"createdat"`
+	// 更新日時
+	Updatedat time.Time `json:"updatedat"`
+}
+
+// モンスターの元になったゴミ箱の画像の分析結果
+type Monstertrashanalysis struct {
+	// モンスターID(UUID)
+	Monsterid string `json:"monsterid"`
+	// 判定したゴミ種別の名前(判定できない場合はunknown)
+	Trashtype string `json:"trashtype"`
+	// 判定の確信度(0 ~ 1)
+	Confidence float64 `json:"confidence"`
+	// ゴミ箱の画像の分析結果(色・マーク・中身・判定理由・ゴミ箱の位置など)
+	Result json.RawMessage `json:"result"`
+	// 作成日時
+	Createdat time.Time `json:"createdat"`
+	// 更新日時
+	Updatedat time.Time `json:"updatedat"`
+}
+
 // モンスターのゴミ種別(多対多)
 type Monstertrashcategory struct {
 	// モンスターゴミ種別ID(UUID)
@@ -12,12 +12,13 @@ import (
 )
 
 const createMonster = `-- name: CreateMonster :execresult
-INSERT INTO Monster (MonsterId, Nickname, OriginalTrashBinImageUrl, GeneratedMonsterImageUrl, Latitude, Longitude)
-VALUES (?, ?, ?, ?, ?, ?)
+INSERT INTO Monster (MonsterId, UserId, Nickname, OriginalTrashBinImageUrl, GeneratedMonsterImageUrl, Latitude, Longitude)
+VALUES (?, ?, ?, ?, ?, ?, ?)
 `
 
 type CreateMonsterParams struct {
 	Monsterid                  string         `json:"monsterid"`
+	Userid                     sql.NullString `json:"userid"`
 	Nickname                   string         `json:"nickname"`
 	Originaltrashbinimageurl   string         `json:"originaltrashbinimageurl"`
 	Generatedmonsterimageurl   string         `json:"generatedmonsterimageurl"`
@@ -28,6 +29,7 @@ type CreateMonsterParams struct {
 func (q *Queries) CreateMonster(ctx context.Context, arg CreateMonsterParams) (sql.Result, error) {
 	return q.db.ExecContext(ctx, createMonster,
 		arg.Monsterid,
+		arg.Userid,
 		arg.Nickname,
 		arg.Originaltrashbinimageurl,
 		arg.Generatedmonsterimageurl,
@@ -47,7 +49,7 @@ func (q *Queries) DeleteMonster(ctx context.Context, monsterid string) error {
 }
 
 const getMonster = `-- name: GetMonster :one
-SELECT monsterid, nickname, originaltrashbinimageurl, generatedmonsterimageurl, latitude, longitude, createdat, updatedat FROM Monster
+SELECT monsterid, userid, nickname, originaltrashbinimageurl, generatedmonsterimageurl, latitude, longitude, createdat, updatedat FROM Monster
 WHERE MonsterId = ? LIMIT 1
 `
 
@@ -56,6 +58,7 @@ func (q *Queries) GetMonster(ctx context.Context, monsterid string) (Monster, er
 	var i Monster
 	err := row.Scan(
 		&i.Monsterid,
+		&i.Userid,
 		&i.Nickname,
 		&i.Originaltrashbinimageurl,
 		&i.Generatedmonsterimageurl,
@@ -67,56 +70,53 @@ func (q *Queries) GetMonster(ctx context.Context, monsterid string) (Monster, er
 	return i, err
 }
 
-const getMonsterDetail = `-- name: GetMonsterDetail :one
-SELECT
-    m.MonsterId,
-    m.Nickname,
-    m.OriginalTrashBinImageUrl,
-    m.GeneratedMonsterImageUrl,
-    m.Latitude,
-    m.Longitude,
-    m.CreatedAt,
-    m.UpdatedAt,
-    ma.AttributeName,
-    ma.ColorCode
-FROM Monster m
-LEFT JOIN MonsterAttribute ma ON m.MonsterId = ma.MonsterId
-WHERE m.MonsterId = ? LIMIT 1
+const listIncompleteMonsters = `-- name: ListIncompleteMonsters :many
+SELECT monsterid, userid, nickname, originaltrashbinimageurl, generatedmonsterimageurl, latitude, longitude, createdat, updatedat FROM Monster
+WHERE CreatedAt < ?
+  AND (GeneratedMonsterImageUrl = '' OR OriginalTrashBinImageUrl = ''
+    OR NOT EXISTS (SELECT 1 FROM MonsterTrashCategory c WHERE c.MonsterId = Monster.MonsterId))
+  AND NOT EXISTS (
+    SELECT 1 FROM MonsterGenerationJob j
+    WHERE j.MonsterId = Monster.MonsterId AND j.Status NOT IN ('done', 'failed')
+  )
+ORDER BY CreatedAt
 `
 
-type GetMonsterDetailRow struct {
-	Monsterid                  string         `json:"monsterid"`
-	Nickname                   string         `json:"nickname"`
-	Originaltrashbinimageurl  string         `json:"originaltrashbinimageurl"`
-	Generatedmonsterimageurl  string         `json:"generatedmonsterimageurl"`
-	Latitude                   sql.NullString `json:"latitude"`
-	Longitude                  sql.NullString `json:"longitude"`
-	Createdat                  time.Time      `json:"createdat"`
-	Updatedat                  time.Time      `json:"updatedat"`
-	Attributename              sql.NullString `json:"attributename"`
-	Colorcode                  sql.NullString `json:"colorcode"`
-}
-
-func (q *Queries) GetMonsterDetail(ctx context.Context, monsterid string) (GetMonsterDetailRow, error) {
-	row := q.db.QueryRowContext(ctx, getMonsterDetail, monsterid)
-	var i GetMonsterDetailRow
-	err := row.Scan(
-		&i.Monsterid,
-		&i.Nickname,
-		&i.Originaltrashbinimageurl,
-		&i.Generatedmonsterimageurl,
-		&i.Latitude,
-		&i.Longitude,
-		&i.Createdat,
-		&i.Updatedat,
-		&i.Attributename,
-		&i.Colorcode,
-	)
-	return i, err
+func (q *Queries) ListIncompleteMonsters(ctx context.Context, createdat time.Time) ([]Monster, error) {
+	rows, err := q.db.QueryContext(ctx, listIncompleteMonsters, createdat)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Monster{}
+	for rows.Next() {
+		var i Monster
+		if err := rows.Scan(
+			&i.Monsterid,
+			&i.Userid,
+			&i.Nickname,
+			&i.Originaltrashbinimageurl,
+			&i.Generatedmonsterimageurl,
+			&i.Latitude,
+			&i.Longitude,
+			&i.Createdat,
+			&i.Updatedat,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
 const listMonsters = `-- name: ListMonsters :many
-SELECT monsterid, nickname, originaltrashbinimageurl, generatedmonsterimageurl, latitude, longitude, createdat, updatedat FROM Monster
+SELECT monsterid, userid, nickname, originaltrashbinimageurl, generatedmonsterimageurl, latitude, longitude, createdat, updatedat FROM Monster
 ORDER BY CreatedAt DESC
 `
 
@@ -131,6 +131,7 @@ func (q *Queries) ListMonsters(ctx context.Context) ([]Monster, error) {
 		var i Monster
 		if err := rows.Scan(
 			&i.Monsterid,
+			&i.Userid,
 			&i.Nickname,
 			&i.Originaltrashbinimageurl,
 			&i.Generatedmonsterimageurl,
@@ -152,47 +153,260 @@ func (q *Queries) ListMonsters(ctx context.Context) ([]Monster, error) {
 	return items, nil
 }
 
-const listMonstersWithAttribute = `-- name: ListMonstersWithAttribute :many
-SELECT
-    m.MonsterId,
-    m.Nickname,
-    m.OriginalTrashBinImageUrl,
-    m.GeneratedMonsterImageUrl,
-    m.Latitude,
-    m.Longitude,
-    m.CreatedAt,
-    m.UpdatedAt,
-    ma.AttributeName,
-    ma.ColorCode
-FROM Monster m
-LEFT JOIN MonsterAttribute ma ON m.MonsterId = ma.MonsterId
+const listMonstersByUserId = `-- name: ListMonstersByUserId :many
+SELECT monsterid, userid, nickname, originaltrashbinimageurl, generatedmonsterimageurl, latitude, longitude, createdat, updatedat FROM Monster
+WHERE UserId = ?
+ORDER BY CreatedAt DESC
+`
+
+func (q *Queries) ListMonstersByUserId(ctx context.Context, userid sql.NullString) ([]Monster, error) {
+	rows, err := q.db.QueryContext(ctx, listMonstersByUserId, userid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Monster{}
+	for rows.Next() {
+		var i Monster
+		if err := rows.Scan(
+			&i.Monsterid,
+			&i.Userid,
+			&i.Nickname,
+			&i.Originaltrashbinimageurl,
+			&i.Generatedmonsterimageurl,
+			&i.Latitude,
+			&i.Longitude,
+			&i.Createdat,
+			&i.Updatedat,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMonstersInBounds = `-- name: ListMonstersInBounds :many
+SELECT monsterid, userid, nickname, originaltrashbinimageurl, generatedmonsterimageurl, latitude, longitude, createdat, updatedat FROM Monster
+WHERE Latitude BETWEEN ? AND ?
+  AND Longitude BETWEEN ? AND ?
+ORDER BY CreatedAt DESC
+LIMIT ?
+`
+
+type ListMonstersInBoundsParams struct {
+	MinLatitude  sql.NullString `json:"min_latitude"`
+	MaxLatitude  sql.NullString `json:"max_latitude"`
+	MinLongitude sql.NullString `json:"min_longitude"`
+	MaxLongitude sql.NullString `json:"max_longitude"`
+	MaxRows      int32          `json:"max_rows"`
+}
+
+func (q *Queries) ListMonstersInBounds(ctx context.Context, arg ListMonstersInBoundsParams) ([]Monster, error) {
+	rows, err := q.db.QueryContext(ctx, listMonstersInBounds,
+		arg.MinLatitude,
+		arg.MaxLatitude,
+		arg.MinLongitude,
+		arg.MaxLongitude,
+		arg.MaxRows,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Monster{}
+	for rows.Next() {
+		var i Monster
+		if err := rows.Scan(
+			&i.Monsterid,
+			&i.Userid,
+			&i.Nickname,
+			&i.Originaltrashbinimageurl,
+			&i.Generatedmonsterimageurl,
+			&i.Latitude,
+			&i.Longitude,
+			&i.Createdat,
+			&i.Updatedat,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMonstersInBoundsByTrashCategory = `-- name: ListMonstersInBoundsByTrashCategory :many
+SELECT m.monsterid, m.userid, m.nickname, m.originaltrashbinimageurl, m.generatedmonsterimageurl, m.latitude, m.longitude, m.createdat, m.updatedat FROM Monster m
+JOIN MonsterTrashCategory c ON c.MonsterId = m.MonsterId
+WHERE m.Latitude BETWEEN ? AND ?
+  AND m.Longitude BETWEEN ? AND ?
+  AND c.TrashCategory = ?
 ORDER BY m.CreatedAt DESC
+LIMIT ?
 `
 
-type ListMonstersWithAttributeRow struct {
-	Monsterid                 string         `json:"monsterid"`
-	Nickname                  string         `json:"nickname"`
-	Originaltrashbinimageurl  string         `json:"originaltrashbinimageurl"`
-	Generatedmonsterimageurl  string         `json:"generatedmonsterimageurl"`
-	Latitude                  sql.NullString `json:"latitude"`
-	Longitude                 sql.NullString `json:"longitude"`
-	Createdat                 time.Time      `json:"createdat"`
-	Updatedat                 time.Time      `json:"updatedat"`
-	Attributename             sql.NullString `json:"attributename"`
-	Colorcode                 sql.NullString `json:"colorcode"`
+type ListMonstersInBoundsByTrashCategoryParams struct {
+	MinLatitude   sql.NullString `json:"min_latitude"`
+	MaxLatitude   sql.NullString `json:"max_latitude"`
+	MinLongitude  sql.NullString `json:"min_longitude"`
+	MaxLongitude  sql.NullString `json:"max_longitude"`
+	TrashCategory uint8          `json:"trash_category"`
+	MaxRows       int32          `json:"max_rows"`
+}
+
+func (q *Queries) ListMonstersInBoundsByTrashCategory(ctx context.Context, arg ListMonstersInBoundsByTrashCategoryParams) ([]Monster, error) {
+	rows, err := q.db.QueryContext(ctx, listMonstersInBoundsByTrashCategory,
+		arg.MinLatitude,
+		arg.MaxLatitude,
+		arg.MinLongitude,
+		arg.MaxLongitude,
+		arg.TrashCategory,
+		arg.MaxRows,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Monster{}
+	for rows.Next() {
+		var i Monster
+		if err := rows.Scan(
+			&i.Monsterid,
+			&i.Userid,
+			&i.Nickname,
+			&i.Originaltrashbinimageurl,
+			&i.Generatedmonsterimageurl,
+			&i.Latitude,
+			&i.Longitude,
+			&i.Createdat,
+			&i.Updatedat,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-func (q *Queries) ListMonstersWithAttribute(ctx context.Context) ([]ListMonstersWithAttributeRow, error) {
-	rows, err := q.db.QueryContext(ctx, listMonstersWithAttribute)
+const listMonstersNearby = `-- name: ListMonstersNearby :many
+SELECT monsterid, userid, nickname, originaltrashbinimageurl, generatedmonsterimageurl, latitude, longitude, createdat, updatedat FROM Monster
+WHERE Latitude BETWEEN ? AND ?
+  AND Longitude BETWEEN ? AND ?
+ORDER BY ST_Distance_Sphere(POINT(Longitude, Latitude), POINT(?, ?))
+LIMIT ?
+`
+
+type ListMonstersNearbyParams struct {
+	MinLatitude     sql.NullString `json:"min_latitude"`
+	MaxLatitude     sql.NullString `json:"max_latitude"`
+	MinLongitude    sql.NullString `json:"min_longitude"`
+	MaxLongitude    sql.NullString `json:"max_longitude"`
+	CenterLongitude interface{}    `json:"center_longitude"`
+	CenterLatitude  interface{}    `json:"center_latitude"`
+	MaxRows         int32          `json:"max_rows"`
+}
+
+func (q *Queries) ListMonstersNearby(ctx context.Context, arg ListMonstersNearbyParams) ([]Monster, error) {
+	rows, err := q.db.QueryContext(ctx, listMonstersNearby,
+		arg.MinLatitude,
+		arg.MaxLatitude,
+		arg.MinLongitude,
+		arg.MaxLongitude,
+		arg.CenterLongitude,
+		arg.CenterLatitude,
+		arg.MaxRows,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Monster{}
+	for rows.Next() {
+		var i Monster
+		if err := rows.Scan(
+			&i.Monsterid,
+			&i.Userid,
+			&i.Nickname,
+			&i.Originaltrashbinimageurl,
+			&i.Generatedmonsterimageurl,
+			&i.Latitude,
+			&i.Longitude,
+			&i.Createdat,
+			&i.Updatedat,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMonstersNearbyByTrashCategory = `-- name: ListMonstersNearbyByTrashCategory :many
+SELECT m.monsterid, m.userid, m.nickname, m.originaltrashbinimageurl, m.generatedmonsterimageurl, m.latitude, m.longitude, m.createdat, m.updatedat FROM Monster m
+JOIN MonsterTrashCategory c ON c.MonsterId = m.MonsterId
+WHERE m.Latitude BETWEEN ? AND ?
+  AND m.Longitude BETWEEN ? AND ?
+  AND c.TrashCategory = ?
+ORDER BY ST_Distance_Sphere(POINT(m.Longitude, m.Latitude), POINT(?, ?))
+LIMIT ?
+`
+
+type ListMonstersNearbyByTrashCategoryParams struct {
+	MinLatitude     sql.NullString `json:"min_latitude"`
+	MaxLatitude     sql.NullString `json:"max_latitude"`
+	MinLongitude    sql.NullString `json:"min_longitude"`
+	MaxLongitude    sql.NullString `json:"max_longitude"`
+	TrashCategory   uint8          `json:"trash_category"`
+	CenterLongitude interface{}    `json:"center_longitude"`
+	CenterLatitude  interface{}    `json:"center_latitude"`
+	MaxRows         int32          `json:"max_rows"`
+}
+
+func (q *Queries) ListMonstersNearbyByTrashCategory(ctx context.Context, arg ListMonstersNearbyByTrashCategoryParams) ([]Monster, error) {
+	rows, err := q.db.QueryContext(ctx, listMonstersNearbyByTrashCategory,
+		arg.MinLatitude,
+		arg.MaxLatitude,
+		arg.MinLongitude,
+		arg.MaxLongitude,
+		arg.TrashCategory,
+		arg.CenterLongitude,
+		arg.CenterLatitude,
+		arg.MaxRows,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []ListMonstersWithAttributeRow{}
+	items := []Monster{}
 	for rows.Next() {
-		var i ListMonstersWithAttributeRow
+		var i Monster
 		if err := rows.Scan(
 			&i.Monsterid,
+			&i.Userid,
 			&i.Nickname,
 			&i.Originaltrashbinimageurl,
 			&i.Generatedmonsterimageurl,
@@ -200,8 +414,6 @@ func (q *Queries) ListMonstersWithAttribute(ctx context.Context) ([]ListMonsters
 			&i.Longitude,
 			&i.Createdat,
 			&i.Updatedat,
-			&i.Attributename,
-			&i.Colorcode,
 		); err != nil {
 			return nil, err
 		}
@@ -241,3 +453,18 @@ func (q *Queries) UpdateMonster(ctx context.Context, arg UpdateMonsterParams) (s
 		arg.Monsterid,
 	)
 }
+
+const updateMonsterNickname = `-- name: UpdateMonsterNickname :execresult
+UPDATE Monster
+SET Nickname = ?
+WHERE MonsterId = ?
+`
+
+type UpdateMonsterNicknameParams struct {
+	Nickname  string `json:"nickname"`
+	Monsterid string `json:"monsterid"`
+}
+
+func (q *Queries) UpdateMonsterNickname(ctx context.Context, arg UpdateMonsterNicknameParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, updateMonsterNickname, arg.Nickname, arg.Monsterid)
+}
@@ -8,6 +8,7 @@ package mysql
 import (
 	"context"
 	"database/sql"
+	"strings"
 )
 
 const createMonsterAttribute = `-- name: CreateMonsterAttribute :execresult
@@ -53,6 +54,51 @@ func (q *Queries) GetMonsterAttribute(ctx context.Context, monsterid string) (Mo
 	return i, err
 }
 
+const listMonsterAttributesByMonsterIds = `-- name: ListMonsterAttributesByMonsterIds :many
+SELECT monsterid, attributename, colorcode, createdat, updatedat FROM MonsterAttribute
+WHERE MonsterId IN (/*SLICE:monster_ids*/?)
+ORDER BY MonsterId
+`
+
+func (q *Queries) ListMonsterAttributesByMonsterIds(ctx context.Context, monsterIds []string) ([]Monsterattribute, error) {
+	query := listMonsterAttributesByMonsterIds
+	var queryParams []interface{}
+	if len(monsterIds) > 0 {
+		for _, v := range monsterIds {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:monster_ids*/?", strings.Repeat(",?", len(monsterIds))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:monster_ids*/?", "NULL", 1)
+	}
+	rows, err := q.db.QueryContext(ctx, query, queryParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Monsterattribute{}
+	for rows.Next() {
+		var i Monsterattribute
+		if err := rows.Scan(
+			&i.Monsterid,
+			&i.Attributename,
+			&i.Colorcode,
+			&i.Createdat,
+			&i.Updatedat,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateMonsterAttribute = `-- name: UpdateMonsterAttribute :execresult
 UPDATE MonsterAttribute
 SET AttributeName = ?, ColorCode = ?
@@ -8,6 +8,7 @@ package mysql
 import (
 	"context"
 	"database/sql"
+	"strings"
 )
 
 const createMonsterTrashCategory = `-- name: CreateMonsterTrashCategory :execresult
@@ -98,6 +99,51 @@ func (q *Queries) ListMonsterTrashCategories(ctx context.Context, monsterid stri
 	return items, nil
 }
 
+const listMonsterTrashCategoriesByMonsterIds = `-- name: ListMonsterTrashCategoriesByMonsterIds :many
+SELECT monstertrashcategoryid, monsterid, trashcategory, createdat, updatedat FROM MonsterTrashCategory
+WHERE MonsterId IN (/*SLICE:monster_ids*/?)
+ORDER BY MonsterId, TrashCategory
+`
+
+func (q *Queries) ListMonsterTrashCategoriesByMonsterIds(ctx context.Context, monsterIds []string) ([]Monstertrashcategory, error) {
+	query := listMonsterTrashCategoriesByMonsterIds
+	var queryParams []interface{}
+	if len(monsterIds) > 0 {
+		for _, v := range monsterIds {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:monster_ids*/?", strings.Repeat(",?", len(monsterIds))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:monster_ids*/?", "NULL", 1)
+	}
+	rows, err := q.db.QueryContext(ctx, query, queryParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Monstertrashcategory{}
+	for rows.Next() {
+		var i Monstertrashcategory
+		if err := rows.Scan(
+			&i.Monstertrashcategoryid,
+			&i.Monsterid,
+			&i.Trashcategory,
+			&i.Createdat,
+			&i.Updatedat,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listMonstersByTrashCategory = `-- name: ListMonstersByTrashCategory :many
 SELECT monstertrashcategoryid, monsterid, trashcategory, createdat, updatedat FROM MonsterTrashCategory
 WHERE TrashCategory = ?
@@ -7,31 +7,69 @@ package mysql
 import (
 	"context"
 	"database/sql"
+	"time"
 )
 
 type Querier interface {
+	ClaimMonsterGenerationJob(ctx context.Context, arg ClaimMonsterGenerationJobParams) (sql.Result, error)
+	CompleteMonsterGenerationJob(ctx context.Context, arg CompleteMonsterGenerationJobParams) (sql.Result, error)
+	CountActiveMonsterGenerationJobs(ctx context.Context, monsterid string) (int64, error)
+	CreateApiKey(ctx context.Context, arg CreateApiKeyParams) (sql.Result, error)
 	CreateMonster(ctx context.Context, arg CreateMonsterParams) (sql.Result, error)
 	CreateMonsterAttribute(ctx context.Context, arg CreateMonsterAttributeParams) (sql.Result, error)
+	CreateMonsterGenerationJob(ctx context.Context, arg CreateMonsterGenerationJobParams) (sql.Result, error)
 	CreateMonsterTrashCategory(ctx context.Context, arg CreateMonsterTrashCategoryParams) (sql.Result, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (sql.Result, error)
 	DeleteMonster(ctx context.Context, monsterid string) error
 	DeleteMonsterAttribute(ctx context.Context, monsterid string) error
+	DeleteMonsterGenerationJobsByMonsterId(ctx context.Context, monsterid string) error
+	DeleteMonsterProfile(ctx context.Context, monsterid string) error
+	DeleteMonsterStats(ctx context.Context, monsterid string) error
+	DeleteMonsterTrashAnalysis(ctx context.Context, monsterid string) error
 	DeleteMonsterTrashCategoriesByMonsterId(ctx context.Context, monsterid string) error
 	DeleteMonsterTrashCategory(ctx context.Context, monstertrashcategoryid string) error
 	DeleteUser(ctx context.Context, userid string) error
+	FailExpiredMonsterGenerationJob(ctx context.Context, arg FailExpiredMonsterGenerationJobParams) (sql.Result, error)
+	FailMonsterGenerationJob(ctx context.Context, arg FailMonsterGenerationJobParams) (sql.Result, error)
+	GetApiKeyByHash(ctx context.Context, keyhash string) (Apikey, error)
 	GetMonster(ctx context.Context, monsterid string) (Monster, error)
 	GetMonsterAttribute(ctx context.Context, monsterid string) (Monsterattribute, error)
-	GetMonsterDetail(ctx context.Context, monsterid string) (GetMonsterDetailRow, error)
+	GetMonsterGenerationJob(ctx context.Context, jobid string) (Monstergenerationjob, error)
+	GetMonsterGenerationJobStatus(ctx context.Context, jobid string) (GetMonsterGenerationJobStatusRow, error)
+	GetMonsterProfile(ctx context.Context, monsterid string) (Monsterprofile, error)
+	GetMonsterStats(ctx context.Context, monsterid string) (Monsterstat, error)
+	GetMonsterTrashAnalysis(ctx context.Context, monsterid string) (Monstertrashanalysis, error)
 	GetMonsterTrashCategory(ctx context.Context, monstertrashcategoryid string) (Monstertrashcategory, error)
 	GetUser(ctx context.Context, userid string) (User, error)
+	HeartbeatMonsterGenerationJob(ctx context.Context, arg HeartbeatMonsterGenerationJobParams) (sql.Result, error)
+	ListApiKeysByUserId(ctx context.Context, userid string) ([]Apikey, error)
+	ListExpiredMonsterGenerationJobs(ctx context.Context, arg ListExpiredMonsterGenerationJobsParams) ([]ListExpiredMonsterGenerationJobsRow, error)
+	ListIncompleteMonsters(ctx context.Context, createdat time.Time) ([]Monster, error)
+	ListMonsterAttributesByMonsterIds(ctx context.Context, monsterIds []string) ([]Monsterattribute, error)
+	ListMonsterProfilesByMonsterIds(ctx context.Context, monsterIds []string) ([]Monsterprofile, error)
+	ListMonsterStatsByMonsterIds(ctx context.Context, monsterIds []string) ([]Monsterstat, error)
 	ListMonsterTrashCategories(ctx context.Context, monsterid string) ([]Monstertrashcategory, error)
+	ListMonsterTrashCategoriesByMonsterIds(ctx context.Context, monsterIds []string) ([]Monstertrashcategory, error)
 	ListMonsters(ctx context.Context) ([]Monster, error)
 	ListMonstersByTrashCategory(ctx context.Context, trashcategory uint8) ([]Monstertrashcategory, error)
-	ListMonstersWithAttribute(ctx context.Context) ([]ListMonstersWithAttributeRow, error)
+	ListMonstersByUserId(ctx context.Context, userid sql.NullString) ([]Monster, error)
+	ListMonstersInBounds(ctx context.Context, arg ListMonstersInBoundsParams) ([]Monster, error)
+	ListMonstersInBoundsByTrashCategory(ctx context.Context, arg ListMonstersInBoundsByTrashCategoryParams) ([]Monster, error)
+	ListMonstersNearby(ctx context.Context, arg ListMonstersNearbyParams) ([]Monster, error)
+	ListMonstersNearbyByTrashCategory(ctx context.Context, arg ListMonstersNearbyByTrashCategoryParams) ([]Monster, error)
+	ListRunnableMonsterGenerationJobIds(ctx context.Context, arg ListRunnableMonsterGenerationJobIdsParams) ([]string, error)
 	ListUsers(ctx context.Context) ([]User, error)
+	RetryMonsterGenerationJob(ctx context.Context, arg RetryMonsterGenerationJobParams) (sql.Result, error)
+	RevokeApiKey(ctx context.Context, apikeyid string) error
 	UpdateMonster(ctx context.Context, arg UpdateMonsterParams) (sql.Result, error)
 	UpdateMonsterAttribute(ctx context.Context, arg UpdateMonsterAttributeParams) (sql.Result, error)
+	UpdateMonsterGenerationJobGenerationInput(ctx context.Context, arg UpdateMonsterGenerationJobGenerationInputParams) (sql.Result, error)
+	UpdateMonsterGenerationJobStatus(ctx context.Context, arg UpdateMonsterGenerationJobStatusParams) (sql.Result, error)
+	UpdateMonsterNickname(ctx context.Context, arg UpdateMonsterNicknameParams) (sql.Result, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (sql.Result, error)
+	UpsertMonsterProfile(ctx context.Context, arg UpsertMonsterProfileParams) error
+	UpsertMonsterStats(ctx context.Context, arg UpsertMonsterStatsParams) error
+	UpsertMonsterTrashAnalysis(ctx context.Context, arg UpsertMonsterTrashAnalysisParams) error
 }
 
 var _ Querier = (*Queries)(nil)
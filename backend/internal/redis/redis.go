@@ -0,0 +1,60 @@
+package redis
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"sync"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/kinpatsu-everyone/backend-template/config"
+)
+
+// Client はRedis（互換）のクライアントです
+// go-redis の接続プールを使い、複数のリクエストから並行してコマンドを実行できます
+type Client struct {
+	rdb *goredis.Client
+
+	// scripts はLuaスクリプトごとの *goredis.Script です（SHA1の計算を毎回行わないようにキャッシュします）
+	scripts sync.Map
+}
+
+// NewClient は新しいクライアントを作成します（接続は最初のコマンドの実行時に行います）
+func NewClient(cfg config.CacheConfig) *Client {
+	return &Client{rdb: goredis.NewClient(options(cfg))}
+}
+
+// options は設定から go-redis の接続オプションを作成します
+func options(cfg config.CacheConfig) *goredis.Options {
+	opts := &goredis.Options{
+		Addr:     cfg.Addr,
+		Username: cfg.Username,
+		Password: cfg.Password,
+		DB:       cfg.DB,
+		PoolSize: cfg.PoolSize,
+	}
+	if cfg.TLSEnabled {
+		opts.TLSConfig = &tls.Config{InsecureSkipVerify: cfg.TLSInsecure}
+	}
+	return opts
+}
+
+// Eval はLuaスクリプトを実行します（EVALSHAで実行し、未登録の場合はEVALで登録します）
+// 応答は string / int64 / []any / nil（Null）のいずれかです
+func (c *Client) Eval(ctx context.Context, script string, keys []string, args ...any) (any, error) {
+	s, ok := c.scripts.Load(script)
+	if !ok {
+		s, _ = c.scripts.LoadOrStore(script, goredis.NewScript(script))
+	}
+	reply, err := s.(*goredis.Script).Run(ctx, c.rdb, keys, args...).Result()
+	if errors.Is(err, goredis.Nil) {
+		return nil, nil
+	}
+	return reply, err
+}
+
+// Close は接続プールを閉じます
+func (c *Client) Close() error {
+	return c.rdb.Close()
+}
@@ -0,0 +1,50 @@
+package redis
+
+import (
+	"testing"
+
+	"github.com/kinpatsu-everyone/backend-template/config"
+)
+
+func TestOptions(t *testing.T) {
+	tests := []struct {
+		name         string
+		cfg          config.CacheConfig
+		wantTLS      bool
+		wantInsecure bool
+	}{
+		{
+			name: "TLSなし",
+			cfg:  config.CacheConfig{Addr: "127.0.0.1:6379", Username: "user", Password: "pass", DB: 2, PoolSize: 20},
+		},
+		{
+			name:    "TLSあり",
+			cfg:     config.CacheConfig{Addr: "redis.example.com:6380", TLSEnabled: true},
+			wantTLS: true,
+		},
+		{
+			name:         "証明書を検証しないTLS",
+			cfg:          config.CacheConfig{Addr: "redis.example.com:6380", TLSEnabled: true, TLSInsecure: true},
+			wantTLS:      true,
+			wantInsecure: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := options(tt.cfg)
+			if opts.Addr != tt.cfg.Addr || opts.Username != tt.cfg.Username || opts.Password != tt.cfg.Password {
+				t.Errorf("addr/username/password = %s/%s/%s, want %s/%s/%s", opts.Addr, opts.Username, opts.Password, tt.cfg.Addr, tt.cfg.Username, tt.cfg.Password)
+			}
+			if opts.DB != tt.cfg.DB || opts.PoolSize != tt.cfg.PoolSize {
+				t.Errorf("db/pool size = %d/%d, want %d/%d", opts.DB, opts.PoolSize, tt.cfg.DB, tt.cfg.PoolSize)
+			}
+			if got := opts.TLSConfig != nil; got != tt.wantTLS {
+				t.Fatalf("tls = %v, want %v", got, tt.wantTLS)
+			}
+			if tt.wantTLS && opts.TLSConfig.InsecureSkipVerify != tt.wantInsecure {
+				t.Errorf("insecure skip verify = %v, want %v", opts.TLSConfig.InsecureSkipVerify, tt.wantInsecure)
+			}
+		})
+	}
+}
@@ -0,0 +1,246 @@
+package outorouter
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// 認証に関するエラーコードです
+const (
+	ErrorCodeUnauthorized = "UNAUTHORIZED"
+	ErrorCodeInvalidToken = "INVALID_TOKEN"
+	ErrorCodeForbidden    = "FORBIDDEN"
+)
+
+var (
+	// ErrUnsupportedToken はVerifierが扱わない形式のトークンであることを表します（次のVerifierを試します）
+	ErrUnsupportedToken = errors.New("outorouter: unsupported token")
+	// ErrInvalidToken はトークンが不正・期限切れ・無効化済みであることを表します（401を返します）
+	ErrInvalidToken = errors.New("outorouter: invalid token")
+)
+
+// AuthRequirement はエンドポイントの認証の要否です
+type AuthRequirement string
+
+const (
+	// AuthNone は認証を行いません（デフォルト）
+	AuthNone AuthRequirement = "none"
+	// AuthOptional はトークンがあれば検証し、なければ未認証のまま処理します
+	AuthOptional AuthRequirement = "optional"
+	// AuthRequired はトークンが必須です（ない場合は401を返します）
+	AuthRequired AuthRequirement = "required"
+)
+
+func (a AuthRequirement) String() string {
+	if a == "" {
+		return string(AuthNone)
+	}
+	return string(a)
+}
+
+// Principal は認証されたリクエストの主体です
+type Principal struct {
+	// Subject はユーザーIDです（JWTの sub / APIキーの所有者）
+	Subject string
+	// Method は認証方式です（"jwt" / "api_key"）
+	Method string
+	// Scopes は許可されたスコープです
+	Scopes []string
+	// Claims はJWTのクレームです（APIキーの場合は空）
+	Claims map[string]any
+}
+
+// HasScope はスコープが許可されているかを返します
+func (p *Principal) HasScope(scope string) bool {
+	return slices.Contains(p.Scopes, scope)
+}
+
+// Verifier はトークンを検証して Principal を返します
+// 扱わない形式のトークンには ErrUnsupportedToken、不正なトークンには ErrInvalidToken をラップしたエラーを返します
+type Verifier interface {
+	Verify(ctx context.Context, token string) (*Principal, error)
+}
+
+// Verifiers は登録した順にトークンを検証します
+type Verifiers []Verifier
+
+func (vs Verifiers) Verify(ctx context.Context, token string) (*Principal, error) {
+	for _, v := range vs {
+		p, err := v.Verify(ctx, token)
+		if errors.Is(err, ErrUnsupportedToken) {
+			continue
+		}
+		return p, err
+	}
+	return nil, fmt.Errorf("no verifier accepts the token: %w", ErrInvalidToken)
+}
+
+// WithVerifiers は Auth を宣言したエンドポイントで使う Verifier を設定します
+func WithVerifiers(vs ...Verifier) Option {
+	return func(r *Router) {
+		r.verifier = Verifiers(vs)
+	}
+}
+
+type ctxKeyPrincipal struct{}
+
+// GetPrincipalFromContext は認証された Principal を取得します（未認証の場合は nil, false）
+func GetPrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(ctxKeyPrincipal{}).(*Principal)
+	return p, ok && p != nil
+}
+
+// WithPrincipal は Principal をコンテキストにセットします（テスト用）
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, ctxKeyPrincipal{}, p)
+}
+
+// AuthConfig は AuthMiddleware の設定です
+type AuthConfig struct {
+	Requirement AuthRequirement
+	// Scopes は Principal に必要なスコープです（不足している場合は403を返します、Requirement が AuthRequired の場合のみ指定できます）
+	Scopes   []string
+	Verifier Verifier
+}
+
+// AuthMiddleware は Authorization: Bearer ヘッダー（または X-API-Key ヘッダー）のトークンを検証し、
+// Principal をコンテキストにセットするミドルウェアを生成します
+func AuthMiddleware(config AuthConfig) MiddlewareFunc {
+	if len(config.Scopes) > 0 && config.Requirement != AuthRequired {
+		panic("outorouter: AuthMiddleware: Scopes requires Requirement to be AuthRequired")
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if config.Requirement == AuthNone || config.Requirement == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			token := bearerToken(r)
+			if token == "" {
+				if config.Requirement == AuthOptional {
+					next.ServeHTTP(w, r)
+					return
+				}
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				WriteError(w, r, UnauthorizedError(ErrorCodeUnauthorized, "認証が必要です"))
+				return
+			}
+
+			var principal *Principal
+			err := fmt.Errorf("no verifier is configured: %w", ErrInvalidToken)
+			if config.Verifier != nil {
+				principal, err = config.Verifier.Verify(r.Context(), token)
+			}
+			switch {
+			case errors.Is(err, ErrInvalidToken) || errors.Is(err, ErrUnsupportedToken):
+				w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+				WriteError(w, r, UnauthorizedError(ErrorCodeInvalidToken, "認証トークンが不正です"))
+				return
+			case err != nil:
+				WriteError(w, r, internalError())
+				return
+			}
+
+			for _, scope := range config.Scopes {
+				if !principal.HasScope(scope) {
+					WriteError(w, r, ForbiddenError(ErrorCodeForbidden, fmt.Sprintf("スコープ %s が必要です", scope)))
+					return
+				}
+			}
+
+			next.ServeHTTP(w, r.WithContext(WithPrincipal(r.Context(), principal)))
+		})
+	}
+}
+
+// webSocketTokenProtocol はWebSocketのハンドシェイクでトークンを渡すためのサブプロトコル名です
+// ブラウザのWebSocket APIはヘッダーを指定できないため、Sec-WebSocket-Protocol: bearer, <token> でトークンを受け付けます
+const webSocketTokenProtocol = "bearer"
+
+// bearerToken はリクエストから認証トークンを取り出します
+func bearerToken(r *http.Request) string {
+	if scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " "); ok && strings.EqualFold(scheme, "Bearer") {
+		return strings.TrimSpace(token)
+	}
+	if token := strings.TrimSpace(r.Header.Get("X-API-Key")); token != "" {
+		return token
+	}
+	return webSocketProtocolToken(r)
+}
+
+// webSocketProtocolToken は Sec-WebSocket-Protocol ヘッダーの bearer の次に指定されたトークンを返します
+func webSocketProtocolToken(r *http.Request) string {
+	protocols := strings.Split(r.Header.Get("Sec-WebSocket-Protocol"), ",")
+	if len(protocols) != 2 || strings.TrimSpace(protocols[0]) != webSocketTokenProtocol {
+		return ""
+	}
+	return strings.TrimSpace(protocols[1])
+}
+
+// endpointMiddlewares は Auth の宣言に応じて AuthMiddleware をエンドポイントのミドルウェアの先頭に加えます
+func (r *Router) endpointMiddlewares(name string, auth AuthRequirement, scopes []string, mws []MiddlewareFunc) []MiddlewareFunc {
+	switch auth {
+	case "", AuthNone, AuthOptional, AuthRequired:
+	default:
+		panic(fmt.Sprintf("outorouter: %s: unknown auth requirement %q", name, auth))
+	}
+	// AuthOptional の場合はトークンのないリクエストがスコープを確認せずに通過するため、スコープは AuthRequired でのみ宣言できる
+	if len(scopes) > 0 && auth != AuthRequired {
+		panic(fmt.Sprintf("outorouter: %s: Scopes requires Auth to be AuthRequired", name))
+	}
+	if auth == "" || auth == AuthNone {
+		return mws
+	}
+
+	authMw := AuthMiddleware(AuthConfig{Requirement: auth, Scopes: scopes, Verifier: r.verifier})
+	return append([]MiddlewareFunc{authMw}, mws...)
+}
+
+// authErrors は認証を宣言したエンドポイントが返しうるエラーです（メタデータ用）
+func authErrors(auth AuthRequirement, scopes []string) []HTTPError {
+	if auth == "" || auth == AuthNone {
+		return nil
+	}
+
+	errs := []HTTPError{UnauthorizedError(ErrorCodeInvalidToken, "認証トークンが不正です")}
+	if auth == AuthRequired {
+		errs = append(errs, UnauthorizedError(ErrorCodeUnauthorized, "認証が必要です"))
+	}
+	if len(scopes) > 0 {
+		errs = append(errs, ForbiddenError(ErrorCodeForbidden, "スコープが不足しています"))
+	}
+	return errs
+}
+
+// APIKeyStore はハッシュ化したAPIキーから Principal を取得します
+// 見つからない・無効化済みの場合は ErrInvalidToken をラップしたエラーを返します
+type APIKeyStore interface {
+	LookupAPIKey(ctx context.Context, keyHash string) (*Principal, error)
+}
+
+// APIKeyVerifier は保存済みのAPIキー（不透明なトークン）を検証します
+type APIKeyVerifier struct {
+	Store APIKeyStore
+	// Prefix はAPIキーの接頭辞です（一致しないトークンは他のVerifierに任せます）
+	Prefix string
+}
+
+func (v APIKeyVerifier) Verify(ctx context.Context, token string) (*Principal, error) {
+	if !strings.HasPrefix(token, v.Prefix) {
+		return nil, ErrUnsupportedToken
+	}
+	return v.Store.LookupAPIKey(ctx, HashAPIKey(token))
+}
+
+// HashAPIKey はAPIキーを保存用のハッシュ（SHA-256の16進数）に変換します
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
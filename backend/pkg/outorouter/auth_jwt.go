@@ -0,0 +1,207 @@
+package outorouter
+
+import (
+	"context"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+// JWTVerifier はHS256・RS256で署名されたJWTを検証します
+type JWTVerifier struct {
+	// HMACSecret はHS256の署名鍵です（空の場合はHS256のトークンを受け付けません）
+	HMACSecret []byte
+	// RSAKeys はRS256の公開鍵です（kid → 公開鍵、LoadJWKSFile で読み込みます）
+	RSAKeys map[string]*rsa.PublicKey
+	// Issuer・Audience が空でない場合は iss・aud クレームと一致する必要があります
+	Issuer   string
+	Audience string
+	// Leeway は exp・nbf の検証で許容する時計のずれです
+	Leeway time.Duration
+	// AllowMissingExp がtrueの場合は exp クレームのないトークンを受け付けます（デフォルトは期限のないトークンを拒否します）
+	AllowMissingExp bool
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+func (v JWTVerifier) Verify(ctx context.Context, token string) (*Principal, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrUnsupportedToken
+	}
+
+	var header jwtHeader
+	if err := decodeJWTSegment(parts[0], &header); err != nil {
+		return nil, err
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode jwt signature: %w", ErrInvalidToken)
+	}
+	if err := v.verifySignature(header, parts[0]+"."+parts[1], signature); err != nil {
+		return nil, err
+	}
+
+	var claims map[string]any
+	if err := decodeJWTSegment(parts[1], &claims); err != nil {
+		return nil, err
+	}
+	if err := v.verifyClaims(ctx, claims); err != nil {
+		return nil, err
+	}
+
+	return &Principal{
+		Subject: claims["sub"].(string),
+		Method:  "jwt",
+		Scopes:  jwtScopes(claims),
+		Claims:  claims,
+	}, nil
+}
+
+func (v JWTVerifier) verifySignature(header jwtHeader, signingInput string, signature []byte) error {
+	switch header.Alg {
+	case "HS256":
+		if len(v.HMACSecret) == 0 {
+			return fmt.Errorf("hs256 is not configured: %w", ErrInvalidToken)
+		}
+		mac := hmac.New(sha256.New, v.HMACSecret)
+		mac.Write([]byte(signingInput))
+		if !hmac.Equal(signature, mac.Sum(nil)) {
+			return fmt.Errorf("jwt signature mismatch: %w", ErrInvalidToken)
+		}
+		return nil
+	case "RS256":
+		key, ok := v.RSAKeys[header.Kid]
+		if !ok && header.Kid == "" && len(v.RSAKeys) == 1 {
+			for _, k := range v.RSAKeys {
+				key, ok = k, true
+			}
+		}
+		if !ok {
+			return fmt.Errorf("unknown jwt key id %q: %w", header.Kid, ErrInvalidToken)
+		}
+		digest := sha256.Sum256([]byte(signingInput))
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+			return fmt.Errorf("jwt signature mismatch: %w", ErrInvalidToken)
+		}
+		return nil
+	default:
+		// "none" を含むその他のアルゴリズムは受け付けない
+		return fmt.Errorf("unsupported jwt algorithm %q: %w", header.Alg, ErrInvalidToken)
+	}
+}
+
+func (v JWTVerifier) verifyClaims(ctx context.Context, claims map[string]any) error {
+	if sub, _ := claims["sub"].(string); sub == "" {
+		return fmt.Errorf("jwt has no sub claim: %w", ErrInvalidToken)
+	}
+
+	now := nowFromContext(ctx)
+	exp, ok := claims["exp"].(float64)
+	switch {
+	case !ok && (claims["exp"] != nil || !v.AllowMissingExp):
+		return fmt.Errorf("jwt has no valid exp claim: %w", ErrInvalidToken)
+	case ok && now.After(time.Unix(int64(exp), 0).Add(v.Leeway)):
+		return fmt.Errorf("jwt is expired: %w", ErrInvalidToken)
+	}
+	if nbf, ok := claims["nbf"].(float64); ok && now.Add(v.Leeway).Before(time.Unix(int64(nbf), 0)) {
+		return fmt.Errorf("jwt is not valid yet: %w", ErrInvalidToken)
+	}
+
+	if v.Issuer != "" && claims["iss"] != v.Issuer {
+		return fmt.Errorf("jwt issuer mismatch: %w", ErrInvalidToken)
+	}
+	if v.Audience != "" && !slices.Contains(jwtStrings(claims["aud"]), v.Audience) {
+		return fmt.Errorf("jwt audience mismatch: %w", ErrInvalidToken)
+	}
+	return nil
+}
+
+func decodeJWTSegment(segment string, v any) error {
+	raw, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return fmt.Errorf("failed to decode jwt segment: %w", ErrInvalidToken)
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return fmt.Errorf("failed to parse jwt segment: %w", ErrInvalidToken)
+	}
+	return nil
+}
+
+// jwtScopes は scope（スペース区切り）または scopes（配列）クレームからスコープを取り出します
+func jwtScopes(claims map[string]any) []string {
+	if scope, ok := claims["scope"].(string); ok {
+		return strings.Fields(scope)
+	}
+	return jwtStrings(claims["scopes"])
+}
+
+// jwtStrings は文字列または文字列の配列のクレームを []string に変換します
+func jwtStrings(v any) []string {
+	switch v := v.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+// LoadJWKSFile はJWKS形式のファイルからRS256の公開鍵を読み込みます（kid → 公開鍵）
+// RSA以外の鍵は無視します
+func LoadJWKSFile(path string) (map[string]*rsa.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read jwks file: %w", err)
+	}
+
+	var jwks struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &jwks); err != nil {
+		return nil, fmt.Errorf("failed to parse jwks file: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey)
+	for _, k := range jwks.Keys {
+		if k.Kty != "RSA" {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode modulus of key %q: %w", k.Kid, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode exponent of key %q: %w", k.Kid, err)
+		}
+		keys[k.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+	return keys, nil
+}
@@ -0,0 +1,118 @@
+package outorouter
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+type ctxKeyClientIP struct{}
+
+// GetClientIPFromContext は ClientIPMiddleware が判定したクライアントのIPアドレスを返します
+func GetClientIPFromContext(ctx context.Context) string {
+	if v, ok := ctx.Value(ctxKeyClientIP{}).(string); ok {
+		return v
+	}
+	return ""
+}
+
+// ParseTrustedProxies は信頼するプロキシのIPアドレスまたはCIDR（例: "10.0.0.0/8"）を解析します
+func ParseTrustedProxies(values []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(values))
+	for _, v := range values {
+		if strings.Contains(v, "/") {
+			prefix, err := netip.ParsePrefix(v)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse trusted proxy %q: %w", v, err)
+			}
+			prefixes = append(prefixes, prefix.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse trusted proxy %q: %w", v, err)
+		}
+		prefixes = append(prefixes, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+	}
+	return prefixes, nil
+}
+
+// ClientIPMiddleware はクライアントのIPアドレスを判定し、context にセットします
+// X-Forwarded-For は接続元が trustedProxies に含まれる場合のみ参照し、末尾から信頼するプロキシを除いた最初のアドレスを使います
+// trustedProxies が空の場合は常に接続元のアドレス（RemoteAddr）を使います
+func ClientIPMiddleware(trustedProxies []netip.Prefix) MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := context.WithValue(r.Context(), ctxKeyClientIP{}, resolveClientIP(r, trustedProxies))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// clientIP はクライアントのIPアドレスを返します
+// ClientIPMiddleware が適用されていない場合は、偽装できる X-Forwarded-For を使わずに接続元のアドレスを返します
+func clientIP(r *http.Request) string {
+	if ip := GetClientIPFromContext(r.Context()); ip != "" {
+		return ip
+	}
+	return remoteIP(r)
+}
+
+// resolveClientIP は信頼するプロキシを経由したリクエストの X-Forwarded-For からクライアントのIPアドレスを求めます
+func resolveClientIP(r *http.Request, trustedProxies []netip.Prefix) string {
+	remote := remoteIP(r)
+	if !isTrustedProxy(remote, trustedProxies) {
+		return remote
+	}
+
+	var hops []string
+	for _, xff := range r.Header.Values("X-Forwarded-For") {
+		for _, hop := range strings.Split(xff, ",") {
+			if hop = strings.TrimSpace(hop); hop != "" {
+				hops = append(hops, hop)
+			}
+		}
+	}
+
+	// 末尾は直前のプロキシが付与した値のため、信頼するプロキシを除いた最初の値がクライアントのアドレス
+	client := remote
+	for i := len(hops) - 1; i >= 0; i-- {
+		addr, err := netip.ParseAddr(hops[i])
+		if err != nil {
+			// 解析できない値より前はクライアントが付与した値のため信頼しない
+			break
+		}
+		client = addr.Unmap().String()
+		if !isTrustedProxy(client, trustedProxies) {
+			break
+		}
+	}
+	return client
+}
+
+// isTrustedProxy はIPアドレスが信頼するプロキシに含まれるかどうかを返します
+func isTrustedProxy(ip string, trustedProxies []netip.Prefix) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range trustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// remoteIP は接続元のIPアドレスを返します
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
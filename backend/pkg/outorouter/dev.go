@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/kinpatsu-everyone/backend-template/pkg/outorouter/internal/generator"
 	"github.com/kinpatsu-everyone/backend-template/pkg/outorouter/internal/parser"
@@ -19,9 +20,57 @@ type TypeScriptClientConfig struct {
 	BaseURL string
 }
 
+// OpenAPIConfig はOpenAPIドキュメント生成の設定です。
+type OpenAPIConfig struct {
+	// Enabled はOpenAPIドキュメント生成を有効にするかどうか
+	Enabled bool
+	// OutputPath は生成されるドキュメントの出力パス（拡張子が .yaml/.yml の場合はYAMLで出力）
+	OutputPath string
+	// ServerURL はAPIのベースURL
+	ServerURL string
+}
+
+// DartClientConfig はDart(Flutter)クライアント生成の設定です。
+type DartClientConfig struct {
+	// Enabled はDartクライアント生成を有効にするかどうか
+	Enabled bool
+	// OutputPath は生成されるDartファイルの出力パス
+	OutputPath string
+	// BaseURL はAPIのベースURL
+	BaseURL string
+}
+
+// GoClientConfig はGoクライアント生成の設定です。
+type GoClientConfig struct {
+	// Enabled はGoクライアント生成を有効にするかどうか
+	Enabled bool
+	// OutputPath は生成されるGoファイルの出力パス
+	OutputPath string
+	// PackageName は生成されるGoファイルのパッケージ名
+	PackageName string
+}
+
+// E2ETestConfig はE2Eテストの雛形生成の設定です。
+type E2ETestConfig struct {
+	// Enabled はE2Eテストの雛形生成を有効にするかどうか
+	Enabled bool
+	// OutputPath は生成されるテストファイルの出力パス
+	OutputPath string
+	// PackageName は生成されるテストファイルのパッケージ名
+	PackageName string
+	// RouterImportPath は Build 関数を持つパッケージのインポートパス
+	RouterImportPath string
+	// RunHappyPath は正常系のケースをスキップせずに実行するかどうか
+	RunHappyPath bool
+}
+
 type DevConfig struct {
 	metadataFilePath       string
 	typeScriptClientConfig TypeScriptClientConfig
+	openAPIConfig          OpenAPIConfig
+	dartClientConfig       DartClientConfig
+	goClientConfig         GoClientConfig
+	e2eTestConfig          E2ETestConfig
 }
 
 func DefaultDevConfig() *DevConfig {
@@ -32,6 +81,26 @@ func DefaultDevConfig() *DevConfig {
 			OutputPath: ".api/client.ts",
 			BaseURL:    "http://localhost:8080",
 		},
+		openAPIConfig: OpenAPIConfig{
+			Enabled:    false,
+			OutputPath: ".api/openapi.json",
+			ServerURL:  "http://localhost:8080",
+		},
+		dartClientConfig: DartClientConfig{
+			Enabled:    false,
+			OutputPath: ".api/api_client.dart",
+			BaseURL:    "http://localhost:8080",
+		},
+		goClientConfig: GoClientConfig{
+			Enabled:     false,
+			OutputPath:  ".api/apiclient/client.go",
+			PackageName: "apiclient",
+		},
+		e2eTestConfig: E2ETestConfig{
+			Enabled:     false,
+			OutputPath:  "router/e2e_generated_test.go",
+			PackageName: "router_test",
+		},
 	}
 }
 
@@ -56,6 +125,65 @@ func WithTypeScriptClient(outputPath string, baseURL string) DevConfigOption {
 	}
 }
 
+// WithOpenAPI はOpenAPIドキュメント生成を有効にします。
+func WithOpenAPI(outputPath string, serverURL string) DevConfigOption {
+	return func(cfg *DevConfig) {
+		cfg.openAPIConfig.Enabled = true
+		if outputPath != "" {
+			cfg.openAPIConfig.OutputPath = outputPath
+		}
+		if serverURL != "" {
+			cfg.openAPIConfig.ServerURL = serverURL
+		}
+	}
+}
+
+// WithDartClient はDart(Flutter)クライアント生成を有効にします。
+func WithDartClient(outputPath string, baseURL string) DevConfigOption {
+	return func(cfg *DevConfig) {
+		cfg.dartClientConfig.Enabled = true
+		if outputPath != "" {
+			cfg.dartClientConfig.OutputPath = outputPath
+		}
+		if baseURL != "" {
+			cfg.dartClientConfig.BaseURL = baseURL
+		}
+	}
+}
+
+// WithGoClient はGoクライアント生成を有効にします。
+func WithGoClient(outputPath string, packageName string) DevConfigOption {
+	return func(cfg *DevConfig) {
+		cfg.goClientConfig.Enabled = true
+		if outputPath != "" {
+			cfg.goClientConfig.OutputPath = outputPath
+		}
+		if packageName != "" {
+			cfg.goClientConfig.PackageName = packageName
+		}
+	}
+}
+
+// WithE2ETest はE2Eテストの雛形生成を有効にします。
+func WithE2ETest(outputPath string, routerImportPath string) DevConfigOption {
+	return func(cfg *DevConfig) {
+		cfg.e2eTestConfig.Enabled = true
+		if outputPath != "" {
+			cfg.e2eTestConfig.OutputPath = outputPath
+		}
+		if routerImportPath != "" {
+			cfg.e2eTestConfig.RouterImportPath = routerImportPath
+		}
+	}
+}
+
+// WithE2ETestHappyPath は生成するE2Eテストの正常系のケースを実行するようにします。
+func WithE2ETestHappyPath() DevConfigOption {
+	return func(cfg *DevConfig) {
+		cfg.e2eTestConfig.RunHappyPath = true
+	}
+}
+
 func NewDevConfig(opts ...DevConfigOption) *DevConfig {
 	cfg := DefaultDevConfig()
 	for _, opt := range opts {
@@ -77,6 +205,34 @@ func (cfg *DevConfig) Run(router *Router) error {
 		}
 	}
 
+	// OpenAPIドキュメント生成
+	if cfg.openAPIConfig.Enabled {
+		if err := cfg.generateOpenAPI(); err != nil {
+			return fmt.Errorf("failed to generate OpenAPI document: %w", err)
+		}
+	}
+
+	// Dartクライアント生成
+	if cfg.dartClientConfig.Enabled {
+		if err := cfg.generateDartClient(); err != nil {
+			return fmt.Errorf("failed to generate Dart client: %w", err)
+		}
+	}
+
+	// Goクライアント生成
+	if cfg.goClientConfig.Enabled {
+		if err := cfg.generateGoClient(); err != nil {
+			return fmt.Errorf("failed to generate Go client: %w", err)
+		}
+	}
+
+	// E2Eテストの雛形生成
+	if cfg.e2eTestConfig.Enabled {
+		if err := cfg.generateE2ETest(); err != nil {
+			return fmt.Errorf("failed to generate E2E test: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -111,3 +267,116 @@ func (cfg *DevConfig) generateTypeScriptClient() error {
 
 	return nil
 }
+
+func (cfg *DevConfig) generateOpenAPI() error {
+	meta, err := parser.ParseFile(cfg.metadataFilePath)
+	if err != nil {
+		return fmt.Errorf("failed to parse metadata: %w", err)
+	}
+
+	format := generator.OpenAPIFormatJSON
+	switch strings.ToLower(filepath.Ext(cfg.openAPIConfig.OutputPath)) {
+	case ".yaml", ".yml":
+		format = generator.OpenAPIFormatYAML
+	}
+
+	gen := generator.New(generator.OpenAPIStrategy{
+		ServerURL: cfg.openAPIConfig.ServerURL,
+		Format:    format,
+	})
+
+	doc, err := gen.Generate(meta)
+	if err != nil {
+		return fmt.Errorf("failed to generate document: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(cfg.openAPIConfig.OutputPath), 0o755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	if err := os.WriteFile(cfg.openAPIConfig.OutputPath, []byte(doc), 0o644); err != nil {
+		return fmt.Errorf("failed to write OpenAPI document: %w", err)
+	}
+
+	return nil
+}
+
+func (cfg *DevConfig) generateDartClient() error {
+	meta, err := parser.ParseFile(cfg.metadataFilePath)
+	if err != nil {
+		return fmt.Errorf("failed to parse metadata: %w", err)
+	}
+
+	gen := generator.New(generator.DartClientStrategy{
+		BaseURL: cfg.dartClientConfig.BaseURL,
+	})
+
+	code, err := gen.Generate(meta)
+	if err != nil {
+		return fmt.Errorf("failed to generate code: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(cfg.dartClientConfig.OutputPath), 0o755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	if err := os.WriteFile(cfg.dartClientConfig.OutputPath, []byte(code), 0o644); err != nil {
+		return fmt.Errorf("failed to write Dart client: %w", err)
+	}
+
+	return nil
+}
+
+func (cfg *DevConfig) generateGoClient() error {
+	meta, err := parser.ParseFile(cfg.metadataFilePath)
+	if err != nil {
+		return fmt.Errorf("failed to parse metadata: %w", err)
+	}
+
+	gen := generator.New(generator.GoTypeStrategy{
+		PackageName: cfg.goClientConfig.PackageName,
+	})
+
+	code, err := gen.Generate(meta)
+	if err != nil {
+		return fmt.Errorf("failed to generate code: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(cfg.goClientConfig.OutputPath), 0o755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	if err := os.WriteFile(cfg.goClientConfig.OutputPath, []byte(code), 0o644); err != nil {
+		return fmt.Errorf("failed to write Go client: %w", err)
+	}
+
+	return nil
+}
+
+func (cfg *DevConfig) generateE2ETest() error {
+	meta, err := parser.ParseFile(cfg.metadataFilePath)
+	if err != nil {
+		return fmt.Errorf("failed to parse metadata: %w", err)
+	}
+
+	gen := generator.New(generator.E2ETestStrategy{
+		PackageName:      cfg.e2eTestConfig.PackageName,
+		RouterImportPath: cfg.e2eTestConfig.RouterImportPath,
+		RunHappyPath:     cfg.e2eTestConfig.RunHappyPath,
+	})
+
+	code, err := gen.Generate(meta)
+	if err != nil {
+		return fmt.Errorf("failed to generate code: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(cfg.e2eTestConfig.OutputPath), 0o755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	if err := os.WriteFile(cfg.e2eTestConfig.OutputPath, []byte(code), 0o644); err != nil {
+		return fmt.Errorf("failed to write E2E test: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package outorouter
+
+import (
+	"fmt"
+
+	"github.com/kinpatsu-everyone/backend-template/pkg/outorouter/internal/differ"
+	"github.com/kinpatsu-everyone/backend-template/pkg/outorouter/internal/parser"
+)
+
+// MetadataChange は2つのメタデータ間の1件の差分です
+type MetadataChange = differ.Change
+
+// 差分の重要度です
+const (
+	SeverityBreaking    = differ.SeverityBreaking
+	SeverityNonBreaking = differ.SeverityNonBreaking
+)
+
+// DiffMetadataFiles は2つのメタデータJSONを比較し、差分を返します
+// 同じバージョンのエンドポイントに対する変更を、既存のクライアントを壊すかどうかで分類します
+func DiffMetadataFiles(oldPath, newPath string) ([]MetadataChange, error) {
+	oldMeta, err := parser.ParseFile(oldPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", oldPath, err)
+	}
+
+	newMeta, err := parser.ParseFile(newPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", newPath, err)
+	}
+
+	return differ.Diff(oldMeta, newMeta), nil
+}
+
+// HasBreakingChanges は破壊的変更が含まれているかを返します
+func HasBreakingChanges(changes []MetadataChange) bool {
+	return differ.HasBreaking(changes)
+}
@@ -0,0 +1,74 @@
+package outorouter
+
+import (
+	"io"
+	"strings"
+
+	"github.com/kinpatsu-everyone/backend-template/pkg/outorouter/internal/linter"
+	"github.com/kinpatsu-everyone/backend-template/pkg/outorouter/internal/parser"
+)
+
+// LintIssue はメタデータのlintの指摘1件です
+type LintIssue = linter.Issue
+
+// LintConfig はlintのルールごとの設定です
+type LintConfig = linter.Config
+
+// LintFormat はlintレポートの出力形式です
+type LintFormat = linter.Format
+
+// lintレポートの出力形式です
+const (
+	LintFormatText  = linter.FormatText
+	LintFormatJSON  = linter.FormatJSON
+	LintFormatSARIF = linter.FormatSARIF
+)
+
+// LoadLintConfig はJSON形式のlint設定ファイルを読み込みます
+func LoadLintConfig(path string) (LintConfig, error) {
+	return linter.LoadConfig(path)
+}
+
+// LintRouter はルーターに登録されたエンドポイントのメタデータを検査します
+func LintRouter(router *Router, cfg LintConfig) ([]LintIssue, error) {
+	meta, err := routerMetadata(router)
+	if err != nil {
+		return nil, err
+	}
+	return linter.LintWithConfig(meta, cfg), nil
+}
+
+// LintMetadataFile はメタデータJSONファイルを検査します
+func LintMetadataFile(path string, cfg LintConfig) ([]LintIssue, error) {
+	meta, err := parser.ParseFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return linter.LintWithConfig(meta, cfg), nil
+}
+
+// WriteLintReport はlintの指摘を指定した形式で書き出します
+func WriteLintReport(w io.Writer, format LintFormat, issues []LintIssue) error {
+	return linter.WriteReport(w, format, issues)
+}
+
+// HasLintErrors はエラーレベルの指摘が含まれているかを返します
+func HasLintErrors(issues []LintIssue) bool {
+	return linter.HasErrors(issues)
+}
+
+// parseLintTag は lint:"ignore=OR010,undocumented-field" 形式のタグから抑制するルールを取り出します
+func parseLintTag(tag string) []string {
+	value, ok := strings.CutPrefix(tag, "ignore=")
+	if !ok {
+		return nil
+	}
+
+	var rules []string
+	for _, rule := range strings.Split(value, ",") {
+		if rule = strings.TrimSpace(rule); rule != "" {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 )
 
 type ExportedEndpoint struct {
@@ -13,6 +14,7 @@ type ExportedEndpoint struct {
 	Version      uint8  `json:"version"`
 	MethodName   string `json:"method_name"`
 	HTTPMethod   string `json:"http_method"`
+	Path         string `json:"path"`
 	RequestType  string `json:"request_type"`
 	ResponseType string `json:"response_type"`
 	Summary      string `json:"summary"`
@@ -22,6 +24,20 @@ type ExportedEndpoint struct {
 	// 型情報（コード生成用）
 	RequestTypeInfo  TypeInfo `json:"request_type_info"`
 	ResponseTypeInfo TypeInfo `json:"response_type_info"`
+
+	// エラーレスポンスの形式と返しうるエラーの一覧（コード生成用）
+	ErrorTypeInfo TypeInfo    `json:"error_type_info"`
+	Errors        []ErrorInfo `json:"errors"`
+
+	// 抑制するlintルール
+	LintIgnore []string `json:"lint_ignore,omitempty"`
+
+	// 認証の要否（"none" / "optional" / "required"）と必要なスコープ
+	Auth   string   `json:"auth"`
+	Scopes []string `json:"scopes,omitempty"`
+
+	// 適用されるミドルウェア（外側から順）
+	Middlewares []string `json:"middlewares"`
 }
 
 // ExportMetadataJSON はルーターのメタデータを JSON ファイルとしてエクスポートします。
@@ -56,6 +72,7 @@ func ExportMetadata(router *Router) (map[string]map[uint8][]ExportedEndpoint, er
 	registries := router.GetRegistries()
 
 	result := make(map[string]map[uint8][]ExportedEndpoint)
+	errorTypeInfo := extractTypeInfo(reflect.TypeOf(ErrorResponse{}))
 
 	for domain, versions := range registries {
 		// ドメインが存在しなかったら初期化
@@ -74,6 +91,7 @@ func ExportMetadata(router *Router) (map[string]map[uint8][]ExportedEndpoint, er
 						Version:          ep.Version,
 						MethodName:       ep.MethodName,
 						HTTPMethod:       ep.HTTPMethod,
+						Path:             ep.Path,
 						RequestType:      ep.RequestType,
 						ResponseType:     ep.ResponseType,
 						Summary:          ep.Summary,
@@ -81,6 +99,12 @@ func ExportMetadata(router *Router) (map[string]map[uint8][]ExportedEndpoint, er
 						Tags:             ep.Tags,
 						RequestTypeInfo:  ep.RequestTypeInfo,
 						ResponseTypeInfo: ep.ResponseTypeInfo,
+						ErrorTypeInfo:    errorTypeInfo,
+						Errors:           toErrorInfos(ep.Errors),
+						LintIgnore:       ep.LintIgnore,
+						Auth:             ep.Auth,
+						Scopes:           ep.Scopes,
+						Middlewares:      router.middlewareNames(ep),
 					})
 				}
 			}
@@ -0,0 +1,63 @@
+package outorouter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/kinpatsu-everyone/backend-template/pkg/outorouter/internal/generator"
+	"github.com/kinpatsu-everyone/backend-template/pkg/outorouter/internal/parser"
+)
+
+// OpenAPIDocumentPath はOpenAPIドキュメントを配信するパスです
+const OpenAPIDocumentPath = "/openapi.json"
+
+// OpenAPIHandler はルーターに登録されたエンドポイントからOpenAPIドキュメントを生成して返すハンドラーです
+// 開発環境でのみ登録することを想定しています
+func OpenAPIHandler(router *Router, serverURL string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			WriteError(w, req, NewHTTPError(http.StatusMethodNotAllowed, ErrorCodeMethodNotAllowed, "指定した形式のリクエストではありません"))
+			return
+		}
+
+		doc, err := GenerateOpenAPI(router, serverURL)
+		if err != nil {
+			router.writeHandlerError(w, req, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(doc))
+	})
+}
+
+// GenerateOpenAPI はルーターのメタデータからOpenAPIドキュメント（JSON）を生成します
+func GenerateOpenAPI(router *Router, serverURL string) (string, error) {
+	meta, err := routerMetadata(router)
+	if err != nil {
+		return "", err
+	}
+
+	return generator.New(generator.OpenAPIStrategy{ServerURL: serverURL}).Generate(meta)
+}
+
+// routerMetadata はルーターのメタデータをファイルを経由せずに中間表現に変換します
+func routerMetadata(router *Router) (*parser.Metadata, error) {
+	data, err := ExportMetadata(router)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export metadata: %w", err)
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+
+	meta, err := parser.Parse(bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse metadata: %w", err)
+	}
+	return meta, nil
+}
@@ -1,5 +1,28 @@
 package outorouter
 
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// フレームワークが返す共通のエラーコードです
+const (
+	ErrorCodeNotFound             = "NOT_FOUND"
+	ErrorCodeMethodNotAllowed     = "METHOD_NOT_ALLOWED"
+	ErrorCodeUnsupportedMediaType = "UNSUPPORTED_MEDIA_TYPE"
+	ErrorCodeRequestTooLarge      = "REQUEST_TOO_LARGE"
+	ErrorCodeInvalidJSON          = "INVALID_JSON"
+	ErrorCodeInvalidMultipart     = "INVALID_MULTIPART"
+	ErrorCodeInvalidRequest       = "INVALID_REQUEST"
+	ErrorCodeInvalidFrame         = "INVALID_FRAME"
+	ErrorCodeValidationFailed     = "VALIDATION_FAILED"
+	ErrorCodeInternal             = "UNKNOWN_INTERNAL_ERROR"
+)
+
 type HTTPError interface {
 	error
 	StatusCode() int
@@ -7,16 +30,31 @@ type HTTPError interface {
 	Message() string
 }
 
+// HTTPErrorDetails はフィールド単位のエラー詳細を持つHTTPErrorが任意で実装するインターフェースです
+// 実装している場合、Details の内容がエラーレスポンスの details に出力されます
+type HTTPErrorDetails interface {
+	Details() []FieldError
+}
+
+// FieldError はリクエストのフィールド単位のエラーです
+type FieldError struct {
+	Field   string `json:"field"`   // JSON/multipart上のフィールド名
+	Code    string `json:"code"`    // エラーの種類（例: "required", "min"）
+	Message string `json:"message"` // エラーメッセージ
+}
+
 type httpError struct {
 	statusCode uint16
 	code       string
 	message    string
+	details    []FieldError
 }
 
-func (e *httpError) Error() string   { return e.message }
-func (e *httpError) StatusCode() int { return int(e.statusCode) }
-func (e *httpError) Code() string    { return e.code }
-func (e *httpError) Message() string { return e.message }
+func (e *httpError) Error() string         { return e.message }
+func (e *httpError) StatusCode() int       { return int(e.statusCode) }
+func (e *httpError) Code() string          { return e.code }
+func (e *httpError) Message() string       { return e.message }
+func (e *httpError) Details() []FieldError { return e.details }
 
 func NewHTTPError(statusCode uint16, code, message string) HTTPError {
 	return &httpError{
@@ -42,6 +80,10 @@ func NotFoundError(code, message string) HTTPError {
 	return NewHTTPError(404, code, message)
 }
 
+func TooManyRequestsError(code, message string) HTTPError {
+	return NewHTTPError(429, code, message)
+}
+
 func InternalServerError(code, message string) HTTPError {
 	return NewHTTPError(500, code, message)
 }
@@ -49,3 +91,177 @@ func InternalServerError(code, message string) HTTPError {
 func ServiceUnavailableError(code, message string) HTTPError {
 	return NewHTTPError(503, code, message)
 }
+
+// ValidationFailedError はフィールド単位の詳細を持つバリデーションエラーを返します
+func ValidationFailedError(details ...FieldError) HTTPError {
+	messages := make([]string, 0, len(details))
+	for _, d := range details {
+		messages = append(messages, fmt.Sprintf("%s: %s", d.Field, d.Message))
+	}
+
+	message := "リクエストのバリデーションに失敗しました"
+	if len(messages) > 0 {
+		message = fmt.Sprintf("%s: %s", message, strings.Join(messages, ", "))
+	}
+
+	return &httpError{
+		statusCode: http.StatusBadRequest,
+		code:       ErrorCodeValidationFailed,
+		message:    message,
+		details:    details,
+	}
+}
+
+// RegisterErrors はエンドポイントが返しうるエラーを宣言します（メタデータ出力用）
+func RegisterErrors(errs ...HTTPError) []HTTPError {
+	return errs
+}
+
+// ErrorResponse はすべてのエンドポイントで共通のエラーレスポンスの形式です
+type ErrorResponse struct {
+	Error ErrorBody `json:"error"`
+}
+
+// ErrorBody はエラーレスポンスの本体です
+type ErrorBody struct {
+	Code      string       `json:"code"`                 // 機械可読なエラーコード
+	Message   string       `json:"message"`              // 人間向けのエラーメッセージ
+	RequestID string       `json:"request_id,omitempty"` // リクエストID
+	Details   []FieldError `json:"details,omitempty"`    // フィールド単位のエラー詳細
+}
+
+// newErrorResponse はHTTPErrorから共通のエラーレスポンスを生成します
+func newErrorResponse(ctx context.Context, httpErr HTTPError) ErrorResponse {
+	var details []FieldError
+	if d, ok := httpErr.(HTTPErrorDetails); ok {
+		details = d.Details()
+	}
+
+	return ErrorResponse{
+		Error: ErrorBody{
+			Code:      httpErr.Code(),
+			Message:   httpErr.Message(),
+			RequestID: GetRequestIDFromContext(ctx),
+			Details:   details,
+		},
+	}
+}
+
+// WriteError はHTTPErrorを共通のエラーレスポンスとして書き出します
+// RegisterCustomHandler で登録したハンドラーやミドルウェアからも利用できます
+func WriteError(w http.ResponseWriter, req *http.Request, httpErr HTTPError) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpErr.StatusCode())
+	_ = json.NewEncoder(w).Encode(newErrorResponse(req.Context(), httpErr))
+}
+
+// internalError はハンドラー内部の予期しないエラーを表すHTTPErrorです
+func internalError() HTTPError {
+	return InternalServerError(ErrorCodeInternal, "サーバー内部で予期しないエラーが発生しました")
+}
+
+// toHTTPError はハンドラーから返されたエラーをHTTPErrorに変換します
+// HTTPErrorでないエラーは詳細を隠して500として扱います
+func toHTTPError(err error) HTTPError {
+	var httpErr HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr
+	}
+	return internalError()
+}
+
+// validationError はValidate()が返したエラーをHTTPErrorに変換します
+// HTTPError（ValidationFailedErrorなど）が返された場合はそのまま利用します
+func validationError(err error) HTTPError {
+	var httpErr HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr
+	}
+	return BadRequestError(ErrorCodeValidationFailed, fmt.Sprintf("リクエストのバリデーションに失敗しました: %v", err))
+}
+
+// bodyReadError はリクエストボディの読み込みエラーをHTTPErrorに変換します
+// ボディサイズの上限を超えた場合は413、それ以外は指定されたコードの400を返します
+func bodyReadError(err error, code, message string) HTTPError {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return NewHTTPError(http.StatusRequestEntityTooLarge, ErrorCodeRequestTooLarge, "リクエストボディが大きすぎます")
+	}
+	return BadRequestError(code, message)
+}
+
+// writeHandlerError はハンドラーのエラーを共通のエラーレスポンスとして書き出します
+// 5xxのエラーはロガーが設定されている場合にログへ出力します
+func (r *Router) writeHandlerError(w http.ResponseWriter, req *http.Request, err error) {
+	httpErr := toHTTPError(err)
+
+	if httpErr.StatusCode() >= http.StatusInternalServerError && r.logger != nil {
+		r.logger.Error(req.Context(), "ハンドラーでエラーが発生しました", map[string]any{
+			"error":  err.Error(),
+			"path":   req.URL.Path,
+			"method": req.Method,
+		})
+	}
+
+	WriteError(w, req, httpErr)
+}
+
+// standardErrors はエンドポイントの種類ごとにフレームワークが返しうるエラーを返します
+func standardErrors(kind EndpointKind) []HTTPError {
+	errs := []HTTPError{
+		NewHTTPError(http.StatusMethodNotAllowed, ErrorCodeMethodNotAllowed, "指定した形式のリクエストではありません"),
+		BadRequestError(ErrorCodeValidationFailed, "リクエストのバリデーションに失敗しました"),
+	}
+
+	switch kind {
+	case KindUnaryJSON:
+		errs = append(errs,
+			NewHTTPError(http.StatusUnsupportedMediaType, ErrorCodeUnsupportedMediaType, "Content-Type must be application/json"),
+			NewHTTPError(http.StatusRequestEntityTooLarge, ErrorCodeRequestTooLarge, "リクエストボディが大きすぎます"),
+			BadRequestError(ErrorCodeInvalidJSON, "リクエストのJSON形式が不正です"),
+		)
+	case KindFileUpload:
+		errs = append(errs,
+			NewHTTPError(http.StatusUnsupportedMediaType, ErrorCodeUnsupportedMediaType, "Content-Type must be multipart/form-data"),
+			NewHTTPError(http.StatusRequestEntityTooLarge, ErrorCodeRequestTooLarge, "リクエストボディが大きすぎます"),
+			BadRequestError(ErrorCodeInvalidMultipart, "multipart/form-dataのパースに失敗しました"),
+			BadRequestError(ErrorCodeInvalidRequest, "リクエストのパースに失敗しました"),
+		)
+	case KindFileDownload:
+		errs = append(errs,
+			BadRequestError(ErrorCodeInvalidRequest, "リクエストのパースに失敗しました"),
+		)
+	case KindWebSocket:
+		errs = append(errs,
+			BadRequestError(ErrorCodeInvalidRequest, "WebSocketのUpgradeリクエストではありません"),
+			BadRequestError(ErrorCodeInvalidFrame, "フレームのJSON形式が不正です"),
+		)
+	}
+
+	return append(errs, internalError())
+}
+
+// ErrorInfo はエンドポイントが返しうるエラーの情報です（コード生成用）
+type ErrorInfo struct {
+	StatusCode int    `json:"status_code"`
+	Code       string `json:"code"`
+	Message    string `json:"message"`
+}
+
+// toErrorInfos はHTTPErrorの一覧をメタデータ用に変換します（コードの重複は除外）
+func toErrorInfos(errs []HTTPError) []ErrorInfo {
+	seen := make(map[string]bool)
+	infos := make([]ErrorInfo, 0, len(errs))
+	for _, e := range errs {
+		if seen[e.Code()] {
+			continue
+		}
+		seen[e.Code()] = true
+		infos = append(infos, ErrorInfo{
+			StatusCode: e.StatusCode(),
+			Code:       e.Code(),
+			Message:    e.Message(),
+		})
+	}
+	return infos
+}
@@ -0,0 +1,48 @@
+package outorouter_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kinpatsu-everyone/backend-template/pkg/outorouter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// teapotError は Details を実装しない外部パッケージのHTTPErrorです
+type teapotError struct{}
+
+func (teapotError) Error() string   { return "teapot" }
+func (teapotError) StatusCode() int { return http.StatusTeapot }
+func (teapotError) Code() string    { return "TEAPOT" }
+func (teapotError) Message() string { return "teapot" }
+
+func TestWriteError_Detailsを実装しないHTTPErrorも書き出せる(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	w := httptest.NewRecorder()
+
+	outorouter.WriteError(w, req, teapotError{})
+
+	require.Equal(t, http.StatusTeapot, w.Code)
+	var res outorouter.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, "TEAPOT", res.Error.Code)
+	assert.Equal(t, "teapot", res.Error.Message)
+	assert.Empty(t, res.Error.Details)
+}
+
+func TestWriteError_ValidationFailedErrorはdetailsを書き出す(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	w := httptest.NewRecorder()
+
+	detail := outorouter.FieldError{Field: "name", Code: "required", Message: "必須です"}
+	outorouter.WriteError(w, req, outorouter.ValidationFailedError(detail))
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	var res outorouter.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, outorouter.ErrorCodeValidationFailed, res.Error.Code)
+	assert.Equal(t, []outorouter.FieldError{detail}, res.Error.Details)
+}
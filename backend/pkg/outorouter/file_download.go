@@ -1,26 +1,330 @@
 package outorouter
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"mime"
 	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type FileDownloadResponseObject struct {
 	Filename    string
 	ContentType string
 	Content     []byte
+
+	// Inline がtrueの場合は Content-Disposition: inline で返します（ブラウザで直接表示する画像など）
+	Inline bool
+	// ETag は条件付きリクエスト用のETagです。空の場合はContentのハッシュから生成します
+	ETag string
+	// ModTime はファイルの最終更新日時です。ゼロ値の場合はLast-Modifiedを返しません
+	ModTime time.Time
 }
 
-type FileDownloadHandlerFunc func(ctx context.Context, r *http.Request) (*FileDownloadResponseObject, error)
+type FileDownloadHandlerFunc[Req RequestObject] func(ctx context.Context, req *Req) (*FileDownloadResponseObject, error)
+
+// FileDownloadETagFunc はファイルを取得せずにETagを返す関数です（空文字列の場合は条件付きリクエストを事前に判定しません）
+type FileDownloadETagFunc[Req RequestObject] func(ctx context.Context, req *Req) (string, error)
 
-type FileDownloadEndpoint struct {
+// FileDownloadEndpoint はHTTP GETでファイルをダウンロードさせるためのエンドポイントです
+// リクエストはクエリ文字列から組み立てられ、Range・ETagによる部分取得・条件付き取得に対応します
+type FileDownloadEndpoint[Req RequestObject] struct {
 	Domain     string
 	Version    uint8
 	MethodName string
 
+	// Path はパスパラメータを含むパスのパターンです（例: "/monster/v1/monsters/{id}/image"）
+	Path string
+
 	Summary     string
 	Description string
 	Tags        []Tag
 
-	Handler FileDownloadHandlerFunc
+	Handler FileDownloadHandlerFunc[Req]
+
+	// ETag は Handler の前に呼び出し、If-None-Match が一致する場合は Handler を呼び出さずに304を返します（省略可）
+	// Handler はファイル全体を取得するため、ストレージのメタデータなどから安くETagを求められる場合に指定します
+	// 指定しない場合も条件付きリクエストには対応しますが、304を返す場合もファイル全体を取得します
+	ETag FileDownloadETagFunc[Req]
+
+	// Errors はハンドラーが返しうるエラーです（メタデータ・クライアント生成用）
+	Errors []HTTPError
+	// LintIgnore はこのエンドポイントで抑制するlintルールのIDまたは名前です
+	LintIgnore []string
+
+	// Auth はエンドポイントの認証の要否です（空の場合は AuthNone）
+	Auth AuthRequirement
+	// Scopes は認証された Principal に必要なスコープです（Auth が AuthRequired の場合のみ指定できます）
+	Scopes []string
+
+	// CacheControl はレスポンスに付与するCache-Controlヘッダーの値です
+	// 空の場合は "private, max-age=0, must-revalidate" を使用します
+	CacheControl string
+}
+
+func (f FileDownloadEndpoint[Req]) GetFullPath() string {
+	if f.Path != "" {
+		return f.Path
+	}
+	return fmt.Sprintf("/%s/%s/%s", f.Domain, f.GetVersionWithPrefix(), f.MethodName)
+}
+
+func (f FileDownloadEndpoint[Req]) GetDomain() string {
+	return f.Domain
+}
+
+func (f FileDownloadEndpoint[Req]) GetVersion() uint8 {
+	return f.Version
+}
+
+func (f FileDownloadEndpoint[Req]) GetVersionWithPrefix() string {
+	return fmt.Sprintf("v%d", f.Version)
+}
+
+// RegisterFileDownloadEndpoint はファイルダウンロード用のエンドポイントを登録します
+func RegisterFileDownloadEndpoint[Req RequestObject](
+	r *Router,
+	ep FileDownloadEndpoint[Req],
+) {
+	cacheControl := ep.CacheControl
+	if cacheControl == "" {
+		cacheControl = "private, max-age=0, must-revalidate"
+	}
+
+	h := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		// GET/HEADメソッドではない場合は不正と見做す
+		if req.Method != http.MethodGet && req.Method != http.MethodHead {
+			WriteError(w, req, NewHTTPError(http.StatusMethodNotAllowed, ErrorCodeMethodNotAllowed, "指定した形式のリクエストではありません"))
+			return
+		}
+
+		ctx := req.Context()
+
+		// クエリ文字列からリクエスト構造体を組み立てる
+		var request Req
+		reqType := reflect.TypeOf(request)
+		hasFields := reqType.Kind() == reflect.Struct && reqType.NumField() > 0
+
+		if hasFields {
+			if err := populateRequestFromQuery(&request, req.URL.Query()); err != nil {
+				WriteError(w, req, BadRequestError(ErrorCodeInvalidRequest, fmt.Sprintf("リクエストのパースに失敗しました: %v", err)))
+				return
+			}
+			if err := populateRequestFromParams(&request, req); err != nil {
+				WriteError(w, req, BadRequestError(ErrorCodeInvalidRequest, fmt.Sprintf("リクエストのパースに失敗しました: %v", err)))
+				return
+			}
+		}
+
+		// validateタグによる検証の後、Validate()を実行する
+		if err := validateRequest(&request); err != nil {
+			WriteError(w, req, err)
+			return
+		}
+
+		// ファイルを取得する前に If-None-Match を判定する
+		var etag string
+		if ep.ETag != nil {
+			var err error
+			if etag, err = ep.ETag(ctx, &request); err != nil {
+				r.writeHandlerError(w, req, err)
+				return
+			}
+			if etag != "" && etagMatches(req.Header.Get("If-None-Match"), etag) {
+				w.Header().Set("ETag", strconv.Quote(etag))
+				w.Header().Set("Cache-Control", cacheControl)
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+		}
+
+		file, err := ep.Handler(ctx, &request)
+		if err != nil {
+			r.writeHandlerError(w, req, err)
+			return
+		}
+
+		contentType := file.ContentType
+		if contentType == "" {
+			contentType = http.DetectContentType(file.Content)
+		}
+
+		if file.ETag != "" {
+			etag = file.ETag
+		}
+		if etag == "" {
+			sum := sha256.Sum256(file.Content)
+			etag = hex.EncodeToString(sum[:16])
+		}
+
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Content-Disposition", contentDisposition(file.Filename, file.Inline))
+		w.Header().Set("ETag", strconv.Quote(etag))
+		w.Header().Set("Cache-Control", cacheControl)
+
+		// Range, If-Range, If-None-Match, If-Modified-Since の処理は ServeContent に委譲する
+		http.ServeContent(w, req, file.Filename, file.ModTime, bytes.NewReader(file.Content))
+	})
+
+	// リクエストモデルのメタデータ
+	// レスポンスはバイナリのためフィールド情報は持たない
+	var reqZero Req
+	resType := reflect.TypeOf(FileDownloadResponseObject{})
+
+	// validateタグ・path タグの誤りは登録時に検出する
+	validatorFor(reflect.TypeOf(reqZero))
+	checkRequestParams(http.MethodGet, ep.GetFullPath(), reflect.TypeOf(reqZero), false)
+
+	mws := r.endpointMiddlewares(ep.GetFullPath(), ep.Auth, ep.Scopes, nil)
+	entry := newRouteEntry(ep.Domain, ep.Version, mws, h)
+	r.addRoute(http.MethodGet, ep.GetFullPath(), "", entry)
+	r.addRoute(http.MethodHead, ep.GetFullPath(), "", entry)
+
+	internalEp := internalEndpoint{
+		Kind:             KindFileDownload,
+		Domain:           ep.Domain,
+		Version:          ep.Version,
+		MethodName:       ep.MethodName,
+		Summary:          ep.Summary,
+		Description:      ep.Description,
+		Tags:             ep.Tags,
+		HTTPMethod:       http.MethodGet,
+		Path:             ep.GetFullPath(),
+		handler:          h,
+		middlewares:      mws,
+		Auth:             ep.Auth.String(),
+		Scopes:           ep.Scopes,
+		RequestType:      reflect.TypeOf(reqZero).String(),
+		ResponseType:     resType.String(),
+		RequestTypeInfo:  extractTypeInfo(reflect.TypeOf(reqZero)),
+		ResponseTypeInfo: TypeInfo{Name: resType.Name(), Fields: make([]FieldInfo, 0)},
+		Errors:           append(append(standardErrors(KindFileDownload), authErrors(ep.Auth, ep.Scopes)...), ep.Errors...),
+		LintIgnore:       ep.LintIgnore,
+	}
+
+	r.addToRegistry(internalEp)
+}
+
+// etagMatches は If-None-Match ヘッダーの値が etag（引用符なし）に一致するかを返します
+// If-None-Match は弱い比較のため、W/ の接頭辞は無視します
+func etagMatches(ifNoneMatch, etag string) bool {
+	quoted := strconv.Quote(etag)
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == quoted {
+			return true
+		}
+	}
+	return false
+}
+
+// contentDisposition はContent-Dispositionヘッダーの値を生成します
+// 日本語などの非ASCIIファイル名はRFC 2231形式でエンコードされます
+func contentDisposition(filename string, inline bool) string {
+	dispositionType := "attachment"
+	if inline {
+		dispositionType = "inline"
+	}
+	if filename == "" {
+		return dispositionType
+	}
+
+	value := mime.FormatMediaType(dispositionType, map[string]string{"filename": filename})
+	if value == "" {
+		// フォーマットできないファイル名の場合はファイル名を省略する
+		return dispositionType
+	}
+	return value
+}
+
+// populateRequestFromQuery はクエリ文字列からリクエスト構造体を埋めます
+// フィールド名はqueryタグ、なければjsonタグの名前を使用します
+func populateRequestFromQuery(req interface{}, query url.Values) error {
+	reqValue := reflect.ValueOf(req).Elem()
+	reqType := reqValue.Type()
+
+	for i := 0; i < reqType.NumField(); i++ {
+		field := reqType.Field(i)
+		fieldValue := reqValue.Field(i)
+
+		if !field.IsExported() {
+			continue
+		}
+
+		// queryタグがあればその名前、なければjsonタグの名前を使う（パスパラメータは対象外）
+		fieldName, _ := parseJSONTag(field.Tag.Get("json"), field.Name)
+		if in, name := fieldParam(field); in == ParamInPath {
+			continue
+		} else if in == ParamInQuery {
+			fieldName = name
+		}
+		if fieldName == "-" {
+			continue
+		}
+
+		values, ok := query[fieldName]
+		if !ok || len(values) == 0 {
+			continue
+		}
+
+		if err := setFieldFromString(fieldValue, values); err != nil {
+			return fmt.Errorf("%s: %w", fieldName, err)
+		}
+	}
+
+	return nil
+}
+
+// setFieldFromString は文字列の値をフィールドの型に変換してセットします
+func setFieldFromString(fieldValue reflect.Value, values []string) error {
+	value := values[0]
+	switch fieldValue.Kind() {
+	case reflect.String:
+		fieldValue.SetString(value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		intVal, err := strconv.ParseInt(value, 10, fieldValue.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", value)
+		}
+		fieldValue.SetInt(intVal)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		uintVal, err := strconv.ParseUint(value, 10, fieldValue.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid unsigned integer %q", value)
+		}
+		fieldValue.SetUint(uintVal)
+	case reflect.Float32, reflect.Float64:
+		floatVal, err := strconv.ParseFloat(value, fieldValue.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid number %q", value)
+		}
+		fieldValue.SetFloat(floatVal)
+	case reflect.Bool:
+		boolVal, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q", value)
+		}
+		fieldValue.SetBool(boolVal)
+	case reflect.Slice:
+		elemType := fieldValue.Type().Elem()
+		if elemType.Kind() != reflect.String {
+			return fmt.Errorf("unsupported slice type %s", fieldValue.Type())
+		}
+		// []enum.X のような名前付きの文字列型の要素にも代入できるように要素ごとに変換する
+		slice := reflect.MakeSlice(fieldValue.Type(), len(values), len(values))
+		for i, v := range values {
+			slice.Index(i).Set(reflect.ValueOf(v).Convert(elemType))
+		}
+		fieldValue.Set(slice)
+	default:
+		return fmt.Errorf("unsupported type %s", fieldValue.Type())
+	}
+	return nil
 }
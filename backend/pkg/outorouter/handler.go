@@ -3,6 +3,7 @@ package outorouter
 import (
 	"context"
 	"net/http"
+	"strings"
 	"sync"
 )
 
@@ -46,9 +47,10 @@ func (k EndpointKind) String() string {
 }
 
 const (
-	KindUnaryJSON  EndpointKind = "JSON"
-	KindFileUpload EndpointKind = "FileUpload"
-	KindWebSocket  EndpointKind = "WebSocket"
+	KindUnaryJSON    EndpointKind = "JSON"
+	KindFileUpload   EndpointKind = "FileUpload"
+	KindFileDownload EndpointKind = "FileDownload"
+	KindWebSocket    EndpointKind = "WebSocket"
 )
 
 type Endpoint interface {
@@ -65,7 +67,12 @@ type FieldInfo struct {
 	Type       string    `json:"type"`                  // Goの型名
 	TSType     string    `json:"ts_type"`               // TypeScriptの型名
 	Optional   bool      `json:"optional"`              // omitemptyの有無
+	In         string    `json:"in,omitempty"`          // パラメータの受け取り位置（"path" / "query"、空の場合はボディ）
 	NestedType *TypeInfo `json:"nested_type,omitempty"` // ネストされた構造体の型情報（構造体またはスライス/配列の要素が構造体の場合）
+
+	Validation  []ValidationRule `json:"validation,omitempty"`  // validateタグのルール
+	Description string           `json:"description,omitempty"` // docタグの説明
+	LintIgnore  []string         `json:"lint_ignore,omitempty"` // lintタグで抑制するルール
 }
 
 // TypeInfo は構造体の型情報を保持します
@@ -86,7 +93,15 @@ type internalEndpoint struct {
 	Tags        []Tag
 
 	HTTPMethod string
-	handler    http.Handler
+	// Path はルーティングに使用するパスのパターンです（例: "/monster/v1/monsters/{id}"）
+	Path    string
+	handler http.Handler
+	// middlewares はエンドポイント固有のミドルウェアです（AuthMiddlewareを含む）
+	middlewares []MiddlewareFunc
+
+	// 認証の要否と必要なスコープ
+	Auth   string
+	Scopes []string
 
 	// 型名 (コード生成用)
 	RequestType  string
@@ -95,6 +110,12 @@ type internalEndpoint struct {
 	// 型情報 (コード生成用)
 	RequestTypeInfo  TypeInfo
 	ResponseTypeInfo TypeInfo
+
+	// 返しうるエラー (コード生成用)
+	Errors []HTTPError
+
+	// 抑制するlintルール
+	LintIgnore []string
 }
 
 type Router struct {
@@ -103,13 +124,18 @@ type Router struct {
 	// domain → version → kind → []internalEndpoint
 	registry map[string]map[uint8]map[EndpointKind][]internalEndpoint
 
-	// 実行時ルーティング: method → fullPath → handler
-	httpRoutes map[string]map[string]http.Handler
-
-	// Content-Type別ルーティング: method → fullPath → Content-Type → handler
-	contentTypeRoutes map[string]map[string]map[string]http.Handler
+	// 実行時ルーティング: パスのトライ木（葉に method → Content-Type → handler を持つ）
+	routes *routeNode
 
+	// グローバルなミドルウェアとグループ（domain/version）ごとのミドルウェア
 	middlewares []MiddlewareFunc
+	groups      map[groupKey][]MiddlewareFunc
+	// generation はミドルウェアの変更ごとに増え、合成済みハンドラーのキャッシュを無効化します
+	generation uint64
+	// verifier は Auth を宣言したエンドポイントでトークンを検証します
+	verifier Verifier
+	// rateLimitStore は RateLimitMiddleware で利用するレート制限の状態の保存先です
+	rateLimitStore RateLimitStore
 	// TODO: loggerを組み込む
 	logger Logger
 }
@@ -118,11 +144,13 @@ type Option func(*Router)
 
 func New(opts ...Option) *Router {
 	r := &Router{
-		registry:          make(map[string]map[uint8]map[EndpointKind][]internalEndpoint),
-		httpRoutes:        make(map[string]map[string]http.Handler),
-		contentTypeRoutes: make(map[string]map[string]map[string]http.Handler),
-		middlewares:       make([]MiddlewareFunc, 0),
-		logger:            nil,
+		registry:    make(map[string]map[uint8]map[EndpointKind][]internalEndpoint),
+		routes:      newRouteNode(),
+		middlewares: make([]MiddlewareFunc, 0),
+		groups:      make(map[groupKey][]MiddlewareFunc),
+		logger:      nil,
+
+		rateLimitStore: NewMemoryRateLimitStore(),
 	}
 
 	for _, opt := range opts {
@@ -144,9 +172,6 @@ func (r *Router) GetRegistries() map[string]map[uint8]map[EndpointKind][]interna
 
 func (r *Router) Handler() http.Handler {
 	return http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
-		r.mu.RLock()
-
-		// Content-Type別ルーティングを優先的にチェック
 		contentType := req.Header.Get("Content-Type")
 
 		// multipart/form-dataの場合、boundaryパラメータを除去して判定
@@ -165,119 +190,112 @@ func (r *Router) Handler() http.Handler {
 			})
 		}
 
-		if contentTypeRoutes, methodExists := r.contentTypeRoutes[req.Method]; methodExists {
-			if pathRoutes, pathExists := contentTypeRoutes[req.URL.Path]; pathExists {
-				// Content-Typeに基づいてハンドラーを選択
-				if h, exists := pathRoutes[normalizedContentType]; exists {
-					r.mu.RUnlock()
-					h.ServeHTTP(res, req)
-					return
-				}
-				// Content-Typeが空の場合はapplication/jsonを試す
-				if contentType == "" {
-					if h, exists := pathRoutes["application/json"]; exists {
-						r.mu.RUnlock()
-						h.ServeHTTP(res, req)
-						return
-					}
-				}
-			}
-		}
-
-		// フォールバック: 通常のルーティング
-		// 通常のルーティングでも、Content-Typeに応じて適切なハンドラーを選択
-		methodRoutes, methodExists := r.httpRoutes[req.Method]
-		if !methodExists {
+		r.mu.RLock()
+		node, params := r.routes.lookup(req.URL.Path)
+		if node == nil {
 			r.mu.RUnlock()
 			// デバッグ: ログが有効な場合は404の原因を出力
 			if r.logger != nil {
-				r.logger.Info(req.Context(), "404: method not found", map[string]any{
+				r.logger.Info(req.Context(), "404: path not found", map[string]any{
 					"method": req.Method,
 					"path":   req.URL.Path,
 				})
 			}
-			http.NotFound(res, req)
+			WriteError(res, req, NotFoundError(ErrorCodeNotFound, "指定されたエンドポイントは存在しません"))
 			return
 		}
 
-		h, pathExists := methodRoutes[req.URL.Path]
+		contentTypeRoutes, methodExists := node.handlers[req.Method]
+		allowed := node.allowedMethods()
+		var h http.Handler
+		if methodExists {
+			// Content-Typeに基づいてハンドラーを選択し、なければフォールバックのハンドラーを使う
+			entry := contentTypeRoutes[normalizedContentType]
+			if entry == nil && contentType == "" {
+				entry = contentTypeRoutes["application/json"]
+			}
+			if entry == nil {
+				entry = contentTypeRoutes[""]
+			}
+			if entry != nil {
+				h = r.compose(entry)
+			}
+		}
 		r.mu.RUnlock()
 
-		if !pathExists {
-			// デバッグ: ログが有効な場合は404の原因を出力
+		if h == nil {
+			res.Header().Set("Allow", strings.Join(allowed, ", "))
+
+			// プリフライトリクエストはミドルウェア（CORS）に処理させる
+			if req.Method == http.MethodOptions {
+				r.applyMiddlewares(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusNoContent)
+				})).ServeHTTP(res, req)
+				return
+			}
+
 			if r.logger != nil {
-				r.logger.Info(req.Context(), "404: path not found", map[string]any{
-					"method": req.Method,
-					"path":   req.URL.Path,
-					"available_paths": func() []string {
-						r.mu.RLock()
-						defer r.mu.RUnlock()
-						paths := make([]string, 0)
-						if routes, ok := r.httpRoutes[req.Method]; ok {
-							for p := range routes {
-								paths = append(paths, p)
-							}
-						}
-						return paths
-					}(),
+				r.logger.Info(req.Context(), "405: method not allowed", map[string]any{
+					"method":  req.Method,
+					"path":    req.URL.Path,
+					"allowed": allowed,
 				})
 			}
-			http.NotFound(res, req)
+			WriteError(res, req, NewHTTPError(http.StatusMethodNotAllowed, ErrorCodeMethodNotAllowed, "指定した形式のリクエストではありません"))
 			return
 		}
 
+		for name, value := range params {
+			req.SetPathValue(name, value)
+		}
 		h.ServeHTTP(res, req)
 	})
 }
 
-// Use はミドルウェアを追加します。
+// Use はすべてのルートに適用するミドルウェアを追加します。
+// 既に登録済みのルートにも適用されます。
 func (r *Router) Use(mws ...MiddlewareFunc) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	r.middlewares = append(r.middlewares, mws...)
+	r.generation++
 }
 
 // RegisterCustomHandler はカスタムHTTPハンドラーを登録します
+// グローバルなミドルウェアのみが適用されます
 func (r *Router) RegisterCustomHandler(method, path string, h http.Handler) {
-	r.addHTTPRoute(method, path, r.applyMiddlewares(h))
+	r.addRoute(method, path, "", &routeEntry{handler: h})
 }
 
+// applyMiddlewares はグローバルなミドルウェアを適用したハンドラーを返します
 func (r *Router) applyMiddlewares(h http.Handler) http.Handler {
 	r.mu.RLock()
 	mws := make([]MiddlewareFunc, len(r.middlewares))
 	copy(mws, r.middlewares)
 	r.mu.RUnlock()
 
-	for i := len(mws) - 1; i >= 0; i-- {
-		h = mws[i](h)
-	}
-	return h
+	return wrapMiddlewares(h, mws)
 }
 
-func (r *Router) addHTTPRoute(method, fullPath string, h http.Handler) {
+// addRoute はルーティングのトライ木にハンドラーを登録します
+// contentType を指定した場合はそのContent-Typeのリクエストに優先して使われ、
+// 最後に登録したハンドラーは一致するContent-Typeがない場合のフォールバックになります
+func (r *Router) addRoute(method, path, contentType string, entry *routeEntry) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	if _, exists := r.httpRoutes[method]; !exists {
-		r.httpRoutes[method] = make(map[string]http.Handler)
+	node := r.routes.insert(path)
+	if node.handlers == nil {
+		node.handlers = make(map[string]map[string]*routeEntry)
 	}
-
-	r.httpRoutes[method][fullPath] = h
-}
-
-func (r *Router) addContentTypeRoute(method, fullPath, contentType string, h http.Handler) {
-	r.mu.Lock()
-	defer r.mu.Unlock()
-
-	if _, exists := r.contentTypeRoutes[method]; !exists {
-		r.contentTypeRoutes[method] = make(map[string]map[string]http.Handler)
+	if _, exists := node.handlers[method]; !exists {
+		node.handlers[method] = make(map[string]*routeEntry)
 	}
 
-	if _, exists := r.contentTypeRoutes[method][fullPath]; !exists {
-		r.contentTypeRoutes[method][fullPath] = make(map[string]http.Handler)
+	node.handlers[method][""] = entry
+	if contentType != "" {
+		node.handlers[method][contentType] = entry
 	}
-
-	r.contentTypeRoutes[method][fullPath][contentType] = h
 }
 
 func (r *Router) addToRegistry(ep internalEndpoint) {
@@ -0,0 +1,314 @@
+package differ
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/kinpatsu-everyone/backend-template/pkg/outorouter/internal/parser"
+)
+
+// Severity は変更が既存のクライアントを壊すかどうかを表す。
+type Severity string
+
+const (
+	SeverityBreaking    Severity = "breaking"
+	SeverityNonBreaking Severity = "non-breaking"
+)
+
+// ChangeType は変更の種類を表す。
+type ChangeType string
+
+const (
+	ChangeEndpointAdded       ChangeType = "endpoint_added"
+	ChangeEndpointRemoved     ChangeType = "endpoint_removed"
+	ChangeKindChanged         ChangeType = "kind_changed"
+	ChangeHTTPMethodChanged   ChangeType = "http_method_changed"
+	ChangeHTTPPathChanged     ChangeType = "http_path_changed"
+	ChangeAuthChanged         ChangeType = "auth_changed"
+	ChangeFieldMoved          ChangeType = "field_moved"
+	ChangeFieldAdded          ChangeType = "field_added"
+	ChangeFieldRemoved        ChangeType = "field_removed"
+	ChangeFieldRenamed        ChangeType = "field_renamed"
+	ChangeFieldTypeChanged    ChangeType = "field_type_changed"
+	ChangeFieldBecameRequired ChangeType = "field_became_required"
+	ChangeFieldBecameOptional ChangeType = "field_became_optional"
+)
+
+// Change は2つのメタデータ間の1件の差分を表す。
+type Change struct {
+	Severity Severity   `json:"severity"`
+	Type     ChangeType `json:"type"`
+	Path     string     `json:"path"`            // エンドポイントのパス (domain/v{version}/method)
+	Field    string     `json:"field,omitempty"` // 対象フィールド（例: "request.monster.name"）
+	Message  string     `json:"message"`
+}
+
+// HasBreaking は破壊的変更が含まれているかを返す。
+func HasBreaking(changes []Change) bool {
+	for _, c := range changes {
+		if c.Severity == SeverityBreaking {
+			return true
+		}
+	}
+	return false
+}
+
+// Diff は旧メタデータと新メタデータを比較し、差分を返す。
+// エンドポイントはパス（ドメイン・バージョン・メソッド名）で対応付けるため、
+// 新しいバージョンとして追加されたエンドポイントは既存バージョンの変更として扱わない。
+func Diff(oldMeta, newMeta *parser.Metadata) []Change {
+	oldEndpoints := indexEndpoints(oldMeta)
+	newEndpoints := indexEndpoints(newMeta)
+
+	var changes []Change
+	for _, path := range sortedKeys(oldEndpoints) {
+		oldEp := oldEndpoints[path]
+		newEp, ok := newEndpoints[path]
+		if !ok {
+			changes = append(changes, Change{
+				Severity: SeverityBreaking,
+				Type:     ChangeEndpointRemoved,
+				Path:     path,
+				Message:  "エンドポイントが削除されました",
+			})
+			continue
+		}
+		changes = append(changes, diffEndpoint(path, oldEp, newEp)...)
+	}
+
+	for _, path := range sortedKeys(newEndpoints) {
+		if _, ok := oldEndpoints[path]; !ok {
+			changes = append(changes, Change{
+				Severity: SeverityNonBreaking,
+				Type:     ChangeEndpointAdded,
+				Path:     path,
+				Message:  "エンドポイントが追加されました",
+			})
+		}
+	}
+
+	return changes
+}
+
+func indexEndpoints(meta *parser.Metadata) map[string]parser.Endpoint {
+	index := make(map[string]parser.Endpoint)
+	if meta == nil {
+		return index
+	}
+	for _, ep := range meta.All {
+		index[ep.Path()] = ep
+	}
+	return index
+}
+
+func sortedKeys(m map[string]parser.Endpoint) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func diffEndpoint(path string, oldEp, newEp parser.Endpoint) []Change {
+	var changes []Change
+
+	if oldEp.Kind != newEp.Kind {
+		changes = append(changes, Change{
+			Severity: SeverityBreaking,
+			Type:     ChangeKindChanged,
+			Path:     path,
+			Message:  fmt.Sprintf("エンドポイントの種類が %s から %s に変更されました", oldEp.Kind, newEp.Kind),
+		})
+	}
+	if oldEp.HTTPMethod != newEp.HTTPMethod {
+		changes = append(changes, Change{
+			Severity: SeverityBreaking,
+			Type:     ChangeHTTPMethodChanged,
+			Path:     path,
+			Message:  fmt.Sprintf("HTTPメソッドが %s から %s に変更されました", oldEp.HTTPMethod, newEp.HTTPMethod),
+		})
+	}
+	if oldEp.RoutePath() != newEp.RoutePath() {
+		changes = append(changes, Change{
+			Severity: SeverityBreaking,
+			Type:     ChangeHTTPPathChanged,
+			Path:     path,
+			Message:  fmt.Sprintf("パスが %s から %s に変更されました", oldEp.RoutePath(), newEp.RoutePath()),
+		})
+	}
+
+	if oldAuth, newAuth := oldEp.AuthRequirement(), newEp.AuthRequirement(); oldAuth != newAuth || !slices.Equal(oldEp.Scopes, newEp.Scopes) {
+		// 認証が厳しくなる（required になる・スコープが増える）と既存のクライアントが401/403になる
+		severity := SeverityNonBreaking
+		if newAuth == "required" && oldAuth != "required" || slices.ContainsFunc(newEp.Scopes, func(s string) bool { return !slices.Contains(oldEp.Scopes, s) }) {
+			severity = SeverityBreaking
+		}
+		changes = append(changes, Change{
+			Severity: severity,
+			Type:     ChangeAuthChanged,
+			Path:     path,
+			Message:  fmt.Sprintf("認証が %s%v から %s%v に変更されました", oldAuth, oldEp.Scopes, newAuth, newEp.Scopes),
+		})
+	}
+
+	changes = append(changes, diffFields(path, "request", oldEp.RequestTypeInfo.Fields, newEp.RequestTypeInfo.Fields, true)...)
+	changes = append(changes, diffFields(path, "response", oldEp.ResponseTypeInfo.Fields, newEp.ResponseTypeInfo.Fields, false)...)
+	return changes
+}
+
+// diffFields はフィールドの差分を検出する。
+// リクエストはクライアントが送る側、レスポンスはクライアントが受け取る側として破壊的かどうかを判定する。
+func diffFields(path, prefix string, oldFields, newFields []parser.FieldInfo, isRequest bool) []Change {
+	var changes []Change
+
+	oldByJSON := indexFields(oldFields)
+	newByJSON := indexFields(newFields)
+
+	// Goのフィールド名が同じでJSON名だけ変わったものはリネームとして扱う
+	renamed := make(map[string]bool)
+	for _, of := range oldFields {
+		if _, ok := newByJSON[of.JSONName]; ok {
+			continue
+		}
+		for _, nf := range newFields {
+			if _, ok := oldByJSON[nf.JSONName]; ok || nf.Name != of.Name {
+				continue
+			}
+			renamed[of.JSONName] = true
+			renamed[nf.JSONName] = true
+			changes = append(changes, Change{
+				Severity: SeverityBreaking,
+				Type:     ChangeFieldRenamed,
+				Path:     path,
+				Field:    prefix + "." + of.JSONName,
+				Message:  fmt.Sprintf("フィールド名が %s から %s に変更されました", of.JSONName, nf.JSONName),
+			})
+		}
+	}
+
+	for _, of := range oldFields {
+		field := prefix + "." + of.JSONName
+		nf, ok := newByJSON[of.JSONName]
+		if !ok {
+			if renamed[of.JSONName] {
+				continue
+			}
+			// 削除されたリクエストのフィールドはサーバーが無視するだけなので互換性は保たれる
+			severity := SeverityBreaking
+			if isRequest {
+				severity = SeverityNonBreaking
+			}
+			changes = append(changes, Change{
+				Severity: severity,
+				Type:     ChangeFieldRemoved,
+				Path:     path,
+				Field:    field,
+				Message:  "フィールドが削除されました",
+			})
+			continue
+		}
+
+		// パスパラメータ・クエリ文字列・ボディの間で送る位置が変わると既存のクライアントは値を送れない
+		if of.In != nf.In {
+			changes = append(changes, Change{
+				Severity: SeverityBreaking,
+				Type:     ChangeFieldMoved,
+				Path:     path,
+				Field:    field,
+				Message:  fmt.Sprintf("送信位置が %s から %s に変更されました", fieldLocation(of), fieldLocation(nf)),
+			})
+		}
+
+		if of.TSType != nf.TSType {
+			changes = append(changes, Change{
+				Severity: SeverityBreaking,
+				Type:     ChangeFieldTypeChanged,
+				Path:     path,
+				Field:    field,
+				Message:  fmt.Sprintf("型が %s から %s に変更されました", of.TSType, nf.TSType),
+			})
+		} else if of.NestedType != nil && nf.NestedType != nil {
+			changes = append(changes, diffFields(path, field, of.NestedType.Fields, nf.NestedType.Fields, isRequest)...)
+		}
+
+		switch oldRequired, newRequired := isRequired(of, isRequest), isRequired(nf, isRequest); {
+		case !oldRequired && newRequired:
+			// 必須になったリクエストのフィールドは既存のクライアントが送っていない可能性がある
+			severity := SeverityNonBreaking
+			if isRequest {
+				severity = SeverityBreaking
+			}
+			changes = append(changes, Change{
+				Severity: severity,
+				Type:     ChangeFieldBecameRequired,
+				Path:     path,
+				Field:    field,
+				Message:  "任意項目から必須項目に変更されました",
+			})
+		case oldRequired && !newRequired:
+			// 任意になったレスポンスのフィールドは既存のクライアントが存在を前提にしている可能性がある
+			severity := SeverityNonBreaking
+			if !isRequest {
+				severity = SeverityBreaking
+			}
+			changes = append(changes, Change{
+				Severity: severity,
+				Type:     ChangeFieldBecameOptional,
+				Path:     path,
+				Field:    field,
+				Message:  "必須項目から任意項目に変更されました",
+			})
+		}
+	}
+
+	for _, nf := range newFields {
+		if _, ok := oldByJSON[nf.JSONName]; ok || renamed[nf.JSONName] {
+			continue
+		}
+		// 必須のリクエストフィールドの追加は既存のクライアントを壊す
+		severity := SeverityNonBreaking
+		if isRequest && isRequired(nf, true) {
+			severity = SeverityBreaking
+		}
+		changes = append(changes, Change{
+			Severity: severity,
+			Type:     ChangeFieldAdded,
+			Path:     path,
+			Field:    prefix + "." + nf.JSONName,
+			Message:  "フィールドが追加されました",
+		})
+	}
+
+	return changes
+}
+
+func fieldLocation(f parser.FieldInfo) string {
+	if f.In == "" {
+		return "body"
+	}
+	return f.In
+}
+
+func indexFields(fields []parser.FieldInfo) map[string]parser.FieldInfo {
+	index := make(map[string]parser.FieldInfo, len(fields))
+	for _, f := range fields {
+		index[f.JSONName] = f
+	}
+	return index
+}
+
+// isRequired はフィールドが必須かどうかを返す。
+// リクエストは validate:"required"、レスポンスは optional でないことを必須とみなす。
+func isRequired(f parser.FieldInfo, isRequest bool) bool {
+	if !isRequest {
+		return !f.Optional
+	}
+	for _, r := range f.Validation {
+		if r.Name == "required" {
+			return true
+		}
+	}
+	return false
+}
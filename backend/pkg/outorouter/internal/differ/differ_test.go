@@ -0,0 +1,203 @@
+package differ
+
+import (
+	"testing"
+
+	"github.com/kinpatsu-everyone/backend-template/pkg/outorouter/internal/parser"
+)
+
+func endpoint(kind parser.EndpointKind, req, res []parser.FieldInfo) parser.Endpoint {
+	return parser.Endpoint{
+		Kind:             kind,
+		Domain:           "monster",
+		Version:          1,
+		MethodName:       "GetMonster",
+		HTTPMethod:       "POST",
+		RequestTypeInfo:  parser.TypeInfo{Name: "GetMonsterRequest", Fields: req},
+		ResponseTypeInfo: parser.TypeInfo{Name: "GetMonsterResponse", Fields: res},
+	}
+}
+
+func withPath(ep parser.Endpoint, path string) parser.Endpoint {
+	ep.HTTPPath = path
+	return ep
+}
+
+func withAuth(ep parser.Endpoint, auth string) parser.Endpoint {
+	ep.Auth = auth
+	return ep
+}
+
+func meta(eps ...parser.Endpoint) *parser.Metadata {
+	return &parser.Metadata{All: eps}
+}
+
+var (
+	idField       = parser.FieldInfo{Name: "ID", JSONName: "id", Type: "string", TSType: "string"}
+	requiredID    = parser.FieldInfo{Name: "ID", JSONName: "id", Type: "string", TSType: "string", Validation: []parser.ValidationRule{{Name: "required"}}}
+	pathID        = parser.FieldInfo{Name: "ID", JSONName: "id", Type: "string", TSType: "string", In: "path"}
+	nameField     = parser.FieldInfo{Name: "Name", JSONName: "name", Type: "string", TSType: "string"}
+	optionalName  = parser.FieldInfo{Name: "Name", JSONName: "name", Type: "*string", TSType: "string", Optional: true}
+	renamedName   = parser.FieldInfo{Name: "Name", JSONName: "nickname", Type: "string", TSType: "string"}
+	numericName   = parser.FieldInfo{Name: "Name", JSONName: "name", Type: "int", TSType: "number"}
+	nestedMonster = func(fields ...parser.FieldInfo) parser.FieldInfo {
+		return parser.FieldInfo{Name: "Monster", JSONName: "monster", Type: "handler.MonsterItem", TSType: "MonsterItem",
+			NestedType: &parser.TypeInfo{Name: "MonsterItem", Fields: fields}}
+	}
+)
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name         string
+		oldMeta      *parser.Metadata
+		newMeta      *parser.Metadata
+		wantType     ChangeType
+		wantSeverity Severity
+		wantField    string
+	}{
+		{
+			name:         "エンドポイントの削除は破壊的変更",
+			oldMeta:      meta(endpoint(parser.KindUnaryJSON, nil, nil)),
+			newMeta:      meta(),
+			wantType:     ChangeEndpointRemoved,
+			wantSeverity: SeverityBreaking,
+		},
+		{
+			name:         "エンドポイントの追加は非破壊的変更",
+			oldMeta:      meta(),
+			newMeta:      meta(endpoint(parser.KindUnaryJSON, nil, nil)),
+			wantType:     ChangeEndpointAdded,
+			wantSeverity: SeverityNonBreaking,
+		},
+		{
+			name:         "種類の変更は破壊的変更",
+			oldMeta:      meta(endpoint(parser.KindUnaryJSON, nil, nil)),
+			newMeta:      meta(endpoint(parser.KindFileUpload, nil, nil)),
+			wantType:     ChangeKindChanged,
+			wantSeverity: SeverityBreaking,
+		},
+		{
+			name:         "パスの変更は破壊的変更",
+			oldMeta:      meta(endpoint(parser.KindUnaryJSON, nil, nil)),
+			newMeta:      meta(withPath(endpoint(parser.KindUnaryJSON, nil, nil), "/monster/v1/monsters/{id}")),
+			wantType:     ChangeHTTPPathChanged,
+			wantSeverity: SeverityBreaking,
+		},
+		{
+			name:         "認証が必須になるのは破壊的変更",
+			oldMeta:      meta(endpoint(parser.KindUnaryJSON, nil, nil)),
+			newMeta:      meta(withAuth(endpoint(parser.KindUnaryJSON, nil, nil), "required")),
+			wantType:     ChangeAuthChanged,
+			wantSeverity: SeverityBreaking,
+		},
+		{
+			name:         "認証が任意になるのは非破壊的変更",
+			oldMeta:      meta(withAuth(endpoint(parser.KindUnaryJSON, nil, nil), "required")),
+			newMeta:      meta(withAuth(endpoint(parser.KindUnaryJSON, nil, nil), "optional")),
+			wantType:     ChangeAuthChanged,
+			wantSeverity: SeverityNonBreaking,
+		},
+		{
+			name:         "ボディからパスパラメータへの移動は破壊的変更",
+			oldMeta:      meta(endpoint(parser.KindUnaryJSON, []parser.FieldInfo{idField}, nil)),
+			newMeta:      meta(endpoint(parser.KindUnaryJSON, []parser.FieldInfo{pathID}, nil)),
+			wantType:     ChangeFieldMoved,
+			wantSeverity: SeverityBreaking,
+			wantField:    "request.id",
+		},
+		{
+			name:         "レスポンスのフィールド削除は破壊的変更",
+			oldMeta:      meta(endpoint(parser.KindUnaryJSON, nil, []parser.FieldInfo{idField, nameField})),
+			newMeta:      meta(endpoint(parser.KindUnaryJSON, nil, []parser.FieldInfo{idField})),
+			wantType:     ChangeFieldRemoved,
+			wantSeverity: SeverityBreaking,
+			wantField:    "response.name",
+		},
+		{
+			name:         "リクエストのフィールド削除は非破壊的変更",
+			oldMeta:      meta(endpoint(parser.KindUnaryJSON, []parser.FieldInfo{idField, nameField}, nil)),
+			newMeta:      meta(endpoint(parser.KindUnaryJSON, []parser.FieldInfo{idField}, nil)),
+			wantType:     ChangeFieldRemoved,
+			wantSeverity: SeverityNonBreaking,
+			wantField:    "request.name",
+		},
+		{
+			name:         "JSON名の変更はリネームとして検出する",
+			oldMeta:      meta(endpoint(parser.KindUnaryJSON, nil, []parser.FieldInfo{nameField})),
+			newMeta:      meta(endpoint(parser.KindUnaryJSON, nil, []parser.FieldInfo{renamedName})),
+			wantType:     ChangeFieldRenamed,
+			wantSeverity: SeverityBreaking,
+			wantField:    "response.name",
+		},
+		{
+			name:         "TSTypeの変更は破壊的変更",
+			oldMeta:      meta(endpoint(parser.KindUnaryJSON, nil, []parser.FieldInfo{nameField})),
+			newMeta:      meta(endpoint(parser.KindUnaryJSON, nil, []parser.FieldInfo{numericName})),
+			wantType:     ChangeFieldTypeChanged,
+			wantSeverity: SeverityBreaking,
+			wantField:    "response.name",
+		},
+		{
+			name:         "リクエストの任意項目が必須になるのは破壊的変更",
+			oldMeta:      meta(endpoint(parser.KindUnaryJSON, []parser.FieldInfo{idField}, nil)),
+			newMeta:      meta(endpoint(parser.KindUnaryJSON, []parser.FieldInfo{requiredID}, nil)),
+			wantType:     ChangeFieldBecameRequired,
+			wantSeverity: SeverityBreaking,
+			wantField:    "request.id",
+		},
+		{
+			name:         "必須のリクエストフィールドの追加は破壊的変更",
+			oldMeta:      meta(endpoint(parser.KindUnaryJSON, nil, nil)),
+			newMeta:      meta(endpoint(parser.KindUnaryJSON, []parser.FieldInfo{requiredID}, nil)),
+			wantType:     ChangeFieldAdded,
+			wantSeverity: SeverityBreaking,
+			wantField:    "request.id",
+		},
+		{
+			name:         "レスポンスの必須項目が任意になるのは破壊的変更",
+			oldMeta:      meta(endpoint(parser.KindUnaryJSON, nil, []parser.FieldInfo{nameField})),
+			newMeta:      meta(endpoint(parser.KindUnaryJSON, nil, []parser.FieldInfo{optionalName})),
+			wantType:     ChangeFieldBecameOptional,
+			wantSeverity: SeverityBreaking,
+			wantField:    "response.name",
+		},
+		{
+			name:         "ネストされた型のフィールド削除も検出する",
+			oldMeta:      meta(endpoint(parser.KindUnaryJSON, nil, []parser.FieldInfo{nestedMonster(idField, nameField)})),
+			newMeta:      meta(endpoint(parser.KindUnaryJSON, nil, []parser.FieldInfo{nestedMonster(idField)})),
+			wantType:     ChangeFieldRemoved,
+			wantSeverity: SeverityBreaking,
+			wantField:    "response.monster.name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes := Diff(tt.oldMeta, tt.newMeta)
+			if len(changes) != 1 {
+				t.Fatalf("expected 1 change, got %d: %+v", len(changes), changes)
+			}
+
+			c := changes[0]
+			if c.Type != tt.wantType {
+				t.Errorf("type = %s, want %s", c.Type, tt.wantType)
+			}
+			if c.Severity != tt.wantSeverity {
+				t.Errorf("severity = %s, want %s", c.Severity, tt.wantSeverity)
+			}
+			if c.Field != tt.wantField {
+				t.Errorf("field = %s, want %s", c.Field, tt.wantField)
+			}
+			if got := HasBreaking(changes); got != (tt.wantSeverity == SeverityBreaking) {
+				t.Errorf("HasBreaking = %v", got)
+			}
+		})
+	}
+}
+
+func TestDiff_変更がない場合は空(t *testing.T) {
+	m := meta(endpoint(parser.KindUnaryJSON, []parser.FieldInfo{requiredID}, []parser.FieldInfo{nestedMonster(idField)}))
+	if changes := Diff(m, m); len(changes) != 0 {
+		t.Fatalf("expected no changes, got %+v", changes)
+	}
+}
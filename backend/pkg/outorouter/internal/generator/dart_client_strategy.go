@@ -0,0 +1,637 @@
+package generator
+
+import (
+	"bytes"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/kinpatsu-everyone/backend-template/pkg/outorouter/internal/parser"
+)
+
+// DartClientStrategy は Flutter 向けの型安全な API クライアントコードを生成する。
+// 生成されるコードは package:http と package:http_parser に依存する。
+type DartClientStrategy struct {
+	// BaseURL はAPIのベースURL（開発用デフォルト値）
+	BaseURL string
+}
+
+func (s DartClientStrategy) Name() string { return "dart-client" }
+
+func (s DartClientStrategy) Generate(meta *parser.Metadata) (string, error) {
+	if s.BaseURL == "" {
+		s.BaseURL = "http://localhost:8080"
+	}
+
+	endpoints := sorted(meta.All)
+
+	var classes []dartClassData
+	nestedInfos := collectNestedTypeInfos(endpoints)
+	nestedTypes := nestedTypeNames(nestedInfos)
+	for _, nested := range nestedInfos {
+		classes = append(classes, newDartClass(nested.Name, "Nested type: "+nested.Name, nested.Fields, nestedTypes, false))
+	}
+
+	var dartEndpoints []dartEndpointData
+	var unsupported []string
+	for _, ep := range endpoints {
+		if ep.Kind == parser.KindWebSocket {
+			// WebSocketは package:web_socket_channel が必要なため対象外とする
+			unsupported = append(unsupported, ep.Path())
+			continue
+		}
+
+		requestClass := ep.MethodName + "Request"
+		responseClass := ep.MethodName + "Response"
+		isMultipart := ep.Kind == parser.KindFileUpload
+		isFileDownload := ep.Kind == parser.KindFileDownload
+
+		classes = append(classes, newDartClass(requestClass, ep.Summary+" - Request", ep.RequestTypeInfo.Fields, nestedTypes, isMultipart))
+		if !isFileDownload {
+			classes = append(classes, newDartClass(responseClass, ep.Summary+" - Response", ep.ResponseTypeInfo.Fields, nestedTypes, false))
+		}
+
+		uriExpr, bodyExpr := dartRequestExprs(ep)
+		dartEndpoints = append(dartEndpoints, dartEndpointData{
+			HTTPMethod:     ep.HTTPMethod,
+			URIExpr:        uriExpr,
+			BodyExpr:       bodyExpr,
+			MethodName:     toLowerCamel(ep.MethodName),
+			Summary:        ep.Summary,
+			RequestClass:   requestClass,
+			ResponseClass:  responseClass,
+			IsMultipart:    isMultipart,
+			IsFileDownload: isFileDownload,
+		})
+	}
+
+	var errorConstants []dartErrorCodeData
+	for _, code := range collectErrorCodes(endpoints) {
+		errorConstants = append(errorConstants, dartErrorCodeData{
+			Name: dartIdentifier(code),
+			Code: code,
+		})
+	}
+
+	data := map[string]any{
+		"BaseURL":     s.BaseURL,
+		"Classes":     classes,
+		"Endpoints":   dartEndpoints,
+		"ErrorCodes":  errorConstants,
+		"Unsupported": unsupported,
+	}
+
+	buf := &bytes.Buffer{}
+	tmpl := template.Must(template.New("dartClient").Parse(dartClientTemplate))
+	if err := tmpl.Execute(buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+type dartClassData struct {
+	Name        string
+	Doc         string
+	Fields      []dartFieldData
+	IsMultipart bool
+}
+
+type dartFieldData struct {
+	JSONName   string
+	Name       string
+	Type       string
+	Nullable   bool
+	Decode     string
+	Encode     string
+	FormValue  string
+	IsFile     bool
+	IsFileList bool
+	// IsParam はパスパラメータ・クエリ文字列で送るフィールドかどうかです（multipartのフォームには含めない）
+	IsParam bool
+}
+
+type dartEndpointData struct {
+	HTTPMethod     string
+	URIExpr        string // リクエストのUriを組み立てるDartの式
+	BodyExpr       string // JSONのリクエストボディを組み立てるDartの式
+	MethodName     string
+	Summary        string
+	RequestClass   string
+	ResponseClass  string
+	IsMultipart    bool
+	IsFileDownload bool
+}
+
+type dartErrorCodeData struct {
+	Name string
+	Code string
+}
+
+func newDartClass(name, doc string, fields []parser.FieldInfo, nestedTypes map[string]bool, isMultipart bool) dartClassData {
+	class := dartClassData{Name: name, Doc: doc, IsMultipart: isMultipart}
+	for _, f := range fields {
+		goType := strings.TrimPrefix(namedScalarGoType(f.Type, f.TSType), "*")
+		nullable := f.Optional || (strings.HasPrefix(f.Type, "*") && !hasRule(f, "required"))
+
+		field := dartFieldData{
+			JSONName:   f.JSONName,
+			Name:       dartIdentifier(f.JSONName),
+			Type:       dartType(goType, nestedTypes),
+			Nullable:   nullable,
+			Encode:     dartEncode(goType, nestedTypes, dartIdentifier(f.JSONName)+bangIf(nullable)),
+			IsFile:     goType == "multipart.FileHeader",
+			IsFileList: goType == "[]*multipart.FileHeader",
+			IsParam:    f.In != "",
+		}
+
+		field.FormValue = field.Name + bangIf(nullable)
+		if field.Type != "String" {
+			field.FormValue += ".toString()"
+		}
+
+		source := "json['" + f.JSONName + "']"
+		if nullable {
+			field.Decode = source + " == null ? null : " + dartDecode(goType, nestedTypes, source)
+		} else {
+			field.Decode = dartDecode(goType, nestedTypes, source)
+		}
+
+		class.Fields = append(class.Fields, field)
+	}
+	return class
+}
+
+// dartRequestExprs はリクエストのUriとJSONボディを組み立てるDartの式を返す
+// パスパラメータはパスに埋め込み、クエリ文字列のフィールドは toJson() から取り出して送る
+func dartRequestExprs(ep parser.Endpoint) (uriExpr, bodyExpr string) {
+	path := ""
+	var pathKeys, queryKeys []string
+	fieldNames := make(map[string]string)
+	for _, f := range ep.RequestTypeInfo.Fields {
+		switch {
+		case f.In == "path":
+			pathKeys = append(pathKeys, f.JSONName)
+			fieldNames[f.JSONName] = dartIdentifier(f.JSONName)
+		case f.In == "query" || ep.Kind == parser.KindFileDownload:
+			queryKeys = append(queryKeys, f.JSONName)
+		}
+	}
+	for _, segment := range strings.Split(strings.Trim(ep.RoutePath(), "/"), "/") {
+		name, ok := strings.CutPrefix(segment, "{")
+		name, isParam := strings.CutSuffix(name, "}")
+		if ok && isParam && fieldNames[name] != "" {
+			segment = "${Uri.encodeComponent(request." + fieldNames[name] + ".toString())}"
+		}
+		path += "/" + segment
+	}
+
+	uriExpr = "_uri('" + path + "')"
+	if len(queryKeys) > 0 {
+		uriExpr = "_uri('" + path + "', _pick(request.toJson(), " + dartStringList(queryKeys) + "))"
+	}
+
+	switch {
+	case !ep.HasRequestBody():
+		bodyExpr = "null"
+	case len(pathKeys)+len(queryKeys) > 0:
+		bodyExpr = "_omit(request.toJson(), " + dartStringList(append(pathKeys, queryKeys...)) + ")"
+	default:
+		bodyExpr = "request.toJson()"
+	}
+	return uriExpr, bodyExpr
+}
+
+func dartStringList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = "'" + v + "'"
+	}
+	return "const [" + strings.Join(quoted, ", ") + "]"
+}
+
+func bangIf(b bool) string {
+	if b {
+		return "!"
+	}
+	return ""
+}
+
+// dartType はGoの型名をDartの型名に変換する
+// nestedTypes は生成コードにクラスを出力するネストされた型の名前の集合
+func dartType(goType string, nestedTypes map[string]bool) string {
+	goType = strings.TrimPrefix(goType, "*")
+
+	switch {
+	case goType == "[]byte" || goType == "[]uint8":
+		// []byte はJSONではbase64文字列になる
+		return "String"
+	case strings.HasPrefix(goType, "[]"):
+		return "List<" + dartType(goType[2:], nestedTypes) + ">"
+	case strings.HasPrefix(goType, "map["):
+		end := strings.Index(goType, "]")
+		return "Map<String, " + dartType(goType[end+1:], nestedTypes) + ">"
+	}
+
+	switch goType {
+	case "string":
+		return "String"
+	case "bool":
+		return "bool"
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64":
+		return "int"
+	case "float32", "float64":
+		return "double"
+	case "time.Time":
+		return "DateTime"
+	case "multipart.FileHeader":
+		return "ApiUploadFile"
+	}
+
+	if name, ok := resolveNestedType(goType, nestedTypes); ok {
+		return name
+	}
+	return "dynamic"
+}
+
+// dartDecode はJSONの値 v をDartの型に変換する式を返す
+func dartDecode(goType string, nestedTypes map[string]bool, v string) string {
+	goType = strings.TrimPrefix(goType, "*")
+
+	switch {
+	case goType == "[]byte" || goType == "[]uint8":
+		return v + " as String"
+	case strings.HasPrefix(goType, "[]"):
+		// Goのnilスライスはnullとしてエンコードされるため空リストとして扱う
+		return "((" + v + " as List<dynamic>?) ?? const []).map((e) => " + dartDecode(goType[2:], nestedTypes, "e") + ").toList()"
+	case strings.HasPrefix(goType, "map["):
+		end := strings.Index(goType, "]")
+		return "((" + v + " as Map<String, dynamic>?) ?? const {}).map((k, e) => MapEntry(k, " + dartDecode(goType[end+1:], nestedTypes, "e") + "))"
+	}
+
+	switch typ := dartType(goType, nestedTypes); typ {
+	case "String":
+		return v + " as String"
+	case "bool":
+		return v + " as bool"
+	case "int":
+		return "(" + v + " as num).toInt()"
+	case "double":
+		return "(" + v + " as num).toDouble()"
+	case "DateTime":
+		return "DateTime.parse(" + v + " as String)"
+	case "ApiUploadFile":
+		return v + " as ApiUploadFile"
+	case "dynamic":
+		return v
+	default:
+		return typ + ".fromJson(" + v + " as Map<String, dynamic>)"
+	}
+}
+
+// dartEncode はDartの値 v をJSONに変換する式を返す
+func dartEncode(goType string, nestedTypes map[string]bool, v string) string {
+	goType = strings.TrimPrefix(goType, "*")
+
+	switch {
+	case goType == "[]byte" || goType == "[]uint8":
+		return v
+	case strings.HasPrefix(goType, "[]"):
+		elem := dartEncode(goType[2:], nestedTypes, "e")
+		if elem == "e" {
+			return v
+		}
+		return v + ".map((e) => " + elem + ").toList()"
+	case strings.HasPrefix(goType, "map["):
+		end := strings.Index(goType, "]")
+		elem := dartEncode(goType[end+1:], nestedTypes, "e")
+		if elem == "e" {
+			return v
+		}
+		return v + ".map((k, e) => MapEntry(k, " + elem + "))"
+	}
+
+	switch dartType(goType, nestedTypes) {
+	case "String", "bool", "int", "double", "dynamic", "ApiUploadFile":
+		return v
+	case "DateTime":
+		return v + ".toUtc().toIso8601String()"
+	default:
+		return v + ".toJson()"
+	}
+}
+
+// dartReservedWords はDartの予約語です（フィールド名として使えないもの）
+var dartReservedWords = map[string]bool{
+	"assert": true, "break": true, "case": true, "catch": true, "class": true, "const": true,
+	"continue": true, "default": true, "do": true, "else": true, "enum": true, "extends": true,
+	"false": true, "final": true, "finally": true, "for": true, "if": true, "in": true, "is": true,
+	"new": true, "null": true, "rethrow": true, "return": true, "super": true, "switch": true,
+	"this": true, "throw": true, "true": true, "try": true, "var": true, "void": true,
+	"while": true, "with": true,
+}
+
+// dartIdentifier は snake_case / UPPER_SNAKE_CASE の名前を lowerCamelCase のDart識別子に変換する
+func dartIdentifier(name string) string {
+	parts := strings.FieldsFunc(name, func(r rune) bool {
+		return r == '_' || r == '-' || r == ' '
+	})
+
+	var b strings.Builder
+	for i, p := range parts {
+		r := []rune(p)
+		if isAllUpper(p) {
+			r = []rune(strings.ToLower(p))
+		}
+		if i == 0 {
+			r[0] = unicode.ToLower(r[0])
+		} else {
+			r[0] = unicode.ToUpper(r[0])
+		}
+		b.WriteString(string(r))
+	}
+
+	ident := b.String()
+	if ident == "" {
+		return "value"
+	}
+	if unicode.IsDigit([]rune(ident)[0]) {
+		ident = "v" + ident
+	}
+	if dartReservedWords[ident] {
+		ident += "Value"
+	}
+	return ident
+}
+
+func isAllUpper(s string) bool {
+	for _, r := range s {
+		if unicode.IsLower(r) {
+			return false
+		}
+	}
+	return true
+}
+
+const dartClientTemplate = `// Code generated by outorouter; DO NOT EDIT.
+// This file provides a type-safe API client for Flutter.
+//
+// Dependencies (pubspec.yaml):
+//   http: ^1.2.0
+//   http_parser: ^4.0.0
+
+import 'dart:convert';
+import 'dart:typed_data';
+
+import 'package:http/http.dart' as http;
+import 'package:http_parser/http_parser.dart';
+
+const String defaultBaseUrl = '{{ .BaseURL }}';
+
+// ============================================================================
+// Errors
+// ============================================================================
+
+/// Error codes that the server may return.
+class ApiErrorCode {
+  ApiErrorCode._();
+{{- range .ErrorCodes }}
+  static const String {{ .Name }} = '{{ .Code }}';
+{{- end }}
+
+  /// Used when the error response could not be parsed.
+  static const String unknownError = 'UNKNOWN_ERROR';
+}
+
+/// Field-level error detail (e.g. validation failures).
+class ApiFieldError {
+  const ApiFieldError({required this.field, required this.code, required this.message});
+
+  final String field;
+  final String code;
+  final String message;
+
+  factory ApiFieldError.fromJson(Map<String, dynamic> json) => ApiFieldError(
+        field: json['field'] as String? ?? '',
+        code: json['code'] as String? ?? '',
+        message: json['message'] as String? ?? '',
+      );
+}
+
+/// Thrown when the server responds with a non-2xx status code.
+class ApiException implements Exception {
+  const ApiException({
+    required this.statusCode,
+    required this.code,
+    required this.message,
+    this.requestId,
+    this.details = const [],
+  });
+
+  final int statusCode;
+  final String code;
+  final String message;
+  final String? requestId;
+  final List<ApiFieldError> details;
+
+  factory ApiException.fromResponse(http.BaseResponse response, String body) {
+    try {
+      final decoded = jsonDecode(body) as Map<String, dynamic>;
+      final error = decoded['error'] as Map<String, dynamic>;
+      return ApiException(
+        statusCode: response.statusCode,
+        code: error['code'] as String? ?? ApiErrorCode.unknownError,
+        message: error['message'] as String? ?? '',
+        requestId: error['request_id'] as String?,
+        details: ((error['details'] as List<dynamic>?) ?? const [])
+            .map((e) => ApiFieldError.fromJson(e as Map<String, dynamic>))
+            .toList(),
+      );
+    } catch (_) {
+      return ApiException(
+        statusCode: response.statusCode,
+        code: ApiErrorCode.unknownError,
+        message: response.reasonPhrase ?? 'Unknown error',
+      );
+    }
+  }
+
+  @override
+  String toString() => 'ApiException($statusCode, $code): $message';
+}
+
+/// A file to upload with multipart/form-data.
+class ApiUploadFile {
+  const ApiUploadFile({required this.filename, required this.bytes, this.contentType});
+
+  final String filename;
+  final List<int> bytes;
+  final String? contentType;
+
+  http.MultipartFile toMultipartFile(String field) => http.MultipartFile.fromBytes(
+        field,
+        bytes,
+        filename: filename,
+        contentType: contentType == null ? null : MediaType.parse(contentType!),
+      );
+}
+
+// ============================================================================
+// Models
+// ============================================================================
+{{- range .Classes }}
+
+/// {{ .Doc }}
+class {{ .Name }} {
+{{- if .Fields }}
+  const {{ .Name }}({
+{{- range .Fields }}
+    {{ if not .Nullable }}required {{ end }}this.{{ .Name }},
+{{- end }}
+  });
+{{ range .Fields }}
+  final {{ .Type }}{{ if .Nullable }}?{{ end }} {{ .Name }};
+{{- end }}
+
+  factory {{ .Name }}.fromJson(Map<String, dynamic> json) => {{ .Name }}(
+{{- range .Fields }}
+        {{ .Name }}: {{ .Decode }},
+{{- end }}
+      );
+
+  Map<String, dynamic> toJson() => {
+{{- range .Fields }}
+{{- if not (or .IsFile .IsFileList) }}
+        {{ if .Nullable }}if ({{ .Name }} != null) {{ end }}'{{ .JSONName }}': {{ .Encode }},
+{{- end }}
+{{- end }}
+      };
+{{- if .IsMultipart }}
+
+  Map<String, String> toFields() => {
+{{- range .Fields }}
+{{- if not (or .IsFile .IsFileList .IsParam) }}
+        {{ if .Nullable }}if ({{ .Name }} != null) {{ end }}'{{ .JSONName }}': {{ .FormValue }},
+{{- end }}
+{{- end }}
+      };
+
+  List<http.MultipartFile> toFiles() => [
+{{- range .Fields }}
+{{- if .IsFile }}
+        {{ if .Nullable }}if ({{ .Name }} != null) {{ end }}{{ .Name }}{{ if .Nullable }}!{{ end }}.toMultipartFile('{{ .JSONName }}'),
+{{- else if .IsFileList }}
+        {{ if .Nullable }}if ({{ .Name }} != null) {{ end }}...{{ .Name }}{{ if .Nullable }}!{{ end }}.map((f) => f.toMultipartFile('{{ .JSONName }}')),
+{{- end }}
+{{- end }}
+      ];
+{{- end }}
+{{- else }}
+  const {{ .Name }}();
+
+  factory {{ .Name }}.fromJson(Map<String, dynamic> json) => const {{ .Name }}();
+
+  Map<String, dynamic> toJson() => const {};
+{{- if .IsMultipart }}
+
+  Map<String, String> toFields() => const {};
+
+  List<http.MultipartFile> toFiles() => const [];
+{{- end }}
+{{- end }}
+}
+{{- end }}
+
+// ============================================================================
+// Client
+// ============================================================================
+
+class ApiClient {
+  ApiClient({String? baseUrl, http.Client? httpClient, Map<String, String>? headers})
+      : baseUrl = baseUrl ?? defaultBaseUrl,
+        headers = headers ?? const {},
+        _http = httpClient ?? http.Client();
+
+  final String baseUrl;
+  final Map<String, String> headers;
+  final http.Client _http;
+
+  void close() => _http.close();
+
+  Uri _uri(String path, [Map<String, dynamic> query = const {}]) {
+    final uri = Uri.parse('$baseUrl$path');
+    final params = <String, dynamic>{
+      for (final e in query.entries)
+        if (e.value != null)
+          e.key: e.value is List ? (e.value as List).map((v) => v.toString()).toList() : e.value.toString(),
+    };
+    return params.isEmpty ? uri : uri.replace(queryParameters: params);
+  }
+
+  static Map<String, dynamic> _pick(Map<String, dynamic> json, List<String> keys) => {
+        for (final key in keys)
+          if (json.containsKey(key)) key: json[key],
+      };
+
+  static Map<String, dynamic> _omit(Map<String, dynamic> json, List<String> keys) => {
+        for (final e in json.entries)
+          if (!keys.contains(e.key)) e.key: e.value,
+      };
+
+  Future<Map<String, dynamic>> _sendJson(String method, Uri uri, Map<String, dynamic>? body) async {
+    final request = http.Request(method, uri)..headers.addAll(headers);
+    if (body != null) {
+      request.headers['Content-Type'] = 'application/json';
+      request.body = jsonEncode(body);
+    }
+    final response = await http.Response.fromStream(await _http.send(request));
+    if (response.statusCode < 200 || response.statusCode >= 300) {
+      throw ApiException.fromResponse(response, response.body);
+    }
+    return jsonDecode(response.body) as Map<String, dynamic>;
+  }
+
+  Future<Map<String, dynamic>> _sendMultipart(
+    String method,
+    Uri uri,
+    Map<String, String> fields,
+    List<http.MultipartFile> files,
+  ) async {
+    final request = http.MultipartRequest(method, uri)
+      ..headers.addAll(headers)
+      ..fields.addAll(fields)
+      ..files.addAll(files);
+    final streamed = await _http.send(request);
+    final body = await streamed.stream.bytesToString();
+    if (streamed.statusCode < 200 || streamed.statusCode >= 300) {
+      throw ApiException.fromResponse(streamed, body);
+    }
+    return jsonDecode(body) as Map<String, dynamic>;
+  }
+
+  Future<Uint8List> _download(Uri uri) async {
+    final response = await _http.get(uri, headers: headers);
+    if (response.statusCode < 200 || response.statusCode >= 300) {
+      throw ApiException.fromResponse(response, response.body);
+    }
+    return response.bodyBytes;
+  }
+{{- range .Endpoints }}
+
+  /// {{ .Summary }}
+{{- if .IsMultipart }}
+  Future<{{ .ResponseClass }}> {{ .MethodName }}({{ .RequestClass }} request) async => {{ .ResponseClass }}.fromJson(
+      await _sendMultipart('{{ .HTTPMethod }}', {{ .URIExpr }}, request.toFields(), request.toFiles()));
+{{- else if .IsFileDownload }}
+  Future<Uint8List> {{ .MethodName }}({{ .RequestClass }} request) =>
+      _download({{ .URIExpr }});
+{{- else }}
+  Future<{{ .ResponseClass }}> {{ .MethodName }}({{ .RequestClass }} request) async =>
+      {{ .ResponseClass }}.fromJson(await _sendJson('{{ .HTTPMethod }}', {{ .URIExpr }}, {{ .BodyExpr }}));
+{{- end }}
+{{- end }}
+}
+{{- range .Unsupported }}
+
+// {{ . }}: WebSocket endpoints are not supported by the Dart client.
+{{- end }}
+`
@@ -0,0 +1,125 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDartClientStrategy(t *testing.T) {
+	code, err := New(DartClientStrategy{BaseURL: "https://api.example.com"}).Generate(openAPITestMetadata())
+	if err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "base url", want: "const String defaultBaseUrl = 'https://api.example.com';"},
+		{name: "package:http", want: "import 'package:http/http.dart' as http;"},
+		{name: "request model", want: "class GetMonsterRequest {"},
+		{name: "response model", want: "class GetMonsterResponse {"},
+		{name: "nested model", want: "class MonsterItem {"},
+		{name: "fromJson", want: "factory GetMonsterRequest.fromJson(Map<String, dynamic> json)"},
+		{name: "required field", want: "required this.id,"},
+		{name: "double decode", want: "latitude: (json['latitude'] as num).toDouble(),"},
+		{name: "nested decode", want: "monster: MonsterItem.fromJson(json['monster'] as Map<String, dynamic>),"},
+		{name: "nested encode", want: "'monster': monster.toJson(),"},
+		{name: "optional field", want: "final String? type;"},
+		{name: "optional encode", want: "if (type != null) 'type': type!,"},
+		{name: "error code", want: "static const String monsterNotFound = 'MONSTER_NOT_FOUND';"},
+		{name: "json method", want: "Future<GetMonsterResponse> getMonster(GetMonsterRequest request) async =>"},
+		{name: "json call", want: "await _sendJson('POST', _uri('/monster/v1/GetMonster'), request.toJson())"},
+		{name: "multipart method", want: "await _sendMultipart('POST', _uri('/monster/v1/CreateMonster'), request.toFields(), request.toFiles())"},
+		{name: "multipart file", want: "image.toMultipartFile('image'),"},
+		{name: "multipart field", want: "'latitude': latitude.toString(),"},
+		{name: "download method", want: "Future<Uint8List> downloadMonsterImage(DownloadMonsterImageRequest request) =>"},
+		{name: "download query", want: "_download(_uri('/monster/v1/DownloadMonsterImage', _pick(request.toJson(), const ['type'])))"},
+		{name: "path parameter", want: "_sendJson('GET', _uri('/monster/v1/monsters/${Uri.encodeComponent(request.id.toString())}', _pick(request.toJson(), const ['lang'])), null)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !strings.Contains(code, tt.want) {
+				t.Errorf("generated code missing %q", tt.want)
+			}
+		})
+	}
+
+	if strings.Contains(code, "class DownloadMonsterImageResponse") {
+		t.Error("file download endpoint should not have a response model")
+	}
+}
+
+func TestDartClientStrategy_同じ型のフィールドはどちらもネストされた型のクラスを使う(t *testing.T) {
+	code, err := New(DartClientStrategy{}).Generate(sharedNestedTypeMetadata())
+	if err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+
+	for _, want := range []string{
+		"final GeoPoint sw;",
+		"final GeoPoint ne;",
+		"final List<GeoPoint>? corners;",
+		"ne: GeoPoint.fromJson(json['ne'] as Map<String, dynamic>),",
+		"'ne': ne.toJson(),",
+		"((json['corners'] as List<dynamic>?) ?? const []).map((e) => GeoPoint.fromJson(e as Map<String, dynamic>)).toList()",
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("generated code missing %q", want)
+		}
+	}
+	if strings.Contains(code, "final dynamic") {
+		t.Error("nested type should not fall back to dynamic")
+	}
+}
+
+func TestDartClientStrategy_文字列を基底の型とする外部パッケージの型はStringにする(t *testing.T) {
+	code, err := New(DartClientStrategy{}).Generate(namedScalarTypeMetadata())
+	if err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+
+	for _, want := range []string{
+		"final String? trashCategorySlug;",
+		"final String trashCategory;",
+		"trashCategory: json['trash_category'] as String,",
+		"final List<String> slugs;",
+		"final DateTime updatedAt;",
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("generated code missing %q", want)
+		}
+	}
+	if strings.Contains(code, "final dynamic") {
+		t.Error("named string type should not fall back to dynamic")
+	}
+}
+
+func TestDartType(t *testing.T) {
+	nested := map[string]bool{"MonsterItem": true}
+
+	tests := []struct {
+		goType string
+		nested map[string]bool
+		want   string
+	}{
+		{goType: "string", want: "String"},
+		{goType: "int64", want: "int"},
+		{goType: "*float64", want: "double"},
+		{goType: "time.Time", want: "DateTime"},
+		{goType: "[]string", want: "List<String>"},
+		{goType: "[]handler.MonsterItem", nested: nested, want: "List<MonsterItem>"},
+		{goType: "map[string]int", want: "Map<String, int>"},
+		{goType: "[]byte", want: "String"},
+		{goType: "interface {}", want: "dynamic"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.goType, func(t *testing.T) {
+			if got := dartType(tt.goType, tt.nested); got != tt.want {
+				t.Errorf("dartType(%q) = %q, want %q", tt.goType, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,840 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/kinpatsu-everyone/backend-template/pkg/outorouter/internal/parser"
+)
+
+// E2ETestStrategy は各エンドポイントに対するE2Eテストの雛形（_test.go）を生成する。
+// テストは httptest を使って router.Build で構築したハンドラーにリクエストを送る。
+type E2ETestStrategy struct {
+	// PackageName は生成されるテストファイルのパッケージ名
+	PackageName string
+	// RouterImportPath は Build 関数を持つパッケージのインポートパス
+	RouterImportPath string
+	// OutorouterImportPath は outorouter パッケージのインポートパス
+	OutorouterImportPath string
+	// RunHappyPath がtrueの場合、ハンドラーまで到達するケースもスキップせずに実行する
+	RunHappyPath bool
+}
+
+func (s E2ETestStrategy) Name() string { return "e2e-test" }
+
+func (s E2ETestStrategy) Generate(meta *parser.Metadata) (string, error) {
+	if s.PackageName == "" {
+		s.PackageName = "router_test"
+	}
+	if s.RouterImportPath == "" {
+		s.RouterImportPath = "github.com/kinpatsu-everyone/backend-template/router"
+	}
+	if s.OutorouterImportPath == "" {
+		s.OutorouterImportPath = "github.com/kinpatsu-everyone/backend-template/pkg/outorouter"
+	}
+
+	var tests []e2eTestData
+	hasWebSocket := false
+	for _, ep := range sorted(meta.All) {
+		newTest := s.newE2ETest
+		if ep.Kind == parser.KindWebSocket {
+			newTest = s.newE2EWebSocketTest
+			hasWebSocket = true
+		}
+
+		test, err := newTest(ep)
+		if err != nil {
+			return "", fmt.Errorf("failed to generate test for %s: %w", ep.Path(), err)
+		}
+		tests = append(tests, test)
+	}
+
+	data := map[string]any{
+		"Package":          s.PackageName,
+		"RouterImport":     s.RouterImportPath,
+		"OutorouterImport": s.OutorouterImportPath,
+		"Tests":            tests,
+		"HasWebSocket":     hasWebSocket,
+		"Scopes":           e2eScopes(meta.All),
+	}
+
+	buf := &bytes.Buffer{}
+	tmpl := template.Must(template.New("e2eTest").Parse(e2eTestTemplate))
+	if err := tmpl.Execute(buf, data); err != nil {
+		return "", err
+	}
+
+	code, err := format.Source(buf.Bytes())
+	if err != nil {
+		return "", fmt.Errorf("failed to format generated code: %w", err)
+	}
+	return string(code), nil
+}
+
+type e2eTestData struct {
+	FuncName string
+	Method   string
+	Path     string
+	Summary  string
+	Request  string
+	Cases    []e2eCaseData
+	// WebSocket がtrueの場合は接続してフレームを送るテストを生成する
+	WebSocket bool
+}
+
+type e2eCaseData struct {
+	Name           string
+	Input          string
+	ExpectedStatus string
+	ExpectedCode   string
+	Skip           string
+	Authenticated  bool // テスト用のトークンを付けてリクエストする
+}
+
+const e2eSkipMessage = "TODO: 正常系のリクエストと外部依存（DB・外部API）のセットアップを記述してください"
+
+func (s E2ETestStrategy) newE2ETest(ep parser.Endpoint) (e2eTestData, error) {
+	test := e2eTestData{
+		FuncName: fmt.Sprintf("TestE2E_%s_v%d_%s", ep.Domain, ep.Version, ep.MethodName),
+		Method:   ep.HTTPMethod,
+		Path:     ep.RoutePath(),
+		Summary:  ep.Summary,
+	}
+	if test.Method == "" {
+		test.Method = "POST"
+	}
+
+	fields := ep.RequestTypeInfo.Fields
+	switch {
+	case ep.Kind == parser.KindFileUpload:
+		test.Request = "newE2EMultipartRequest"
+	case ep.Kind == parser.KindFileDownload || !ep.HasRequestBody():
+		test.Request = "newE2EQueryRequest"
+	default:
+		test.Request = "newE2EJSONRequest"
+	}
+
+	valid := e2eSampleObject(fields)
+
+	// パスパラメータ・クエリ文字列・ボディに値を振り分ける
+	// パスパラメータが省略された場合は正常系のサンプル値で埋める（空のセグメントは404になるため）
+	input := func(values map[string]any) (string, error) {
+		var lines []string
+		query := make(map[string]any)
+		rest := make(map[string]any)
+		path := ep.RoutePath()
+		for _, f := range fields {
+			v, ok := values[f.JSONName]
+			switch {
+			case f.In == "path":
+				if !ok {
+					v = valid[f.JSONName]
+				}
+				path = strings.ReplaceAll(path, "{"+f.JSONName+"}", url.PathEscape(fmt.Sprint(v)))
+			case !ok:
+			case f.In == "query" || ep.Kind == parser.KindFileDownload:
+				query[f.JSONName] = v
+			default:
+				rest[f.JSONName] = v
+			}
+		}
+
+		if path != ep.RoutePath() {
+			lines = append(lines, fmt.Sprintf("path: %q,", path))
+		}
+		if len(query) > 0 || test.Request == "newE2EQueryRequest" {
+			lines = append(lines, fmt.Sprintf("query: %q,", e2eQuery(query)))
+		}
+		switch test.Request {
+		case "newE2EMultipartRequest":
+			lines = append(lines, e2eMultipartInput(fields, rest))
+		case "newE2EJSONRequest":
+			body, err := json.Marshal(rest)
+			if err != nil {
+				return "", err
+			}
+			lines = append(lines, fmt.Sprintf("body: %s,", strconv.Quote(string(body))))
+		}
+		return strings.Join(lines, "\n"), nil
+	}
+
+	// 認証が必須のエンドポイントは、トークンなしで401になることを確認し、以降のケースはトークンを付ける
+	authRequired := ep.AuthRequirement() == "required"
+	if authRequired {
+		in, err := input(valid)
+		if err != nil {
+			return test, err
+		}
+		test.Cases = append(test.Cases, e2eCaseData{
+			Name:           "認証なし",
+			Input:          in,
+			ExpectedStatus: "http.StatusUnauthorized",
+			ExpectedCode:   "UNAUTHORIZED",
+		})
+	}
+
+	// 空のリクエスト（フィールドがパスパラメータのみの場合は正常系と同じになるため省略する）
+	if len(fields) == 0 || len(nonPathFields(fields)) > 0 {
+		empty, err := input(map[string]any{})
+		if err != nil {
+			return test, err
+		}
+		emptyCase := e2eCaseData{Name: "空のリクエスト", Input: empty}
+		if hasRequiredField(nonPathFields(fields)) {
+			emptyCase.ExpectedStatus = "http.StatusBadRequest"
+			emptyCase.ExpectedCode = "VALIDATION_FAILED"
+		} else {
+			emptyCase.ExpectedStatus = "http.StatusOK"
+			emptyCase.Skip = s.skip()
+		}
+		test.Cases = append(test.Cases, emptyCase)
+	}
+
+	// バリデーションエラー
+	invalid, ok := e2eInvalidObject(nonPathFields(fields), valid)
+	if !ok {
+		invalid, ok = e2eInvalidPathObject(fields, valid)
+	}
+	if ok {
+		in, err := input(invalid)
+		if err != nil {
+			return test, err
+		}
+		test.Cases = append(test.Cases, e2eCaseData{
+			Name:           "バリデーションエラー",
+			Input:          in,
+			ExpectedStatus: "http.StatusBadRequest",
+			ExpectedCode:   "VALIDATION_FAILED",
+		})
+	}
+
+	// 正常系
+	in, err := input(valid)
+	if err != nil {
+		return test, err
+	}
+	test.Cases = append(test.Cases, e2eCaseData{
+		Name:           "正常系",
+		Input:          in,
+		ExpectedStatus: "http.StatusOK",
+		Skip:           s.skip(),
+	})
+
+	for i := range test.Cases {
+		test.Cases[i].Authenticated = authRequired && i > 0
+	}
+	return test, nil
+}
+
+// newE2EWebSocketTest はWebSocketエンドポイントのテストを生成する
+// ハンドシェイクの認証と、最初に送るフレームのバリデーションを確認する（各ケースの body をフレームとして送る）
+func (s E2ETestStrategy) newE2EWebSocketTest(ep parser.Endpoint) (e2eTestData, error) {
+	test := e2eTestData{
+		FuncName:  fmt.Sprintf("TestE2E_%s_v%d_%s", ep.Domain, ep.Version, ep.MethodName),
+		Method:    "GET",
+		Path:      ep.RoutePath(),
+		Summary:   ep.Summary,
+		WebSocket: true,
+	}
+
+	fields := ep.RequestTypeInfo.Fields
+	valid := e2eSampleObject(fields)
+	frame := func(values map[string]any) (string, error) {
+		body, err := json.Marshal(values)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("body: %s,", strconv.Quote(string(body))), nil
+	}
+
+	validFrame, err := frame(valid)
+	if err != nil {
+		return test, err
+	}
+
+	// 認証が必須のエンドポイントは、トークンなしでハンドシェイクが401になることを確認し、以降のケースはトークンを付ける
+	authRequired := ep.AuthRequirement() == "required"
+	if authRequired {
+		test.Cases = append(test.Cases, e2eCaseData{
+			Name:           "認証なし",
+			Input:          validFrame,
+			ExpectedStatus: "http.StatusUnauthorized",
+			ExpectedCode:   "UNAUTHORIZED",
+		})
+	}
+
+	// 空のフレーム
+	if hasRequiredField(fields) {
+		test.Cases = append(test.Cases, e2eCaseData{
+			Name:           "空のフレーム",
+			Input:          `body: "{}",`,
+			ExpectedStatus: "http.StatusSwitchingProtocols",
+			ExpectedCode:   "VALIDATION_FAILED",
+		})
+	}
+
+	// バリデーションエラー
+	if invalid, ok := e2eInvalidObject(fields, valid); ok {
+		in, err := frame(invalid)
+		if err != nil {
+			return test, err
+		}
+		test.Cases = append(test.Cases, e2eCaseData{
+			Name:           "バリデーションエラー",
+			Input:          in,
+			ExpectedStatus: "http.StatusSwitchingProtocols",
+			ExpectedCode:   "VALIDATION_FAILED",
+		})
+	}
+
+	// 正常系
+	test.Cases = append(test.Cases, e2eCaseData{
+		Name:           "正常系",
+		Input:          validFrame,
+		ExpectedStatus: "http.StatusSwitchingProtocols",
+		Skip:           s.skip(),
+	})
+
+	for i := range test.Cases {
+		test.Cases[i].Authenticated = authRequired && i > 0
+	}
+	return test, nil
+}
+
+func (s E2ETestStrategy) skip() string {
+	if s.RunHappyPath {
+		return ""
+	}
+	return e2eSkipMessage
+}
+
+func hasRequiredField(fields []parser.FieldInfo) bool {
+	for _, f := range fields {
+		if hasRule(f, "required") {
+			return true
+		}
+	}
+	return false
+}
+
+// nonPathFields はパスパラメータ以外のフィールドを返す
+func nonPathFields(fields []parser.FieldInfo) []parser.FieldInfo {
+	var result []parser.FieldInfo
+	for _, f := range fields {
+		if f.In != "path" {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+func ruleValue(f parser.FieldInfo, name string) (string, bool) {
+	for _, r := range f.Validation {
+		if r.Name == name {
+			return r.Value, true
+		}
+	}
+	return "", false
+}
+
+func ruleNumber(f parser.FieldInfo, name string) (float64, bool) {
+	v, ok := ruleValue(f, name)
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.ParseFloat(v, 64)
+	return n, err == nil
+}
+
+// e2eSampleObject はvalidateタグを満たすリクエストのサンプル値を生成する
+func e2eSampleObject(fields []parser.FieldInfo) map[string]any {
+	obj := make(map[string]any)
+	for _, f := range fields {
+		if v := e2eSampleValue(f); v != nil {
+			obj[f.JSONName] = v
+		}
+	}
+	return obj
+}
+
+func e2eSampleValue(f parser.FieldInfo) any {
+	typ := strings.TrimPrefix(f.Type, "*")
+
+	if oneOf, ok := ruleValue(f, "oneof"); ok {
+		first := strings.Fields(oneOf)[0]
+		if typ == "string" {
+			return first
+		}
+		if n, err := strconv.ParseFloat(first, 64); err == nil {
+			return n
+		}
+	}
+
+	switch {
+	case typ == "multipart.FileHeader" || typ == "[]*multipart.FileHeader":
+		return e2eFile(f, true)
+	case typ == "[]byte" || typ == "[]uint8":
+		return ""
+	case strings.HasPrefix(typ, "[]"):
+		n, _ := ruleNumber(f, "min")
+		elem := parser.FieldInfo{Type: typ[2:], NestedType: f.NestedType}
+		values := make([]any, 0, int(n))
+		for i := 0; i < int(n); i++ {
+			values = append(values, e2eSampleValue(elem))
+		}
+		return values
+	case strings.HasPrefix(typ, "map["):
+		return map[string]any{}
+	}
+
+	switch typ {
+	case "string":
+		return e2eSampleString(f)
+	case "bool":
+		return true
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64":
+		return int64(e2eSampleNumber(f))
+	case "float32", "float64":
+		return e2eSampleNumber(f)
+	case "time.Time":
+		return "2025-01-01T00:00:00Z"
+	}
+
+	if f.NestedType != nil {
+		return e2eSampleObject(f.NestedType.Fields)
+	}
+	return nil
+}
+
+func e2eSampleString(f parser.FieldInfo) string {
+	s := "test"
+	if n, ok := ruleNumber(f, "min"); ok && int(n) > len(s) {
+		s = strings.Repeat("a", int(n))
+	}
+	if n, ok := ruleNumber(f, "max"); ok && int(n) < len(s) {
+		s = strings.Repeat("a", int(n))
+	}
+	return s
+}
+
+func e2eSampleNumber(f parser.FieldInfo) float64 {
+	v := 1.0
+	if n, ok := ruleNumber(f, "min"); ok && n > v {
+		v = n
+	}
+	if n, ok := ruleNumber(f, "max"); ok && n < v {
+		v = n
+	}
+	return v
+}
+
+// e2eInvalidObject はvalidateタグに違反するリクエストを生成する
+// 値の範囲に違反できるフィールドを優先し、なければ必須フィールドを省略する
+func e2eInvalidObject(fields []parser.FieldInfo, valid map[string]any) (map[string]any, bool) {
+	clone := func() map[string]any {
+		obj := make(map[string]any, len(valid))
+		for k, v := range valid {
+			obj[k] = v
+		}
+		return obj
+	}
+
+	for _, f := range fields {
+		if v, ok := e2eInvalidValue(f); ok {
+			obj := clone()
+			obj[f.JSONName] = v
+			return obj, true
+		}
+	}
+
+	for _, f := range fields {
+		if hasRule(f, "required") {
+			obj := clone()
+			delete(obj, f.JSONName)
+			return obj, true
+		}
+	}
+
+	return nil, false
+}
+
+// e2eInvalidPathObject はパスパラメータの値がルールに違反するリクエストを返す
+// 空のセグメントは404になるため、空ではない不正な値（最大長の超過など）のみ対象にする
+func e2eInvalidPathObject(fields []parser.FieldInfo, valid map[string]any) (map[string]any, bool) {
+	for _, f := range fields {
+		if f.In != "path" {
+			continue
+		}
+		if v, ok := e2eInvalidValue(f); ok && fmt.Sprint(v) != "" {
+			obj := make(map[string]any, len(valid))
+			for k, v := range valid {
+				obj[k] = v
+			}
+			obj[f.JSONName] = v
+			return obj, true
+		}
+	}
+	return nil, false
+}
+
+func e2eInvalidValue(f parser.FieldInfo) (any, bool) {
+	typ := strings.TrimPrefix(f.Type, "*")
+	isNumber := e2eIsNumber(typ)
+
+	for _, r := range f.Validation {
+		switch r.Name {
+		case "oneof":
+			if typ == "string" {
+				return "__invalid__", true
+			}
+		case "max":
+			n, err := strconv.ParseFloat(r.Value, 64)
+			if err != nil {
+				continue
+			}
+			switch {
+			case typ == "string":
+				return strings.Repeat("a", int(n)+1), true
+			case isNumber:
+				return n + 1, true
+			}
+		case "min":
+			n, err := strconv.ParseFloat(r.Value, 64)
+			if err != nil {
+				continue
+			}
+			switch {
+			case typ == "string" && n > 1:
+				return strings.Repeat("a", int(n)-1), true
+			case isNumber:
+				return n - 1, true
+			}
+		case "mime":
+			if typ == "multipart.FileHeader" {
+				return e2eFile(f, false), true
+			}
+		}
+	}
+	return nil, false
+}
+
+func e2eIsNumber(typ string) bool {
+	switch typ {
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64", "float32", "float64":
+		return true
+	}
+	return false
+}
+
+// e2eFileFixture はmultipartで送るファイルのフィクスチャです
+type e2eFileFixture struct {
+	Fixture string
+}
+
+// e2eFile はmimeルールに応じたフィクスチャを返す（valid=falseの場合はルールに違反するファイル）
+func e2eFile(f parser.FieldInfo, valid bool) e2eFileFixture {
+	if !valid {
+		return e2eFileFixture{Fixture: "e2eTextFile"}
+	}
+
+	// 許可されたMIMEタイプのうち先頭のものに合わせる
+	mime, _ := ruleValue(f, "mime")
+	allowed := strings.Fields(mime)
+	switch {
+	case len(allowed) == 0:
+		return e2eFileFixture{Fixture: "e2eTextFile"}
+	case allowed[0] == "image/jpeg":
+		return e2eFileFixture{Fixture: "e2eJPEGFile"}
+	case strings.HasPrefix(allowed[0], "image/"):
+		return e2eFileFixture{Fixture: "e2ePNGFile"}
+	default:
+		return e2eFileFixture{Fixture: "e2eTextFile"}
+	}
+}
+
+func e2eMultipartInput(fields []parser.FieldInfo, values map[string]any) string {
+	var formFields, files []string
+	for _, f := range fields {
+		v, ok := values[f.JSONName]
+		if !ok {
+			continue
+		}
+		switch v := v.(type) {
+		case e2eFileFixture:
+			files = append(files, fmt.Sprintf("%q: %s,", f.JSONName, v.Fixture))
+		case []any:
+			// 空のスライスは送らない
+			if len(v) > 0 {
+				formFields = append(formFields, fmt.Sprintf("%q: %q,", f.JSONName, fmt.Sprint(v[0])))
+			}
+		default:
+			formFields = append(formFields, fmt.Sprintf("%q: %q,", f.JSONName, fmt.Sprint(v)))
+		}
+	}
+
+	return fmt.Sprintf("fields: map[string]string{%s},\nfiles: map[string]e2eFile{%s},",
+		strings.Join(formFields, " "), strings.Join(files, " "))
+}
+
+func e2eQuery(values map[string]any) string {
+	q := url.Values{}
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		q.Set(k, fmt.Sprint(values[k]))
+	}
+	return q.Encode()
+}
+
+// e2eScopes はいずれかのエンドポイントが必要とするスコープを重複なしで名前順に返す
+// 生成したテストのトークンにはすべてのスコープを付与し、スコープの不足で 403 にならないようにする
+func e2eScopes(eps []parser.Endpoint) []string {
+	seen := map[string]bool{}
+	var scopes []string
+	for _, ep := range eps {
+		for _, scope := range ep.Scopes {
+			if !seen[scope] {
+				seen[scope] = true
+				scopes = append(scopes, scope)
+			}
+		}
+	}
+	sort.Strings(scopes)
+	return scopes
+}
+
+const e2eTestTemplate = `// Code generated by outorouter parser. DO NOT EDIT.
+// 正常系のテストは外部依存が必要なためスキップされます。
+// 実装する場合は生成元のファイルとは別のテストファイルに記述してください。
+
+package {{ .Package }}
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+{{- if .HasWebSocket }}
+	"time"
+{{- end }}
+{{ if .HasWebSocket }}
+	"github.com/gorilla/websocket"
+{{- end }}
+	"{{ .OutorouterImport }}"
+	"{{ .RouterImport }}"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// e2eFile はmultipartで送信するファイルのフィクスチャです
+type e2eFile struct {
+	filename    string
+	contentType string
+	content     []byte
+}
+
+var (
+	e2ePNGFile  = e2eFile{filename: "test.png", contentType: "image/png", content: []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")}
+	e2eJPEGFile = e2eFile{filename: "test.jpg", contentType: "image/jpeg", content: []byte("\xff\xd8\xff\xe0\x00\x10JFIF\x00")}
+	e2eTextFile = e2eFile{filename: "test.txt", contentType: "text/plain", content: []byte("hello")}
+)
+
+type e2eCase struct {
+	name           string
+	path           string
+	body           string
+	query          string
+	fields         map[string]string
+	files          map[string]e2eFile
+	authenticated  bool
+	expectedStatus int
+	expectedCode   string
+	skip           string
+}
+
+// e2eToken は認証が必須のエンドポイントに付けるテスト用のトークンです
+const e2eToken = "e2e-token"
+
+// e2eScopes は e2eToken に付与するスコープです（いずれかのエンドポイントが必要とするすべてのスコープ）
+var e2eScopes = []string{ {{- range $i, $s := .Scopes }}{{ if $i }}, {{ end }}{{ printf "%q" $s }}{{ end -}} }
+
+// e2eVerifier は e2eToken のみを受け付けるテスト用の Verifier です
+type e2eVerifier struct{}
+
+func (e2eVerifier) Verify(ctx context.Context, token string) (*outorouter.Principal, error) {
+	if token != e2eToken {
+		return nil, outorouter.ErrInvalidToken
+	}
+	return &outorouter.Principal{Subject: "e2e-user", Method: "e2e", Scopes: e2eScopes}, nil
+}
+
+func newE2EHandler(t *testing.T) http.Handler {
+	t.Helper()
+	handler, err := router.Build(outorouter.New(outorouter.WithVerifiers(e2eVerifier{})))
+	require.NoError(t, err)
+	return handler
+}
+
+func newE2EJSONRequest(t *testing.T, method, path string, tt e2eCase) *http.Request {
+	t.Helper()
+	if tt.query != "" {
+		path += "?" + tt.query
+	}
+	req := httptest.NewRequest(method, path, strings.NewReader(tt.body))
+	req.Header.Set("Content-Type", "application/json")
+	return req
+}
+
+func newE2EQueryRequest(t *testing.T, method, path string, tt e2eCase) *http.Request {
+	t.Helper()
+	return httptest.NewRequest(method, path+"?"+tt.query, nil)
+}
+
+func newE2EMultipartRequest(t *testing.T, method, path string, tt e2eCase) *http.Request {
+	t.Helper()
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+	for name, value := range tt.fields {
+		require.NoError(t, w.WriteField(name, value))
+	}
+	for name, f := range tt.files {
+		part, err := w.CreatePart(map[string][]string{
+			"Content-Disposition": {` + "`form-data; name=\"` + name + `\"; filename=\"` + f.filename + `\"`" + `},
+			"Content-Type":        {f.contentType},
+		})
+		require.NoError(t, err)
+		_, err = io.Copy(part, bytes.NewReader(f.content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+
+	if tt.query != "" {
+		path += "?" + tt.query
+	}
+	req := httptest.NewRequest(method, path, body)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	return req
+}
+
+// runE2ECases はケースごとにリクエストを送ります（パスパラメータを含む場合は各ケースの path を使います）
+func runE2ECases(t *testing.T, method, path string, newRequest func(*testing.T, string, string, e2eCase) *http.Request, tests []e2eCase) {
+	t.Helper()
+	handler := newE2EHandler(t)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.skip != "" {
+				t.Skip(tt.skip)
+			}
+
+			target := path
+			if tt.path != "" {
+				target = tt.path
+			}
+
+			req := newRequest(t, method, target, tt)
+			if tt.authenticated {
+				req.Header.Set("Authorization", "Bearer "+e2eToken)
+			}
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			if tt.expectedCode != "" {
+				var res outorouter.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+				assert.Equal(t, tt.expectedCode, res.Error.Code)
+			}
+		})
+	}
+}
+{{- if .HasWebSocket }}
+
+// runE2EWebSocketCases はケースごとにWebSocketで接続し、body をフレームとして送ります
+// ハンドシェイクで失敗するケースはレスポンスのエラーを、フレームで失敗するケースはエラーフレームを確認します
+// トークンはブラウザと同じくサブプロトコル（bearer, <token>）で渡します
+func runE2EWebSocketCases(t *testing.T, path string, tests []e2eCase) {
+	t.Helper()
+	srv := httptest.NewServer(newE2EHandler(t))
+	t.Cleanup(srv.Close)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.skip != "" {
+				t.Skip(tt.skip)
+			}
+
+			var dialer websocket.Dialer
+			if tt.authenticated {
+				dialer.Subprotocols = []string{"bearer", e2eToken}
+			}
+			conn, res, err := dialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http")+path, nil)
+			require.NotNil(t, res)
+			assert.Equal(t, tt.expectedStatus, res.StatusCode)
+
+			var errRes outorouter.ErrorResponse
+			if tt.expectedStatus != http.StatusSwitchingProtocols {
+				require.ErrorIs(t, err, websocket.ErrBadHandshake)
+				require.NoError(t, json.NewDecoder(res.Body).Decode(&errRes))
+			} else {
+				require.NoError(t, err)
+				defer conn.Close()
+				require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+				require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(tt.body)))
+				if tt.expectedCode == "" {
+					return
+				}
+				require.NoError(t, conn.ReadJSON(&errRes))
+			}
+			if tt.expectedCode != "" {
+				assert.Equal(t, tt.expectedCode, errRes.Error.Code)
+			}
+		})
+	}
+}
+{{- end }}
+{{- range .Tests }}
+
+// {{ .FuncName }} は {{ .Method }} {{ .Path }}{{ if .Summary }}（{{ .Summary }}）{{ end }} のE2Eテストです
+func {{ .FuncName }}(t *testing.T) {
+{{- if .WebSocket }}
+	runE2EWebSocketCases(t, "{{ .Path }}", []e2eCase{
+{{- else }}
+	runE2ECases(t, "{{ .Method }}", "{{ .Path }}", {{ .Request }}, []e2eCase{
+{{- end }}
+{{- range .Cases }}
+		{
+			name: "{{ .Name }}",
+			{{ .Input }}
+{{- if .Authenticated }}
+			authenticated: true,
+{{- end }}
+			expectedStatus: {{ .ExpectedStatus }},
+{{- if .ExpectedCode }}
+			expectedCode: "{{ .ExpectedCode }}",
+{{- end }}
+{{- if .Skip }}
+			skip: "{{ .Skip }}",
+{{- end }}
+		},
+{{- end }}
+	})
+}
+{{- end }}
+`
@@ -0,0 +1,134 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kinpatsu-everyone/backend-template/pkg/outorouter/internal/parser"
+)
+
+func TestE2ETestStrategy(t *testing.T) {
+	code, err := New(E2ETestStrategy{}).Generate(openAPITestMetadata())
+	if err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "パッケージ", want: "package router_test"},
+		{name: "routerのインポート", want: `"github.com/kinpatsu-everyone/backend-template/router"`},
+		{name: "エンドポイントごとのテスト関数", want: "func TestE2E_monster_v1_GetMonster(t *testing.T) {"},
+		{name: "JSONリクエスト", want: `runE2ECases(t, "POST", "/monster/v1/GetMonster", newE2EJSONRequest,`},
+		{name: "必須フィールドがある場合の空リクエスト", want: `body:           "{}",` + "\n\t\t\texpectedStatus: http.StatusBadRequest,"},
+		{name: "maxルールに違反するリクエスト", want: `"{\"id\":\"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa\"}"`},
+		{name: "multipartリクエスト", want: `runE2ECases(t, "POST", "/monster/v1/CreateMonster", newE2EMultipartRequest,`},
+		{name: "mimeルールに合うフィクスチャ", want: `map[string]e2eFile{"image": e2ePNGFile}`},
+		{name: "minルールに違反するフィールド", want: `"latitude": "-91"`},
+		{name: "クエリリクエスト", want: `runE2ECases(t, "GET", "/monster/v1/DownloadMonsterImage", newE2EQueryRequest,`},
+		{name: "パスパラメータ", want: `path:           "/monster/v1/monsters/test",`},
+		{name: "oneofルールのサンプル値", want: `query:          "type=generated",`},
+		{name: "正常系はスキップ", want: `skip:           "` + e2eSkipMessage + `",`},
+		{name: "認証が必須の場合はトークンなしで401", want: "name:           \"認証なし\",\n\t\t\tpath:           \"/monster/v1/monsters/test\",\n\t\t\tquery:          \"lang=test\",\n\t\t\texpectedStatus: http.StatusUnauthorized,"},
+		{name: "認証が必須の場合はトークンを付ける", want: "authenticated:  true,"},
+		{name: "テスト用のVerifier", want: "outorouter.WithVerifiers(e2eVerifier{})"},
+		{name: "テスト用のトークンにはエンドポイントのスコープを付与", want: `var e2eScopes = []string{"monster:read"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !strings.Contains(code, tt.want) {
+				t.Errorf("generated code missing %q", tt.want)
+			}
+		})
+	}
+}
+
+func TestE2ETestStrategy_パスパラメータのみの場合(t *testing.T) {
+	meta := &parser.Metadata{All: []parser.Endpoint{{
+		Kind:         parser.KindUnaryJSON,
+		Domain:       "monster",
+		Version:      1,
+		MethodName:   "GetMonster",
+		HTTPMethod:   "GET",
+		HTTPPath:     "/monster/v1/monsters/{id}",
+		RequestType:  "GetMonsterRequest",
+		ResponseType: "GetMonsterResponse",
+		RequestTypeInfo: parser.TypeInfo{
+			Name: "GetMonsterRequest",
+			Fields: []parser.FieldInfo{
+				{Name: "ID", JSONName: "id", Type: "string", In: "path", Validation: []parser.ValidationRule{{Name: "required"}, {Name: "max", Value: "3"}}},
+			},
+		},
+		ResponseTypeInfo: parser.TypeInfo{Name: "GetMonsterResponse"},
+	}}}
+
+	code, err := New(E2ETestStrategy{}).Generate(meta)
+	if err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+	if strings.Contains(code, "空のリクエスト") {
+		t.Error("empty request case should be omitted when every field is a path parameter")
+	}
+	want := "name:           \"バリデーションエラー\",\n\t\t\tpath:           \"/monster/v1/monsters/aaaa\","
+	if !strings.Contains(code, want) {
+		t.Errorf("generated code missing %q", want)
+	}
+}
+
+func TestE2ETestStrategy_RunHappyPath(t *testing.T) {
+	code, err := New(E2ETestStrategy{RunHappyPath: true}).Generate(openAPITestMetadata())
+	if err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+	if strings.Contains(code, e2eSkipMessage) {
+		t.Error("happy path cases should not be skipped")
+	}
+}
+
+func TestE2ETestStrategy_WebSocket(t *testing.T) {
+	meta := &parser.Metadata{All: []parser.Endpoint{{
+		Kind:         parser.KindWebSocket,
+		Domain:       "monster",
+		Version:      1,
+		MethodName:   "WatchMonster",
+		HTTPMethod:   "GET",
+		HTTPPath:     "/monster/v1/WatchMonster",
+		Auth:         "required",
+		RequestType:  "WatchMonsterRequest",
+		ResponseType: "WatchMonsterResponse",
+		RequestTypeInfo: parser.TypeInfo{
+			Name: "WatchMonsterRequest",
+			Fields: []parser.FieldInfo{
+				{Name: "ID", JSONName: "id", Type: "string", Validation: []parser.ValidationRule{{Name: "required"}, {Name: "max", Value: "3"}}},
+			},
+		},
+		ResponseTypeInfo: parser.TypeInfo{Name: "WatchMonsterResponse"},
+	}}}
+
+	code, err := New(E2ETestStrategy{}).Generate(meta)
+	if err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "WebSocketのテスト関数", want: `runE2EWebSocketCases(t, "/monster/v1/WatchMonster", []e2eCase{`},
+		{name: "gorilla/websocketのインポート", want: `"github.com/gorilla/websocket"`},
+		{name: "トークンなしのハンドシェイクは401", want: "name:           \"認証なし\",\n\t\t\tbody:           \"{\\\"id\\\":\\\"aaa\\\"}\",\n\t\t\texpectedStatus: http.StatusUnauthorized,"},
+		{name: "空のフレームはエラーフレーム", want: "name:           \"空のフレーム\",\n\t\t\tbody:           \"{}\",\n\t\t\tauthenticated:  true,\n\t\t\texpectedStatus: http.StatusSwitchingProtocols,\n\t\t\texpectedCode:   \"VALIDATION_FAILED\","},
+		{name: "maxルールに違反するフレーム", want: `"{\"id\":\"aaaa\"}"`},
+		{name: "トークンはサブプロトコルで渡す", want: `dialer.Subprotocols = []string{"bearer", e2eToken}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !strings.Contains(code, tt.want) {
+				t.Errorf("generated code missing %q", tt.want)
+			}
+		})
+	}
+}
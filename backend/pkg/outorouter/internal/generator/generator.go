@@ -1,6 +1,10 @@
 package generator
 
-import "github.com/kinpatsu-everyone/backend-template/pkg/outorouter/internal/parser"
+import (
+	"strings"
+
+	"github.com/kinpatsu-everyone/backend-template/pkg/outorouter/internal/parser"
+)
 
 // Strategy は中間表現を任意フォーマットへ変換する戦略を表す。
 type Strategy interface {
@@ -25,3 +29,71 @@ func (g *Generator) Generate(meta *parser.Metadata) (string, error) {
 func (g *Generator) StrategyName() string {
 	return g.strategy.Name()
 }
+
+// collectNestedTypeInfos は全エンドポイントのリクエスト・レスポンスからネストされた型を重複なく収集する。
+// 出現順（深さ優先）で返す。
+func collectNestedTypeInfos(endpoints []parser.Endpoint) []parser.TypeInfo {
+	seen := make(map[string]bool)
+	var result []parser.TypeInfo
+
+	var collect func(fields []parser.FieldInfo)
+	collect = func(fields []parser.FieldInfo) {
+		for _, f := range fields {
+			if f.NestedType == nil || f.NestedType.Name == "" || seen[f.NestedType.Name] {
+				continue
+			}
+			seen[f.NestedType.Name] = true
+			result = append(result, *f.NestedType)
+			collect(f.NestedType.Fields)
+		}
+	}
+
+	for _, ep := range endpoints {
+		collect(ep.RequestTypeInfo.Fields)
+		collect(ep.ResponseTypeInfo.Fields)
+	}
+	return result
+}
+
+// nestedTypeNames はネストされた型の名前の集合を返す。
+func nestedTypeNames(infos []parser.TypeInfo) map[string]bool {
+	names := make(map[string]bool, len(infos))
+	for _, info := range infos {
+		names[info.Name] = true
+	}
+	return names
+}
+
+// resolveNestedType はGoの型名（"handler.GeoPoint" など）がネストされた型であれば、その型名を返す。
+// メタデータは同じ構造体を2回目以降に参照したフィールドに NestedType を持たないため、
+// フィールドの NestedType ではなく収集したネストされた型から名前で解決する。
+func resolveNestedType(goType string, names map[string]bool) (string, bool) {
+	name := goType[strings.LastIndex(goType, ".")+1:]
+	return name, names[name]
+}
+
+// namedScalarGoType は列挙型など、文字列・真偽値を基底の型とする外部パッケージの型を基底の型に置き換えたGoの型名を返す。
+// 生成コードから外部パッケージの型は参照できないため、tsType（メタデータのTypeScriptの型）で基底の型を判定する。
+func namedScalarGoType(goType, tsType string) string {
+	switch {
+	case strings.HasPrefix(goType, "*"):
+		return "*" + namedScalarGoType(goType[1:], tsType)
+	case strings.HasPrefix(goType, "[]"):
+		return "[]" + namedScalarGoType(goType[2:], strings.TrimSuffix(tsType, "[]"))
+	case strings.HasPrefix(goType, "map["):
+		end := strings.Index(goType, "]")
+		_, value, _ := strings.Cut(strings.TrimSuffix(strings.TrimPrefix(tsType, "Record<"), ">"), ", ")
+		return goType[:end+1] + namedScalarGoType(goType[end+1:], value)
+	}
+
+	if !strings.Contains(goType, ".") || goType == "time.Time" {
+		return goType
+	}
+	switch tsType {
+	case "string":
+		return "string"
+	case "boolean":
+		return "bool"
+	}
+	return goType
+}
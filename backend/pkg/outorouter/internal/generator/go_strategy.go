@@ -2,13 +2,16 @@ package generator
 
 import (
 	"bytes"
+	"fmt"
+	"go/format"
 	"sort"
+	"strings"
 	"text/template"
 
 	"github.com/kinpatsu-everyone/backend-template/pkg/outorouter/internal/parser"
 )
 
-// GoTypeStrategy は Go 用のエンドポイント定義を生成する。
+// GoTypeStrategy は Go 用の型定義と型安全な API クライアントを生成する。
 type GoTypeStrategy struct {
 	PackageName string
 }
@@ -20,9 +23,90 @@ func (s GoTypeStrategy) Generate(meta *parser.Metadata) (string, error) {
 		s.PackageName = "generated"
 	}
 
+	endpoints := sorted(meta.All)
+	imports := map[string]bool{
+		"bytes":         true,
+		"context":       true,
+		"encoding/json": true,
+		"fmt":           true,
+		"io":            true,
+		"net/http":      true,
+	}
+
+	var structs []goStructData
+	nestedInfos := collectNestedTypeInfos(endpoints)
+	nestedTypes := nestedTypeNames(nestedInfos)
+	for _, nested := range nestedInfos {
+		structs = append(structs, newGoStruct(nested.Name, "is a nested type.", nested.Fields, nestedTypes, imports))
+	}
+
+	var goEndpoints []goEndpointData
+	var unsupported []string
+	for _, ep := range endpoints {
+		if ep.Kind == parser.KindWebSocket {
+			// WebSocketはクライアント側のライブラリに依存するため対象外とする
+			unsupported = append(unsupported, ep.Path())
+			continue
+		}
+
+		data := goEndpointData{
+			Endpoint:       ep,
+			RequestStruct:  ep.MethodName + "Request",
+			ResponseStruct: ep.MethodName + "Response",
+			IsMultipart:    ep.Kind == parser.KindFileUpload,
+			IsFileDownload: ep.Kind == parser.KindFileDownload,
+			PathExpr:       goPathExpr(ep),
+		}
+
+		var bodyFields, queryFields []parser.FieldInfo
+		for _, f := range ep.RequestTypeInfo.Fields {
+			switch {
+			case f.In == "path":
+			case f.In == "query" || data.IsFileDownload:
+				queryFields = append(queryFields, f)
+			default:
+				bodyFields = append(bodyFields, f)
+			}
+		}
+		if len(ep.PathParams()) > 0 {
+			imports["net/url"] = true
+		}
+
+		request := newGoStruct(data.RequestStruct, "is the request of "+ep.MethodName+".", ep.RequestTypeInfo.Fields, nestedTypes, imports)
+		if data.IsMultipart {
+			request.FormWrites = goFormWrites(bodyFields)
+			imports["mime/multipart"] = true
+			imports["net/textproto"] = true
+		}
+		if len(queryFields) > 0 {
+			request.QueryWrites = goQueryWrites(queryFields)
+			data.HasQuery = true
+			imports["net/url"] = true
+		}
+		structs = append(structs, request)
+
+		if !data.IsFileDownload {
+			structs = append(structs, newGoStruct(data.ResponseStruct, "is the response of "+ep.MethodName+".", ep.ResponseTypeInfo.Fields, nestedTypes, imports))
+		}
+
+		goEndpoints = append(goEndpoints, data)
+	}
+
+	importList := make([]string, 0, len(imports))
+	for path := range imports {
+		importList = append(importList, path)
+	}
+	sort.Strings(importList)
+
 	data := map[string]any{
-		"Package":   s.PackageName,
-		"Endpoints": sorted(meta.All),
+		"Package":      s.PackageName,
+		"Imports":      importList,
+		"Endpoints":    endpoints,
+		"Structs":      structs,
+		"Methods":      goEndpoints,
+		"HasMultipart": imports["mime/multipart"],
+		"HasDownload":  hasKind(endpoints, parser.KindFileDownload),
+		"Unsupported":  unsupported,
 	}
 
 	buf := &bytes.Buffer{}
@@ -30,12 +114,209 @@ func (s GoTypeStrategy) Generate(meta *parser.Metadata) (string, error) {
 	if err := tmpl.Execute(buf, data); err != nil {
 		return "", err
 	}
-	return buf.String(), nil
+
+	code, err := format.Source(buf.Bytes())
+	if err != nil {
+		return "", fmt.Errorf("failed to format generated code: %w", err)
+	}
+	return string(code), nil
+}
+
+type goStructData struct {
+	Name        string
+	Doc         string
+	Fields      []goFieldData
+	FormWrites  []string
+	QueryWrites []string
+}
+
+type goFieldData struct {
+	Name string
+	Type string
+	Tag  string
+}
+
+type goEndpointData struct {
+	parser.Endpoint
+	RequestStruct  string
+	ResponseStruct string
+	IsMultipart    bool
+	IsFileDownload bool
+	// PathExpr はパスパラメータを埋めたパスを組み立てるGoの式です
+	PathExpr string
+	HasQuery bool
+}
+
+// hasKind は指定した種類のエンドポイントが含まれているかを返す
+func hasKind(endpoints []parser.Endpoint, kind parser.EndpointKind) bool {
+	for _, ep := range endpoints {
+		if ep.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// goPathExpr はパスパラメータをリクエストのフィールドで埋めるGoの式を返す
+// 例: "/monster/v1/monsters/" + url.PathEscape(fmt.Sprint(req.ID))
+func goPathExpr(ep parser.Endpoint) string {
+	fieldNames := make(map[string]string)
+	for _, f := range ep.RequestTypeInfo.Fields {
+		if f.In == "path" {
+			fieldNames[f.JSONName] = f.Name
+		}
+	}
+
+	var parts []string
+	literal := ""
+	for _, segment := range strings.Split(strings.Trim(ep.RoutePath(), "/"), "/") {
+		literal += "/"
+		name, ok := strings.CutPrefix(segment, "{")
+		name, isParam := strings.CutSuffix(name, "}")
+		if !ok || !isParam || fieldNames[name] == "" {
+			literal += segment
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%q", literal), fmt.Sprintf("url.PathEscape(fmt.Sprint(req.%s))", fieldNames[name]))
+		literal = ""
+	}
+	if literal != "" {
+		parts = append(parts, fmt.Sprintf("%q", literal))
+	}
+	return strings.Join(parts, " + ")
+}
+
+func newGoStruct(name, doc string, fields []parser.FieldInfo, nestedTypes, imports map[string]bool) goStructData {
+	st := goStructData{Name: name, Doc: name + " " + doc}
+	for _, f := range fields {
+		typ := goClientType(namedScalarGoType(f.Type, f.TSType), nestedTypes)
+		if strings.Contains(typ, "time.Time") {
+			imports["time"] = true
+		}
+
+		tag := f.JSONName
+		if f.Optional {
+			tag += ",omitempty"
+		}
+		if strings.Contains(typ, "*File") || f.In != "" {
+			// ファイルはmultipartでのみ、パスパラメータ・クエリ文字列はURLでのみ送信する
+			tag = "-"
+		}
+
+		st.Fields = append(st.Fields, goFieldData{
+			Name: f.Name,
+			Type: typ,
+			Tag:  fmt.Sprintf("`json:%q`", tag),
+		})
+	}
+	return st
+}
+
+// goClientType はメタデータ上のGoの型名を生成コード内の型名に変換する
+// nestedTypes は生成コードに構造体を出力するネストされた型の名前の集合
+func goClientType(goType string, nestedTypes map[string]bool) string {
+	switch {
+	case strings.HasPrefix(goType, "*"):
+		return "*" + goClientType(goType[1:], nestedTypes)
+	case strings.HasPrefix(goType, "[]"):
+		return "[]" + goClientType(goType[2:], nestedTypes)
+	case strings.HasPrefix(goType, "map["):
+		end := strings.Index(goType, "]")
+		return goType[:end+1] + goClientType(goType[end+1:], nestedTypes)
+	}
+
+	switch goType {
+	case "multipart.FileHeader":
+		return "File"
+	case "time.Time":
+		return goType
+	case "interface {}", "interface{}", "any":
+		return "any"
+	}
+
+	if name, ok := resolveNestedType(goType, nestedTypes); ok {
+		return name
+	}
+	if strings.Contains(goType, ".") {
+		// 生成コードから参照できない外部パッケージの型
+		return "json.RawMessage"
+	}
+	return goType
+}
+
+// goFormWrites はmultipartリクエストの各フィールドを書き込むコードを返す
+func goFormWrites(fields []parser.FieldInfo) []string {
+	var writes []string
+	for _, f := range fields {
+		v := "r." + f.Name
+		switch typ := strings.TrimPrefix(f.Type, "*"); {
+		case typ == "multipart.FileHeader":
+			writes = append(writes, fmt.Sprintf(`if %s != nil {
+	if err := %s.write(w, %q); err != nil {
+		return err
+	}
+}`, v, v, f.JSONName))
+		case typ == "[]*multipart.FileHeader":
+			writes = append(writes, fmt.Sprintf(`for _, f := range %s {
+	if err := f.write(w, %q); err != nil {
+		return err
+	}
+}`, v, f.JSONName))
+		case strings.HasPrefix(typ, "[]"):
+			writes = append(writes, fmt.Sprintf(`for _, v := range %s {
+	if err := w.WriteField(%q, fmt.Sprint(v)); err != nil {
+		return err
+	}
+}`, v, f.JSONName))
+		case strings.HasPrefix(f.Type, "*"):
+			writes = append(writes, fmt.Sprintf(`if %s != nil {
+	if err := w.WriteField(%q, fmt.Sprint(*%s)); err != nil {
+		return err
+	}
+}`, v, f.JSONName, v))
+		default:
+			writes = append(writes, fmt.Sprintf(`if err := w.WriteField(%q, fmt.Sprint(%s)); err != nil {
+	return err
+}`, f.JSONName, v))
+		}
+	}
+	return writes
+}
+
+// goQueryWrites はクエリ文字列で送るフィールドをクエリパラメータに変換するコードを返す
+func goQueryWrites(fields []parser.FieldInfo) []string {
+	var writes []string
+	for _, f := range fields {
+		v := "r." + f.Name
+		switch typ := namedScalarGoType(f.Type, f.TSType); {
+		case strings.HasPrefix(typ, "[]"):
+			writes = append(writes, fmt.Sprintf(`for _, v := range %s {
+	q.Add(%q, fmt.Sprint(v))
+}`, v, f.JSONName))
+		case strings.HasPrefix(typ, "*"):
+			writes = append(writes, fmt.Sprintf(`if %s != nil {
+	q.Set(%q, fmt.Sprint(*%s))
+}`, v, f.JSONName, v))
+		case typ == "string":
+			writes = append(writes, fmt.Sprintf(`if %s != "" {
+	q.Set(%q, %s)
+}`, v, f.JSONName, v))
+		default:
+			writes = append(writes, fmt.Sprintf(`q.Set(%q, fmt.Sprint(%s))`, f.JSONName, v))
+		}
+	}
+	return writes
 }
 
 const goTemplate = `// Code generated by outorouter parser; DO NOT EDIT.
 package {{.Package}}
 
+import (
+{{- range .Imports }}
+	"{{ . }}"
+{{- end }}
+)
+
 type EndpointDescriptor struct {
 	Method       string
 	Path         string
@@ -49,7 +330,7 @@ var Endpoints = []EndpointDescriptor{
 {{- range .Endpoints }}
 	{
 		Method: "{{ .HTTPMethod }}",
-		Path: "{{ .Path }}",
+		Path: "{{ .RoutePath }}",
 		RequestType: "{{ .RequestType }}",
 		ResponseType: "{{ .ResponseType }}",
 		Summary: "{{ .Summary }}",
@@ -57,6 +338,286 @@ var Endpoints = []EndpointDescriptor{
 	},
 {{- end }}
 }
+
+// ============================================================================
+// Errors
+// ============================================================================
+
+// FieldError is a field-level error detail (e.g. validation failures).
+type FieldError struct {
+	Field   string ` + "`json:\"field\"`" + `
+	Code    string ` + "`json:\"code\"`" + `
+	Message string ` + "`json:\"message\"`" + `
+}
+
+// HTTPError is returned when the server responds with a non-2xx status code.
+type HTTPError struct {
+	StatusCode int
+	Code       string
+	Message    string
+	RequestID  string
+	Details    []FieldError
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("%d %s: %s", e.StatusCode, e.Code, e.Message)
+}
+
+// decodeError decodes the common error response of the server.
+func decodeError(res *http.Response) error {
+	body, _ := io.ReadAll(res.Body)
+
+	var envelope struct {
+		Error struct {
+			Code      string       ` + "`json:\"code\"`" + `
+			Message   string       ` + "`json:\"message\"`" + `
+			RequestID string       ` + "`json:\"request_id\"`" + `
+			Details   []FieldError ` + "`json:\"details\"`" + `
+		} ` + "`json:\"error\"`" + `
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil || envelope.Error.Code == "" {
+		return &HTTPError{
+			StatusCode: res.StatusCode,
+			Code:       "UNKNOWN_ERROR",
+			Message:    http.StatusText(res.StatusCode),
+		}
+	}
+
+	return &HTTPError{
+		StatusCode: res.StatusCode,
+		Code:       envelope.Error.Code,
+		Message:    envelope.Error.Message,
+		RequestID:  envelope.Error.RequestID,
+		Details:    envelope.Error.Details,
+	}
+}
+{{- if .HasMultipart }}
+
+// File is a file to upload with multipart/form-data.
+type File struct {
+	Filename    string
+	ContentType string
+	Content     io.Reader
+}
+
+func (f *File) write(w *multipart.Writer, field string) error {
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", fmt.Sprintf("form-data; name=%q; filename=%q", field, f.Filename))
+	contentType := f.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	header.Set("Content-Type", contentType)
+
+	part, err := w.CreatePart(header)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(part, f.Content)
+	return err
+}
+{{- end }}
+{{- if .HasDownload }}
+
+// FileResponse is the response of file download endpoints. The caller must close Body.
+type FileResponse struct {
+	ContentType   string
+	ContentLength int64
+	Body          io.ReadCloser
+}
+{{- end }}
+
+// ============================================================================
+// Models
+// ============================================================================
+{{- range .Structs }}
+
+// {{ .Doc }}
+type {{ .Name }} struct {
+{{- range .Fields }}
+	{{ .Name }} {{ .Type }} {{ .Tag }}
+{{- end }}
+}
+{{- if .FormWrites }}
+
+func (r *{{ .Name }}) writeMultipart(w *multipart.Writer) error {
+{{- range .FormWrites }}
+	{{ . }}
+{{- end }}
+	return nil
+}
+{{- end }}
+{{- if .QueryWrites }}
+
+func (r *{{ .Name }}) query() url.Values {
+	q := url.Values{}
+{{- range .QueryWrites }}
+	{{ . }}
+{{- end }}
+	return q
+}
+{{- end }}
+{{- end }}
+
+// ============================================================================
+// Client
+// ============================================================================
+
+// Client is a type-safe API client.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	header     http.Header
+}
+
+// ClientOption configures the Client.
+type ClientOption func(*Client)
+
+// WithHTTPClient sets the http.Client used for requests.
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithHeader sets a header sent with every request.
+func WithHeader(key, value string) ClientOption {
+	return func(c *Client) {
+		c.header.Set(key, value)
+	}
+}
+
+// NewClient creates a new Client.
+func NewClient(baseURL string, opts ...ClientOption) *Client {
+	c := &Client{
+		baseURL:    baseURL,
+		httpClient: http.DefaultClient,
+		header:     make(http.Header),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	for key, values := range c.header {
+		for _, v := range values {
+			req.Header.Add(key, v)
+		}
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	return res, nil
+}
+
+func (c *Client) do(req *http.Request, out any) error {
+	res, err := c.send(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return decodeError(res)
+	}
+	if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// doJSON sends in as the JSON body. If in is nil, the request is sent without a body.
+func (c *Client) doJSON(ctx context.Context, method, path string, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		encoded, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		body = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return c.do(req, out)
+}
+{{- if .HasMultipart }}
+
+func (c *Client) doMultipart(ctx context.Context, method, path string, write func(*multipart.Writer) error, out any) error {
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+	if err := write(w); err != nil {
+		return fmt.Errorf("failed to write multipart body: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to write multipart body: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	return c.do(req, out)
+}
+{{- end }}
+{{- if .HasDownload }}
+
+func (c *Client) download(ctx context.Context, path string) (*FileResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	res, err := c.send(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		defer res.Body.Close()
+		return nil, decodeError(res)
+	}
+
+	return &FileResponse{
+		ContentType:   res.Header.Get("Content-Type"),
+		ContentLength: res.ContentLength,
+		Body:          res.Body,
+	}, nil
+}
+{{- end }}
+{{- range .Methods }}
+
+// {{ .MethodName }} calls {{ .HTTPMethod }} {{ .RoutePath }}.{{ if .Summary }} {{ .Summary }}{{ end }}
+{{- if .IsFileDownload }}
+func (c *Client) {{ .MethodName }}(ctx context.Context, req *{{ .RequestStruct }}) (*FileResponse, error) {
+	return c.download(ctx, {{ .PathExpr }}{{ if .HasQuery }}+"?"+req.query().Encode(){{ end }})
+}
+{{- else }}
+func (c *Client) {{ .MethodName }}(ctx context.Context, req *{{ .RequestStruct }}) (*{{ .ResponseStruct }}, error) {
+	var res {{ .ResponseStruct }}
+{{- if .IsMultipart }}
+	if err := c.doMultipart(ctx, "{{ .HTTPMethod }}", {{ .PathExpr }}{{ if .HasQuery }}+"?"+req.query().Encode(){{ end }}, req.writeMultipart, &res); err != nil {
+{{- else }}
+	if err := c.doJSON(ctx, "{{ .HTTPMethod }}", {{ .PathExpr }}{{ if .HasQuery }}+"?"+req.query().Encode(){{ end }}, {{ if .HasRequestBody }}req{{ else }}nil{{ end }}, &res); err != nil {
+{{- end }}
+		return nil, err
+	}
+	return &res, nil
+}
+{{- end }}
+{{- end }}
+{{- range .Unsupported }}
+
+// {{ . }}: WebSocket endpoints are not supported by the Go client.
+{{- end }}
 `
 
 func sorted(eps []parser.Endpoint) []parser.Endpoint {
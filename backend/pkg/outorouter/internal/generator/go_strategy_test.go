@@ -0,0 +1,192 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kinpatsu-everyone/backend-template/pkg/outorouter/internal/parser"
+)
+
+func TestGoTypeStrategy_Client(t *testing.T) {
+	code, err := New(GoTypeStrategy{PackageName: "apiclient"}).Generate(openAPITestMetadata())
+	if err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "package", want: "package apiclient"},
+		{name: "request struct", want: "type GetMonsterRequest struct {\n\tID string `json:\"id\"`\n}"},
+		{name: "nested struct", want: "type MonsterItem struct {\n\tLatitude float64 `json:\"latitude\"`\n}"},
+		{name: "nested field", want: "Monster MonsterItem `json:\"monster\"`"},
+		{name: "optional field", want: "Type string `json:\"type,omitempty\"`"},
+		{name: "file field", want: "`json:\"-\"`"},
+		{name: "typed error", want: "type HTTPError struct {"},
+		{name: "json method", want: "func (c *Client) GetMonster(ctx context.Context, req *GetMonsterRequest) (*GetMonsterResponse, error) {"},
+		{name: "json call", want: `c.doJSON(ctx, "POST", "/monster/v1/GetMonster", req, &res)`},
+		{name: "multipart call", want: `c.doMultipart(ctx, "POST", "/monster/v1/CreateMonster", req.writeMultipart, &res)`},
+		{name: "multipart file", want: `r.Image.write(w, "image")`},
+		{name: "multipart field", want: `w.WriteField("latitude", fmt.Sprint(r.Latitude))`},
+		{name: "download method", want: "func (c *Client) DownloadMonsterImage(ctx context.Context, req *DownloadMonsterImageRequest) (*FileResponse, error) {"},
+		{name: "download query", want: `q.Set("type", r.Type)`},
+		{name: "path parameter", want: `c.doJSON(ctx, "GET", "/monster/v1/monsters/"+url.PathEscape(fmt.Sprint(req.ID))+"?"+req.query().Encode(), nil, &res)`},
+		{name: "descriptor", want: `Path:         "/monster/v1/GetMonster",`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !strings.Contains(code, tt.want) {
+				t.Errorf("generated code missing %q", tt.want)
+			}
+		})
+	}
+}
+
+// sharedNestedTypeMetadata は同じ構造体の型のフィールドを2つ持つリクエストのメタデータを返す
+// メタデータは2つ目のフィールドに NestedType を持たない
+func sharedNestedTypeMetadata() *parser.Metadata {
+	return &parser.Metadata{All: []parser.Endpoint{
+		{
+			Kind:       parser.KindUnaryJSON,
+			Domain:     "monster",
+			Version:    1,
+			MethodName: "GetMonstersInBounds",
+			HTTPMethod: "POST",
+			RequestTypeInfo: parser.TypeInfo{
+				Name: "GetMonstersInBoundsRequest",
+				Fields: []parser.FieldInfo{
+					{Name: "SouthWest", JSONName: "sw", Type: "handler.GeoPoint", TSType: "GeoPoint", NestedType: &parser.TypeInfo{
+						Name: "GeoPoint",
+						Fields: []parser.FieldInfo{
+							{Name: "Latitude", JSONName: "latitude", Type: "float64", TSType: "number"},
+						},
+					}},
+					{Name: "NorthEast", JSONName: "ne", Type: "handler.GeoPoint", TSType: "GeoPoint"},
+					{Name: "Corners", JSONName: "corners", Type: "[]*handler.GeoPoint", TSType: "GeoPoint[]", Optional: true},
+				},
+			},
+			ResponseTypeInfo: parser.TypeInfo{Name: "GetMonstersInBoundsResponse"},
+		},
+	}}
+}
+
+func TestGoTypeStrategy_同じ型のフィールドはどちらもネストされた型の構造体を使う(t *testing.T) {
+	code, err := New(GoTypeStrategy{PackageName: "apiclient"}).Generate(sharedNestedTypeMetadata())
+	if err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+
+	for _, want := range []string{
+		"SouthWest GeoPoint    `json:\"sw\"`",
+		"NorthEast GeoPoint    `json:\"ne\"`",
+		"Corners   []*GeoPoint `json:\"corners,omitempty\"`",
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("generated code missing %q", want)
+		}
+	}
+	if strings.Contains(code, "json.RawMessage") {
+		t.Error("nested type should not fall back to json.RawMessage")
+	}
+}
+
+// namedScalarTypeMetadata は文字列を基底の型とする外部パッケージの型（列挙型）のフィールドを持つメタデータを返す
+func namedScalarTypeMetadata() *parser.Metadata {
+	return &parser.Metadata{All: []parser.Endpoint{
+		{
+			Kind:       parser.KindUnaryJSON,
+			Domain:     "monster",
+			Version:    1,
+			MethodName: "SearchMonsters",
+			HTTPMethod: "GET",
+			RequestTypeInfo: parser.TypeInfo{
+				Name: "SearchMonstersRequest",
+				Fields: []parser.FieldInfo{
+					{Name: "TrashCategorySlug", JSONName: "trash_category_slug", Type: "enum.TrashCategoryFilterSlug", TSType: "string", Optional: true, In: "query"},
+				},
+			},
+			ResponseTypeInfo: parser.TypeInfo{
+				Name: "SearchMonstersResponse",
+				Fields: []parser.FieldInfo{
+					{Name: "TrashCategory", JSONName: "trash_category", Type: "enum.TrashCategoryName", TSType: "string"},
+					{Name: "Slugs", JSONName: "slugs", Type: "[]enum.TrashCategorySlug", TSType: "string[]"},
+					{Name: "UpdatedAt", JSONName: "updated_at", Type: "time.Time", TSType: "string"},
+				},
+			},
+		},
+	}}
+}
+
+func TestGoTypeStrategy_文字列を基底の型とする外部パッケージの型はstringにする(t *testing.T) {
+	code, err := New(GoTypeStrategy{PackageName: "apiclient"}).Generate(namedScalarTypeMetadata())
+	if err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+
+	for _, want := range []string{
+		"TrashCategorySlug string `json:\"-\"`",
+		"TrashCategory string    `json:\"trash_category\"`",
+		"Slugs         []string  `json:\"slugs\"`",
+		"UpdatedAt     time.Time `json:\"updated_at\"`",
+		"if r.TrashCategorySlug != \"\" {\n\t\tq.Set(\"trash_category_slug\", r.TrashCategorySlug)\n\t}",
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("generated code missing %q", want)
+		}
+	}
+	if strings.Contains(code, "json.RawMessage") {
+		t.Error("named string type should not fall back to json.RawMessage")
+	}
+}
+
+func TestNamedScalarGoType(t *testing.T) {
+	tests := []struct {
+		goType string
+		tsType string
+		want   string
+	}{
+		{goType: "enum.TrashCategoryName", tsType: "string", want: "string"},
+		{goType: "*enum.TrashCategoryName", tsType: "string", want: "*string"},
+		{goType: "[]enum.TrashCategorySlug", tsType: "string[]", want: "[]string"},
+		{goType: "map[string]enum.Flag", tsType: "Record<string, boolean>", want: "map[string]bool"},
+		{goType: "time.Time", tsType: "string", want: "time.Time"},
+		{goType: "handler.GeoPoint", tsType: "GeoPoint", want: "handler.GeoPoint"},
+		{goType: "enum.Level", tsType: "number", want: "enum.Level"},
+		{goType: "string", tsType: "string", want: "string"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.goType, func(t *testing.T) {
+			if got := namedScalarGoType(tt.goType, tt.tsType); got != tt.want {
+				t.Errorf("namedScalarGoType(%q, %q) = %q, want %q", tt.goType, tt.tsType, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGoClientType(t *testing.T) {
+	tests := []struct {
+		goType string
+		want   string
+	}{
+		{goType: "string", want: "string"},
+		{goType: "*int64", want: "*int64"},
+		{goType: "time.Time", want: "time.Time"},
+		{goType: "*multipart.FileHeader", want: "*File"},
+		{goType: "[]*multipart.FileHeader", want: "[]*File"},
+		{goType: "map[string]interface {}", want: "map[string]any"},
+		{goType: "sql.NullString", want: "json.RawMessage"},
+		{goType: "handler.GeoPoint", want: "GeoPoint"},
+		{goType: "[]*handler.GeoPoint", want: "[]*GeoPoint"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.goType, func(t *testing.T) {
+			if got := goClientType(tt.goType, map[string]bool{"GeoPoint": true}); got != tt.want {
+				t.Errorf("goClientType(%q) = %q, want %q", tt.goType, got, tt.want)
+			}
+		})
+	}
+}
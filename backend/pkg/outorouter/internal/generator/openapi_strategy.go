@@ -0,0 +1,615 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/kinpatsu-everyone/backend-template/pkg/outorouter/internal/parser"
+	"gopkg.in/yaml.v3"
+)
+
+// OpenAPIFormat はOpenAPIドキュメントの出力形式です。
+type OpenAPIFormat string
+
+const (
+	OpenAPIFormatJSON OpenAPIFormat = "json"
+	OpenAPIFormatYAML OpenAPIFormat = "yaml"
+)
+
+// OpenAPIStrategy は OpenAPI 3.1 のドキュメントを生成する。
+type OpenAPIStrategy struct {
+	// Title はAPIのタイトル
+	Title string
+	// Version はAPIドキュメントのバージョン
+	Version string
+	// ServerURL はAPIのベースURL
+	ServerURL string
+	// Format は出力形式（デフォルトはJSON）
+	Format OpenAPIFormat
+}
+
+func (s OpenAPIStrategy) Name() string { return "openapi" }
+
+func (s OpenAPIStrategy) Generate(meta *parser.Metadata) (string, error) {
+	doc := s.Build(meta)
+
+	switch s.Format {
+	case OpenAPIFormatYAML:
+		out, err := yaml.Marshal(doc)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal openapi yaml: %w", err)
+		}
+		return string(out), nil
+	case OpenAPIFormatJSON, "":
+		out, err := json.MarshalIndent(doc, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal openapi json: %w", err)
+		}
+		return string(out) + "\n", nil
+	default:
+		return "", fmt.Errorf("unknown openapi format %q", s.Format)
+	}
+}
+
+// OpenAPIDocument は OpenAPI 3.1 ドキュメントのルートです。
+type OpenAPIDocument struct {
+	OpenAPI    string                      `json:"openapi" yaml:"openapi"`
+	Info       openAPIInfo                 `json:"info" yaml:"info"`
+	Servers    []openAPIServer             `json:"servers,omitempty" yaml:"servers,omitempty"`
+	Tags       []openAPITag                `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Paths      map[string]*openAPIPathItem `json:"paths" yaml:"paths"`
+	Components openAPIComponents           `json:"components" yaml:"components"`
+}
+
+type openAPIInfo struct {
+	Title   string `json:"title" yaml:"title"`
+	Version string `json:"version" yaml:"version"`
+}
+
+type openAPIServer struct {
+	URL string `json:"url" yaml:"url"`
+}
+
+type openAPITag struct {
+	Name string `json:"name" yaml:"name"`
+}
+
+type openAPIPathItem struct {
+	Get    *openAPIOperation `json:"get,omitempty" yaml:"get,omitempty"`
+	Head   *openAPIOperation `json:"head,omitempty" yaml:"head,omitempty"`
+	Post   *openAPIOperation `json:"post,omitempty" yaml:"post,omitempty"`
+	Put    *openAPIOperation `json:"put,omitempty" yaml:"put,omitempty"`
+	Patch  *openAPIOperation `json:"patch,omitempty" yaml:"patch,omitempty"`
+	Delete *openAPIOperation `json:"delete,omitempty" yaml:"delete,omitempty"`
+}
+
+type openAPIOperation struct {
+	OperationID string                      `json:"operationId" yaml:"operationId"`
+	Summary     string                      `json:"summary,omitempty" yaml:"summary,omitempty"`
+	Description string                      `json:"description,omitempty" yaml:"description,omitempty"`
+	Tags        []string                    `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Parameters  []openAPIParameter          `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	RequestBody *openAPIRequestBody         `json:"requestBody,omitempty" yaml:"requestBody,omitempty"`
+	Responses   map[string]*openAPIResponse `json:"responses" yaml:"responses"`
+	// Security は認証の要件です（optional の場合は未認証を表す空の要件を含む）
+	Security []map[string][]string `json:"security,omitempty" yaml:"security,omitempty"`
+
+	// WebSocketのメッセージ形式（OpenAPIでは表現できないため拡張フィールドで示す）
+	XWebSocket *openAPIWebSocket `json:"x-websocket,omitempty" yaml:"x-websocket,omitempty"`
+}
+
+type openAPIWebSocket struct {
+	ClientMessage *openAPISchema `json:"clientMessage" yaml:"clientMessage"`
+	ServerMessage *openAPISchema `json:"serverMessage" yaml:"serverMessage"`
+}
+
+type openAPIParameter struct {
+	Name     string         `json:"name" yaml:"name"`
+	In       string         `json:"in" yaml:"in"`
+	Required bool           `json:"required,omitempty" yaml:"required,omitempty"`
+	Schema   *openAPISchema `json:"schema" yaml:"schema"`
+}
+
+type openAPIRequestBody struct {
+	Required bool                         `json:"required" yaml:"required"`
+	Content  map[string]*openAPIMediaType `json:"content" yaml:"content"`
+}
+
+type openAPIResponse struct {
+	Description string                       `json:"description" yaml:"description"`
+	Headers     map[string]*openAPIHeader    `json:"headers,omitempty" yaml:"headers,omitempty"`
+	Content     map[string]*openAPIMediaType `json:"content,omitempty" yaml:"content,omitempty"`
+}
+
+type openAPIHeader struct {
+	Schema *openAPISchema `json:"schema" yaml:"schema"`
+}
+
+type openAPIMediaType struct {
+	Schema   *openAPISchema              `json:"schema,omitempty" yaml:"schema,omitempty"`
+	Encoding map[string]*openAPIEncoding `json:"encoding,omitempty" yaml:"encoding,omitempty"`
+	Examples map[string]*openAPIExample  `json:"examples,omitempty" yaml:"examples,omitempty"`
+}
+
+type openAPIEncoding struct {
+	ContentType string `json:"contentType" yaml:"contentType"`
+}
+
+type openAPIExample struct {
+	Summary string `json:"summary,omitempty" yaml:"summary,omitempty"`
+	Value   any    `json:"value" yaml:"value"`
+}
+
+type openAPIComponents struct {
+	Schemas         map[string]*openAPISchema         `json:"schemas" yaml:"schemas"`
+	SecuritySchemes map[string]*openAPISecurityScheme `json:"securitySchemes,omitempty" yaml:"securitySchemes,omitempty"`
+}
+
+type openAPISecurityScheme struct {
+	Type         string `json:"type" yaml:"type"`
+	Scheme       string `json:"scheme" yaml:"scheme"`
+	BearerFormat string `json:"bearerFormat,omitempty" yaml:"bearerFormat,omitempty"`
+	Description  string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+// bearerAuthScheme は Authorization: Bearer で送るトークン（JWT・APIキー）の securityScheme の名前
+const bearerAuthScheme = "bearerAuth"
+
+type openAPISchema struct {
+	Ref                  string                    `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+	Type                 string                    `json:"type,omitempty" yaml:"type,omitempty"`
+	Format               string                    `json:"format,omitempty" yaml:"format,omitempty"`
+	ContentMediaType     string                    `json:"contentMediaType,omitempty" yaml:"contentMediaType,omitempty"`
+	Description          string                    `json:"description,omitempty" yaml:"description,omitempty"`
+	Properties           map[string]*openAPISchema `json:"properties,omitempty" yaml:"properties,omitempty"`
+	Required             []string                  `json:"required,omitempty" yaml:"required,omitempty"`
+	Items                *openAPISchema            `json:"items,omitempty" yaml:"items,omitempty"`
+	AdditionalProperties *openAPISchema            `json:"additionalProperties,omitempty" yaml:"additionalProperties,omitempty"`
+	Enum                 []any                     `json:"enum,omitempty" yaml:"enum,omitempty"`
+	Minimum              *float64                  `json:"minimum,omitempty" yaml:"minimum,omitempty"`
+	Maximum              *float64                  `json:"maximum,omitempty" yaml:"maximum,omitempty"`
+	MinLength            *int                      `json:"minLength,omitempty" yaml:"minLength,omitempty"`
+	MaxLength            *int                      `json:"maxLength,omitempty" yaml:"maxLength,omitempty"`
+	MinItems             *int                      `json:"minItems,omitempty" yaml:"minItems,omitempty"`
+	MaxItems             *int                      `json:"maxItems,omitempty" yaml:"maxItems,omitempty"`
+
+	// ファイルフィールドの制約（validateタグのmaxsize/mime）
+	XMaxSize   string   `json:"x-max-size,omitempty" yaml:"x-max-size,omitempty"`
+	XMIMETypes []string `json:"x-mime-types,omitempty" yaml:"x-mime-types,omitempty"`
+}
+
+// Build はメタデータから OpenAPI ドキュメントを組み立てる。
+func (s OpenAPIStrategy) Build(meta *parser.Metadata) *OpenAPIDocument {
+	title := s.Title
+	if title == "" {
+		title = "API"
+	}
+	version := s.Version
+	if version == "" {
+		version = "1.0.0"
+	}
+
+	doc := &OpenAPIDocument{
+		OpenAPI: "3.1.0",
+		Info:    openAPIInfo{Title: title, Version: version},
+		Paths:   make(map[string]*openAPIPathItem),
+		Components: openAPIComponents{
+			Schemas: make(map[string]*openAPISchema),
+		},
+	}
+	if s.ServerURL != "" {
+		doc.Servers = []openAPIServer{{URL: s.ServerURL}}
+	}
+
+	b := &openAPIBuilder{schemas: doc.Components.Schemas}
+	tagSet := make(map[string]bool)
+
+	for _, ep := range sorted(meta.All) {
+		for _, t := range ep.Tags {
+			tagSet[string(t)] = true
+		}
+
+		op := b.operation(ep)
+		if ep.UsesAuth() {
+			scopes := append([]string{}, ep.Scopes...)
+			op.Security = []map[string][]string{{bearerAuthScheme: scopes}}
+			if ep.AuthRequirement() == "optional" {
+				op.Security = append(op.Security, map[string][]string{})
+			}
+			doc.Components.SecuritySchemes = map[string]*openAPISecurityScheme{
+				bearerAuthScheme: {Type: "http", Scheme: "bearer", Description: "JWT or API key"},
+			}
+		}
+		path := ep.RoutePath()
+		item, ok := doc.Paths[path]
+		if !ok {
+			item = &openAPIPathItem{}
+			doc.Paths[path] = item
+		}
+
+		switch ep.Kind {
+		case parser.KindFileDownload:
+			item.Get = op
+			head := *op
+			head.OperationID = op.OperationID + "_head"
+			item.Head = &head
+		case parser.KindWebSocket:
+			item.Get = op
+		default:
+			switch ep.HTTPMethod {
+			case "GET":
+				item.Get = op
+			case "PUT":
+				item.Put = op
+			case "PATCH":
+				item.Patch = op
+			case "DELETE":
+				item.Delete = op
+			default:
+				item.Post = op
+			}
+		}
+	}
+
+	tags := make([]string, 0, len(tagSet))
+	for t := range tagSet {
+		tags = append(tags, t)
+	}
+	sort.Strings(tags)
+	for _, t := range tags {
+		doc.Tags = append(doc.Tags, openAPITag{Name: t})
+	}
+
+	return doc
+}
+
+type openAPIBuilder struct {
+	schemas map[string]*openAPISchema
+}
+
+func (b *openAPIBuilder) operation(ep parser.Endpoint) *openAPIOperation {
+	op := &openAPIOperation{
+		OperationID: fmt.Sprintf("%s_v%d_%s", ep.Domain, ep.Version, ep.MethodName),
+		Summary:     ep.Summary,
+		Description: ep.Description,
+		Tags:        tagsToStrings(ep.Tags),
+		Responses:   make(map[string]*openAPIResponse),
+	}
+
+	requestName := schemaName(ep.RequestTypeInfo.Name, ep.MethodName+"Request")
+	responseName := schemaName(ep.ResponseTypeInfo.Name, ep.MethodName+"Response")
+
+	switch ep.Kind {
+	case parser.KindUnaryJSON:
+		body := b.parameters(op, ep.RequestTypeInfo, "")
+		b.component(responseName, ep.ResponseTypeInfo, false)
+		if ep.HasRequestBody() {
+			b.component(requestName, body, true)
+			op.RequestBody = &openAPIRequestBody{
+				Required: true,
+				Content: map[string]*openAPIMediaType{
+					"application/json": {Schema: ref(requestName)},
+				},
+			}
+		}
+		op.Responses["200"] = &openAPIResponse{
+			Description: "OK",
+			Content: map[string]*openAPIMediaType{
+				"application/json": {Schema: ref(responseName)},
+			},
+		}
+
+	case parser.KindFileUpload:
+		body := b.parameters(op, ep.RequestTypeInfo, "")
+		b.component(responseName, ep.ResponseTypeInfo, false)
+		op.RequestBody = &openAPIRequestBody{
+			Required: true,
+			Content: map[string]*openAPIMediaType{
+				"multipart/form-data": b.multipartBody(body),
+			},
+		}
+		op.Responses["200"] = &openAPIResponse{
+			Description: "OK",
+			Content: map[string]*openAPIMediaType{
+				"application/json": {Schema: ref(responseName)},
+			},
+		}
+
+	case parser.KindFileDownload:
+		// リクエストはパスパラメータとクエリ文字列で受け取る
+		b.parameters(op, ep.RequestTypeInfo, "query")
+		binary := map[string]*openAPIMediaType{
+			"application/octet-stream": {Schema: &openAPISchema{Type: "string", ContentMediaType: "application/octet-stream"}},
+		}
+		headers := map[string]*openAPIHeader{
+			"ETag":                {Schema: &openAPISchema{Type: "string"}},
+			"Content-Disposition": {Schema: &openAPISchema{Type: "string"}},
+		}
+		op.Responses["200"] = &openAPIResponse{Description: "File content", Headers: headers, Content: binary}
+		op.Responses["206"] = &openAPIResponse{Description: "Partial content (Range request)", Headers: headers, Content: binary}
+		op.Responses["304"] = &openAPIResponse{Description: "Not modified (If-None-Match)"}
+
+	case parser.KindWebSocket:
+		b.component(requestName, ep.RequestTypeInfo, true)
+		b.component(responseName, ep.ResponseTypeInfo, false)
+		op.Responses["101"] = &openAPIResponse{Description: "Switching Protocols (WebSocket)"}
+		op.XWebSocket = &openAPIWebSocket{
+			ClientMessage: ref(requestName),
+			ServerMessage: ref(responseName),
+		}
+	}
+
+	b.errorResponses(op, ep)
+	return op
+}
+
+// parameters はパスパラメータ・クエリ文字列のフィールドをオペレーションのパラメータに追加し、
+// 残りのリクエストボディのフィールドを返す
+// defaultIn を指定した場合は、受け取り位置の指定がないフィールドもその位置のパラメータとして扱う
+func (b *openAPIBuilder) parameters(op *openAPIOperation, info parser.TypeInfo, defaultIn string) parser.TypeInfo {
+	body := parser.TypeInfo{Name: info.Name}
+	for _, f := range info.Fields {
+		in := f.In
+		if in == "" {
+			in = defaultIn
+		}
+		if in == "" {
+			body.Fields = append(body.Fields, f)
+			continue
+		}
+		op.Parameters = append(op.Parameters, openAPIParameter{
+			Name:     f.JSONName,
+			In:       in,
+			Required: in == "path" || hasRule(f, "required"),
+			Schema:   b.fieldSchema(f),
+		})
+	}
+	return body
+}
+
+// multipartBody はmultipart/form-dataのリクエストボディを生成する
+func (b *openAPIBuilder) multipartBody(info parser.TypeInfo) *openAPIMediaType {
+	schema := b.objectSchema(info, true)
+	media := &openAPIMediaType{Schema: schema}
+
+	for _, f := range info.Fields {
+		for _, rule := range f.Validation {
+			if rule.Name != "mime" {
+				continue
+			}
+			if media.Encoding == nil {
+				media.Encoding = make(map[string]*openAPIEncoding)
+			}
+			media.Encoding[f.JSONName] = &openAPIEncoding{ContentType: strings.Join(strings.Fields(rule.Value), ", ")}
+		}
+	}
+	return media
+}
+
+// errorResponses は返しうるエラーをステータスコードごとにまとめてレスポンスに追加する
+func (b *openAPIBuilder) errorResponses(op *openAPIOperation, ep parser.Endpoint) {
+	if len(ep.Errors) == 0 {
+		return
+	}
+	b.errorComponent(ep.ErrorTypeInfo)
+
+	byStatus := make(map[int][]parser.ErrorInfo)
+	for _, e := range ep.Errors {
+		byStatus[e.StatusCode] = append(byStatus[e.StatusCode], e)
+	}
+
+	for status, errs := range byStatus {
+		codes := make([]string, 0, len(errs))
+		examples := make(map[string]*openAPIExample, len(errs))
+		for _, e := range errs {
+			codes = append(codes, e.Code)
+			examples[e.Code] = &openAPIExample{
+				Summary: e.Message,
+				Value: map[string]any{
+					"error": map[string]any{
+						"code":    e.Code,
+						"message": e.Message,
+					},
+				},
+			}
+		}
+		op.Responses[strconv.Itoa(status)] = &openAPIResponse{
+			Description: strings.Join(codes, ", "),
+			Content: map[string]*openAPIMediaType{
+				"application/json": {Schema: ref("ErrorResponse"), Examples: examples},
+			},
+		}
+	}
+}
+
+// errorComponent は共通のエラーレスポンスの形式をコンポーネントとして登録する
+func (b *openAPIBuilder) errorComponent(info parser.TypeInfo) {
+	if _, ok := b.schemas["ErrorResponse"]; ok {
+		return
+	}
+	if len(info.Fields) == 0 {
+		// 古いメタデータにはエラー形式が含まれないため既定の形式を使う
+		info = parser.TypeInfo{
+			Name: "ErrorResponse",
+			Fields: []parser.FieldInfo{
+				{JSONName: "error", Type: "outorouter.ErrorBody", NestedType: &parser.TypeInfo{
+					Name: "ErrorBody",
+					Fields: []parser.FieldInfo{
+						{JSONName: "code", Type: "string"},
+						{JSONName: "message", Type: "string"},
+						{JSONName: "request_id", Type: "string", Optional: true},
+					},
+				}},
+			},
+		}
+	}
+	b.component("ErrorResponse", info, false)
+}
+
+// component は型情報をコンポーネントのスキーマとして登録する
+func (b *openAPIBuilder) component(name string, info parser.TypeInfo, isRequest bool) {
+	if _, ok := b.schemas[name]; ok {
+		return
+	}
+	// 再帰的な型に備えて先に登録しておく
+	b.schemas[name] = &openAPISchema{Type: "object"}
+	b.schemas[name] = b.objectSchema(info, isRequest)
+}
+
+// objectSchema は型情報からobjectスキーマを生成する
+// リクエストはvalidateタグのrequiredを、レスポンスはomitemptyでないフィールドを必須とする
+func (b *openAPIBuilder) objectSchema(info parser.TypeInfo, isRequest bool) *openAPISchema {
+	schema := &openAPISchema{
+		Type:       "object",
+		Properties: make(map[string]*openAPISchema),
+	}
+	for _, f := range info.Fields {
+		schema.Properties[f.JSONName] = b.fieldSchema(f)
+
+		required := !f.Optional
+		if isRequest {
+			required = hasRule(f, "required")
+		}
+		if required {
+			schema.Required = append(schema.Required, f.JSONName)
+		}
+
+		if f.NestedType != nil && f.NestedType.Name != "" {
+			b.component(f.NestedType.Name, *f.NestedType, isRequest)
+		}
+	}
+	return schema
+}
+
+// fieldSchema はフィールドのスキーマを生成する
+func (b *openAPIBuilder) fieldSchema(f parser.FieldInfo) *openAPISchema {
+	schema := goTypeSchema(f.Type, f.NestedType)
+	schema.Description = f.Description
+	applyValidation(schema, f.Validation)
+	return schema
+}
+
+// goTypeSchema はGoの型名からスキーマを生成する
+func goTypeSchema(goType string, nested *parser.TypeInfo) *openAPISchema {
+	goType = strings.TrimPrefix(goType, "*")
+
+	switch {
+	case strings.HasPrefix(goType, "[]"):
+		if goType == "[]byte" || goType == "[]uint8" {
+			return &openAPISchema{Type: "string", Format: "byte"}
+		}
+		return &openAPISchema{Type: "array", Items: goTypeSchema(goType[2:], nested)}
+	case strings.HasPrefix(goType, "map["):
+		end := strings.Index(goType, "]")
+		return &openAPISchema{Type: "object", AdditionalProperties: goTypeSchema(goType[end+1:], nested)}
+	}
+
+	switch goType {
+	case "string":
+		return &openAPISchema{Type: "string"}
+	case "bool":
+		return &openAPISchema{Type: "boolean"}
+	case "int", "int8", "int16", "int32", "uint", "uint8", "uint16", "uint32":
+		return &openAPISchema{Type: "integer", Format: "int32"}
+	case "int64", "uint64":
+		return &openAPISchema{Type: "integer", Format: "int64"}
+	case "float32":
+		return &openAPISchema{Type: "number", Format: "float"}
+	case "float64":
+		return &openAPISchema{Type: "number", Format: "double"}
+	case "time.Time":
+		return &openAPISchema{Type: "string", Format: "date-time"}
+	case "multipart.FileHeader":
+		return &openAPISchema{Type: "string", Format: "binary"}
+	}
+
+	if nested != nil && nested.Name != "" {
+		return ref(nested.Name)
+	}
+	// 型が特定できない場合は任意の値を許容する
+	return &openAPISchema{}
+}
+
+// applyValidation はvalidateタグのルールをスキーマの制約に反映する
+func applyValidation(schema *openAPISchema, rules []parser.ValidationRule) {
+	for _, rule := range rules {
+		switch rule.Name {
+		case "min", "max":
+			n, err := strconv.ParseFloat(rule.Value, 64)
+			if err != nil {
+				continue
+			}
+			applyBound(schema, rule.Name == "min", n)
+		case "oneof":
+			for _, v := range strings.Fields(rule.Value) {
+				if schema.Type == "integer" || schema.Type == "number" {
+					if n, err := strconv.ParseFloat(v, 64); err == nil {
+						schema.Enum = append(schema.Enum, n)
+						continue
+					}
+				}
+				schema.Enum = append(schema.Enum, v)
+			}
+		case "maxsize":
+			target := schema
+			if schema.Type == "array" && schema.Items != nil {
+				target = schema.Items
+			}
+			target.XMaxSize = rule.Value
+		case "mime":
+			target := schema
+			if schema.Type == "array" && schema.Items != nil {
+				target = schema.Items
+			}
+			target.XMIMETypes = strings.Fields(rule.Value)
+		}
+	}
+}
+
+// applyBound はmin/maxを型に応じた制約（値・文字数・要素数）として設定する
+func applyBound(schema *openAPISchema, isMin bool, n float64) {
+	count := int(n)
+	switch schema.Type {
+	case "string":
+		if isMin {
+			schema.MinLength = &count
+		} else {
+			schema.MaxLength = &count
+		}
+	case "array":
+		if isMin {
+			schema.MinItems = &count
+		} else {
+			schema.MaxItems = &count
+		}
+	default:
+		if isMin {
+			schema.Minimum = &n
+		} else {
+			schema.Maximum = &n
+		}
+	}
+}
+
+func hasRule(f parser.FieldInfo, name string) bool {
+	for _, rule := range f.Validation {
+		if rule.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func schemaName(name, fallback string) string {
+	if name == "" {
+		return fallback
+	}
+	return name
+}
+
+func ref(name string) *openAPISchema {
+	return &openAPISchema{Ref: "#/components/schemas/" + name}
+}
@@ -0,0 +1,194 @@
+package generator
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/kinpatsu-everyone/backend-template/pkg/outorouter/internal/parser"
+	"gopkg.in/yaml.v3"
+)
+
+func openAPITestMetadata() *parser.Metadata {
+	errorTypeInfo := parser.TypeInfo{
+		Name: "ErrorResponse",
+		Fields: []parser.FieldInfo{
+			{Name: "Error", JSONName: "error", Type: "outorouter.ErrorBody", NestedType: &parser.TypeInfo{
+				Name: "ErrorBody",
+				Fields: []parser.FieldInfo{
+					{Name: "Code", JSONName: "code", Type: "string"},
+					{Name: "Message", JSONName: "message", Type: "string"},
+				},
+			}},
+		},
+	}
+
+	return &parser.Metadata{All: []parser.Endpoint{
+		{
+			Kind:       parser.KindUnaryJSON,
+			Domain:     "monster",
+			Version:    1,
+			MethodName: "GetMonster",
+			HTTPMethod: "POST",
+			Summary:    "Get monster",
+			Tags:       []parser.Tag{"Monster"},
+			RequestTypeInfo: parser.TypeInfo{
+				Name: "GetMonsterRequest",
+				Fields: []parser.FieldInfo{
+					{Name: "ID", JSONName: "id", Type: "string", Validation: []parser.ValidationRule{{Name: "required"}, {Name: "max", Value: "36"}}},
+				},
+			},
+			ResponseTypeInfo: parser.TypeInfo{
+				Name: "GetMonsterResponse",
+				Fields: []parser.FieldInfo{
+					{Name: "Monster", JSONName: "monster", Type: "handler.MonsterItem", NestedType: &parser.TypeInfo{
+						Name: "MonsterItem",
+						Fields: []parser.FieldInfo{
+							{Name: "Latitude", JSONName: "latitude", Type: "float64"},
+						},
+					}},
+				},
+			},
+			ErrorTypeInfo: errorTypeInfo,
+			Errors: []parser.ErrorInfo{
+				{StatusCode: 400, Code: "VALIDATION_FAILED", Message: "バリデーションに失敗しました"},
+				{StatusCode: 404, Code: "MONSTER_NOT_FOUND", Message: "モンスターが見つかりません"},
+			},
+		},
+		{
+			Kind:       parser.KindFileUpload,
+			Domain:     "monster",
+			Version:    1,
+			MethodName: "CreateMonster",
+			HTTPMethod: "POST",
+			Tags:       []parser.Tag{"Monster", "Image"},
+			RequestTypeInfo: parser.TypeInfo{
+				Name: "CreateMonsterRequest",
+				Fields: []parser.FieldInfo{
+					{Name: "Latitude", JSONName: "latitude", Type: "float64", Validation: []parser.ValidationRule{{Name: "min", Value: "-90"}, {Name: "max", Value: "90"}}},
+					{Name: "Image", JSONName: "image", Type: "*multipart.FileHeader", Validation: []parser.ValidationRule{{Name: "required"}, {Name: "mime", Value: "image/png image/jpeg"}}},
+				},
+			},
+			ResponseTypeInfo: parser.TypeInfo{Name: "CreateMonsterResponse"},
+		},
+		{
+			Kind:       parser.KindFileDownload,
+			Domain:     "monster",
+			Version:    1,
+			MethodName: "DownloadMonsterImage",
+			HTTPMethod: "GET",
+			RequestTypeInfo: parser.TypeInfo{
+				Name: "DownloadMonsterImageRequest",
+				Fields: []parser.FieldInfo{
+					{Name: "Type", JSONName: "type", Type: "string", Optional: true, Validation: []parser.ValidationRule{{Name: "oneof", Value: "generated original"}}},
+				},
+			},
+		},
+		{
+			Kind:         parser.KindUnaryJSON,
+			Domain:       "monster",
+			Version:      1,
+			MethodName:   "FindMonster",
+			HTTPMethod:   "GET",
+			HTTPPath:     "/monster/v1/monsters/{id}",
+			Auth:         "required",
+			Scopes:       []string{"monster:read"},
+			RequestType:  "FindMonsterRequest",
+			ResponseType: "FindMonsterResponse",
+			RequestTypeInfo: parser.TypeInfo{
+				Name: "FindMonsterRequest",
+				Fields: []parser.FieldInfo{
+					{Name: "ID", JSONName: "id", Type: "string", In: "path", Validation: []parser.ValidationRule{{Name: "required"}}},
+					{Name: "Lang", JSONName: "lang", Type: "string", Optional: true, In: "query"},
+				},
+			},
+			ResponseTypeInfo: parser.TypeInfo{
+				Name:   "FindMonsterResponse",
+				Fields: []parser.FieldInfo{{Name: "Name", JSONName: "name", Type: "string"}},
+			},
+		},
+	}}
+}
+
+func TestOpenAPIStrategy_JSON(t *testing.T) {
+	out, err := New(OpenAPIStrategy{ServerURL: "http://localhost:8080"}).Generate(openAPITestMetadata())
+	if err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal([]byte(out), &doc); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		path []string
+		want any
+	}{
+		{"openapi version", []string{"openapi"}, "3.1.0"},
+		{"json request body ref", []string{"paths", "/monster/v1/GetMonster", "post", "requestBody", "content", "application/json", "schema", "$ref"}, "#/components/schemas/GetMonsterRequest"},
+		{"error response ref", []string{"paths", "/monster/v1/GetMonster", "post", "responses", "404", "content", "application/json", "schema", "$ref"}, "#/components/schemas/ErrorResponse"},
+		{"error response description", []string{"paths", "/monster/v1/GetMonster", "post", "responses", "404", "description"}, "MONSTER_NOT_FOUND"},
+		{"validate max as maxLength", []string{"components", "schemas", "GetMonsterRequest", "properties", "id", "maxLength"}, float64(36)},
+		{"nested type as component", []string{"components", "schemas", "GetMonsterResponse", "properties", "monster", "$ref"}, "#/components/schemas/MonsterItem"},
+		{"error body component", []string{"components", "schemas", "ErrorResponse", "properties", "error", "$ref"}, "#/components/schemas/ErrorBody"},
+		{"multipart file field", []string{"paths", "/monster/v1/CreateMonster", "post", "requestBody", "content", "multipart/form-data", "schema", "properties", "image", "format"}, "binary"},
+		{"multipart encoding", []string{"paths", "/monster/v1/CreateMonster", "post", "requestBody", "content", "multipart/form-data", "encoding", "image", "contentType"}, "image/png, image/jpeg"},
+		{"validate min as minimum", []string{"paths", "/monster/v1/CreateMonster", "post", "requestBody", "content", "multipart/form-data", "schema", "properties", "latitude", "minimum"}, float64(-90)},
+		{"download query parameter", []string{"paths", "/monster/v1/DownloadMonsterImage", "get", "parameters", "0", "in"}, "query"},
+		{"download head operation", []string{"paths", "/monster/v1/DownloadMonsterImage", "head", "operationId"}, "monster_v1_DownloadMonsterImage_head"},
+		{"path parameter", []string{"paths", "/monster/v1/monsters/{id}", "get", "parameters", "0", "in"}, "path"},
+		{"path parameter required", []string{"paths", "/monster/v1/monsters/{id}", "get", "parameters", "0", "required"}, true},
+		{"query parameter", []string{"paths", "/monster/v1/monsters/{id}", "get", "parameters", "1", "name"}, "lang"},
+		{"security requirement", []string{"paths", "/monster/v1/monsters/{id}", "get", "security", "0", "bearerAuth", "0"}, "monster:read"},
+		{"security scheme", []string{"components", "securitySchemes", "bearerAuth", "scheme"}, "bearer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := lookup(t, doc, tt.path)
+			if got != tt.want {
+				t.Errorf("%s = %v, want %v", strings.Join(tt.path, "."), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOpenAPIStrategy_YAML(t *testing.T) {
+	out, err := New(OpenAPIStrategy{Format: OpenAPIFormatYAML}).Generate(openAPITestMetadata())
+	if err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+
+	var doc map[string]any
+	if err := yaml.Unmarshal([]byte(out), &doc); err != nil {
+		t.Fatalf("output is not valid YAML: %v", err)
+	}
+	if doc["openapi"] != "3.1.0" {
+		t.Errorf("unexpected openapi version: %v", doc["openapi"])
+	}
+	if !strings.Contains(out, "$ref: '#/components/schemas/GetMonsterRequest'") {
+		t.Errorf("yaml output missing request ref")
+	}
+}
+
+func lookup(t *testing.T, v any, path []string) any {
+	t.Helper()
+	for _, key := range path {
+		switch node := v.(type) {
+		case map[string]any:
+			v = node[key]
+		case []any:
+			i, err := strconv.Atoi(key)
+			if err != nil || i >= len(node) {
+				t.Fatalf("index %s out of range", key)
+			}
+			v = node[i]
+		default:
+			t.Fatalf("cannot descend into %T at %q", v, key)
+		}
+	}
+	return v
+}
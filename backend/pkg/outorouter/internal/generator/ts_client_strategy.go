@@ -2,6 +2,9 @@ package generator
 
 import (
 	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 	"text/template"
 	"unicode"
@@ -45,13 +48,28 @@ func (s TypeScriptClientStrategy) Generate(meta *parser.Metadata) (string, error
 	// エンドポイントごとにTypeScript用のデータを生成
 	tsEndpoints := make([]tsEndpointData, 0, len(endpoints))
 	hasMultipart := false
+	hasWebSocket := false
+	hasFileDownload := false
 	for _, ep := range endpoints {
 		isMultipart := ep.Kind == parser.KindFileUpload
 		if isMultipart {
 			hasMultipart = true
 		}
+		isWebSocket := ep.Kind == parser.KindWebSocket
+		if isWebSocket {
+			hasWebSocket = true
+		}
+		isFileDownload := ep.Kind == parser.KindFileDownload
+		if isFileDownload {
+			hasFileDownload = true
+		}
 		tsEndpoints = append(tsEndpoints, tsEndpointData{
-			Path:               "/" + ep.Path(),
+			Key:                tsEndpointKey(ep),
+			Path:               ep.RoutePath(),
+			PathParams:         tsStringArray(ep.PathParams()),
+			QueryParams:        tsStringArray(queryParams(ep)),
+			HasBody:            ep.HasRequestBody(),
+			Auth:               ep.AuthRequirement(),
 			MethodName:         ep.MethodName,
 			HTTPMethod:         ep.HTTPMethod,
 			RequestTypeName:    ep.MethodName + "Request",
@@ -61,14 +79,20 @@ func (s TypeScriptClientStrategy) Generate(meta *parser.Metadata) (string, error
 			RequestTypeFields:  convertFieldsToTS(ep.RequestTypeInfo.Fields),
 			ResponseTypeFields: convertFieldsToTS(ep.ResponseTypeInfo.Fields),
 			IsMultipart:        isMultipart,
+			IsWebSocket:        isWebSocket,
+			IsFileDownload:     isFileDownload,
+			ErrorCodes:         errorCodes(ep.Errors),
 		})
 	}
 
 	data := map[string]any{
-		"BaseURL":      s.BaseURL,
-		"Endpoints":    tsEndpoints,
-		"NestedTypes":  nestedTypes,
-		"HasMultipart": hasMultipart,
+		"BaseURL":         s.BaseURL,
+		"Endpoints":       tsEndpoints,
+		"NestedTypes":     nestedTypes,
+		"HasMultipart":    hasMultipart,
+		"HasWebSocket":    hasWebSocket,
+		"HasFileDownload": hasFileDownload,
+		"ErrorCodes":      collectErrorCodes(endpoints),
 	}
 
 	buf := &bytes.Buffer{}
@@ -80,7 +104,12 @@ func (s TypeScriptClientStrategy) Generate(meta *parser.Metadata) (string, error
 }
 
 type tsEndpointData struct {
-	Path               string
+	Key                string // EndpointTypes のキー
+	Path               string // パスパラメータを含むパスのパターン
+	PathParams         string // TypeScriptの配列リテラル
+	QueryParams        string // TypeScriptの配列リテラル
+	HasBody            bool
+	Auth               string // "none" / "optional" / "required"
 	MethodName         string
 	HTTPMethod         string
 	RequestTypeName    string
@@ -90,13 +119,19 @@ type tsEndpointData struct {
 	RequestTypeFields  []tsFieldData
 	ResponseTypeFields []tsFieldData
 	IsMultipart        bool
+	IsWebSocket        bool
+	IsFileDownload     bool
+	ErrorCodes         []string
 }
 
 type tsFieldData struct {
 	JSONName   string
+	In         string
 	TSType     string
 	Optional   bool
 	NestedType *tsNestedTypeData
+	// Doc はvalidateタグから生成したJSDocの内容です
+	Doc string
 }
 
 type tsNestedTypeData struct {
@@ -104,6 +139,61 @@ type tsNestedTypeData struct {
 	Fields []tsFieldData
 }
 
+// tsEndpointKey は EndpointTypes のキーを返します
+// 既存のクライアントとの互換性のため、POSTのエンドポイントはパスをそのままキーにし、
+// それ以外のメソッドのJSONエンドポイントは同じパスの別メソッドと区別できるよう "GET /path" の形式にします
+func tsEndpointKey(ep parser.Endpoint) string {
+	if ep.Kind != parser.KindUnaryJSON || ep.HTTPMethod == "POST" {
+		return ep.RoutePath()
+	}
+	return ep.HTTPMethod + " " + ep.RoutePath()
+}
+
+// queryParams はクエリ文字列で送るフィールドのJSON名を返します
+// ファイルダウンロードはパスパラメータ以外のすべてのフィールドをクエリ文字列で送ります
+func queryParams(ep parser.Endpoint) []string {
+	var params []string
+	for _, f := range ep.RequestTypeInfo.Fields {
+		if f.In == "query" || (ep.Kind == parser.KindFileDownload && f.In == "") {
+			params = append(params, f.JSONName)
+		}
+	}
+	return params
+}
+
+func tsStringArray(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = strconv.Quote(v)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+// errorCodes はエラー情報からエラーコードの一覧を返します
+func errorCodes(errs []parser.ErrorInfo) []string {
+	codes := make([]string, 0, len(errs))
+	for _, e := range errs {
+		codes = append(codes, e.Code)
+	}
+	return codes
+}
+
+// collectErrorCodes は全エンドポイントのエラーコードを重複なしでソートして返します
+func collectErrorCodes(endpoints []parser.Endpoint) []string {
+	seen := make(map[string]bool)
+	var codes []string
+	for _, ep := range endpoints {
+		for _, e := range ep.Errors {
+			if !seen[e.Code] {
+				seen[e.Code] = true
+				codes = append(codes, e.Code)
+			}
+		}
+	}
+	sort.Strings(codes)
+	return codes
+}
+
 func tagsToStrings(tags []parser.Tag) []string {
 	result := make([]string, len(tags))
 	for i, t := range tags {
@@ -147,8 +237,10 @@ func convertFieldsToTS(fields []parser.FieldInfo) []tsFieldData {
 	for i, f := range fields {
 		fieldData := tsFieldData{
 			JSONName: f.JSONName,
-			TSType:   f.TSType,
+			In:       f.In,
+			TSType:   validatedTSType(f),
 			Optional: f.Optional,
+			Doc:      strings.TrimSpace(f.Description + " " + validationDoc(f)),
 		}
 		if f.NestedType != nil && f.NestedType.Name != "" {
 			fieldData.NestedType = &tsNestedTypeData{
@@ -161,6 +253,63 @@ func convertFieldsToTS(fields []parser.FieldInfo) []tsFieldData {
 	return result
 }
 
+// validatedTSType はoneofルールを持つ文字列・数値フィールドをリテラルのユニオン型に絞り込みます
+func validatedTSType(f parser.FieldInfo) string {
+	for _, rule := range f.Validation {
+		if rule.Name != "oneof" {
+			continue
+		}
+		values := strings.Fields(rule.Value)
+		literals := make([]string, len(values))
+		for i, v := range values {
+			switch f.TSType {
+			case "string":
+				literals[i] = strconv.Quote(v)
+			case "number":
+				literals[i] = v
+			default:
+				return f.TSType
+			}
+		}
+		return strings.Join(literals, " | ")
+	}
+	return f.TSType
+}
+
+// validationDoc はvalidateタグのルールをJSDocのタグに変換します
+func validationDoc(f parser.FieldInfo) string {
+	isArray := strings.HasSuffix(f.TSType, "[]")
+	isString := f.TSType == "string"
+
+	tags := make([]string, 0, len(f.Validation))
+	for _, rule := range f.Validation {
+		switch rule.Name {
+		case "required":
+			tags = append(tags, "@required")
+		case "min", "max":
+			bound := "minimum"
+			if rule.Name == "max" {
+				bound = "maximum"
+			}
+			switch {
+			case isArray:
+				tags = append(tags, fmt.Sprintf("@%sItems %s", rule.Name, rule.Value))
+			case isString:
+				tags = append(tags, fmt.Sprintf("@%sLength %s", rule.Name, rule.Value))
+			default:
+				tags = append(tags, fmt.Sprintf("@%s %s", bound, rule.Value))
+			}
+		case "oneof":
+			tags = append(tags, fmt.Sprintf("@enum %s", strings.Join(strings.Fields(rule.Value), ", ")))
+		case "maxsize":
+			tags = append(tags, fmt.Sprintf("@maxSize %s", rule.Value))
+		case "mime":
+			tags = append(tags, fmt.Sprintf("@mimeTypes %s", strings.Join(strings.Fields(rule.Value), ", ")))
+		}
+	}
+	return strings.Join(tags, " ")
+}
+
 const tsClientTemplate = `// Code generated by outorouter; DO NOT EDIT.
 // This file provides a type-safe API client for Expo/React Native.
 
@@ -173,6 +322,8 @@ const DEFAULT_BASE_URL = "{{ .BaseURL }}";
 export interface ApiClientConfig {
   baseUrl?: string;
   headers?: Record<string, string>;
+  /** Returns the access token sent as "Authorization: Bearer" to endpoints that declare auth */
+  getAccessToken?: () => string | null | undefined | Promise<string | null | undefined>;
   onRequest?: (config: RequestConfig) => RequestConfig | Promise<RequestConfig>;
   onResponse?: <T>(response: ApiResponse<T>) => ApiResponse<T> | Promise<ApiResponse<T>>;
   onError?: (error: ApiError) => void;
@@ -201,31 +352,93 @@ export interface RequestConfig {
   body?: string;
 }
 
+/** Authentication requirement of an endpoint */
+export type AuthRequirement = "none" | "optional" | "required";
+
+/** HTTP method, path pattern, parameter locations and auth requirement of an endpoint */
+export interface RouteDefinition {
+  method: string;
+  path: string;
+  pathParams: readonly string[];
+  queryParams: readonly string[];
+  hasBody: boolean;
+  auth: AuthRequirement;
+}
+
 export interface ApiResponse<T> {
   data: T;
   status: number;
   headers: Headers;
 }
 
+/**
+ * Error codes that the server may return.
+ * "UNKNOWN_ERROR" is used when the error response could not be parsed.
+ */
+export type ApiErrorCode =
+{{- range .ErrorCodes }}
+  | "{{ . }}"
+{{- end }}
+  | "UNKNOWN_ERROR"{{ if .HasWebSocket }}
+  | "WEBSOCKET_ERROR"{{ end }};
+
+/** Field-level error detail (e.g. validation failures) */
+export interface ApiFieldError {
+  field: string;
+  code: string;
+  message: string;
+}
+
 export interface ApiErrorResponse {
   error: {
-    error: string;
+    code: ApiErrorCode;
     message: string;
+    request_id?: string;
+    details?: ApiFieldError[];
   };
 }
 
 export class ApiError extends Error {
   constructor(
     public readonly status: number,
-    public readonly code: string,
+    public readonly code: ApiErrorCode,
     message: string,
-    public readonly response?: Response
+    public readonly response?: Response,
+    public readonly requestId?: string,
+    public readonly details: ApiFieldError[] = []
   ) {
     super(message);
     this.name = "ApiError";
   }
 }
 
+/** Returns true if the error is an ApiError with one of the given codes */
+export function isApiError<C extends ApiErrorCode>(
+  error: unknown,
+  ...codes: C[]
+): error is ApiError & { code: C } {
+  if (!(error instanceof ApiError)) {
+    return false;
+  }
+  return codes.length === 0 || (codes as ApiErrorCode[]).includes(error.code);
+}
+
+function createApiError(
+  status: number,
+  errorData: Partial<ApiErrorResponse> | null,
+  fallbackMessage: string,
+  response?: Response
+): ApiError {
+  return new ApiError(
+    status,
+    errorData?.error?.code ?? "UNKNOWN_ERROR",
+    errorData?.error?.message ?? fallbackMessage,
+    response,
+    errorData?.error?.request_id,
+    errorData?.error?.details ?? []
+  );
+}
+
 // ============================================================================
 // Nested Type Definitions
 // ============================================================================
@@ -235,6 +448,9 @@ export class ApiError extends Error {
 export interface {{ .Name }} {
 {{- if .Fields }}
 {{- range .Fields }}
+{{- if .Doc }}
+  /** {{ .Doc }} */
+{{- end }}
   {{ .JSONName }}{{ if .Optional }}?{{ end }}: {{ .TSType }};
 {{- end }}
 {{- else }}
@@ -248,21 +464,38 @@ export interface {{ .Name }} {
 // ============================================================================
 {{- range .Endpoints }}
 
+/**
+ * {{ .Summary }} - Error codes
+ */
+export type {{ .MethodName }}ErrorCode ={{ range .ErrorCodes }}
+  | "{{ . }}"{{ else }} never{{ end }};
+
 /** {{ .Summary }} - Request */
 export interface {{ .RequestTypeName }} {
 {{- if .RequestTypeFields }}
 {{- range .RequestTypeFields }}
+{{- if .Doc }}
+  /** {{ .Doc }} */
+{{- end }}
   {{ .JSONName }}{{ if .Optional }}?{{ end }}: {{ .TSType }};
 {{- end }}
 {{- else }}
   // Empty request
 {{- end }}
 }
+{{- if .IsFileDownload }}
+
+/** {{ .Summary }} - Response (binary file) */
+export type {{ .ResponseTypeName }} = Blob;
+{{- else }}
 
 /** {{ .Summary }} - Response */
 export interface {{ .ResponseTypeName }} {
 {{- if .ResponseTypeFields }}
 {{- range .ResponseTypeFields }}
+{{- if .Doc }}
+  /** {{ .Doc }} */
+{{- end }}
   {{ .JSONName }}{{ if .Optional }}?{{ end }}: {{ .TSType }};
 {{- end }}
 {{- else }}
@@ -270,6 +503,7 @@ export interface {{ .ResponseTypeName }} {
 {{- end }}
 }
 {{- end }}
+{{- end }}
 
 // ============================================================================
 // Endpoint Path Definitions
@@ -277,12 +511,76 @@ export interface {{ .ResponseTypeName }} {
 
 export const Endpoints = {
 {{- range .Endpoints }}
-  {{ .MethodName }}: "{{ .Path }}",
+  {{ .MethodName }}: "{{ .Key }}",
 {{- end }}
 } as const;
 
 export type EndpointPath = (typeof Endpoints)[keyof typeof Endpoints];
 
+// ============================================================================
+// Route Definitions
+// ============================================================================
+
+export const Routes = {
+{{- range .Endpoints }}
+  {{ .MethodName }}: {
+    method: "{{ .HTTPMethod }}",
+    path: "{{ .Path }}",
+    pathParams: {{ .PathParams }},
+    queryParams: {{ .QueryParams }},
+    hasBody: {{ .HasBody }},
+    auth: "{{ .Auth }}",
+  },
+{{- end }}
+} as const;
+
+/**
+ * Fills the path parameters of the route and appends the query parameters.
+ * Returns the resolved path and the remaining fields to send in the request body.
+ */
+export function resolveRoute(
+  route: RouteDefinition,
+  request: object
+): { path: string; body: Record<string, unknown> } {
+  const body: Record<string, unknown> = { ...(request as Record<string, unknown>) };
+
+  let path = route.path;
+  for (const name of route.pathParams) {
+    path = path.replace("{" + name + "}", encodeURIComponent(String(body[name] ?? "")));
+    delete body[name];
+  }
+
+  const params = new URLSearchParams();
+  for (const name of route.queryParams) {
+    const value = body[name];
+    delete body[name];
+    if (value === undefined || value === null) {
+      continue;
+    }
+    if (Array.isArray(value)) {
+      value.forEach((v) => params.append(name, String(v)));
+    } else {
+      params.append(name, String(value));
+    }
+  }
+
+  const query = params.toString();
+  return { path: query ? path + "?" + query : path, body };
+}
+
+/**
+ * Returns the Authorization header for endpoints that declare auth.
+ * The token is obtained from getAccessToken in the client configuration.
+ */
+export async function authHeaders(route: Pick<RouteDefinition, "auth">): Promise<Record<string, string>> {
+  const config = getApiClientConfig();
+  if (route.auth === "none" || !config.getAccessToken) {
+    return {};
+  }
+  const token = await config.getAccessToken();
+  return token ? { Authorization: ` + "`" + `Bearer ${token}` + "`" + ` } : {};
+}
+
 // ============================================================================
 // Endpoint Type Mapping
 // ============================================================================
@@ -293,13 +591,23 @@ export type EndpointPath = (typeof Endpoints)[keyof typeof Endpoints];
  */
 export interface EndpointTypes {
 {{- range .Endpoints }}
-  "{{ .Path }}": {
+{{- if not (or .IsWebSocket .IsFileDownload) }}
+  "{{ .Key }}": {
     request: {{ .RequestTypeName }};
     response: {{ .ResponseTypeName }};
   };
 {{- end }}
+{{- end }}
 }
 
+const endpointRoutes: { [P in keyof EndpointTypes]: RouteDefinition } = {
+{{- range .Endpoints }}
+{{- if not (or .IsWebSocket .IsFileDownload) }}
+  "{{ .Key }}": Routes.{{ .MethodName }},
+{{- end }}
+{{- end }}
+};
+
 // ============================================================================
 // Type-Safe API Client
 // ============================================================================
@@ -328,17 +636,20 @@ export async function api<P extends keyof EndpointTypes>(
   }
 ): Promise<ApiResponse<EndpointTypes[P]["response"]>> {
   const config = getApiClientConfig();
-  const url = ` + "`" + `${config.baseUrl ?? DEFAULT_BASE_URL}${endpoint}` + "`" + `;
+  const route = endpointRoutes[endpoint];
+  const { path, body } = resolveRoute(route, request);
+  const url = ` + "`" + `${config.baseUrl ?? DEFAULT_BASE_URL}${path}` + "`" + `;
 
   let requestConfig: RequestConfig = {
-    method: "POST",
+    method: route.method,
     url,
     headers: {
-      "Content-Type": "application/json",
+      ...(route.hasBody ? { "Content-Type": "application/json" } : {}),
       ...config.headers,
+      ...(await authHeaders(route)),
       ...options?.headers,
     },
-    body: JSON.stringify(request),
+    body: route.hasBody ? JSON.stringify(body) : undefined,
   };
 
   // Apply request interceptor
@@ -363,12 +674,7 @@ export async function api<P extends keyof EndpointTypes>(
       // Response body is not JSON
     }
 
-    const apiError = new ApiError(
-      response.status,
-      errorData?.error?.error ?? "UNKNOWN_ERROR",
-      errorData?.error?.message ?? response.statusText,
-      response
-    );
+    const apiError = createApiError(response.status, errorData, response.statusText, response);
 
     if (config.onError) {
       config.onError(apiError);
@@ -423,9 +729,11 @@ export function createApiCaller<P extends keyof EndpointTypes>(endpoint: P) {
 
 export const apiCallers = {
 {{- range .Endpoints }}
+{{- if not (or .IsWebSocket .IsFileDownload) }}
   /** {{ .Summary }} */
   {{ .MethodName }}: createApiCaller(Endpoints.{{ .MethodName }}),
 {{- end }}
+{{- end }}
 };
 {{- if .HasMultipart }}
 
@@ -443,15 +751,17 @@ export async function apiMultipart<T>(
   options?: {
     headers?: Record<string, string>;
     signal?: AbortSignal;
-  }
+  },
+  route: Pick<RouteDefinition, "method" | "auth"> = { method: "POST", auth: "none" }
 ): Promise<ApiResponse<T>> {
   const config = getApiClientConfig();
   const url = ` + "`" + `${config.baseUrl ?? DEFAULT_BASE_URL}${endpoint}` + "`" + `;
 
   const fetchOptions: RequestInit = {
-    method: "POST",
+    method: route.method,
     headers: {
       ...config.headers,
+      ...(await authHeaders(route)),
       ...options?.headers,
     },
     body: formData,
@@ -468,12 +778,7 @@ export async function apiMultipart<T>(
       // Response body is not JSON
     }
 
-    const apiError = new ApiError(
-      response.status,
-      errorData?.error?.error ?? "UNKNOWN_ERROR",
-      errorData?.error?.message ?? response.statusText,
-      response
-    );
+    const apiError = createApiError(response.status, errorData, response.statusText, response);
 
     if (config.onError) {
       config.onError(apiError);
@@ -506,8 +811,14 @@ export async function apiMultipart<T>(
 export interface {{ .MethodName }}Params {
 {{- range .RequestTypeFields }}
 {{- if eq .TSType "FileHeader" }}
+{{- if .Doc }}
+  /** {{ .Doc }} */
+{{- end }}
   {{ .JSONName }}: string; // File URI (e.g., "file:///path/to/photo.jpg")
 {{- else }}
+{{- if .Doc }}
+  /** {{ .Doc }} */
+{{- end }}
   {{ .JSONName }}{{ if .Optional }}?{{ end }}: {{ .TSType }};
 {{- end }}
 {{- end }}
@@ -524,9 +835,11 @@ export async function {{ .MethodName | toLowerCamel }}(
     signal?: AbortSignal;
   }
 ): Promise<ApiResponse<{{ .ResponseTypeName }}>> {
+  const { path } = resolveRoute(Routes.{{ .MethodName }}, params);
   const formData = new FormData();
 {{- range .RequestTypeFields }}
-{{- if eq .TSType "FileHeader" }}
+{{- if .In }}
+{{- else if eq .TSType "FileHeader" }}
   formData.append("{{ .JSONName }}", {
     uri: params.{{ .JSONName }},
     type: "image/jpeg",
@@ -538,9 +851,220 @@ export async function {{ .MethodName | toLowerCamel }}(
 {{- end }}
 
   return apiMultipart<{{ .ResponseTypeName }}>(
-    Endpoints.{{ .MethodName }},
+    path,
     formData,
-    options
+    options,
+    Routes.{{ .MethodName }}
+  );
+}
+{{- end }}
+{{- end }}
+{{- end }}
+{{- if .HasFileDownload }}
+
+// ============================================================================
+// File Download Client
+// ============================================================================
+
+/**
+ * Downloads a file via GET. The request fields are sent as path and query parameters.
+ * Pass a Range header in options.headers to fetch part of the file.
+ */
+export async function apiDownload(
+  route: RouteDefinition,
+  request: object,
+  options?: {
+    headers?: Record<string, string>;
+    signal?: AbortSignal;
+  }
+): Promise<ApiResponse<Blob>> {
+  const config = getApiClientConfig();
+  const { path } = resolveRoute(route, request);
+  const url = ` + "`" + `${config.baseUrl ?? DEFAULT_BASE_URL}${path}` + "`" + `;
+
+  const response = await fetch(url, {
+    method: "GET",
+    headers: {
+      ...config.headers,
+      ...(await authHeaders(route)),
+      ...options?.headers,
+    },
+    signal: options?.signal,
+  });
+
+  if (!response.ok) {
+    let errorData: ApiErrorResponse | null = null;
+    try {
+      errorData = await response.json();
+    } catch {
+      // Response body is not JSON
+    }
+
+    const apiError = createApiError(response.status, errorData, response.statusText, response);
+
+    if (config.onError) {
+      config.onError(apiError);
+    }
+
+    throw apiError;
+  }
+
+  const data = await response.blob();
+
+  let result: ApiResponse<Blob> = {
+    data,
+    status: response.status,
+    headers: response.headers,
+  };
+
+  if (config.onResponse) {
+    result = await config.onResponse(result);
+  }
+
+  return result;
+}
+
+// ============================================================================
+// File Download Helper Functions
+// ============================================================================
+{{- range .Endpoints }}
+{{- if .IsFileDownload }}
+
+/**
+ * {{ .Summary }}
+ * Downloads the file as a Blob.
+ */
+export async function {{ .MethodName | toLowerCamel }}(
+  request: {{ .RequestTypeName }},
+  options?: {
+    headers?: Record<string, string>;
+    signal?: AbortSignal;
+  }
+): Promise<ApiResponse<{{ .ResponseTypeName }}>> {
+  return apiDownload(Routes.{{ .MethodName }}, request, options);
+}
+{{- end }}
+{{- end }}
+{{- end }}
+{{- if .HasWebSocket }}
+
+// ============================================================================
+// WebSocket Client (for subscriptions)
+// ============================================================================
+
+export interface WebSocketHandlers<T> {
+  onOpen?: () => void;
+  onMessage: (message: T) => void;
+  onError?: (error: ApiError) => void;
+  onClose?: (code: number, reason: string) => void;
+}
+
+export interface WebSocketSubscription<Req> {
+  send: (request: Req) => void;
+  close: () => void;
+}
+
+/**
+ * Opens a WebSocket connection to the endpoint and sends the initial request on open.
+ * Error frames sent by the server are passed to onError instead of onMessage.
+ * For endpoints that declare auth, the token from getAccessToken is sent as the
+ * "bearer" subprotocol because the WebSocket API cannot set the Authorization header.
+ */
+export function subscribe<Req, Res>(
+  endpoint: string,
+  auth: AuthRequirement,
+  request: Req | undefined,
+  handlers: WebSocketHandlers<Res>
+): WebSocketSubscription<Req> {
+  const config = getApiClientConfig();
+  const baseUrl = (config.baseUrl ?? DEFAULT_BASE_URL).replace(/^http/, "ws");
+  let socket: WebSocket | undefined;
+  let closed = false;
+  // Requests sent before the connection opens are sent on open
+  const pending: Req[] = [];
+
+  const open = (token: string | null | undefined) => {
+    if (closed) {
+      return;
+    }
+    const ws = new WebSocket(` + "`" + `${baseUrl}${endpoint}` + "`" + `, token ? ["bearer", token] : undefined);
+    socket = ws;
+
+    ws.onopen = () => {
+      handlers.onOpen?.();
+      if (request !== undefined) {
+        ws.send(JSON.stringify(request));
+      }
+      pending.splice(0).forEach((req) => ws.send(JSON.stringify(req)));
+    };
+
+    ws.onmessage = (event: MessageEvent) => {
+      let payload: unknown;
+      try {
+        payload = JSON.parse(String(event.data));
+      } catch {
+        return;
+      }
+
+      const errorData = payload as Partial<ApiErrorResponse>;
+      if (errorData && typeof errorData === "object" && errorData.error) {
+        const apiError = createApiError(0, errorData, "WebSocket error");
+        handlers.onError?.(apiError);
+        config.onError?.(apiError);
+        return;
+      }
+
+      handlers.onMessage(payload as Res);
+    };
+
+    ws.onerror = () => {
+      handlers.onError?.(new ApiError(0, "WEBSOCKET_ERROR", "WebSocket connection error"));
+    };
+
+    ws.onclose = (event: CloseEvent) => {
+      handlers.onClose?.(event.code, event.reason);
+    };
+  };
+
+  const token = auth === "none" || !config.getAccessToken ? undefined : config.getAccessToken();
+  Promise.resolve(token).then(open, () => {
+    handlers.onError?.(new ApiError(0, "WEBSOCKET_ERROR", "Failed to get the access token"));
+  });
+
+  return {
+    send: (req: Req) => {
+      if (socket?.readyState === WebSocket.OPEN) {
+        socket.send(JSON.stringify(req));
+      } else {
+        pending.push(req);
+      }
+    },
+    close: () => {
+      closed = true;
+      socket?.close(1000);
+    },
+  };
+}
+
+// ============================================================================
+// WebSocket Helper Functions
+// ============================================================================
+{{- range .Endpoints }}
+{{- if .IsWebSocket }}
+
+/**
+ * {{ .Summary }}
+ * Subscribes to server-pushed {{ .ResponseTypeName }} messages over WebSocket.
+ */
+export function {{ .MethodName | toLowerCamel }}(
+  request: {{ .RequestTypeName }} | undefined,
+  handlers: WebSocketHandlers<{{ .ResponseTypeName }}>
+): WebSocketSubscription<{{ .RequestTypeName }}> {
+  return subscribe<{{ .RequestTypeName }}, {{ .ResponseTypeName }}>(
+    Endpoints.{{ .MethodName }},
+    "{{ .Auth }}",
+    request,
+    handlers
   );
 }
 {{- end }}
@@ -59,6 +59,7 @@ func TestTypeScriptClientStrategy(t *testing.T) {
 			ResponseType: "CreateUserResponse",
 			Summary:      "Create a new user",
 			Tags:         []parser.Tag{"User"},
+			Auth:         "required",
 		},
 		{
 			Kind:         parser.KindUnaryJSON,
@@ -114,6 +115,12 @@ func TestTypeScriptClientStrategy(t *testing.T) {
 		// Pre-configured callers
 		`export const apiCallers = {`,
 		`CreateUser: createApiCaller(Endpoints.CreateUser)`,
+
+		// Auth
+		`getAccessToken?: () =>`,
+		`auth: "required",`,
+		`auth: "none",`,
+		`...(await authHeaders(route)),`,
 	}
 
 	for _, expected := range expectedStrings {
@@ -141,3 +148,187 @@ func TestTypeScriptClientStrategy_EmptyEndpoints(t *testing.T) {
 		t.Error("generated code missing api function")
 	}
 }
+
+func TestTypeScriptClientStrategy_WebSocket(t *testing.T) {
+	meta := &parser.Metadata{All: []parser.Endpoint{
+		{
+			Kind:         parser.KindWebSocket,
+			Domain:       "monster",
+			Version:      1,
+			MethodName:   "WatchMonster",
+			HTTPMethod:   "GET",
+			RequestType:  "WatchMonsterRequest",
+			ResponseType: "WatchMonsterResponse",
+			Summary:      "Watch monster progress",
+			Tags:         []parser.Tag{"Monster"},
+			Auth:         "required",
+		},
+	}}
+
+	code, err := New(TypeScriptClientStrategy{}).Generate(meta)
+	if err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+
+	expectedStrings := []string{
+		`WatchMonster: "/monster/v1/WatchMonster"`,
+		`export function subscribe<Req, Res>(`,
+		`export function watchMonster(`,
+		`handlers: WebSocketHandlers<WatchMonsterResponse>`,
+		// 認証を宣言したエンドポイントはトークンをサブプロトコルで渡す
+		"Endpoints.WatchMonster,\n    \"required\",",
+		`new WebSocket(` + "`${baseUrl}${endpoint}`" + `, token ? ["bearer", token] : undefined)`,
+	}
+	for _, expected := range expectedStrings {
+		if !strings.Contains(code, expected) {
+			t.Errorf("generated code missing expected string: %q", expected)
+		}
+	}
+
+	// WebSocketエンドポイントはPOST用のapiCallersに含めない
+	if strings.Contains(code, "createApiCaller(Endpoints.WatchMonster)") {
+		t.Errorf("websocket endpoint must not be exposed via apiCallers")
+	}
+}
+
+func TestTypeScriptClientStrategy_FileDownload(t *testing.T) {
+	meta := &parser.Metadata{All: []parser.Endpoint{
+		{
+			Kind:         parser.KindFileDownload,
+			Domain:       "monster",
+			Version:      1,
+			MethodName:   "DownloadMonsterImage",
+			HTTPMethod:   "GET",
+			RequestType:  "DownloadMonsterImageRequest",
+			ResponseType: "outorouter.FileDownloadResponseObject",
+			Summary:      "Download monster image",
+			Tags:         []parser.Tag{"Monster"},
+			RequestTypeInfo: parser.TypeInfo{
+				Name: "DownloadMonsterImageRequest",
+				Fields: []parser.FieldInfo{
+					{Name: "ID", JSONName: "id", Type: "string", TSType: "string"},
+				},
+			},
+		},
+	}}
+
+	code, err := New(TypeScriptClientStrategy{}).Generate(meta)
+	if err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+
+	expectedStrings := []string{
+		`export type DownloadMonsterImageResponse = Blob;`,
+		`export async function apiDownload(`,
+		`export async function downloadMonsterImage(`,
+		`Promise<ApiResponse<DownloadMonsterImageResponse>>`,
+		`const data = await response.blob();`,
+	}
+	for _, expected := range expectedStrings {
+		if !strings.Contains(code, expected) {
+			t.Errorf("generated code missing expected string: %q", expected)
+		}
+	}
+
+	// ファイルダウンロードはJSON用のapiCallersに含めない
+	if strings.Contains(code, "createApiCaller(Endpoints.DownloadMonsterImage)") {
+		t.Errorf("file download endpoint must not be exposed via apiCallers")
+	}
+}
+
+func TestTypeScriptClientStrategy_ErrorCodes(t *testing.T) {
+	meta := &parser.Metadata{All: []parser.Endpoint{
+		{
+			Kind:         parser.KindUnaryJSON,
+			Domain:       "monster",
+			Version:      1,
+			MethodName:   "GetMonster",
+			HTTPMethod:   "POST",
+			RequestType:  "GetMonsterRequest",
+			ResponseType: "GetMonsterResponse",
+			Summary:      "Get monster",
+			Errors: []parser.ErrorInfo{
+				{StatusCode: 400, Code: "VALIDATION_FAILED", Message: "バリデーションに失敗しました"},
+				{StatusCode: 404, Code: "MONSTER_NOT_FOUND", Message: "モンスターが見つかりません"},
+			},
+		},
+		{
+			Kind:         parser.KindUnaryJSON,
+			Domain:       "health",
+			Version:      1,
+			MethodName:   "Ping",
+			HTTPMethod:   "POST",
+			RequestType:  "PingRequest",
+			ResponseType: "PingResponse",
+			Summary:      "Ping",
+		},
+	}}
+
+	code, err := New(TypeScriptClientStrategy{}).Generate(meta)
+	if err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+
+	expectedStrings := []string{
+		"export type ApiErrorCode =\n  | \"MONSTER_NOT_FOUND\"\n  | \"VALIDATION_FAILED\"\n  | \"UNKNOWN_ERROR\";",
+		"export type GetMonsterErrorCode =\n  | \"VALIDATION_FAILED\"\n  | \"MONSTER_NOT_FOUND\";",
+		`export type PingErrorCode = never;`,
+		`export interface ApiFieldError {`,
+		`request_id?: string;`,
+		`details?: ApiFieldError[];`,
+		`public readonly requestId?: string,`,
+		`export function isApiError<C extends ApiErrorCode>(`,
+		`errorData?.error?.code ?? "UNKNOWN_ERROR"`,
+	}
+	for _, expected := range expectedStrings {
+		if !strings.Contains(code, expected) {
+			t.Errorf("generated code missing expected string: %q", expected)
+		}
+	}
+
+	// 旧形式のエラーフィールドを参照しない
+	if strings.Contains(code, "error?.error?.error") {
+		t.Errorf("generated code must not read legacy error.error field")
+	}
+}
+
+func TestTypeScriptClientStrategy_Validation(t *testing.T) {
+	meta := &parser.Metadata{All: []parser.Endpoint{
+		{
+			Kind:         parser.KindFileUpload,
+			Domain:       "monster",
+			Version:      1,
+			MethodName:   "CreateMonster",
+			HTTPMethod:   "POST",
+			RequestType:  "CreateMonsterRequest",
+			ResponseType: "CreateMonsterResponse",
+			Summary:      "Create monster",
+			RequestTypeInfo: parser.TypeInfo{
+				Name: "CreateMonsterRequest",
+				Fields: []parser.FieldInfo{
+					{Name: "Nickname", JSONName: "nickname", Type: "string", TSType: "string", Validation: []parser.ValidationRule{{Name: "required"}, {Name: "max", Value: "50"}}},
+					{Name: "Latitude", JSONName: "latitude", Type: "float64", TSType: "number", Validation: []parser.ValidationRule{{Name: "min", Value: "-90"}, {Name: "max", Value: "90"}}},
+					{Name: "Kind", JSONName: "kind", Type: "string", TSType: "string", Optional: true, Validation: []parser.ValidationRule{{Name: "oneof", Value: "generated original"}}},
+					{Name: "Image", JSONName: "image", Type: "*multipart.FileHeader", TSType: "FileHeader", Validation: []parser.ValidationRule{{Name: "required"}, {Name: "maxsize", Value: "20MB"}, {Name: "mime", Value: "image/*"}}},
+				},
+			},
+		},
+	}}
+
+	code, err := New(TypeScriptClientStrategy{}).Generate(meta)
+	if err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+
+	expectedStrings := []string{
+		"  /** @required @maxLength 50 */\n  nickname: string;",
+		"  /** @minimum -90 @maximum 90 */\n  latitude: number;",
+		"  /** @enum generated, original */\n  kind?: \"generated\" | \"original\";",
+		"  /** @required @maxSize 20MB @mimeTypes image/* */\n  image: string; // File URI",
+	}
+	for _, expected := range expectedStrings {
+		if !strings.Contains(code, expected) {
+			t.Errorf("generated code missing expected string: %q", expected)
+		}
+	}
+}
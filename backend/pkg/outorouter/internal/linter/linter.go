@@ -1,8 +1,11 @@
 package linter
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/kinpatsu-everyone/backend-template/pkg/outorouter/internal/parser"
@@ -14,58 +17,259 @@ type LintLevel string
 const (
 	LevelError   LintLevel = "error"
 	LevelWarning LintLevel = "warning"
+	LevelInfo    LintLevel = "info"
+	// LevelOff は設定でルールを無効化するために使う。
+	LevelOff LintLevel = "off"
 )
 
+// Rule は検査ルールの定義を表す。
+type Rule struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Level       LintLevel `json:"level"` // デフォルトの重要度
+	Description string    `json:"description"`
+}
+
+// ルールIDの一覧です。
+const (
+	RuleRequestTypeMissing   = "OR001"
+	RuleResponseTypeMissing  = "OR002"
+	RuleMethodNamePascalCase = "OR003"
+	RuleHTTPMethodMissing    = "OR004"
+	RuleHTTPMethodUppercase  = "OR005"
+	RuleDuplicateEndpoint    = "OR006"
+	RuleTagsMissing          = "OR007"
+	RuleSummaryMissing       = "OR008"
+	RuleTypeNameMismatch     = "OR009"
+	RuleJSONNameSnakeCase    = "OR010"
+	RuleUndocumentedField    = "OR011"
+	RuleNestedTypeConflict   = "OR012"
+)
+
+// Rules は組み込みのルールの一覧です。
+var Rules = []Rule{
+	{ID: RuleRequestTypeMissing, Name: "request-type-missing", Level: LevelError, Description: "request_type が設定されていること"},
+	{ID: RuleResponseTypeMissing, Name: "response-type-missing", Level: LevelError, Description: "response_type が設定されていること"},
+	{ID: RuleMethodNamePascalCase, Name: "method-name-pascal-case", Level: LevelWarning, Description: "MethodName がパスカルケースであること"},
+	{ID: RuleHTTPMethodMissing, Name: "http-method-missing", Level: LevelError, Description: "HTTPメソッドが設定されていること"},
+	{ID: RuleHTTPMethodUppercase, Name: "http-method-uppercase", Level: LevelWarning, Description: "HTTPメソッドが大文字であること"},
+	{ID: RuleDuplicateEndpoint, Name: "duplicate-endpoint", Level: LevelError, Description: "同一メソッド・パスのエンドポイントが重複していないこと"},
+	{ID: RuleTagsMissing, Name: "tags-missing", Level: LevelWarning, Description: "Tags が設定されていること"},
+	{ID: RuleSummaryMissing, Name: "summary-missing", Level: LevelWarning, Description: "Summary が設定されていること"},
+	{ID: RuleTypeNameMismatch, Name: "type-name-mismatch", Level: LevelWarning, Description: "リクエスト・レスポンスの型名が {MethodName}Request / {MethodName}Response であること"},
+	{ID: RuleJSONNameSnakeCase, Name: "json-name-snake-case", Level: LevelWarning, Description: "フィールドのJSON名がスネークケースであること"},
+	// フィールドの説明はハンドラーのコメントに書くことが多いため、デフォルトでは無効（設定で有効化する）
+	{ID: RuleUndocumentedField, Name: "undocumented-field", Level: LevelOff, Description: "フィールドに doc タグで説明が書かれていること"},
+	{ID: RuleNestedTypeConflict, Name: "nested-type-conflict", Level: LevelError, Description: "同名のネストされた型が同じ構造であること"},
+}
+
 // Issue は1件の指摘を表す。
 type Issue struct {
-	Level   LintLevel
-	Path    string
-	Message string
+	RuleID  string    `json:"rule_id"`
+	Level   LintLevel `json:"level"`
+	Path    string    `json:"path"`
+	Field   string    `json:"field,omitempty"`
+	Message string    `json:"message"`
+}
+
+// Config はルールごとの設定を表す。
+type Config struct {
+	// Severity はルールIDまたはルール名ごとの重要度の上書きです（"off" で無効化）。
+	Severity map[string]LintLevel `json:"severity"`
+}
+
+// LoadConfig はJSON形式の設定ファイルを読み込む。
+func LoadConfig(path string) (Config, error) {
+	var cfg Config
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, fmt.Errorf("failed to read lint config: %w", err)
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("failed to parse lint config: %w", err)
+	}
+
+	for key, level := range cfg.Severity {
+		if findRule(key) == nil {
+			return cfg, fmt.Errorf("unknown lint rule %q", key)
+		}
+		switch level {
+		case LevelError, LevelWarning, LevelInfo, LevelOff:
+		default:
+			return cfg, fmt.Errorf("invalid level %q for lint rule %q", level, key)
+		}
+	}
+	return cfg, nil
+}
+
+func findRule(key string) *Rule {
+	for i := range Rules {
+		if Rules[i].ID == key || Rules[i].Name == key {
+			return &Rules[i]
+		}
+	}
+	return nil
+}
+
+// level はルールの重要度を設定を考慮して返す。
+func (c Config) level(rule *Rule) LintLevel {
+	if level, ok := c.Severity[rule.ID]; ok {
+		return level
+	}
+	if level, ok := c.Severity[rule.Name]; ok {
+		return level
+	}
+	return rule.Level
+}
+
+// suppressed はルールがIDまたは名前で抑制されているかを返す。
+func suppressed(rule *Rule, ignores []string) bool {
+	return slices.Contains(ignores, rule.ID) || slices.Contains(ignores, rule.Name)
+}
+
+// HasErrors はエラーレベルの指摘が含まれているかを返す。
+func HasErrors(issues []Issue) bool {
+	for _, issue := range issues {
+		if issue.Level == LevelError {
+			return true
+		}
+	}
+	return false
 }
 
-// Lint は中間表現の品質を検査し、問題点を返す。
+var (
+	methodRe    = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+	snakeCaseRe = regexp.MustCompile(`^[a-z][a-z0-9]*(_[a-z0-9]+)*$`)
+)
+
+// Lint は中間表現の品質をデフォルトの設定で検査し、問題点を返す。
 func Lint(meta *parser.Metadata) []Issue {
-	issues := make([]Issue, 0)
+	return LintWithConfig(meta, Config{})
+}
 
+// LintWithConfig は中間表現の品質を検査し、問題点を返す。
+func LintWithConfig(meta *parser.Metadata, cfg Config) []Issue {
 	if meta == nil {
 		return []Issue{{Level: LevelError, Path: "<root>", Message: "metadata is nil"}}
 	}
 
-	seen := make(map[string]struct{})
-	methodRe := regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+	l := &lintRun{cfg: cfg, issues: make([]Issue, 0)}
 
+	seen := make(map[string]struct{})
 	for _, ep := range meta.All {
 		path := ep.Path()
+		report := func(ruleID, message string) {
+			l.report(ruleID, path, "", message, ep.LintIgnore)
+		}
 
 		if ep.RequestType == "" {
-			issues = append(issues, Issue{Level: LevelError, Path: path, Message: "request_type が空です"})
+			report(RuleRequestTypeMissing, "request_type が空です")
 		}
 		if ep.ResponseType == "" {
-			issues = append(issues, Issue{Level: LevelError, Path: path, Message: "response_type が空です"})
+			report(RuleResponseTypeMissing, "response_type が空です")
 		}
 		if !methodRe.MatchString(ep.MethodName) {
-			issues = append(issues, Issue{Level: LevelWarning, Path: path, Message: "MethodName はパスカルケースが推奨です"})
+			report(RuleMethodNamePascalCase, "MethodName はパスカルケースが推奨です")
 		}
 		if ep.HTTPMethod == "" {
-			issues = append(issues, Issue{Level: LevelError, Path: path, Message: "HTTPメソッドが未指定です"})
+			report(RuleHTTPMethodMissing, "HTTPメソッドが未指定です")
 		} else if strings.ToUpper(ep.HTTPMethod) != ep.HTTPMethod {
-			issues = append(issues, Issue{Level: LevelWarning, Path: path, Message: "HTTPメソッドは大文字で記述してください"})
+			report(RuleHTTPMethodUppercase, "HTTPメソッドは大文字で記述してください")
 		}
 
 		key := fmt.Sprintf("%s#%s", ep.HTTPMethod, path)
 		if _, ok := seen[key]; ok {
-			issues = append(issues, Issue{Level: LevelError, Path: path, Message: "同一メソッド・パスのエンドポイントが重複しています"})
+			report(RuleDuplicateEndpoint, "同一メソッド・パスのエンドポイントが重複しています")
 		} else {
 			seen[key] = struct{}{}
 		}
 
 		if len(ep.Tags) == 0 {
-			issues = append(issues, Issue{Level: LevelWarning, Path: path, Message: "Tags が設定されていません"})
+			report(RuleTagsMissing, "Tags が設定されていません")
 		}
 		if strings.TrimSpace(ep.Summary) == "" {
-			issues = append(issues, Issue{Level: LevelWarning, Path: path, Message: "Summary が空です"})
+			report(RuleSummaryMissing, "Summary が空です")
+		}
+
+		if name := ep.RequestTypeInfo.Name; name != "" && name != ep.MethodName+"Request" {
+			report(RuleTypeNameMismatch, fmt.Sprintf("リクエストの型名 %s は %sRequest が推奨です", name, ep.MethodName))
 		}
+		// ファイルのダウンロードは共通の FileDownloadResponseObject を返すため、レスポンスの型名は検査しない
+		if name := ep.ResponseTypeInfo.Name; name != "" && ep.Kind != parser.KindFileDownload && name != ep.MethodName+"Response" {
+			report(RuleTypeNameMismatch, fmt.Sprintf("レスポンスの型名 %s は %sResponse が推奨です", name, ep.MethodName))
+		}
+
+		l.lintFields(path, "request", ep.RequestTypeInfo.Fields, ep.LintIgnore)
+		l.lintFields(path, "response", ep.ResponseTypeInfo.Fields, ep.LintIgnore)
 	}
 
-	return issues
+	return l.issues
+}
+
+type lintRun struct {
+	cfg    Config
+	issues []Issue
+
+	// nestedTypes はネストされた型の名前ごとの構造（最初に出現したもの）です
+	nestedTypes map[string]string
+}
+
+func (l *lintRun) report(ruleID, path, field, message string, ignores []string) {
+	rule := findRule(ruleID)
+	level := l.cfg.level(rule)
+	if level == LevelOff || suppressed(rule, ignores) {
+		return
+	}
+	l.issues = append(l.issues, Issue{
+		RuleID:  rule.ID,
+		Level:   level,
+		Path:    path,
+		Field:   field,
+		Message: message,
+	})
+}
+
+// lintFields はフィールドを再帰的に検査する。
+// ネストされた型のフィールドは型ごとに最初の1回だけ検査する。
+func (l *lintRun) lintFields(path, prefix string, fields []parser.FieldInfo, ignores []string) {
+	for _, f := range fields {
+		field := prefix + "." + f.JSONName
+		fieldIgnores := append(slices.Clone(ignores), f.LintIgnore...)
+
+		if !snakeCaseRe.MatchString(f.JSONName) {
+			l.report(RuleJSONNameSnakeCase, path, field, fmt.Sprintf("JSON名 %s はスネークケースが推奨です", f.JSONName), fieldIgnores)
+		}
+		if strings.TrimSpace(f.Description) == "" {
+			l.report(RuleUndocumentedField, path, field, "フィールドの説明がありません（doc タグで記述してください）", fieldIgnores)
+		}
+
+		nested := f.NestedType
+		if nested == nil || nested.Name == "" {
+			continue
+		}
+
+		shape := typeShape(*nested)
+		if l.nestedTypes == nil {
+			l.nestedTypes = make(map[string]string)
+		}
+		first, ok := l.nestedTypes[nested.Name]
+		if !ok {
+			l.nestedTypes[nested.Name] = shape
+			l.lintFields(path, field, nested.Fields, fieldIgnores)
+			continue
+		}
+		if first != shape {
+			l.report(RuleNestedTypeConflict, path, field, fmt.Sprintf("ネストされた型 %s が異なる構造で複数定義されています", nested.Name), fieldIgnores)
+		}
+	}
+}
+
+// typeShape は型の構造を比較するための文字列を返す。
+func typeShape(info parser.TypeInfo) string {
+	parts := make([]string, 0, len(info.Fields))
+	for _, f := range info.Fields {
+		parts = append(parts, fmt.Sprintf("%s:%s:%t", f.JSONName, f.TSType, f.Optional))
+	}
+	return strings.Join(parts, ",")
 }
@@ -1,6 +1,10 @@
 package linter
 
 import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/kinpatsu-everyone/backend-template/pkg/outorouter/internal/parser"
@@ -64,3 +68,198 @@ func TestLint_TableDriven(t *testing.T) {
 		})
 	}
 }
+
+func validEndpoint(req, res parser.TypeInfo) parser.Endpoint {
+	return parser.Endpoint{
+		Kind:             parser.KindUnaryJSON,
+		Domain:           "monster",
+		Version:          1,
+		MethodName:       "GetMonster",
+		HTTPMethod:       "POST",
+		RequestType:      "GetMonsterRequest",
+		ResponseType:     "GetMonsterResponse",
+		Summary:          "ok",
+		Tags:             []parser.Tag{"Monster"},
+		RequestTypeInfo:  req,
+		ResponseTypeInfo: res,
+	}
+}
+
+func documented(jsonName string) parser.FieldInfo {
+	return parser.FieldInfo{Name: "F", JSONName: jsonName, TSType: "string", Description: "説明"}
+}
+
+func nested(jsonName, typeName string, fields ...parser.FieldInfo) parser.FieldInfo {
+	return parser.FieldInfo{Name: "N", JSONName: jsonName, TSType: typeName, Description: "説明",
+		NestedType: &parser.TypeInfo{Name: typeName, Fields: fields}}
+}
+
+func TestLintWithConfig(t *testing.T) {
+	undocumented := parser.FieldInfo{Name: "ID", JSONName: "id", TSType: "string"}
+
+	tests := []struct {
+		name      string
+		ep        parser.Endpoint
+		cfg       Config
+		wantRules []string
+		wantLevel LintLevel
+		wantField string
+	}{
+		{
+			name:      "型名がMethodNameと一致しない",
+			ep:        validEndpoint(parser.TypeInfo{Name: "FetchMonsterRequest"}, parser.TypeInfo{Name: "GetMonsterResponse"}),
+			wantRules: []string{RuleTypeNameMismatch},
+			wantLevel: LevelWarning,
+		},
+		{
+			name:      "JSON名がスネークケースでない",
+			ep:        validEndpoint(parser.TypeInfo{Fields: []parser.FieldInfo{documented("monsterId")}}, parser.TypeInfo{}),
+			wantRules: []string{RuleJSONNameSnakeCase},
+			wantLevel: LevelWarning,
+			wantField: "request.monsterId",
+		},
+		{
+			name: "説明のないフィールドはデフォルトでは指摘しない",
+			ep:   validEndpoint(parser.TypeInfo{Fields: []parser.FieldInfo{undocumented}}, parser.TypeInfo{}),
+		},
+		{
+			name:      "説明のないフィールドは設定で有効化できる",
+			ep:        validEndpoint(parser.TypeInfo{Fields: []parser.FieldInfo{undocumented}}, parser.TypeInfo{}),
+			cfg:       Config{Severity: map[string]LintLevel{"undocumented-field": LevelInfo}},
+			wantRules: []string{RuleUndocumentedField},
+			wantLevel: LevelInfo,
+			wantField: "request.id",
+		},
+		{
+			name: "ファイルのダウンロードはレスポンスの型名を検査しない",
+			ep: func() parser.Endpoint {
+				ep := validEndpoint(parser.TypeInfo{Name: "GetMonsterRequest"}, parser.TypeInfo{Name: "FileDownloadResponseObject"})
+				ep.Kind = parser.KindFileDownload
+				return ep
+			}(),
+		},
+		{
+			name: "同名のネストされた型の構造が異なる",
+			ep: validEndpoint(
+				parser.TypeInfo{Fields: []parser.FieldInfo{nested("item", "Item", documented("id"))}},
+				parser.TypeInfo{Fields: []parser.FieldInfo{nested("item", "Item", documented("id"), documented("name"))}},
+			),
+			wantRules: []string{RuleNestedTypeConflict},
+			wantLevel: LevelError,
+			wantField: "response.item",
+		},
+		{
+			name:      "設定でルールIDの重要度を上書きできる",
+			ep:        validEndpoint(parser.TypeInfo{Fields: []parser.FieldInfo{undocumented}}, parser.TypeInfo{}),
+			cfg:       Config{Severity: map[string]LintLevel{RuleUndocumentedField: LevelError}},
+			wantRules: []string{RuleUndocumentedField},
+			wantLevel: LevelError,
+			wantField: "request.id",
+		},
+		{
+			name: "設定でルール名を指定して無効化できる",
+			ep:   validEndpoint(parser.TypeInfo{Fields: []parser.FieldInfo{documented("monsterId")}}, parser.TypeInfo{}),
+			cfg:  Config{Severity: map[string]LintLevel{"json-name-snake-case": LevelOff}},
+		},
+		{
+			name: "エンドポイント単位で抑制できる",
+			ep: func() parser.Endpoint {
+				ep := validEndpoint(parser.TypeInfo{Fields: []parser.FieldInfo{undocumented}}, parser.TypeInfo{})
+				ep.Summary = ""
+				ep.LintIgnore = []string{RuleSummaryMissing, "undocumented-field"}
+				return ep
+			}(),
+			cfg: Config{Severity: map[string]LintLevel{RuleUndocumentedField: LevelInfo}},
+		},
+		{
+			name: "フィールド単位で抑制できる",
+			ep: func() parser.Endpoint {
+				f := documented("monsterId")
+				f.LintIgnore = []string{RuleJSONNameSnakeCase}
+				return validEndpoint(parser.TypeInfo{Fields: []parser.FieldInfo{f}}, parser.TypeInfo{})
+			}(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := LintWithConfig(&parser.Metadata{All: []parser.Endpoint{tt.ep}}, tt.cfg)
+			if len(issues) != len(tt.wantRules) {
+				t.Fatalf("expected %d issues, got %+v", len(tt.wantRules), issues)
+			}
+			for i, issue := range issues {
+				if issue.RuleID != tt.wantRules[i] {
+					t.Errorf("rule = %s, want %s", issue.RuleID, tt.wantRules[i])
+				}
+				if issue.Level != tt.wantLevel {
+					t.Errorf("level = %s, want %s", issue.Level, tt.wantLevel)
+				}
+				if issue.Field != tt.wantField {
+					t.Errorf("field = %s, want %s", issue.Field, tt.wantField)
+				}
+			}
+			if got := HasErrors(issues); got != (tt.wantLevel == LevelError && len(issues) > 0) {
+				t.Errorf("HasErrors = %v", got)
+			}
+		})
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{name: "ルールIDとルール名を指定できる", content: `{"severity":{"OR011":"off","tags-missing":"error"}}`},
+		{name: "未知のルールはエラー", content: `{"severity":{"OR999":"error"}}`, wantErr: true},
+		{name: "不正な重要度はエラー", content: `{"severity":{"OR011":"fatal"}}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), ".outolint.json")
+			if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			_, err := LoadConfig(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWriteReport(t *testing.T) {
+	issues := []Issue{
+		{RuleID: RuleSummaryMissing, Level: LevelWarning, Path: "monster/v1/GetMonster", Message: "Summary が空です"},
+		{RuleID: RuleUndocumentedField, Level: LevelInfo, Path: "monster/v1/GetMonster", Field: "request.id", Message: "説明がありません"},
+	}
+
+	tests := []struct {
+		format Format
+		want   []string
+	}{
+		{format: FormatText, want: []string{"warning", "OR008", "monster/v1/GetMonster request.id", "2 issues (0 errors, 1 warnings, 1 info)"}},
+		{format: FormatJSON, want: []string{`"rule_id": "OR008"`, `"field": "request.id"`}},
+		{format: FormatSARIF, want: []string{`"version": "2.1.0"`, `"name": "outolint"`, `"ruleId": "OR011"`, `"level": "note"`, `"fullyQualifiedName": "monster/v1/GetMonster/request.id"`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := WriteReport(&buf, tt.format, issues); err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("output does not contain %q:\n%s", want, buf.String())
+				}
+			}
+		})
+	}
+
+	if err := WriteReport(&bytes.Buffer{}, Format("xml"), issues); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
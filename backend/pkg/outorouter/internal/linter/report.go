@@ -0,0 +1,165 @@
+package linter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+// Format はレポートの出力形式を表す。
+type Format string
+
+const (
+	FormatText  Format = "text"
+	FormatJSON  Format = "json"
+	FormatSARIF Format = "sarif"
+)
+
+// WriteReport は指摘を指定した形式で書き出す。
+func WriteReport(w io.Writer, format Format, issues []Issue) error {
+	switch format {
+	case FormatText:
+		return writeText(w, issues)
+	case FormatJSON:
+		return writeJSON(w, issues)
+	case FormatSARIF:
+		return writeSARIF(w, issues)
+	default:
+		return fmt.Errorf("unknown report format %q", format)
+	}
+}
+
+func writeText(w io.Writer, issues []Issue) error {
+	counts := make(map[LintLevel]int)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, issue := range issues {
+		counts[issue.Level]++
+		location := issue.Path
+		if issue.Field != "" {
+			location += " " + issue.Field
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", issue.Level, issue.RuleID, location, issue.Message)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintf(w, "%d issues (%d errors, %d warnings, %d info)\n",
+		len(issues), counts[LevelError], counts[LevelWarning], counts[LevelInfo])
+	return err
+}
+
+func writeJSON(w io.Writer, issues []Issue) error {
+	if issues == nil {
+		issues = []Issue{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(map[string]any{
+		"issues": issues,
+	})
+}
+
+// SARIF 2.1.0 の出力に必要な最小限の構造です。
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	Name                 string             `json:"name"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+// sarifLevel はLintLevelをSARIFのlevelに変換する。
+func sarifLevel(level LintLevel) string {
+	switch level {
+	case LevelError:
+		return "error"
+	case LevelWarning:
+		return "warning"
+	case LevelOff:
+		return "none"
+	default:
+		return "note"
+	}
+}
+
+func writeSARIF(w io.Writer, issues []Issue) error {
+	rules := make([]sarifRule, 0, len(Rules))
+	for _, rule := range Rules {
+		rules = append(rules, sarifRule{
+			ID:                   rule.ID,
+			Name:                 rule.Name,
+			ShortDescription:     sarifMessage{Text: rule.Description},
+			DefaultConfiguration: sarifConfiguration{Level: sarifLevel(rule.Level)},
+		})
+	}
+
+	results := make([]sarifResult, 0, len(issues))
+	for _, issue := range issues {
+		location := sarifLogicalLocation{FullyQualifiedName: issue.Path, Kind: "function"}
+		if issue.Field != "" {
+			location = sarifLogicalLocation{FullyQualifiedName: issue.Path + "/" + issue.Field, Kind: "member"}
+		}
+		results = append(results, sarifResult{
+			RuleID:    issue.RuleID,
+			Level:     sarifLevel(issue.Level),
+			Message:   sarifMessage{Text: issue.Message},
+			Locations: []sarifLocation{{LogicalLocations: []sarifLogicalLocation{location}}},
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool:    sarifTool{Driver: sarifDriver{Name: "outolint", Rules: rules}},
+			Results: results,
+		}},
+	})
+}
@@ -76,6 +76,7 @@ type rawEndpoint struct {
 	Kind         string   `json:"kind"`
 	MethodName   string   `json:"method_name"`
 	HTTPMethod   string   `json:"http_method"`
+	HTTPPath     string   `json:"path"`
 	RequestType  string   `json:"request_type"`
 	ResponseType string   `json:"response_type"`
 	Summary      string   `json:"summary"`
@@ -87,6 +88,17 @@ type rawEndpoint struct {
 	// 型情報
 	RequestTypeInfo  rawTypeInfo `json:"request_type_info"`
 	ResponseTypeInfo rawTypeInfo `json:"response_type_info"`
+
+	// エラー情報
+	ErrorTypeInfo rawTypeInfo `json:"error_type_info"`
+	Errors        []ErrorInfo `json:"errors"`
+
+	// 抑制するlintルール
+	LintIgnore []string `json:"lint_ignore,omitempty"`
+
+	// 認証の要否と必要なスコープ
+	Auth   string   `json:"auth"`
+	Scopes []string `json:"scopes,omitempty"`
 }
 
 type rawTypeInfo struct {
@@ -100,7 +112,12 @@ type rawFieldInfo struct {
 	Type       string       `json:"type"`
 	TSType     string       `json:"ts_type"`
 	Optional   bool         `json:"optional"`
+	In         string       `json:"in,omitempty"`
 	NestedType *rawTypeInfo `json:"nested_type,omitempty"`
+
+	Validation  []ValidationRule `json:"validation,omitempty"`
+	Description string           `json:"description,omitempty"`
+	LintIgnore  []string         `json:"lint_ignore,omitempty"`
 }
 
 func (r rawEndpoint) toEndpoint(domain string, version uint8) (Endpoint, error) {
@@ -123,6 +140,7 @@ func (r rawEndpoint) toEndpoint(domain string, version uint8) (Endpoint, error)
 		Version:          version,
 		MethodName:       r.MethodName,
 		HTTPMethod:       r.HTTPMethod,
+		HTTPPath:         r.HTTPPath,
 		RequestType:      r.RequestType,
 		ResponseType:     r.ResponseType,
 		Summary:          r.Summary,
@@ -130,6 +148,11 @@ func (r rawEndpoint) toEndpoint(domain string, version uint8) (Endpoint, error)
 		Tags:             r.Tags,
 		RequestTypeInfo:  convertTypeInfo(r.RequestTypeInfo),
 		ResponseTypeInfo: convertTypeInfo(r.ResponseTypeInfo),
+		ErrorTypeInfo:    convertTypeInfo(r.ErrorTypeInfo),
+		Errors:           r.Errors,
+		LintIgnore:       r.LintIgnore,
+		Auth:             r.Auth,
+		Scopes:           r.Scopes,
 	}, nil
 }
 
@@ -142,6 +165,11 @@ func convertTypeInfo(raw rawTypeInfo) TypeInfo {
 			Type:     f.Type,
 			TSType:   f.TSType,
 			Optional: f.Optional,
+			In:       f.In,
+
+			Validation:  f.Validation,
+			Description: f.Description,
+			LintIgnore:  f.LintIgnore,
 		}
 		// ネストされた型情報があれば再帰的に変換
 		if f.NestedType != nil {
@@ -158,7 +186,7 @@ func convertTypeInfo(raw rawTypeInfo) TypeInfo {
 
 func toKind(k string) (EndpointKind, error) {
 	switch EndpointKind(k) {
-	case KindUnaryJSON, KindFileUpload, KindWebSocket:
+	case KindUnaryJSON, KindFileUpload, KindFileDownload, KindWebSocket:
 		return EndpointKind(k), nil
 	default:
 		return "", fmt.Errorf("unknown kind %q", k)
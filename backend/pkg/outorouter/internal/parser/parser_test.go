@@ -44,6 +44,77 @@ func TestParse_TableDriven(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "file download endpoint",
+			json: `{"monster":{"1":[{"kind":"FileDownload","method_name":"DownloadMonsterImage","http_method":"GET","request_type":"DownloadMonsterImageRequest","response_type":"outorouter.FileDownloadResponseObject"}]}}`,
+			assert: func(t *testing.T, meta *Metadata) {
+				if meta.All[0].Kind != KindFileDownload {
+					t.Fatalf("unexpected kind: %s", meta.All[0].Kind)
+				}
+				if meta.All[0].HTTPMethod != "GET" {
+					t.Fatalf("unexpected http method: %s", meta.All[0].HTTPMethod)
+				}
+			},
+		},
+		{
+			name: "path parameters and query fields",
+			json: `{"monster":{"1":[{"kind":"JSON","method_name":"GetMonster","http_method":"GET","path":"/monster/v1/monsters/{id}","request_type_info":{"name":"GetMonsterRequest","fields":[{"name":"ID","json_name":"id","type":"string","ts_type":"string","in":"path"},{"name":"Fields","json_name":"fields","type":"string","ts_type":"string","optional":true,"in":"query"}]}}]}}`,
+			assert: func(t *testing.T, meta *Metadata) {
+				ep := meta.All[0]
+				if ep.RoutePath() != "/monster/v1/monsters/{id}" {
+					t.Fatalf("unexpected http path: %s", ep.RoutePath())
+				}
+				if params := ep.PathParams(); len(params) != 1 || params[0] != "id" {
+					t.Fatalf("unexpected path params: %v", params)
+				}
+				if ep.HasRequestBody() {
+					t.Fatal("GET endpoint should not have a request body")
+				}
+				fields := ep.RequestTypeInfo.Fields
+				if fields[0].In != "path" || fields[1].In != "query" {
+					t.Fatalf("unexpected field locations: %+v", fields)
+				}
+			},
+		},
+		{
+			name: "path defaults to domain/version/method",
+			json: `{"user":{"1":[{"kind":"JSON","method_name":"CreateUser","http_method":"POST"}]}}`,
+			assert: func(t *testing.T, meta *Metadata) {
+				if got := meta.All[0].RoutePath(); got != "/user/v1/CreateUser" {
+					t.Fatalf("unexpected http path: %s", got)
+				}
+			},
+		},
+		{
+			name: "auth requirement and scopes",
+			json: `{"monster":{"1":[{"kind":"JSON","method_name":"GetMyMonsters","http_method":"POST","auth":"required","scopes":["monster:read"]},{"kind":"JSON","method_name":"GetMonsters","http_method":"POST"}]}}`,
+			assert: func(t *testing.T, meta *Metadata) {
+				byName := map[string]Endpoint{}
+				for _, ep := range meta.All {
+					byName[ep.MethodName] = ep
+				}
+				mine := byName["GetMyMonsters"]
+				if !mine.UsesAuth() || mine.AuthRequirement() != "required" || len(mine.Scopes) != 1 {
+					t.Fatalf("unexpected auth: %q %v", mine.Auth, mine.Scopes)
+				}
+				if all := byName["GetMonsters"]; all.UsesAuth() || all.AuthRequirement() != "none" {
+					t.Fatalf("endpoint without auth should default to none: %q", all.Auth)
+				}
+			},
+		},
+		{
+			name: "endpoint with errors",
+			json: `{"monster":{"1":[{"kind":"JSON","method_name":"GetMonster","http_method":"POST","errors":[{"status_code":404,"code":"MONSTER_NOT_FOUND","message":"not found"}],"error_type_info":{"name":"ErrorResponse","fields":[{"name":"Error","json_name":"error","type":"outorouter.ErrorBody","ts_type":"ErrorBody"}]}}]}}`,
+			assert: func(t *testing.T, meta *Metadata) {
+				ep := meta.All[0]
+				if len(ep.Errors) != 1 || ep.Errors[0].Code != "MONSTER_NOT_FOUND" || ep.Errors[0].StatusCode != 404 {
+					t.Fatalf("unexpected errors: %+v", ep.Errors)
+				}
+				if ep.ErrorTypeInfo.Name != "ErrorResponse" || len(ep.ErrorTypeInfo.Fields) != 1 {
+					t.Fatalf("unexpected error type info: %+v", ep.ErrorTypeInfo)
+				}
+			},
+		},
 		{
 			name:    "invalid version",
 			json:    `{"user":{"x":[]}}`,
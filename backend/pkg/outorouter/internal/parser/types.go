@@ -1,6 +1,9 @@
 package parser
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // Tag はAPIタグを表す文字列型です。
 type Tag string
@@ -9,9 +12,10 @@ type Tag string
 type EndpointKind string
 
 const (
-	KindUnaryJSON  EndpointKind = "JSON"
-	KindFileUpload EndpointKind = "FileUpload"
-	KindWebSocket  EndpointKind = "WebSocket"
+	KindUnaryJSON    EndpointKind = "JSON"
+	KindFileUpload   EndpointKind = "FileUpload"
+	KindFileDownload EndpointKind = "FileDownload"
+	KindWebSocket    EndpointKind = "WebSocket"
 )
 
 // FieldInfo はGoの構造体フィールドの情報を保持します
@@ -21,7 +25,18 @@ type FieldInfo struct {
 	Type       string    `json:"type"`
 	TSType     string    `json:"ts_type"`
 	Optional   bool      `json:"optional"`
+	In         string    `json:"in,omitempty"`          // パラメータの受け取り位置（"path" / "query"、空の場合はボディ）
 	NestedType *TypeInfo `json:"nested_type,omitempty"` // ネストされた構造体の型情報
+
+	Validation  []ValidationRule `json:"validation,omitempty"`  // validateタグのルール
+	Description string           `json:"description,omitempty"` // docタグの説明
+	LintIgnore  []string         `json:"lint_ignore,omitempty"` // 抑制するlintルール
+}
+
+// ValidationRule はvalidateタグのルールを保持します
+type ValidationRule struct {
+	Name  string `json:"name"`
+	Value string `json:"value,omitempty"`
 }
 
 // TypeInfo は構造体の型情報を保持します
@@ -30,6 +45,13 @@ type TypeInfo struct {
 	Fields []FieldInfo `json:"fields"`
 }
 
+// ErrorInfo はエンドポイントが返しうるエラーの情報を保持します
+type ErrorInfo struct {
+	StatusCode int    `json:"status_code"`
+	Code       string `json:"code"`
+	Message    string `json:"message"`
+}
+
 // Endpoint は metadata.json の1エントリを中間表現として保持する。
 type Endpoint struct {
 	Kind         EndpointKind
@@ -37,6 +59,7 @@ type Endpoint struct {
 	Version      uint8
 	MethodName   string
 	HTTPMethod   string
+	HTTPPath     string // パスパラメータを含むパスのパターン（例: /monster/v1/monsters/{id}）
 	RequestType  string
 	ResponseType string
 	Summary      string
@@ -46,6 +69,17 @@ type Endpoint struct {
 	// 型情報
 	RequestTypeInfo  TypeInfo
 	ResponseTypeInfo TypeInfo
+
+	// エラーレスポンスの形式と返しうるエラー
+	ErrorTypeInfo TypeInfo
+	Errors        []ErrorInfo
+
+	// 抑制するlintルール
+	LintIgnore []string
+
+	// 認証の要否（"none" / "optional" / "required"、古いメタデータでは空）と必要なスコープ
+	Auth   string
+	Scopes []string
 }
 
 // Metadata はドメイン別・バージョン別のエンドポイント集合を表す。
@@ -63,3 +97,46 @@ type Domain struct {
 func (e Endpoint) Path() string {
 	return fmt.Sprintf("%s/v%d/%s", e.Domain, e.Version, e.MethodName)
 }
+
+// RoutePath はルーティングに使用するパスのパターンを返す。
+// path を持たない古いメタデータの場合は /{domain}/v{version}/{method} を返す。
+func (e Endpoint) RoutePath() string {
+	if e.HTTPPath != "" {
+		return e.HTTPPath
+	}
+	return "/" + e.Path()
+}
+
+// PathParams はパスのパターンに含まれるパラメータ名を出現順に返す。
+func (e Endpoint) PathParams() []string {
+	var params []string
+	for _, segment := range strings.Split(strings.Trim(e.RoutePath(), "/"), "/") {
+		if len(segment) > 2 && strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			params = append(params, segment[1:len(segment)-1])
+		}
+	}
+	return params
+}
+
+// UsesAuth は認証トークンを送るエンドポイントかを返す（optional を含む）。
+func (e Endpoint) UsesAuth() bool {
+	return e.Auth == "required" || e.Auth == "optional"
+}
+
+// AuthRequirement は認証の要否を返す（古いメタデータの場合は "none"）。
+func (e Endpoint) AuthRequirement() string {
+	if e.Auth == "" {
+		return "none"
+	}
+	return e.Auth
+}
+
+// HasRequestBody はHTTPメソッドがリクエストボディを持つかを返す。
+func (e Endpoint) HasRequestBody() bool {
+	switch e.HTTPMethod {
+	case "GET", "HEAD", "DELETE", "OPTIONS":
+		return false
+	default:
+		return true
+	}
+}
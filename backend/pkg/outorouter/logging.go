@@ -1,7 +1,10 @@
 package outorouter
 
 import (
+	"bufio"
 	"context"
+	"fmt"
+	"net"
 	"net/http"
 	"time"
 )
@@ -47,6 +50,30 @@ func (r *statusRecorder) Write(b []byte) (int, error) {
 	return size, err
 }
 
+// Hijack はWebSocketなどのUpgradeのために元のResponseWriterの接続を引き渡します
+func (r *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := r.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("underlying ResponseWriter does not implement http.Hijacker")
+	}
+	if r.statusCode == 0 {
+		r.statusCode = http.StatusSwitchingProtocols
+	}
+	return hijacker.Hijack()
+}
+
+// Unwrap は http.ResponseController が元のResponseWriterを操作できるように返します
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// Flush はバッファされたデータをクライアントへ送信します
+func (r *statusRecorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
 // LoggingMiddleware はHTTPリクエストとレスポンスの情報をログに出力するミドルウェアを生成します
 func LoggingMiddleware(logger Logger) MiddlewareFunc {
 	return func(next http.Handler) http.Handler {
@@ -72,7 +99,7 @@ func LoggingMiddleware(logger Logger) MiddlewareFunc {
 					})
 					// Only write error response if headers haven't been sent
 					if recorder.statusCode == 0 {
-						http.Error(recorder, "サーバー内部で予期しないエラーが発生しました", http.StatusInternalServerError)
+						WriteError(recorder, r, internalError())
 					}
 				}
 			}()
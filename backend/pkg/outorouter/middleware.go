@@ -0,0 +1,145 @@
+package outorouter
+
+import (
+	"net/http"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync/atomic"
+)
+
+// ミドルウェアは外側から次の順に適用されます（登録の順序には依存しません）
+//  1. Router.Use で登録したグローバルなミドルウェア
+//  2. Router.Group(domain, version).Use で登録したグループのミドルウェア
+//  3. エンドポイントの Middlewares フィールドのミドルウェア
+// それぞれの中では登録した順に外側から適用されます
+
+// groupKey はミドルウェアを共有するドメイン・バージョンの組です
+type groupKey struct {
+	domain  string
+	version uint8
+}
+
+// Group はドメイン・バージョン単位でミドルウェアを登録するためのグループです
+type Group struct {
+	router *Router
+	key    groupKey
+}
+
+// Group はドメイン・バージョンのグループを返します
+// グループに登録したミドルウェアは、そのドメイン・バージョンのすべてのエンドポイントに適用されます
+func (r *Router) Group(domain string, version uint8) *Group {
+	return &Group{router: r, key: groupKey{domain: domain, version: version}}
+}
+
+// Use はグループにミドルウェアを追加します
+// 既に登録済みのエンドポイントにも適用されます
+func (g *Group) Use(mws ...MiddlewareFunc) {
+	g.router.mu.Lock()
+	defer g.router.mu.Unlock()
+	g.router.groups[g.key] = append(g.router.groups[g.key], mws...)
+	g.router.generation++
+}
+
+// routeEntry はルートに登録されたハンドラーとエンドポイント固有のミドルウェアです
+// ミドルウェアはリクエスト時に合成し、Use・Group.Use が呼ばれるまでキャッシュします
+type routeEntry struct {
+	// grouped が false の場合（カスタムハンドラー）はグループのミドルウェアを適用しない
+	grouped     bool
+	key         groupKey
+	middlewares []MiddlewareFunc
+	handler     http.Handler
+
+	composed atomic.Pointer[composedHandler]
+}
+
+type composedHandler struct {
+	generation uint64
+	handler    http.Handler
+}
+
+func newRouteEntry(domain string, version uint8, mws []MiddlewareFunc, h http.Handler) *routeEntry {
+	return &routeEntry{
+		grouped:     true,
+		key:         groupKey{domain: domain, version: version},
+		middlewares: mws,
+		handler:     h,
+	}
+}
+
+// chain はエントリーに適用するミドルウェアを外側から順に返します
+// 呼び出し側で r.mu のロックを取得している必要があります
+func (r *Router) chain(e *routeEntry) []MiddlewareFunc {
+	mws := make([]MiddlewareFunc, 0, len(r.middlewares)+len(e.middlewares))
+	mws = append(mws, r.middlewares...)
+	if e.grouped {
+		mws = append(mws, r.groups[e.key]...)
+	}
+	return append(mws, e.middlewares...)
+}
+
+// compose はミドルウェアを適用したハンドラーを返します
+// 呼び出し側で r.mu の読み取りロックを取得している必要があります
+func (r *Router) compose(e *routeEntry) http.Handler {
+	if c := e.composed.Load(); c != nil && c.generation == r.generation {
+		return c.handler
+	}
+
+	h := wrapMiddlewares(e.handler, r.chain(e))
+	e.composed.Store(&composedHandler{generation: r.generation, handler: h})
+	return h
+}
+
+func wrapMiddlewares(h http.Handler, mws []MiddlewareFunc) http.Handler {
+	for i := len(mws) - 1; i >= 0; i-- {
+		h = mws[i](h)
+	}
+	return h
+}
+
+// middlewareNames はエンドポイントに適用されるミドルウェアの名前を外側から順に返します（メタデータ用）
+func (r *Router) middlewareNames(ep internalEndpoint) []string {
+	r.mu.RLock()
+	mws := r.chain(newRouteEntry(ep.Domain, ep.Version, ep.middlewares, nil))
+	r.mu.RUnlock()
+
+	names := make([]string, len(mws))
+	for i, mw := range mws {
+		names[i] = middlewareName(mw)
+	}
+	return names
+}
+
+// middlewareName はミドルウェアを生成した関数の名前を返します
+// 例: outorouter.CORSMiddleware(...) が返すクロージャは "outorouter.CORSMiddleware"
+func middlewareName(mw MiddlewareFunc) string {
+	fn := runtime.FuncForPC(reflect.ValueOf(mw).Pointer())
+	if fn == nil {
+		return "unknown"
+	}
+
+	name := fn.Name()
+	name = name[strings.LastIndex(name, "/")+1:]
+	name = strings.TrimSuffix(name, "-fm")
+	for {
+		i := strings.LastIndex(name, ".")
+		if i < 0 || !isClosureSuffix(name[i+1:]) {
+			return name
+		}
+		name = name[:i]
+	}
+}
+
+// isClosureSuffix はコンパイラがクロージャに付ける名前（func1, 1 など）かを返します
+func isClosureSuffix(s string) bool {
+	s = strings.TrimPrefix(s, "func")
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -3,13 +3,11 @@ package outorouter
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
-	"log/slog"
 	"mime/multipart"
 	"net/http"
 	"reflect"
-	"strconv"
+	"strings"
 )
 
 type MultipartHandlerFunc[Req RequestObject, Res ResponseObject] func(ctx context.Context, req *Req) (*Res, error)
@@ -20,21 +18,51 @@ type MultipartEndpoint[Req RequestObject, Res any] struct {
 	Version    uint8
 	MethodName string
 
+	// HTTPMethod はエンドポイントのHTTPメソッドです（空の場合はPOST）
+	HTTPMethod string
+	// Path はパスパラメータを含むパスのパターンです（例: "/monster/v1/monsters/{id}/image"）
+	Path string
+
 	Summary     string
 	Description string
 	Tags        []Tag
 
 	Handler MultipartHandlerFunc[Req, Res]
 
+	// Errors はハンドラーが返しうるエラーです（メタデータ・クライアント生成用）
+	Errors []HTTPError
+	// LintIgnore はこのエンドポイントで抑制するlintルールのIDまたは名前です
+	LintIgnore []string
+
+	// Auth はエンドポイントの認証の要否です（空の場合は AuthNone）
+	Auth AuthRequirement
+	// Scopes は認証された Principal に必要なスコープです（Auth が AuthRequired の場合のみ指定できます）
+	Scopes []string
+
+	// Middlewares はこのエンドポイントにのみ適用するミドルウェアです
+	// グローバル・グループのミドルウェアの内側で、記述した順に適用されます
+	Middlewares []MiddlewareFunc
+
 	// MaxMemory はmultipart/form-dataのパース時に使用する最大メモリサイズ（バイト）です
 	// このサイズを超える場合は一時ファイルに保存されます
 	MaxMemory int64
 }
 
 func (m MultipartEndpoint[Req, Res]) GetFullPath() string {
+	if m.Path != "" {
+		return m.Path
+	}
 	return fmt.Sprintf("/%s/%s/%s", m.Domain, m.GetVersionWithPrefix(), m.MethodName)
 }
 
+// GetHTTPMethod はエンドポイントのHTTPメソッドを返します
+func (m MultipartEndpoint[Req, Res]) GetHTTPMethod() string {
+	if m.HTTPMethod == "" {
+		return http.MethodPost
+	}
+	return strings.ToUpper(m.HTTPMethod)
+}
+
 func (m MultipartEndpoint[Req, Res]) GetDomain() string {
 	return m.Domain
 }
@@ -58,27 +86,26 @@ func RegisterMultipartEndpoint[Req RequestObject, Res any](
 		maxMemory = 32 * 1024 * 1024
 	}
 
+	method := ep.GetHTTPMethod()
+
 	h := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
-		// POSTメソッドではない場合は不正と見做す
-		if req.Method != http.MethodPost {
-			w.Header().Set("Content-Type", "application/json")
-			http.Error(w, "指定した形式のリクエストではありません", http.StatusMethodNotAllowed)
+		// 登録したメソッドではない場合は不正と見做す
+		if req.Method != method {
+			WriteError(w, req, NewHTTPError(http.StatusMethodNotAllowed, ErrorCodeMethodNotAllowed, "指定した形式のリクエストではありません"))
 			return
 		}
 
 		// Content-Type validation
 		contentType := req.Header.Get("Content-Type")
 		if contentType == "" {
-			w.Header().Set("Content-Type", "application/json")
-			http.Error(w, "Content-Type must be multipart/form-data", http.StatusUnsupportedMediaType)
+			WriteError(w, req, NewHTTPError(http.StatusUnsupportedMediaType, ErrorCodeUnsupportedMediaType, "Content-Type must be multipart/form-data"))
 			return
 		}
 
 		// multipart/form-dataであることを確認
 		// boundaryが含まれているかチェック
 		if len(contentType) < 19 || contentType[:19] != "multipart/form-data" {
-			w.Header().Set("Content-Type", "application/json")
-			http.Error(w, "Content-Type must be multipart/form-data", http.StatusUnsupportedMediaType)
+			WriteError(w, req, NewHTTPError(http.StatusUnsupportedMediaType, ErrorCodeUnsupportedMediaType, "Content-Type must be multipart/form-data"))
 			return
 		}
 
@@ -90,37 +117,18 @@ func RegisterMultipartEndpoint[Req RequestObject, Res any](
 		// multipart/form-dataをパース
 		err := req.ParseMultipartForm(maxMemory)
 		if err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			http.Error(w, fmt.Sprintf("multipart/form-dataのパースに失敗しました: %v", err), http.StatusBadRequest)
+			// パーサーのエラー内容はクライアントに返さずログにのみ出力する
+			if r.logger != nil {
+				r.logger.Info(ctx, "multipart/form-dataのパースに失敗しました", map[string]any{
+					"error": err.Error(),
+					"path":  req.URL.Path,
+				})
+			}
+			WriteError(w, req, bodyReadError(err, ErrorCodeInvalidMultipart, "multipart/form-dataのパースに失敗しました"))
 			return
 		}
 		defer req.MultipartForm.RemoveAll()
 
-		// デバッグログ: フォームデータの内容を出力
-		slog.Info("multipart form data received",
-			"values", req.MultipartForm.Value,
-			"file_keys", func() []string {
-				keys := make([]string, 0, len(req.MultipartForm.File))
-				for k := range req.MultipartForm.File {
-					keys = append(keys, k)
-				}
-				return keys
-			}(),
-		)
-
-		// デバッグログ: ファイルの詳細情報を出力
-		for key, files := range req.MultipartForm.File {
-			for i, fh := range files {
-				slog.Info("multipart file details",
-					"key", key,
-					"index", i,
-					"filename", fh.Filename,
-					"size", fh.Size,
-					"content_type", fh.Header.Get("Content-Type"),
-				)
-			}
-		}
-
 		// リクエスト構造体を作成
 		var request Req
 		reqType := reflect.TypeOf(request)
@@ -129,58 +137,24 @@ func RegisterMultipartEndpoint[Req RequestObject, Res any](
 		if hasFields {
 			// リクエスト構造体のフィールドをmultipart/form-dataから埋める
 			if err := populateRequestFromMultipart(&request, req.MultipartForm); err != nil {
-				slog.Error("populateRequestFromMultipart failed", "error", err)
-				w.Header().Set("Content-Type", "application/json")
-				http.Error(w, fmt.Sprintf("リクエストのパースに失敗しました: %v", err), http.StatusBadRequest)
+				WriteError(w, req, BadRequestError(ErrorCodeInvalidRequest, fmt.Sprintf("リクエストのパースに失敗しました: %v", err)))
+				return
+			}
+			if err := populateRequestFromParams(&request, req); err != nil {
+				WriteError(w, req, BadRequestError(ErrorCodeInvalidRequest, fmt.Sprintf("リクエストのパースに失敗しました: %v", err)))
 				return
 			}
-			slog.Info("request populated", "request", fmt.Sprintf("%+v", request))
 		}
 
-		// Validate request if Validate method exists
-		if err := request.Validate(); err != nil {
-			slog.Error("validation failed", "error", err)
-			w.Header().Set("Content-Type", "application/json")
-			http.Error(w, fmt.Sprintf("リクエストのバリデーションに失敗しました: %v", err), http.StatusBadRequest)
+		// validateタグによる検証の後、Validate()を実行する
+		if err := validateRequest(&request); err != nil {
+			WriteError(w, req, err)
 			return
 		}
 
 		response, err := ep.Handler(ctx, &request)
 		if err != nil {
-			var httpErr HTTPError
-			if errors.As(err, &httpErr) {
-				w.Header().Set("Content-Type", "application/json")
-				w.WriteHeader(httpErr.StatusCode())
-
-				_ = json.NewEncoder(w).Encode(map[string]any{
-					"error": map[string]any{
-						"logerror": err,
-						"error":    httpErr.Error(),
-						"message":  httpErr.Message(),
-					},
-				})
-				return
-			}
-
-			// その他のエラーは500として扱う
-			// ロガーが設定されている場合はエラーをログに出力
-			if r.logger != nil {
-				r.logger.Error(ctx, "ハンドラーでエラーが発生しました", map[string]any{
-					"error":  err.Error(),
-					"path":   req.URL.Path,
-					"method": req.Method,
-				})
-			}
-
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusInternalServerError)
-
-			_ = json.NewEncoder(w).Encode(map[string]any{
-				"error": map[string]any{
-					"error":   "UNKNOWN_INTERNAL_ERROR",
-					"message": "サーバー内部で予期しないエラーが発生しました",
-				},
-			})
+			r.writeHandlerError(w, req, err)
 			return
 		}
 
@@ -199,13 +173,17 @@ func RegisterMultipartEndpoint[Req RequestObject, Res any](
 		}
 	})
 
-	r.addHTTPRoute("POST", ep.GetFullPath(), r.applyMiddlewares(h))
-	r.addContentTypeRoute("POST", ep.GetFullPath(), "multipart/form-data", r.applyMiddlewares(h))
-
 	// リクエスト・レスポンスモデルのメタデータ
 	var reqZero Req
 	var resZero Res
 
+	// validateタグ・path タグの誤りは登録時に検出する
+	validatorFor(reflect.TypeOf(reqZero))
+	checkRequestParams(method, ep.GetFullPath(), reflect.TypeOf(reqZero), false)
+
+	mws := r.endpointMiddlewares(ep.GetFullPath(), ep.Auth, ep.Scopes, ep.Middlewares)
+	r.addRoute(method, ep.GetFullPath(), "multipart/form-data", newRouteEntry(ep.Domain, ep.Version, mws, h))
+
 	internalEp := internalEndpoint{
 		Kind:             KindFileUpload,
 		Domain:           ep.Domain,
@@ -214,12 +192,18 @@ func RegisterMultipartEndpoint[Req RequestObject, Res any](
 		Summary:          ep.Summary,
 		Description:      ep.Description,
 		Tags:             ep.Tags,
-		HTTPMethod:       http.MethodPost,
+		HTTPMethod:       method,
+		Path:             ep.GetFullPath(),
 		handler:          h,
+		middlewares:      mws,
+		Auth:             ep.Auth.String(),
+		Scopes:           ep.Scopes,
 		RequestType:      reflect.TypeOf(reqZero).String(),
 		ResponseType:     reflect.TypeOf(resZero).String(),
 		RequestTypeInfo:  extractMultipartTypeInfo(reflect.TypeOf(reqZero)),
 		ResponseTypeInfo: extractTypeInfo(reflect.TypeOf(resZero)),
+		Errors:           append(append(standardErrors(KindFileUpload), authErrors(ep.Auth, ep.Scopes)...), ep.Errors...),
+		LintIgnore:       ep.LintIgnore,
 	}
 
 	r.addToRegistry(internalEp)
@@ -234,6 +218,11 @@ func populateRequestFromMultipart(req interface{}, form *multipart.Form) error {
 		field := reqType.Field(i)
 		fieldValue := reqValue.Field(i)
 
+		// パスパラメータ・クエリ文字列のフィールドは populateRequestFromParams で埋める
+		if in, _ := fieldParam(field); in != "" {
+			continue
+		}
+
 		// multipartタグを優先、なければjsonタグを使用
 		fieldName := ""
 		multipartTag := field.Tag.Get("multipart")
@@ -274,27 +263,19 @@ func populateRequestFromMultipart(req interface{}, form *multipart.Form) error {
 			if values, ok := form.Value[fieldName]; ok && len(values) > 0 {
 				value := values[0]
 				switch fieldValue.Kind() {
-				case reflect.String:
-					fieldValue.SetString(value)
-				case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-					if intVal, err := strconv.ParseInt(value, 10, 64); err == nil {
-						fieldValue.SetInt(intVal)
-					}
-				case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-					if uintVal, err := strconv.ParseUint(value, 10, 64); err == nil {
-						fieldValue.SetUint(uintVal)
-					}
-				case reflect.Float32, reflect.Float64:
-					if floatVal, err := strconv.ParseFloat(value, 64); err == nil {
-						fieldValue.SetFloat(floatVal)
-					}
-				case reflect.Bool:
-					if boolVal, err := strconv.ParseBool(value); err == nil {
-						fieldValue.SetBool(boolVal)
+				case reflect.String, reflect.Bool,
+					reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+					reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+					reflect.Float32, reflect.Float64:
+					// パースできない値はゼロ値にせず、フィールド名を付けてエラーにする
+					if err := setFieldFromString(fieldValue, values); err != nil {
+						return fmt.Errorf("%s: %w", fieldName, err)
 					}
 				case reflect.Slice:
 					if fieldValue.Type().Elem().Kind() == reflect.String {
-						fieldValue.Set(reflect.ValueOf(values))
+						if err := setFieldFromString(fieldValue, values); err != nil {
+							return fmt.Errorf("%s: %w", fieldName, err)
+						}
 					}
 				default:
 					// JSON文字列としてパースを試みる
@@ -357,7 +338,10 @@ func extractMultipartTypeInfoRecursive(t reflect.Type, visited map[reflect.Type]
 		optional := false
 
 		multipartTag := field.Tag.Get("multipart")
-		if multipartTag != "" && multipartTag != "-" {
+		in, paramName := fieldParam(field)
+		if in != "" {
+			fieldName = paramName
+		} else if multipartTag != "" && multipartTag != "-" {
 			fieldName = multipartTag
 		} else {
 			jsonTag := field.Tag.Get("json")
@@ -375,8 +359,15 @@ func extractMultipartTypeInfoRecursive(t reflect.Type, visited map[reflect.Type]
 			Type:     field.Type.String(),
 			TSType:   goTypeToTSType(field.Type),
 			Optional: optional,
+			In:       in,
 		}
 
+		// validateタグと列挙型（Enum）のルール（不正なタグは登録時にvalidatorForで検出済み）
+		fieldInfo.Validation, _ = fieldRules(field)
+		fieldInfo.Description = field.Tag.Get("doc")
+		fieldInfo.LintIgnore = parseLintTag(field.Tag.Get("lint"))
+		applyParamOptional(&fieldInfo)
+
 		// ネストされた構造体の型情報を抽出
 		nestedType := extractNestedTypeInfo(field.Type, visited)
 		if nestedType != nil {
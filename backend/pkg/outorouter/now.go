@@ -16,6 +16,14 @@ func GetNowUTCFromContext(ctx context.Context) time.Time {
 	return time.Time{}
 }
 
+// nowFromContext はリクエスト時刻を返します（NowUTCMiddleware が適用されていない場合は現在時刻）
+func nowFromContext(ctx context.Context) time.Time {
+	if now := GetNowUTCFromContext(ctx); !now.IsZero() {
+		return now
+	}
+	return time.Now().UTC()
+}
+
 // NowUTCMiddleware はリクエストごとに現在時刻（UTC）をコンテキストにセットするミドルウェアです。
 func NowUTCMiddleware() MiddlewareFunc {
 	return func(next http.Handler) http.Handler {
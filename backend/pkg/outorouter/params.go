@@ -0,0 +1,125 @@
+package outorouter
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+)
+
+// パラメータの受け取り位置です（メタデータの FieldInfo.In に出力されます）
+const (
+	ParamInPath  = "path"
+	ParamInQuery = "query"
+)
+
+// fieldParam はフィールドの path / query タグから受け取り位置とパラメータ名を返します
+// どちらのタグもない場合は空文字を返します（リクエストボディから受け取るフィールド）
+func fieldParam(field reflect.StructField) (in, name string) {
+	if name, ok := field.Tag.Lookup("path"); ok {
+		return ParamInPath, name
+	}
+	if name, ok := field.Tag.Lookup("query"); ok {
+		return ParamInQuery, name
+	}
+	return "", ""
+}
+
+// hasRequestBody はHTTPメソッドがリクエストボディを持つかを返します
+func hasRequestBody(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodDelete, http.MethodOptions:
+		return false
+	default:
+		return true
+	}
+}
+
+// populateRequestFromParams はパスパラメータとクエリ文字列からリクエスト構造体を埋めます
+// path / query タグが付いたフィールドのみが対象です
+func populateRequestFromParams(req any, r *http.Request) error {
+	reqValue := reflect.ValueOf(req).Elem()
+	reqType := reqValue.Type()
+	if reqType.Kind() != reflect.Struct {
+		return nil
+	}
+
+	query := r.URL.Query()
+	for i := 0; i < reqType.NumField(); i++ {
+		field := reqType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		var values []string
+		in, name := fieldParam(field)
+		switch in {
+		case ParamInPath:
+			if value := r.PathValue(name); value != "" {
+				values = []string{value}
+			}
+		case ParamInQuery:
+			values = query[name]
+		default:
+			continue
+		}
+		if len(values) == 0 {
+			continue
+		}
+
+		if err := setFieldFromString(reqValue.Field(i), values); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// checkRequestParams はパスのパラメータとリクエスト型の path タグが一致しているかを登録時に検証します
+// ボディを持たないHTTPメソッドの場合、すべてのフィールドに path または query タグが必要です
+func checkRequestParams(method, path string, t reflect.Type, requireTags bool) {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	declared := pathParams(path)
+	var bound []string
+	if t.Kind() == reflect.Struct {
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+
+			in, name := fieldParam(field)
+			switch {
+			case in == ParamInPath:
+				if !slices.Contains(declared, name) {
+					panic(fmt.Sprintf("outorouter: %s %s: field %s binds undeclared path parameter {%s}", method, path, field.Name, name))
+				}
+				bound = append(bound, name)
+			case in == "" && requireTags:
+				panic(fmt.Sprintf("outorouter: %s %s: field %s needs a path or query tag because the method has no request body", method, path, field.Name))
+			}
+		}
+	}
+
+	for _, name := range declared {
+		if !slices.Contains(bound, name) {
+			panic(fmt.Sprintf("outorouter: %s %s: path parameter {%s} is not bound to any field of %s", method, path, name, t))
+		}
+	}
+}
+
+// applyParamOptional はパラメータのフィールドの Optional を設定します
+// パスパラメータは常に必須、クエリ文字列は required ルールがない限り任意です
+func applyParamOptional(info *FieldInfo) {
+	switch info.In {
+	case ParamInPath:
+		info.Optional = false
+	case ParamInQuery:
+		info.Optional = !slices.ContainsFunc(info.Validation, func(rule ValidationRule) bool {
+			return rule.Name == "required"
+		})
+	}
+}
@@ -0,0 +1,360 @@
+package outorouter
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// レート制限に関するエラーコードです
+const (
+	ErrorCodeRateLimited   = "RATE_LIMITED"
+	ErrorCodeQuotaExceeded = "QUOTA_EXCEEDED"
+)
+
+var (
+	// ErrRateLimited はリクエストが多すぎる場合のエラーです
+	ErrRateLimited = TooManyRequestsError(ErrorCodeRateLimited, "リクエストが多すぎます。しばらく待ってから再度お試しください")
+	// ErrQuotaExceeded は1日の利用上限に達した場合のエラーです
+	ErrQuotaExceeded = TooManyRequestsError(ErrorCodeQuotaExceeded, "本日の利用上限に達しました")
+)
+
+// RateLimitAlgorithm はレート制限のアルゴリズムです
+type RateLimitAlgorithm string
+
+const (
+	// TokenBucket は Window ごとに Limit 個のトークンを補充するトークンバケットです（バーストを許容します）
+	TokenBucket RateLimitAlgorithm = "token_bucket"
+	// SlidingWindow は直前の Window 内のリクエスト数を Limit までに制限します（前のウィンドウを重み付けして近似します）
+	SlidingWindow RateLimitAlgorithm = "sliding_window"
+	// FixedWindow は Window ごと（UNIX時間で区切る）のリクエスト数を Limit までに制限します
+	FixedWindow RateLimitAlgorithm = "fixed_window"
+)
+
+// RateLimit はレート制限の方式と上限です
+type RateLimit struct {
+	Algorithm RateLimitAlgorithm
+	Limit     int
+	Window    time.Duration
+}
+
+// DailyQuota は1日（UTC）あたりの利用上限です
+func DailyQuota(limit int) RateLimit {
+	return RateLimit{Algorithm: FixedWindow, Limit: limit, Window: 24 * time.Hour}
+}
+
+// RateLimitResult はレート制限の判定結果です
+type RateLimitResult struct {
+	Allowed   bool
+	Remaining int
+	// Reset は制限が完全に回復するまでの時間です
+	Reset time.Duration
+	// RetryAfter は拒否された場合に次のリクエストが許可されるまでの時間です
+	RetryAfter time.Duration
+}
+
+// RateLimitStore はレート制限の状態を保存し、リクエストを許可するかを判定します
+type RateLimitStore interface {
+	Take(ctx context.Context, key string, limit RateLimit, now time.Time) (RateLimitResult, error)
+	// Refund は Take で消費した1回分を戻します（ウィンドウが変わっている場合や状態が期限切れの場合は何もしません）
+	Refund(ctx context.Context, key string, limit RateLimit, now time.Time) error
+}
+
+// WithRateLimitStore はレート制限の状態を保存するストアを設定します（デフォルトはインメモリ）
+func WithRateLimitStore(store RateLimitStore) Option {
+	return func(r *Router) {
+		r.rateLimitStore = store
+	}
+}
+
+// RateLimitStore はルーターに設定されたレート制限のストアを返します
+func (r *Router) RateLimitStore() RateLimitStore {
+	return r.rateLimitStore
+}
+
+// RateLimitKeyFunc はリクエストからレート制限の単位となるキーを生成します
+type RateLimitKeyFunc func(r *http.Request) string
+
+// RateLimitByIP はクライアントのIPアドレスごとに制限します
+// プロキシを経由する場合は ClientIPMiddleware で信頼するプロキシを設定してください（設定しない場合は接続元のアドレス）
+func RateLimitByIP(r *http.Request) string {
+	return "ip:" + clientIP(r)
+}
+
+// RateLimitByUser は認証されたユーザーごとに制限します（未認証の場合はIPアドレスごと）
+// AuthMiddleware より内側に適用してください
+func RateLimitByUser(r *http.Request) string {
+	if p, ok := GetPrincipalFromContext(r.Context()); ok {
+		return "user:" + p.Subject
+	}
+	return RateLimitByIP(r)
+}
+
+// RateLimitByEndpoint はエンドポイント全体（すべてのクライアントの合計）で制限します
+func RateLimitByEndpoint(r *http.Request) string {
+	return "endpoint"
+}
+
+// RateLimitConfig は RateLimitMiddleware の設定です
+type RateLimitConfig struct {
+	// Name はレート制限の名前です（ストアのキーの接頭辞になります、例: "monster.CreateMonster"）
+	Name  string
+	Limit RateLimit
+	// Key はレート制限の単位です（デフォルトは RateLimitByIP）
+	Key   RateLimitKeyFunc
+	Store RateLimitStore
+	// Error は制限を超えた場合に返すエラーです（デフォルトは ErrRateLimited）
+	Error HTTPError
+	// FailClosed がtrueの場合、ストアのエラー時にリクエストを拒否します（デフォルトは許可）
+	FailClosed bool
+	// RefundOnError がtrueの場合、バリデーションエラーなどで4xx・5xxを返したリクエストの分を戻します
+	// 1日の利用上限など、処理が成功した場合だけ数えたい制限に指定します
+	RefundOnError bool
+}
+
+type ctxKeyRateLimit struct{ name string }
+
+// GetRateLimitKeyFromContext は RateLimitMiddleware がリクエストを数えたストアのキーを返します（name は RateLimitConfig.Name）
+// レスポンスを返した後の非同期の処理が失敗した場合に、RateLimitStore.Refund で戻すために記録します
+func GetRateLimitKeyFromContext(ctx context.Context, name string) (string, bool) {
+	key, ok := ctx.Value(ctxKeyRateLimit{name: name}).(string)
+	return key, ok
+}
+
+// RateLimitMiddleware はリクエストの頻度を制限するミドルウェアを生成します
+// RateLimit-Limit / RateLimit-Remaining / RateLimit-Reset / RateLimit-Policy ヘッダーを付与し、
+// 制限を超えた場合は Retry-After ヘッダー付きで429を返します
+func RateLimitMiddleware(config RateLimitConfig) MiddlewareFunc {
+	if config.Name == "" || config.Store == nil {
+		panic("outorouter: RateLimitMiddleware requires Name and Store")
+	}
+	if config.Limit.Limit <= 0 || config.Limit.Window <= 0 {
+		panic(fmt.Sprintf("outorouter: %s: rate limit must have positive Limit and Window", config.Name))
+	}
+	switch config.Limit.Algorithm {
+	case TokenBucket, SlidingWindow, FixedWindow:
+	default:
+		panic(fmt.Sprintf("outorouter: %s: unknown rate limit algorithm %q", config.Name, config.Limit.Algorithm))
+	}
+	if config.Key == nil {
+		config.Key = RateLimitByIP
+	}
+	if config.Error == nil {
+		config.Error = ErrRateLimited
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			key := config.Name + ":" + config.Key(r)
+
+			result, err := config.Store.Take(ctx, key, config.Limit, nowFromContext(ctx))
+			if err != nil {
+				if config.FailClosed {
+					WriteError(w, r, internalError())
+					return
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			setRateLimitHeaders(w.Header(), config.Limit, result)
+			if !result.Allowed {
+				w.Header().Set("Retry-After", strconv.Itoa(ceilSeconds(result.RetryAfter)))
+				WriteError(w, r, config.Error)
+				return
+			}
+
+			r = r.WithContext(context.WithValue(ctx, ctxKeyRateLimit{name: config.Name}, key))
+			if !config.RefundOnError {
+				next.ServeHTTP(w, r)
+				return
+			}
+			rec := &statusRecorder{ResponseWriter: w}
+			next.ServeHTTP(rec, r)
+			if rec.statusCode >= http.StatusBadRequest {
+				// 戻せなかった場合は消費したままにする（リクエストは拒否しない）
+				_ = config.Store.Refund(context.WithoutCancel(ctx), key, config.Limit, nowFromContext(ctx))
+			}
+		})
+	}
+}
+
+// setRateLimitHeaders はレート制限のヘッダーを付与します
+// 複数のレート制限を適用している場合は、残りが最も少ないものを返します
+func setRateLimitHeaders(h http.Header, limit RateLimit, result RateLimitResult) {
+	if current, err := strconv.Atoi(h.Get("RateLimit-Remaining")); err == nil && current <= result.Remaining {
+		return
+	}
+	h.Set("RateLimit-Limit", strconv.Itoa(limit.Limit))
+	h.Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	h.Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.Reset)))
+	h.Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d", limit.Limit, ceilSeconds(limit.Window)))
+}
+
+func ceilSeconds(d time.Duration) int {
+	if d <= 0 {
+		return 0
+	}
+	return int(math.Ceil(d.Seconds()))
+}
+
+// rateLimitState はキーごとのレート制限の状態です
+type rateLimitState struct {
+	// トークンバケット
+	tokens float64
+	last   int64
+	// スライディングウィンドウ・固定ウィンドウ
+	windowStart int64
+	count       int
+	prevCount   int
+
+	expiresAt int64
+}
+
+// take はレート制限を判定し、状態を更新します（時刻はUNIXミリ秒）
+// RedisRateLimitStore のLuaスクリプトと同じ計算を行います
+func (s *rateLimitState) take(limit RateLimit, now int64, initialized bool) RateLimitResult {
+	window := limit.Window.Milliseconds()
+	max := float64(limit.Limit)
+
+	if limit.Algorithm == TokenBucket {
+		rate := max / float64(window)
+		if !initialized {
+			s.tokens, s.last = max, now
+		}
+		s.tokens = math.Min(max, s.tokens+float64(maxInt64(0, now-s.last))*rate)
+		s.last = now
+
+		result := RateLimitResult{}
+		if s.tokens >= 1 {
+			s.tokens--
+			result.Allowed = true
+		} else {
+			result.RetryAfter = millis(math.Ceil((1 - s.tokens) / rate))
+		}
+		result.Remaining = int(math.Floor(s.tokens))
+		result.Reset = millis(math.Ceil((max - s.tokens) / rate))
+		s.expiresAt = now + maxInt64(result.Reset.Milliseconds(), 1)
+		return result
+	}
+
+	start := now - now%window
+	if !initialized {
+		s.windowStart = start
+	}
+	if s.windowStart != start {
+		s.prevCount = 0
+		if start-s.windowStart == window {
+			s.prevCount = s.count
+		}
+		s.windowStart, s.count = start, 0
+	}
+	elapsed := now - start
+	untilNext := window - elapsed
+
+	estimated := float64(s.count)
+	if limit.Algorithm == SlidingWindow {
+		estimated += float64(s.prevCount) * float64(untilNext) / float64(window)
+	}
+
+	result := RateLimitResult{Reset: millis(float64(untilNext))}
+	if estimated+1 <= max {
+		s.count++
+		result.Allowed = true
+		result.Remaining = int(math.Floor(max - estimated - 1))
+	} else if limit.Algorithm == SlidingWindow && float64(s.count)+1 <= max {
+		// 前のウィンドウの重みが減って上限を下回るまで待つ
+		wait := float64(window)*(1-(max-1-float64(s.count))/float64(s.prevCount)) - float64(elapsed)
+		result.RetryAfter = millis(math.Ceil(wait))
+	} else {
+		result.RetryAfter = millis(float64(untilNext))
+	}
+	if limit.Algorithm == SlidingWindow {
+		// 前のウィンドウの値を参照するため、次のウィンドウの終わりまで保持する
+		result.Reset += limit.Window
+	}
+	s.expiresAt = now + result.Reset.Milliseconds()
+	return result
+}
+
+// refund は take で消費した1回分を戻します（時刻はUNIXミリ秒）
+// RedisRateLimitStore のLuaスクリプトと同じ計算を行います
+func (s *rateLimitState) refund(limit RateLimit, now int64) {
+	if limit.Algorithm == TokenBucket {
+		s.tokens = math.Min(float64(limit.Limit), s.tokens+1)
+		return
+	}
+
+	window := limit.Window.Milliseconds()
+	if s.windowStart == now-now%window && s.count > 0 {
+		s.count--
+	}
+}
+
+func millis(ms float64) time.Duration {
+	return time.Duration(ms) * time.Millisecond
+}
+
+func maxInt64(a, b int64) int64 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// MemoryRateLimitStore はプロセス内のメモリにレート制限の状態を保存します
+// 複数のインスタンスで制限を共有する場合は RedisRateLimitStore を利用してください
+type MemoryRateLimitStore struct {
+	mu      sync.Mutex
+	entries map[string]*rateLimitState
+	takes   int
+}
+
+// NewMemoryRateLimitStore は新しい MemoryRateLimitStore を作成します
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{entries: make(map[string]*rateLimitState)}
+}
+
+// memoryRateLimitSweepInterval ごとに期限切れの状態を削除します
+const memoryRateLimitSweepInterval = 1024
+
+func (s *MemoryRateLimitStore) Take(ctx context.Context, key string, limit RateLimit, now time.Time) (RateLimitResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	nowMs := now.UnixMilli()
+	s.takes++
+	if s.takes%memoryRateLimitSweepInterval == 0 {
+		for k, state := range s.entries {
+			if state.expiresAt <= nowMs {
+				delete(s.entries, k)
+			}
+		}
+	}
+
+	state, ok := s.entries[key]
+	if ok && state.expiresAt <= nowMs {
+		ok = false
+	}
+	if !ok {
+		state = &rateLimitState{}
+		s.entries[key] = state
+	}
+	return state.take(limit, nowMs, ok), nil
+}
+
+func (s *MemoryRateLimitStore) Refund(ctx context.Context, key string, limit RateLimit, now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	nowMs := now.UnixMilli()
+	if state, ok := s.entries[key]; ok && state.expiresAt > nowMs {
+		state.refund(limit, nowMs)
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package outorouter
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// RedisScripter はLuaスクリプトを実行できるRedis（互換）クライアントです
+// 戻り値は整数の配列（[]any の要素が int64）を想定しています
+type RedisScripter interface {
+	Eval(ctx context.Context, script string, keys []string, args ...any) (any, error)
+}
+
+// RedisRateLimitStore はRedisにレート制限の状態を保存します（複数のインスタンスで制限を共有できます）
+type RedisRateLimitStore struct {
+	Client RedisScripter
+	// Prefix はキーの接頭辞です（例: "app:ratelimit:"）
+	Prefix string
+}
+
+// rateLimitScript は rateLimitState.take と同じ計算をアトミックに行います
+// 戻り値は {allowed, remaining, reset_ms, retry_after_ms} です
+const rateLimitScript = `
+local key = KEYS[1]
+local algorithm = ARGV[1]
+local max = tonumber(ARGV[2])
+local window = tonumber(ARGV[3])
+local now = tonumber(ARGV[4])
+
+if algorithm == "token_bucket" then
+  local rate = max / window
+  local state = redis.call("HMGET", key, "tokens", "last")
+  local tokens = tonumber(state[1]) or max
+  local last = tonumber(state[2]) or now
+  tokens = math.min(max, tokens + math.max(0, now - last) * rate)
+
+  local allowed, retry = 0, 0
+  if tokens >= 1 then
+    tokens = tokens - 1
+    allowed = 1
+  else
+    retry = math.ceil((1 - tokens) / rate)
+  end
+  local reset = math.ceil((max - tokens) / rate)
+  redis.call("HSET", key, "tokens", tostring(tokens), "last", now)
+  redis.call("PEXPIRE", key, math.max(reset, 1))
+  return {allowed, math.floor(tokens), reset, retry}
+end
+
+local start = now - (now % window)
+local state = redis.call("HMGET", key, "start", "count", "prev")
+local windowStart = tonumber(state[1]) or start
+local count = tonumber(state[2]) or 0
+local prev = tonumber(state[3]) or 0
+if windowStart ~= start then
+  if start - windowStart == window then prev = count else prev = 0 end
+  count = 0
+end
+local elapsed = now - start
+local untilNext = window - elapsed
+
+local estimated = count
+if algorithm == "sliding_window" then
+  estimated = estimated + prev * untilNext / window
+end
+
+local allowed, remaining, retry = 0, 0, 0
+if estimated + 1 <= max then
+  count = count + 1
+  allowed = 1
+  remaining = math.floor(max - estimated - 1)
+elseif algorithm == "sliding_window" and count + 1 <= max then
+  retry = math.ceil(window * (1 - (max - 1 - count) / prev) - elapsed)
+else
+  retry = untilNext
+end
+
+local reset = untilNext
+if algorithm == "sliding_window" then
+  reset = reset + window
+end
+redis.call("HSET", key, "start", start, "count", count, "prev", prev)
+redis.call("PEXPIRE", key, reset)
+return {allowed, remaining, reset, retry}
+`
+
+// rateLimitRefundScript は rateLimitState.refund と同じ計算をアトミックに行います
+const rateLimitRefundScript = `
+local key = KEYS[1]
+local algorithm = ARGV[1]
+local max = tonumber(ARGV[2])
+local window = tonumber(ARGV[3])
+local now = tonumber(ARGV[4])
+
+if redis.call("EXISTS", key) == 0 then
+  return 0
+end
+
+if algorithm == "token_bucket" then
+  local tokens = tonumber(redis.call("HGET", key, "tokens")) or max
+  redis.call("HSET", key, "tokens", tostring(math.min(max, tokens + 1)))
+  return 1
+end
+
+local state = redis.call("HMGET", key, "start", "count")
+local count = tonumber(state[2]) or 0
+if tonumber(state[1]) == now - (now % window) and count > 0 then
+  redis.call("HSET", key, "count", count - 1)
+  return 1
+end
+return 0
+`
+
+func (s *RedisRateLimitStore) Take(ctx context.Context, key string, limit RateLimit, now time.Time) (RateLimitResult, error) {
+	reply, err := s.Client.Eval(ctx, rateLimitScript, []string{s.Prefix + key},
+		string(limit.Algorithm), limit.Limit, limit.Window.Milliseconds(), now.UnixMilli())
+	if err != nil {
+		return RateLimitResult{}, fmt.Errorf("failed to eval rate limit script: %w", err)
+	}
+
+	values, ok := reply.([]any)
+	if !ok || len(values) != 4 {
+		return RateLimitResult{}, fmt.Errorf("unexpected rate limit script reply: %v", reply)
+	}
+	ints := make([]int64, len(values))
+	for i, v := range values {
+		n, ok := v.(int64)
+		if !ok {
+			return RateLimitResult{}, fmt.Errorf("unexpected rate limit script reply: %v", reply)
+		}
+		ints[i] = n
+	}
+
+	return RateLimitResult{
+		Allowed:    ints[0] == 1,
+		Remaining:  int(ints[1]),
+		Reset:      time.Duration(ints[2]) * time.Millisecond,
+		RetryAfter: time.Duration(ints[3]) * time.Millisecond,
+	}, nil
+}
+
+func (s *RedisRateLimitStore) Refund(ctx context.Context, key string, limit RateLimit, now time.Time) error {
+	if _, err := s.Client.Eval(ctx, rateLimitRefundScript, []string{s.Prefix + key},
+		string(limit.Algorithm), limit.Limit, limit.Window.Milliseconds(), now.UnixMilli()); err != nil {
+		return fmt.Errorf("failed to eval rate limit refund script: %w", err)
+	}
+	return nil
+}
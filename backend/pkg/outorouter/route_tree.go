@@ -0,0 +1,133 @@
+package outorouter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// routeNode はパスのセグメント単位で分岐するトライ木のノードです
+// 静的なセグメントはパスパラメータ（{name}）より優先してマッチします
+type routeNode struct {
+	// 静的なセグメント → 子ノード
+	children map[string]*routeNode
+
+	// パスパラメータの子ノード
+	param     *routeNode
+	paramName string
+
+	// method → Content-Type → ルート
+	// Content-Typeが空文字のルートは、一致するContent-Typeがない場合のフォールバックです
+	handlers map[string]map[string]*routeEntry
+
+	// pattern はこのノードに登録されたパスのパターンです
+	pattern string
+}
+
+func newRouteNode() *routeNode {
+	return &routeNode{children: make(map[string]*routeNode)}
+}
+
+// splitPath はパスを "/" で区切ったセグメントに分割します
+func splitPath(path string) []string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, "/")
+}
+
+// parseParamSegment は "{name}" 形式のセグメントからパラメータ名を取り出します
+func parseParamSegment(segment string) (string, bool) {
+	if len(segment) < 2 || segment[0] != '{' || segment[len(segment)-1] != '}' {
+		return "", false
+	}
+	return segment[1 : len(segment)-1], true
+}
+
+// pathParams はパスのパターンに含まれるパラメータ名を出現順に返します
+func pathParams(pattern string) []string {
+	var params []string
+	for _, segment := range splitPath(pattern) {
+		if name, ok := parseParamSegment(segment); ok {
+			params = append(params, name)
+		}
+	}
+	return params
+}
+
+// insert はパターンに対応するノードを作成して返します
+// 同じ位置に異なる名前のパスパラメータを登録しようとした場合はpanicします
+func (n *routeNode) insert(pattern string) *routeNode {
+	node := n
+	for _, segment := range splitPath(pattern) {
+		name, isParam := parseParamSegment(segment)
+		if !isParam {
+			if strings.ContainsAny(segment, "{}") {
+				panic(fmt.Sprintf("outorouter: invalid path segment %q in %q", segment, pattern))
+			}
+			child, ok := node.children[segment]
+			if !ok {
+				child = newRouteNode()
+				node.children[segment] = child
+			}
+			node = child
+			continue
+		}
+
+		if name == "" {
+			panic(fmt.Sprintf("outorouter: empty path parameter in %q", pattern))
+		}
+		if node.param == nil {
+			node.param = newRouteNode()
+			node.paramName = name
+		} else if node.paramName != name {
+			panic(fmt.Sprintf("outorouter: path parameter {%s} in %q conflicts with {%s}", name, pattern, node.paramName))
+		}
+		node = node.param
+	}
+	node.pattern = pattern
+	return node
+}
+
+// lookup はパスに一致するハンドラーを持つノードと、パスパラメータの値を返します
+func (n *routeNode) lookup(path string) (*routeNode, map[string]string) {
+	params := make(map[string]string)
+	node := n.match(splitPath(path), params)
+	return node, params
+}
+
+func (n *routeNode) match(segments []string, params map[string]string) *routeNode {
+	if len(segments) == 0 {
+		if len(n.handlers) == 0 {
+			return nil
+		}
+		return n
+	}
+
+	segment, rest := segments[0], segments[1:]
+	if child, ok := n.children[segment]; ok {
+		if node := child.match(rest, params); node != nil {
+			return node
+		}
+	}
+
+	// 静的なセグメントで一致しない場合はパスパラメータとしてマッチさせる
+	if n.param != nil && segment != "" {
+		if node := n.param.match(rest, params); node != nil {
+			params[n.paramName] = segment
+			return node
+		}
+	}
+	return nil
+}
+
+// allowedMethods はノードに登録されているHTTPメソッドをソートして返します
+func (n *routeNode) allowedMethods() []string {
+	methods := make([]string, 0, len(n.handlers))
+	for method := range n.handlers {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+	return methods
+}
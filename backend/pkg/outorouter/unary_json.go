@@ -3,7 +3,6 @@ package outorouter
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"net/http"
 	"reflect"
@@ -12,23 +11,60 @@ import (
 
 type UnaryJSONHandlerFunc[Req RequestObject, Res ResponseObject] func(ctx context.Context, req *Req) (*Res, error)
 
-// UnaryJSONEndpoint はHTTP 1.1 のPOSTメソッドでやり取りするためのエンドポイントです
+// UnaryJSONEndpoint はHTTP 1.1 でJSONをやり取りするためのエンドポイントです
+// HTTPMethod・Path を省略した場合は POST /{Domain}/v{Version}/{MethodName} で登録されます
 type UnaryJSONEndpoint[Req RequestObject, Res any] struct {
 	Domain     string
 	Version    uint8
 	MethodName string
 
+	// HTTPMethod はエンドポイントのHTTPメソッドです（空の場合はPOST）
+	// GET・DELETEの場合、リクエストのフィールドには path または query タグが必要です
+	HTTPMethod string
+	// Path はパスパラメータを含むパスのパターンです（例: "/monster/v1/monsters/{id}"）
+	// パスパラメータはリクエストの path タグが付いたフィールドにセットされます
+	Path string
+
 	Summary     string
 	Description string
 	Tags        []Tag
 
 	Handler UnaryJSONHandlerFunc[Req, Res]
+
+	// Errors はハンドラーが返しうるエラーです（メタデータ・クライアント生成用）
+	Errors []HTTPError
+	// LintIgnore はこのエンドポイントで抑制するlintルールのIDまたは名前です
+	LintIgnore []string
+
+	// Auth はエンドポイントの認証の要否です（空の場合は AuthNone）
+	Auth AuthRequirement
+	// Scopes は認証された Principal に必要なスコープです（Auth が AuthRequired の場合のみ指定できます）
+	Scopes []string
+
+	// Middlewares はこのエンドポイントにのみ適用するミドルウェアです
+	// グローバル・グループのミドルウェアの内側で、記述した順に適用されます
+	Middlewares []MiddlewareFunc
+
+	// CacheControl は成功したレスポンスに付与するCache-Controlヘッダーの値です（GETのエンドポイント用）
+	// 空の場合は付与しません
+	CacheControl string
 }
 
 func (u UnaryJSONEndpoint[Req, Res]) GetFullPath() string {
+	if u.Path != "" {
+		return u.Path
+	}
 	return fmt.Sprintf("/%s/%s/%s", u.Domain, u.GetVersionWithPrefix(), u.MethodName)
 }
 
+// GetHTTPMethod はエンドポイントのHTTPメソッドを返します
+func (u UnaryJSONEndpoint[Req, Res]) GetHTTPMethod() string {
+	if u.HTTPMethod == "" {
+		return http.MethodPost
+	}
+	return strings.ToUpper(u.HTTPMethod)
+}
+
 func (u UnaryJSONEndpoint[Req, Res]) GetDomain() string {
 	return u.Domain
 }
@@ -45,19 +81,20 @@ func RegisterUnaryJSONEndpoint[Req RequestObject, Res any](
 	r *Router,
 	ep UnaryJSONEndpoint[Req, Res],
 ) {
+	method := ep.GetHTTPMethod()
+	withBody := hasRequestBody(method)
+
 	h := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
-		// POSTメソッドではない場合は不正と見做す
-		if req.Method != http.MethodPost {
-			w.Header().Set("Content-Type", "application/json")
-			http.Error(w, "指定した形式のリクエストではありません", http.StatusMethodNotAllowed)
+		// 登録したメソッドではない場合は不正と見做す
+		if req.Method != method {
+			WriteError(w, req, NewHTTPError(http.StatusMethodNotAllowed, ErrorCodeMethodNotAllowed, "指定した形式のリクエストではありません"))
 			return
 		}
 
 		// Content-Type validation
 		contentType := req.Header.Get("Content-Type")
-		if contentType != "" && contentType != "application/json" {
-			w.Header().Set("Content-Type", "application/json")
-			http.Error(w, "Content-Type must be application/json", http.StatusUnsupportedMediaType)
+		if withBody && contentType != "" && contentType != "application/json" {
+			WriteError(w, req, NewHTTPError(http.StatusUnsupportedMediaType, ErrorCodeUnsupportedMediaType, "Content-Type must be application/json"))
 			return
 		}
 
@@ -69,69 +106,67 @@ func RegisterUnaryJSONEndpoint[Req RequestObject, Res any](
 		reqType := reflect.TypeOf(request)
 		hasFields := reqType.Kind() == reflect.Struct && reqType.NumField() > 0
 
-		if hasFields && req.Body != nil {
+		if withBody && hasFields && req.Body != nil {
 			defer req.Body.Close()
 			// Set maximum request body size to 10MB
 			req.Body = http.MaxBytesReader(w, req.Body, 10*1024*1024)
 
 			// ここでリクエストボディをパースして request にセットする
 			if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
-				w.Header().Set("Content-Type", "application/json")
-				http.Error(w, "リクエストのJSON形式が不正です", http.StatusBadRequest)
+				WriteError(w, req, bodyReadError(err, ErrorCodeInvalidJSON, "リクエストのJSON形式が不正です"))
+				return
+			}
+		}
+
+		// パスパラメータ・クエリ文字列はボディより優先する
+		if hasFields {
+			if err := populateRequestFromParams(&request, req); err != nil {
+				WriteError(w, req, BadRequestError(ErrorCodeInvalidRequest, fmt.Sprintf("リクエストのパースに失敗しました: %v", err)))
 				return
 			}
 		}
 
-		// Validate request if Validate method exists
-		if err := request.Validate(); err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			http.Error(w, fmt.Sprintf("リクエストのバリデーションに失敗しました: %v", err), http.StatusBadRequest)
+		// validateタグによる検証の後、Validate()を実行する
+		if err := validateRequest(&request); err != nil {
+			WriteError(w, req, err)
 			return
 		}
 
 		response, err := ep.Handler(ctx, &request)
 		if err != nil {
-			var httpErr HTTPError
-			if errors.As(err, &httpErr) {
-				w.Header().Set("Content-Type", "application/json")
-				w.WriteHeader(httpErr.StatusCode())
-
-				_ = json.NewEncoder(w).Encode(map[string]any{
-					"error": map[string]any{
-						"error":   httpErr.Error(),
-						"message": httpErr.Message(),
-					},
-				})
-				return
-			}
-
-			// その他のエラーは500として扱う
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusInternalServerError)
-
-			_ = json.NewEncoder(w).Encode(map[string]any{
-				"error": map[string]any{
-					"error":   "UNKNOWN_INTERNAL_ERROR",
-					"message": "サーバー内部で予期しないエラーが発生しました",
-				},
-			})
+			r.writeHandlerError(w, req, err)
 			return
 		}
 
 		w.Header().Set("Content-Type", "application/json")
+		if ep.CacheControl != "" {
+			w.Header().Set("Cache-Control", ep.CacheControl)
+		}
 		if err := json.NewEncoder(w).Encode(response); err != nil {
-			http.Error(w, "レスポンスの返却に失敗しました", http.StatusInternalServerError)
+			// ロガーが設定されている場合はエラーをログに出力
+			if r.logger != nil {
+				r.logger.Error(ctx, "レスポンスのエンコードに失敗しました", map[string]any{
+					"error":  err.Error(),
+					"path":   req.URL.Path,
+					"method": req.Method,
+				})
+			}
+			// 既にヘッダーが送信されている可能性があるため、エラーレスポンスは送信しない
 			return
 		}
 	})
 
-	r.addHTTPRoute("POST", ep.GetFullPath(), r.applyMiddlewares(h))
-	r.addContentTypeRoute("POST", ep.GetFullPath(), "application/json", r.applyMiddlewares(h))
-
 	// リクエスト・レスポンスモデルのメタデータ
 	var reqZero Req
 	var resZero Res
 
+	// validateタグ・path タグの誤りは登録時に検出する
+	validatorFor(reflect.TypeOf(reqZero))
+	checkRequestParams(method, ep.GetFullPath(), reflect.TypeOf(reqZero), !withBody)
+
+	mws := r.endpointMiddlewares(ep.GetFullPath(), ep.Auth, ep.Scopes, ep.Middlewares)
+	r.addRoute(method, ep.GetFullPath(), "application/json", newRouteEntry(ep.Domain, ep.Version, mws, h))
+
 	internalEp := internalEndpoint{
 		Kind:             KindUnaryJSON,
 		Domain:           ep.Domain,
@@ -140,12 +175,18 @@ func RegisterUnaryJSONEndpoint[Req RequestObject, Res any](
 		Summary:          ep.Summary,
 		Description:      ep.Description,
 		Tags:             ep.Tags,
-		HTTPMethod:       http.MethodPost,
+		HTTPMethod:       method,
+		Path:             ep.GetFullPath(),
 		handler:          h,
+		middlewares:      mws,
+		Auth:             ep.Auth.String(),
+		Scopes:           ep.Scopes,
 		RequestType:      reflect.TypeOf(reqZero).String(),
 		ResponseType:     reflect.TypeOf(resZero).String(),
 		RequestTypeInfo:  extractTypeInfo(reflect.TypeOf(reqZero)),
 		ResponseTypeInfo: extractTypeInfo(reflect.TypeOf(resZero)),
+		Errors:           append(append(standardErrors(KindUnaryJSON), authErrors(ep.Auth, ep.Scopes)...), ep.Errors...),
+		LintIgnore:       ep.LintIgnore,
 	}
 
 	r.addToRegistry(internalEp)
@@ -189,9 +230,13 @@ func extractTypeInfoRecursive(t reflect.Type, visited map[reflect.Type]bool) Typ
 			continue
 		}
 
-		// JSONタグを解析
+		// JSONタグを解析（path / queryタグがある場合はパラメータ名を使う）
 		jsonTag := field.Tag.Get("json")
 		jsonName, optional := parseJSONTag(jsonTag, field.Name)
+		in, paramName := fieldParam(field)
+		if in != "" {
+			jsonName = paramName
+		}
 
 		// "-"の場合はスキップ（JSONで無視されるフィールド）
 		if jsonName == "-" {
@@ -204,8 +249,15 @@ func extractTypeInfoRecursive(t reflect.Type, visited map[reflect.Type]bool) Typ
 			Type:     field.Type.String(),
 			TSType:   goTypeToTSType(field.Type),
 			Optional: optional,
+			In:       in,
 		}
 
+		// validateタグと列挙型（Enum）のルール（不正なタグは登録時にvalidatorForで検出済み）
+		fieldInfo.Validation, _ = fieldRules(field)
+		fieldInfo.Description = field.Tag.Get("doc")
+		fieldInfo.LintIgnore = parseLintTag(field.Tag.Get("lint"))
+		applyParamOptional(&fieldInfo)
+
 		// ネストされた構造体の型情報を抽出
 		nestedType := extractNestedTypeInfo(field.Type, visited)
 		if nestedType != nil {
@@ -0,0 +1,84 @@
+package outorouter_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kinpatsu-everyone/backend-template/pkg/outorouter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// color は名前付きの文字列型です（enum のスラッグと同じ形）
+type color string
+
+type listItemsRequest struct {
+	Colors []color `json:"colors" query:"color"`
+	Limit  int     `json:"limit" query:"limit" validate:"max=10"`
+}
+
+func (r listItemsRequest) Validate() error {
+	return nil
+}
+
+type listItemsResponse struct {
+	Colors []color `json:"colors"`
+	Limit  int     `json:"limit"`
+}
+
+// newListItemsHandler はクエリ文字列をそのまま返すGETエンドポイントを登録したハンドラーを返します
+func newListItemsHandler() http.Handler {
+	r := outorouter.New()
+	outorouter.RegisterUnaryJSONEndpoint(r, outorouter.UnaryJSONEndpoint[listItemsRequest, listItemsResponse]{
+		Domain:       "item",
+		Version:      1,
+		MethodName:   "ListItems",
+		HTTPMethod:   http.MethodGet,
+		CacheControl: "public, max-age=30",
+		Handler: func(_ context.Context, req *listItemsRequest) (*listItemsResponse, error) {
+			return &listItemsResponse{Colors: req.Colors, Limit: req.Limit}, nil
+		},
+	})
+	return r.Handler()
+}
+
+func TestUnaryJSON_GETのクエリ文字列を名前付きの文字列型のスライスにセットする(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/item/v1/ListItems?color=red&color=blue&limit=5", nil)
+	w := httptest.NewRecorder()
+
+	newListItemsHandler().ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var res listItemsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, []color{"red", "blue"}, res.Colors)
+	assert.Equal(t, 5, res.Limit)
+}
+
+func TestUnaryJSON_CacheControlは成功したレスポンスにのみ付与する(t *testing.T) {
+	tests := []struct {
+		name                 string
+		query                string
+		expectedStatus       int
+		expectedCacheControl string
+	}{
+		{name: "成功したレスポンス", query: "limit=5", expectedStatus: http.StatusOK, expectedCacheControl: "public, max-age=30"},
+		{name: "パースできない値", query: "limit=abc", expectedStatus: http.StatusBadRequest},
+		{name: "バリデーションエラー", query: "limit=11", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/item/v1/ListItems?"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			newListItemsHandler().ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.expectedCacheControl, w.Header().Get("Cache-Control"))
+		})
+	}
+}
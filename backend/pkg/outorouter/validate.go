@@ -0,0 +1,510 @@
+package outorouter
+
+import (
+	"fmt"
+	"math"
+	"mime/multipart"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// validateタグで利用できるルール名です
+//
+//	required      ゼロ値（空文字・nil・空スライス）を許可しない
+//	min=N, max=N  数値は値の範囲、文字列は文字数、スライスは要素数
+//	oneof=a b c   列挙値のいずれかであること（空白区切り）
+//	maxsize=10MB  ファイルサイズの上限（B, KB, MB, GB）
+//	mime=image/*  ファイルのMIMEタイプ（空白区切り、ワイルドカード可）
+//
+// required 以外のルールは、値がゼロ値の場合は評価されません
+// フィールドの型が Enum を実装している場合、oneof を書かなくても EnumValues の値を oneof として扱います
+const (
+	RuleRequired = "required"
+	RuleMin      = "min"
+	RuleMax      = "max"
+	RuleOneOf    = "oneof"
+	RuleMaxSize  = "maxsize"
+	RuleMIME     = "mime"
+)
+
+// ValidationRule はvalidateタグから解析したルールです（コード生成用）
+type ValidationRule struct {
+	Name  string `json:"name"`
+	Value string `json:"value,omitempty"`
+}
+
+// Enum はとりうる値が決まっている列挙型です
+// フィールドの型が実装している場合、EnumValues の値を oneof ルールとして検証とコード生成に使います
+// （validateタグに oneof がある場合はタグを優先します）
+type Enum interface {
+	EnumValues() []string
+}
+
+var enumType = reflect.TypeOf((*Enum)(nil)).Elem()
+
+var (
+	fileHeaderPtrType   = reflect.TypeOf((*multipart.FileHeader)(nil))
+	fileHeaderSliceType = reflect.TypeOf(([]*multipart.FileHeader)(nil))
+)
+
+// fieldValidator は1つのフィールドに対するバリデーション定義です
+type fieldValidator struct {
+	index int
+	name  string
+	rules []ValidationRule
+
+	// 数値ルール（min/max/maxsize）は登録時にパースしておく
+	min, max *float64
+	maxSize  int64
+	oneOf    []string
+	mimes    []string
+
+	// nested はネストされた構造体のバリデーション定義です
+	nested *structValidator
+}
+
+// structValidator は構造体全体のバリデーション定義です
+type structValidator struct {
+	fields []fieldValidator
+}
+
+var validatorCache sync.Map // reflect.Type → *structValidator
+
+// validatorFor は型に対応するバリデーション定義を返します
+// validateタグが不正な場合はエンドポイント登録時に気付けるようpanicします
+func validatorFor(t reflect.Type) *structValidator {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if v, ok := validatorCache.Load(t); ok {
+		return v.(*structValidator)
+	}
+
+	v, err := buildStructValidator(t, make(map[reflect.Type]bool))
+	if err != nil {
+		panic(fmt.Sprintf("outorouter: %s: %v", t.String(), err))
+	}
+	actual, _ := validatorCache.LoadOrStore(t, v)
+	return actual.(*structValidator)
+}
+
+func buildStructValidator(t reflect.Type, visited map[reflect.Type]bool) (*structValidator, error) {
+	sv := &structValidator{}
+	if t.Kind() != reflect.Struct || visited[t] {
+		return sv, nil
+	}
+	visited[t] = true
+	defer delete(visited, t)
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := wireFieldName(field)
+		if name == "-" {
+			continue
+		}
+
+		fv := fieldValidator{index: i, name: name}
+
+		rules, err := fieldRules(field)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		fv.rules = rules
+
+		if err := fv.compile(field.Type); err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+
+		// ネストされた構造体も再帰的に検証する
+		if elem := structElemType(field.Type); elem != nil {
+			nested, err := buildStructValidator(elem, visited)
+			if err != nil {
+				return nil, err
+			}
+			if len(nested.fields) > 0 {
+				fv.nested = nested
+			}
+		}
+
+		if len(fv.rules) > 0 || fv.nested != nil {
+			sv.fields = append(sv.fields, fv)
+		}
+	}
+
+	return sv, nil
+}
+
+// wireFieldName はリクエスト上のフィールド名を返します（path/queryタグ → multipartタグ → jsonタグ → フィールド名）
+func wireFieldName(field reflect.StructField) string {
+	if in, name := fieldParam(field); in != "" {
+		return name
+	}
+	if tag := field.Tag.Get("multipart"); tag != "" && tag != "-" {
+		return tag
+	}
+	name, _ := parseJSONTag(field.Tag.Get("json"), field.Name)
+	return name
+}
+
+// structElemType はフィールドの型がネストされた構造体（またはそのスライス）の場合に要素型を返します
+func structElemType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+		if t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+	}
+	if t.Kind() != reflect.Struct || t.PkgPath() == "time" || t.PkgPath() == "mime/multipart" {
+		return nil
+	}
+	return t
+}
+
+// parseValidateTag はvalidateタグをルールの一覧に分解します
+func parseValidateTag(tag string) ([]ValidationRule, error) {
+	if tag == "" || tag == "-" {
+		return nil, nil
+	}
+
+	var rules []ValidationRule
+	for _, part := range strings.Split(tag, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, value, _ := strings.Cut(part, "=")
+		switch name {
+		case RuleRequired:
+			if value != "" {
+				return nil, fmt.Errorf("rule %q does not take a value", name)
+			}
+		case RuleMin, RuleMax, RuleOneOf, RuleMaxSize, RuleMIME:
+			if value == "" {
+				return nil, fmt.Errorf("rule %q requires a value", name)
+			}
+		default:
+			return nil, fmt.Errorf("unknown validate rule %q", name)
+		}
+		rules = append(rules, ValidationRule{Name: name, Value: value})
+	}
+	return rules, nil
+}
+
+// fieldRules はフィールドのvalidateタグのルールに、列挙型（Enum）の値の oneof ルールを加えて返します
+func fieldRules(field reflect.StructField) ([]ValidationRule, error) {
+	rules, err := parseValidateTag(field.Tag.Get("validate"))
+	if err != nil {
+		return nil, err
+	}
+	for _, rule := range rules {
+		if rule.Name == RuleOneOf {
+			return rules, nil
+		}
+	}
+
+	t := field.Type
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if !t.Implements(enumType) {
+		return rules, nil
+	}
+	values := reflect.Zero(t).Interface().(Enum).EnumValues()
+	if len(values) == 0 {
+		return nil, fmt.Errorf("enum %s has no values", t.String())
+	}
+	for _, v := range values {
+		if v == "" || strings.ContainsAny(v, " \t\n") {
+			return nil, fmt.Errorf("enum %s has an invalid value %q", t.String(), v)
+		}
+	}
+	return append(rules, ValidationRule{Name: RuleOneOf, Value: strings.Join(values, " ")}), nil
+}
+
+// compile はルールの値をフィールドの型に合わせて事前にパースします
+func (fv *fieldValidator) compile(t reflect.Type) error {
+	isFile := t == fileHeaderPtrType || t == fileHeaderSliceType
+
+	for _, rule := range fv.rules {
+		switch rule.Name {
+		case RuleMin, RuleMax:
+			n, err := strconv.ParseFloat(rule.Value, 64)
+			if err != nil {
+				return fmt.Errorf("invalid %s value %q", rule.Name, rule.Value)
+			}
+			if rule.Name == RuleMin {
+				fv.min = &n
+			} else {
+				fv.max = &n
+			}
+		case RuleOneOf:
+			fv.oneOf = strings.Fields(rule.Value)
+		case RuleMaxSize:
+			if !isFile {
+				return fmt.Errorf("rule %q is only allowed on file fields", rule.Name)
+			}
+			size, err := parseByteSize(rule.Value)
+			if err != nil {
+				return err
+			}
+			fv.maxSize = size
+		case RuleMIME:
+			if !isFile {
+				return fmt.Errorf("rule %q is only allowed on file fields", rule.Name)
+			}
+			fv.mimes = strings.Fields(rule.Value)
+		}
+	}
+	return nil
+}
+
+// parseByteSize は "10MB" のようなサイズ表記をバイト数に変換します
+func parseByteSize(s string) (int64, error) {
+	units := []struct {
+		suffix string
+		scale  int64
+	}{
+		{"GB", 1 << 30},
+		{"MB", 1 << 20},
+		{"KB", 1 << 10},
+		{"B", 1},
+	}
+
+	upper := strings.ToUpper(strings.TrimSpace(s))
+	scale := int64(1)
+	for _, u := range units {
+		if strings.HasSuffix(upper, u.suffix) {
+			upper = strings.TrimSuffix(upper, u.suffix)
+			scale = u.scale
+			break
+		}
+	}
+
+	n, err := strconv.ParseInt(strings.TrimSpace(upper), 10, 64)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+	return n * scale, nil
+}
+
+// validateTags はvalidateタグに従ってリクエストを検証します
+// 違反がある場合はフィールド単位の詳細を持つ ValidationFailedError を返します
+func validateTags(req any) HTTPError {
+	v := reflect.ValueOf(req)
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	details := validatorFor(v.Type()).validate(v, "")
+	if len(details) == 0 {
+		return nil
+	}
+	return ValidationFailedError(details...)
+}
+
+// validateRequest はvalidateタグによる検証の後、リクエストの Validate() を実行します
+func validateRequest[Req RequestObject](req *Req) HTTPError {
+	if err := validateTags(req); err != nil {
+		return err
+	}
+	if err := (*req).Validate(); err != nil {
+		return validationError(err)
+	}
+	return nil
+}
+
+func (sv *structValidator) validate(v reflect.Value, prefix string) []FieldError {
+	var details []FieldError
+	for _, fv := range sv.fields {
+		path := fv.name
+		if prefix != "" {
+			path = prefix + "." + fv.name
+		}
+		details = append(details, fv.validate(v.Field(fv.index), path)...)
+	}
+	return details
+}
+
+func (fv *fieldValidator) validate(v reflect.Value, path string) []FieldError {
+	isEmpty := v.Kind() != reflect.Struct && (v.IsZero() || (v.Kind() == reflect.Slice && v.Len() == 0))
+	if isEmpty {
+		for _, rule := range fv.rules {
+			if rule.Name == RuleRequired {
+				return []FieldError{{Field: path, Code: RuleRequired, Message: "必須項目です"}}
+			}
+		}
+		return nil
+	}
+
+	if v.Type() == fileHeaderPtrType {
+		return fv.validateFile(v.Interface().(*multipart.FileHeader), path)
+	}
+	if v.Type() == fileHeaderSliceType {
+		details := fv.validateLength(v.Len(), path, "個")
+		for i, fh := range v.Interface().([]*multipart.FileHeader) {
+			details = append(details, fv.validateFile(fh, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+		return details
+	}
+
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+
+	var details []FieldError
+	switch v.Kind() {
+	case reflect.String:
+		details = append(details, fv.validateLength(utf8.RuneCountInString(v.String()), path, "文字")...)
+		details = append(details, fv.validateOneOf(v.String(), path)...)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		details = append(details, fv.validateRange(float64(v.Int()), path)...)
+		details = append(details, fv.validateOneOf(strconv.FormatInt(v.Int(), 10), path)...)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		details = append(details, fv.validateRange(float64(v.Uint()), path)...)
+		details = append(details, fv.validateOneOf(strconv.FormatUint(v.Uint(), 10), path)...)
+	case reflect.Float32, reflect.Float64:
+		details = append(details, fv.validateFloatRange(v.Float(), path)...)
+	case reflect.Slice, reflect.Array:
+		details = append(details, fv.validateLength(v.Len(), path, "個")...)
+		if fv.nested != nil {
+			for i := 0; i < v.Len(); i++ {
+				elem := v.Index(i)
+				if elem.Kind() == reflect.Ptr {
+					if elem.IsNil() {
+						continue
+					}
+					elem = elem.Elem()
+				}
+				details = append(details, fv.nested.validate(elem, fmt.Sprintf("%s[%d]", path, i))...)
+			}
+		}
+	case reflect.Struct:
+		if fv.nested != nil {
+			details = append(details, fv.nested.validate(v, path)...)
+		}
+	}
+	return details
+}
+
+func (fv *fieldValidator) validateRange(n float64, path string) []FieldError {
+	if fv.min != nil && n < *fv.min {
+		return []FieldError{{Field: path, Code: RuleMin, Message: fmt.Sprintf("%s以上である必要があります", formatNumber(*fv.min))}}
+	}
+	if fv.max != nil && n > *fv.max {
+		return []FieldError{{Field: path, Code: RuleMax, Message: fmt.Sprintf("%s以下である必要があります", formatNumber(*fv.max))}}
+	}
+	return nil
+}
+
+// validateFloatRange は validateRange に加えて、min・max を指定したフィールドの NaN と ±Inf を拒否します
+// NaN はどの比較も false になるため、validateRange だけでは範囲の検証を通過してしまいます
+func (fv *fieldValidator) validateFloatRange(n float64, path string) []FieldError {
+	if !math.IsNaN(n) && !math.IsInf(n, 0) {
+		return fv.validateRange(n, path)
+	}
+	switch {
+	case fv.min != nil:
+		return []FieldError{{Field: path, Code: RuleMin, Message: fmt.Sprintf("%s以上の有限の数値である必要があります", formatNumber(*fv.min))}}
+	case fv.max != nil:
+		return []FieldError{{Field: path, Code: RuleMax, Message: fmt.Sprintf("%s以下の有限の数値である必要があります", formatNumber(*fv.max))}}
+	}
+	return nil
+}
+
+func (fv *fieldValidator) validateLength(n int, path, unit string) []FieldError {
+	if fv.min != nil && float64(n) < *fv.min {
+		return []FieldError{{Field: path, Code: RuleMin, Message: fmt.Sprintf("%s%s以上である必要があります", formatNumber(*fv.min), unit)}}
+	}
+	if fv.max != nil && float64(n) > *fv.max {
+		return []FieldError{{Field: path, Code: RuleMax, Message: fmt.Sprintf("%s%s以下である必要があります", formatNumber(*fv.max), unit)}}
+	}
+	return nil
+}
+
+func (fv *fieldValidator) validateOneOf(s, path string) []FieldError {
+	if len(fv.oneOf) == 0 {
+		return nil
+	}
+	for _, candidate := range fv.oneOf {
+		if s == candidate {
+			return nil
+		}
+	}
+	return []FieldError{{Field: path, Code: RuleOneOf, Message: fmt.Sprintf("%s のいずれかである必要があります", strings.Join(fv.oneOf, ", "))}}
+}
+
+func (fv *fieldValidator) validateFile(fh *multipart.FileHeader, path string) []FieldError {
+	if fh == nil {
+		return nil
+	}
+
+	var details []FieldError
+	if fv.maxSize > 0 && fh.Size > fv.maxSize {
+		details = append(details, FieldError{Field: path, Code: RuleMaxSize, Message: fmt.Sprintf("ファイルサイズは%dバイト以下である必要があります", fv.maxSize)})
+	}
+	if len(fv.mimes) > 0 {
+		contentType := detectFileContentType(fh)
+		if !matchMIME(contentType, fv.mimes) {
+			details = append(details, FieldError{Field: path, Code: RuleMIME, Message: fmt.Sprintf("ファイル形式は %s のいずれかである必要があります", strings.Join(fv.mimes, ", "))})
+		}
+	}
+	return details
+}
+
+// detectFileContentType はファイルの先頭バイトからMIMEタイプを判定します
+// 判定できない場合はクライアントが送信したContent-Typeを使用します
+func detectFileContentType(fh *multipart.FileHeader) string {
+	declared := fh.Header.Get("Content-Type")
+
+	f, err := fh.Open()
+	if err != nil {
+		return declared
+	}
+	defer f.Close()
+
+	buf := make([]byte, 512)
+	n, _ := f.Read(buf)
+	detected := http.DetectContentType(buf[:n])
+	if detected == "application/octet-stream" && declared != "" {
+		// HEICなど標準ライブラリで判定できない形式はヘッダーの値を信用する
+		return declared
+	}
+	return detected
+}
+
+// matchMIME はMIMEタイプが許可リスト（"image/*" のようなワイルドカードを含む）に一致するかを判定します
+func matchMIME(contentType string, allowed []string) bool {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+	for _, a := range allowed {
+		a = strings.ToLower(a)
+		if a == mediaType {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(a, "/*"); ok && strings.HasPrefix(mediaType, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func formatNumber(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
@@ -0,0 +1,319 @@
+package outorouter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// ErrWebSocketClosed はクライアントがWebSocket接続を閉じた場合に返されるエラーです
+var ErrWebSocketClosed = errors.New("websocket connection closed")
+
+type WebSocketHandlerFunc[In RequestObject, Out ResponseObject] func(ctx context.Context, stream *WebSocketStream[In, Out]) error
+
+// WebSocketEndpoint はWebSocketでJSONフレームを双方向にやり取りするためのエンドポイントです
+// クライアントからのフレームは In としてデコード・バリデーションされ、サーバーからは Out を送信します
+type WebSocketEndpoint[In RequestObject, Out any] struct {
+	Domain     string
+	Version    uint8
+	MethodName string
+
+	Summary     string
+	Description string
+	Tags        []Tag
+
+	Handler WebSocketHandlerFunc[In, Out]
+
+	// Errors はハンドラーが返しうるエラーです（メタデータ・クライアント生成用）
+	Errors []HTTPError
+	// LintIgnore はこのエンドポイントで抑制するlintルールのIDまたは名前です
+	LintIgnore []string
+
+	// Auth はエンドポイントの認証の要否です（空の場合は AuthNone）
+	// 認証・スコープの検証はハンドシェイクのリクエストに対して行い、失敗した場合はUpgradeせずにエラーレスポンスを返します
+	Auth AuthRequirement
+	// Scopes は認証された Principal に必要なスコープです（Auth が AuthRequired の場合のみ指定できます）
+	Scopes []string
+
+	// Middlewares はこのエンドポイントにのみ適用するミドルウェアです（レート制限など）
+	// グローバル・グループのミドルウェアの内側で、記述した順にハンドシェイクのリクエストに適用されます
+	Middlewares []MiddlewareFunc
+
+	// CheckOrigin はOriginヘッダーを検証する関数です
+	// nilの場合はOriginとHostが一致する場合のみ許可します
+	CheckOrigin func(r *http.Request) bool
+
+	// ReadLimit はクライアントから受信する1フレームの最大サイズ（バイト）です
+	ReadLimit int64
+
+	// PingInterval はサーバーから送信するPingの間隔です
+	PingInterval time.Duration
+}
+
+func (w WebSocketEndpoint[In, Out]) GetFullPath() string {
+	return fmt.Sprintf("/%s/%s/%s", w.Domain, w.GetVersionWithPrefix(), w.MethodName)
+}
+
+func (w WebSocketEndpoint[In, Out]) GetDomain() string {
+	return w.Domain
+}
+
+func (w WebSocketEndpoint[In, Out]) GetVersion() uint8 {
+	return w.Version
+}
+
+func (w WebSocketEndpoint[In, Out]) GetVersionWithPrefix() string {
+	return fmt.Sprintf("v%d", w.Version)
+}
+
+// WebSocketStream は1本のWebSocket接続上で型付きのフレームを送受信します
+type WebSocketStream[In RequestObject, Out any] struct {
+	conn *websocket.Conn
+
+	writeMu      sync.Mutex
+	writeTimeout time.Duration
+
+	incoming chan []byte
+	done     chan struct{}
+	readErr  error
+}
+
+// Receive はクライアントから次のフレームを受信し、デコードとバリデーションを行います
+// 接続が閉じられた場合は ErrWebSocketClosed を返します
+func (s *WebSocketStream[In, Out]) Receive(ctx context.Context) (*In, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case data, ok := <-s.incoming:
+		if !ok {
+			return nil, s.readErr
+		}
+
+		var request In
+		if err := json.Unmarshal(data, &request); err != nil {
+			return nil, BadRequestError(ErrorCodeInvalidFrame, "フレームのJSON形式が不正です")
+		}
+		if err := validateRequest(&request); err != nil {
+			return nil, err
+		}
+		return &request, nil
+	}
+}
+
+// Send はサーバーからクライアントへフレームを送信します
+// 複数のゴルーチンから同時に呼び出しても安全です
+func (s *WebSocketStream[In, Out]) Send(out *Out) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	_ = s.conn.SetWriteDeadline(time.Now().Add(s.writeTimeout))
+	if err := s.conn.WriteJSON(out); err != nil {
+		return fmt.Errorf("failed to write websocket frame: %w", err)
+	}
+	return nil
+}
+
+// Done はクライアントとの接続が切れた時に閉じられるチャネルを返します
+func (s *WebSocketStream[In, Out]) Done() <-chan struct{} {
+	return s.done
+}
+
+// sendError はHTTPと共通のエラーレスポンス形式でエラーフレームを送信します
+func (s *WebSocketStream[In, Out]) sendError(ctx context.Context, httpErr HTTPError) {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	_ = s.conn.SetWriteDeadline(time.Now().Add(s.writeTimeout))
+	_ = s.conn.WriteJSON(newErrorResponse(ctx, httpErr))
+}
+
+// readLoop はクライアントからのフレームを読み続け、incomingへ流します
+// 読み込みに失敗した場合（切断を含む）はcancelを呼び出してハンドラーに終了を伝えます
+func (s *WebSocketStream[In, Out]) readLoop(ctx context.Context, cancel context.CancelFunc) {
+	defer cancel()
+	defer close(s.done)
+	defer close(s.incoming)
+
+	for {
+		messageType, data, err := s.conn.ReadMessage()
+		if err != nil {
+			if websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) || errors.Is(err, io.EOF) {
+				s.readErr = ErrWebSocketClosed
+			} else {
+				s.readErr = fmt.Errorf("%w: %v", ErrWebSocketClosed, err)
+			}
+			return
+		}
+
+		// テキストフレーム以外は無視する
+		if messageType != websocket.TextMessage {
+			continue
+		}
+
+		select {
+		case s.incoming <- data:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// RegisterWebSocketEndpoint はWebSocketでJSONフレームをやり取りするエンドポイントを登録します
+func RegisterWebSocketEndpoint[In RequestObject, Out any](
+	r *Router,
+	ep WebSocketEndpoint[In, Out],
+) {
+	readLimit := ep.ReadLimit
+	if readLimit == 0 {
+		// デフォルトは1MB
+		readLimit = 1024 * 1024
+	}
+
+	pingInterval := ep.PingInterval
+	if pingInterval == 0 {
+		pingInterval = 30 * time.Second
+	}
+	// Pongの待ち時間はPing間隔より長くする
+	pongWait := pingInterval * 2
+	writeTimeout := 10 * time.Second
+
+	upgrader := websocket.Upgrader{
+		ReadBufferSize:  4096,
+		WriteBufferSize: 4096,
+		CheckOrigin:     ep.CheckOrigin,
+		// トークンをサブプロトコルで渡された場合、ブラウザは応答に同じサブプロトコルがないと接続を閉じる
+		Subprotocols: []string{webSocketTokenProtocol},
+	}
+
+	h := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		// WebSocketのハンドシェイクはGETメソッドで行われる
+		if req.Method != http.MethodGet {
+			WriteError(w, req, NewHTTPError(http.StatusMethodNotAllowed, ErrorCodeMethodNotAllowed, "指定した形式のリクエストではありません"))
+			return
+		}
+
+		if !websocket.IsWebSocketUpgrade(req) {
+			WriteError(w, req, BadRequestError(ErrorCodeInvalidRequest, "WebSocketのUpgradeリクエストではありません"))
+			return
+		}
+
+		// Upgrade失敗時はupgraderがエラーレスポンスを返す
+		conn, err := upgrader.Upgrade(w, req, nil)
+		if err != nil {
+			if r.logger != nil {
+				r.logger.Error(req.Context(), "WebSocketのUpgradeに失敗しました", map[string]any{
+					"error": err.Error(),
+					"path":  req.URL.Path,
+				})
+			}
+			return
+		}
+		defer conn.Close()
+
+		conn.SetReadLimit(readLimit)
+		_ = conn.SetReadDeadline(time.Now().Add(pongWait))
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(pongWait))
+		})
+
+		// ハンドラーのコンテキストは接続が切れた時点でキャンセルされる
+		ctx, cancel := context.WithCancel(req.Context())
+		defer cancel()
+
+		stream := &WebSocketStream[In, Out]{
+			conn:         conn,
+			writeTimeout: writeTimeout,
+			incoming:     make(chan []byte, 16),
+			done:         make(chan struct{}),
+		}
+		go stream.readLoop(ctx, cancel)
+
+		// 定期的にPingを送信して接続を維持する
+		go func() {
+			ticker := time.NewTicker(pingInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeTimeout)); err != nil {
+						return
+					}
+				}
+			}
+		}()
+
+		closeCode := websocket.CloseNormalClosure
+		closeText := ""
+
+		if err := ep.Handler(ctx, stream); err != nil && !errors.Is(err, ErrWebSocketClosed) && !errors.Is(err, context.Canceled) {
+			var httpErr HTTPError
+			if errors.As(err, &httpErr) {
+				stream.sendError(ctx, httpErr)
+				closeCode = websocket.ClosePolicyViolation
+				closeText = httpErr.Code()
+			} else {
+				// ロガーが設定されている場合はエラーをログに出力
+				if r.logger != nil {
+					r.logger.Error(ctx, "ハンドラーでエラーが発生しました", map[string]any{
+						"error":  err.Error(),
+						"path":   req.URL.Path,
+						"method": req.Method,
+					})
+				}
+				stream.sendError(ctx, internalError())
+				closeCode = websocket.CloseInternalServerErr
+				closeText = ErrorCodeInternal
+			}
+		}
+
+		_ = conn.WriteControl(
+			websocket.CloseMessage,
+			websocket.FormatCloseMessage(closeCode, closeText),
+			time.Now().Add(writeTimeout),
+		)
+	})
+
+	mws := r.endpointMiddlewares(ep.GetFullPath(), ep.Auth, ep.Scopes, ep.Middlewares)
+	r.addRoute(http.MethodGet, ep.GetFullPath(), "", newRouteEntry(ep.Domain, ep.Version, mws, h))
+
+	// リクエスト・レスポンスモデルのメタデータ
+	var inZero In
+	var outZero Out
+
+	// validateタグの誤りは登録時に検出する
+	validatorFor(reflect.TypeOf(inZero))
+
+	internalEp := internalEndpoint{
+		Kind:             KindWebSocket,
+		Domain:           ep.Domain,
+		Version:          ep.Version,
+		MethodName:       ep.MethodName,
+		Summary:          ep.Summary,
+		Description:      ep.Description,
+		Tags:             ep.Tags,
+		HTTPMethod:       http.MethodGet,
+		Path:             ep.GetFullPath(),
+		handler:          h,
+		middlewares:      mws,
+		Auth:             ep.Auth.String(),
+		Scopes:           ep.Scopes,
+		RequestType:      reflect.TypeOf(inZero).String(),
+		ResponseType:     reflect.TypeOf(outZero).String(),
+		RequestTypeInfo:  extractTypeInfo(reflect.TypeOf(inZero)),
+		ResponseTypeInfo: extractTypeInfo(reflect.TypeOf(outZero)),
+		Errors:           append(append(standardErrors(KindWebSocket), authErrors(ep.Auth, ep.Scopes)...), ep.Errors...),
+		LintIgnore:       ep.LintIgnore,
+	}
+
+	r.addToRegistry(internalEp)
+}
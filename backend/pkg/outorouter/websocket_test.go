@@ -0,0 +1,322 @@
+package outorouter_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/kinpatsu-everyone/backend-template/pkg/outorouter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type echoRequest struct {
+	Message string `json:"message" validate:"required,max=10"`
+}
+
+func (r echoRequest) Validate() error {
+	return nil
+}
+
+type echoResponse struct {
+	Message string `json:"message"`
+	Subject string `json:"subject"`
+}
+
+// echoHandler は受信したメッセージをそのまま返します（"fail" を受信した場合はエラーを返します）
+func echoHandler(ctx context.Context, stream *outorouter.WebSocketStream[echoRequest, echoResponse]) error {
+	for {
+		req, err := stream.Receive(ctx)
+		if err != nil {
+			return err
+		}
+		if req.Message == "fail" {
+			return errors.New("database is down")
+		}
+		res := echoResponse{Message: req.Message}
+		if p, ok := outorouter.GetPrincipalFromContext(ctx); ok {
+			res.Subject = p.Subject
+		}
+		if err := stream.Send(&res); err != nil {
+			return err
+		}
+	}
+}
+
+// testVerifier は "token-<subject>" 形式のトークンを受け付け、"admin" の場合は admin スコープを付与します
+type testVerifier struct{}
+
+func (testVerifier) Verify(_ context.Context, token string) (*outorouter.Principal, error) {
+	subject, ok := strings.CutPrefix(token, "token-")
+	if !ok {
+		return nil, outorouter.ErrInvalidToken
+	}
+	p := &outorouter.Principal{Subject: subject, Method: "test"}
+	if subject == "admin" {
+		p.Scopes = []string{"admin"}
+	}
+	return p, nil
+}
+
+// newWebSocketServer は echoHandler を登録したテスト用のサーバーを起動します
+func newWebSocketServer(t *testing.T, configure func(ep *outorouter.WebSocketEndpoint[echoRequest, echoResponse])) *httptest.Server {
+	t.Helper()
+	r := outorouter.New(outorouter.WithVerifiers(testVerifier{}))
+	ep := outorouter.WebSocketEndpoint[echoRequest, echoResponse]{
+		Domain:     "echo",
+		Version:    1,
+		MethodName: "Echo",
+		Handler:    echoHandler,
+	}
+	if configure != nil {
+		configure(&ep)
+	}
+	outorouter.RegisterWebSocketEndpoint(r, ep)
+
+	srv := httptest.NewServer(r.Handler())
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// dial はサーバーのエンドポイントにWebSocketで接続します（token が空の場合は Authorization ヘッダーを付けません）
+func dial(t *testing.T, srv *httptest.Server, token string) (*websocket.Conn, *http.Response, error) {
+	t.Helper()
+	header := http.Header{}
+	if token != "" {
+		header.Set("Authorization", "Bearer "+token)
+	}
+	conn, res, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http")+"/echo/v1/Echo", header)
+	if conn != nil {
+		t.Cleanup(func() { _ = conn.Close() })
+	}
+	return conn, res, err
+}
+
+// readErrorAndClose はエラーフレームと、続けて送られるクローズフレームを読み込みます
+func readErrorAndClose(t *testing.T, conn *websocket.Conn) (outorouter.ErrorResponse, *websocket.CloseError) {
+	t.Helper()
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+
+	var errRes outorouter.ErrorResponse
+	require.NoError(t, conn.ReadJSON(&errRes))
+
+	_, _, err := conn.ReadMessage()
+	var closeErr *websocket.CloseError
+	require.ErrorAs(t, err, &closeErr)
+	return errRes, closeErr
+}
+
+func TestWebSocket_Upgradeしてフレームをやり取りする(t *testing.T) {
+	srv := newWebSocketServer(t, nil)
+
+	conn, res, err := dial(t, srv, "")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusSwitchingProtocols, res.StatusCode)
+
+	for _, message := range []string{"hello", "world"} {
+		require.NoError(t, conn.WriteJSON(echoRequest{Message: message}))
+		var got echoResponse
+		require.NoError(t, conn.ReadJSON(&got))
+		assert.Equal(t, message, got.Message)
+	}
+}
+
+func TestWebSocket_Upgradeでないリクエストは400を返す(t *testing.T) {
+	srv := newWebSocketServer(t, nil)
+
+	res, err := http.Get(srv.URL + "/echo/v1/Echo")
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	var errRes outorouter.ErrorResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&errRes))
+	assert.Equal(t, outorouter.ErrorCodeInvalidRequest, errRes.Error.Code)
+}
+
+func TestWebSocket_不正なフレームはエラーフレームを送って1008で閉じる(t *testing.T) {
+	tests := []struct {
+		name         string
+		frame        string
+		expectedCode string
+	}{
+		{name: "JSONとして不正", frame: `{"message":`, expectedCode: outorouter.ErrorCodeInvalidFrame},
+		{name: "validateタグに違反", frame: `{"message":"too long message"}`, expectedCode: outorouter.ErrorCodeValidationFailed},
+		{name: "必須フィールドがない", frame: `{}`, expectedCode: outorouter.ErrorCodeValidationFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newWebSocketServer(t, nil)
+			conn, _, err := dial(t, srv, "")
+			require.NoError(t, err)
+
+			require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(tt.frame)))
+			errRes, closeErr := readErrorAndClose(t, conn)
+
+			assert.Equal(t, tt.expectedCode, errRes.Error.Code)
+			assert.Equal(t, websocket.ClosePolicyViolation, closeErr.Code)
+			assert.Equal(t, tt.expectedCode, closeErr.Text)
+		})
+	}
+}
+
+func TestWebSocket_ハンドラーのエラーは1011で閉じる(t *testing.T) {
+	srv := newWebSocketServer(t, nil)
+	conn, _, err := dial(t, srv, "")
+	require.NoError(t, err)
+
+	require.NoError(t, conn.WriteJSON(echoRequest{Message: "fail"}))
+	errRes, closeErr := readErrorAndClose(t, conn)
+
+	assert.Equal(t, outorouter.ErrorCodeInternal, errRes.Error.Code)
+	assert.NotContains(t, errRes.Error.Message, "database is down")
+	assert.Equal(t, websocket.CloseInternalServerErr, closeErr.Code)
+}
+
+func TestWebSocket_認証とスコープはハンドシェイクで検証する(t *testing.T) {
+	tests := []struct {
+		name            string
+		auth            outorouter.AuthRequirement
+		scopes          []string
+		token           string
+		expectedStatus  int
+		expectedCode    string
+		expectedSubject string
+	}{
+		{name: "認証が必須でトークンなしは401", auth: outorouter.AuthRequired, expectedStatus: http.StatusUnauthorized, expectedCode: outorouter.ErrorCodeUnauthorized},
+		{name: "不正なトークンは401", auth: outorouter.AuthRequired, token: "invalid", expectedStatus: http.StatusUnauthorized, expectedCode: outorouter.ErrorCodeInvalidToken},
+		{name: "スコープが不足している場合は403", auth: outorouter.AuthRequired, scopes: []string{"admin"}, token: "token-user-1", expectedStatus: http.StatusForbidden, expectedCode: outorouter.ErrorCodeForbidden},
+		{name: "認証されたPrincipalをハンドラーで使える", auth: outorouter.AuthRequired, token: "token-user-1", expectedStatus: http.StatusSwitchingProtocols, expectedSubject: "user-1"},
+		{name: "スコープを持つ場合はUpgradeする", auth: outorouter.AuthRequired, scopes: []string{"admin"}, token: "token-admin", expectedStatus: http.StatusSwitchingProtocols, expectedSubject: "admin"},
+		{name: "認証が任意でトークンなしはUpgradeする", auth: outorouter.AuthOptional, expectedStatus: http.StatusSwitchingProtocols},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newWebSocketServer(t, func(ep *outorouter.WebSocketEndpoint[echoRequest, echoResponse]) {
+				ep.Auth = tt.auth
+				ep.Scopes = tt.scopes
+			})
+
+			conn, res, err := dial(t, srv, tt.token)
+			require.NotNil(t, res)
+			assert.Equal(t, tt.expectedStatus, res.StatusCode)
+			if tt.expectedStatus != http.StatusSwitchingProtocols {
+				require.ErrorIs(t, err, websocket.ErrBadHandshake)
+				var errRes outorouter.ErrorResponse
+				require.NoError(t, json.NewDecoder(res.Body).Decode(&errRes))
+				assert.Equal(t, tt.expectedCode, errRes.Error.Code)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NoError(t, conn.WriteJSON(echoRequest{Message: "hello"}))
+			var got echoResponse
+			require.NoError(t, conn.ReadJSON(&got))
+			assert.Equal(t, tt.expectedSubject, got.Subject)
+		})
+	}
+}
+
+func TestWebSocket_サブプロトコルで渡したトークンで認証する(t *testing.T) {
+	tests := []struct {
+		name           string
+		protocols      []string
+		expectedStatus int
+	}{
+		{name: "bearerとトークンを指定するとUpgradeする", protocols: []string{"bearer", "token-user-1"}, expectedStatus: http.StatusSwitchingProtocols},
+		{name: "トークンがない場合は401", protocols: []string{"bearer"}, expectedStatus: http.StatusUnauthorized},
+		{name: "bearer以外のサブプロトコルは401", protocols: []string{"chat", "token-user-1"}, expectedStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newWebSocketServer(t, func(ep *outorouter.WebSocketEndpoint[echoRequest, echoResponse]) {
+				ep.Auth = outorouter.AuthRequired
+			})
+
+			dialer := websocket.Dialer{Subprotocols: tt.protocols}
+			conn, res, err := dialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http")+"/echo/v1/Echo", nil)
+			require.NotNil(t, res)
+			assert.Equal(t, tt.expectedStatus, res.StatusCode)
+			if tt.expectedStatus != http.StatusSwitchingProtocols {
+				require.ErrorIs(t, err, websocket.ErrBadHandshake)
+				return
+			}
+
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = conn.Close() })
+			// ブラウザは応答のサブプロトコルが指定したものに含まれない場合に接続を閉じる
+			assert.Equal(t, "bearer", conn.Subprotocol())
+
+			require.NoError(t, conn.WriteJSON(echoRequest{Message: "hello"}))
+			var got echoResponse
+			require.NoError(t, conn.ReadJSON(&got))
+			assert.Equal(t, "user-1", got.Subject)
+		})
+	}
+}
+
+func TestWebSocket_エンドポイントのミドルウェアでレート制限する(t *testing.T) {
+	srv := newWebSocketServer(t, func(ep *outorouter.WebSocketEndpoint[echoRequest, echoResponse]) {
+		ep.Middlewares = []outorouter.MiddlewareFunc{
+			outorouter.RateLimitMiddleware(outorouter.RateLimitConfig{
+				Name:  "echo.Echo",
+				Limit: outorouter.RateLimit{Algorithm: outorouter.FixedWindow, Limit: 1, Window: time.Minute},
+				Store: outorouter.NewMemoryRateLimitStore(),
+			}),
+		}
+	})
+
+	_, res, err := dial(t, srv, "")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusSwitchingProtocols, res.StatusCode)
+
+	_, res, err = dial(t, srv, "")
+	require.ErrorIs(t, err, websocket.ErrBadHandshake)
+	assert.Equal(t, http.StatusTooManyRequests, res.StatusCode)
+}
+
+func TestWebSocket_メタデータに認証とスコープを出力する(t *testing.T) {
+	r := outorouter.New(outorouter.WithVerifiers(testVerifier{}))
+	outorouter.RegisterWebSocketEndpoint(r, outorouter.WebSocketEndpoint[echoRequest, echoResponse]{
+		Domain:     "echo",
+		Version:    1,
+		MethodName: "Echo",
+		Handler:    echoHandler,
+		Auth:       outorouter.AuthRequired,
+		Scopes:     []string{"admin"},
+	})
+
+	eps := r.GetRegistries()["echo"][1][outorouter.KindWebSocket]
+	require.Len(t, eps, 1)
+	assert.Equal(t, "required", eps[0].Auth)
+	assert.Equal(t, []string{"admin"}, eps[0].Scopes)
+
+	var codes []string
+	for _, e := range eps[0].Errors {
+		codes = append(codes, e.Code())
+	}
+	assert.Contains(t, codes, outorouter.ErrorCodeUnauthorized)
+	assert.Contains(t, codes, outorouter.ErrorCodeForbidden)
+}
+
+func TestWebSocket_スコープだけを宣言するとpanicする(t *testing.T) {
+	r := outorouter.New()
+	assert.PanicsWithValue(t, "outorouter: /echo/v1/Echo: Scopes requires Auth to be AuthRequired", func() {
+		outorouter.RegisterWebSocketEndpoint(r, outorouter.WebSocketEndpoint[echoRequest, echoResponse]{
+			Domain:     "echo",
+			Version:    1,
+			MethodName: "Echo",
+			Handler:    echoHandler,
+			Scopes:     []string{"admin"},
+		})
+	})
+}
@@ -0,0 +1,18 @@
+package outorouter
+
+import (
+	"net/http"
+	"time"
+)
+
+// WriteDeadlineMiddleware はレスポンスの書き込み期限を延長するミドルウェアです
+// サーバー全体の WriteTimeout を短くしたまま、同期的に画像を生成するなど時間のかかるエンドポイントだけ期限を延ばします
+// 期限を変更できない ResponseWriter の場合は何もしません
+func WriteDeadlineMiddleware(timeout time.Duration) MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_ = http.NewResponseController(w).SetWriteDeadline(time.Now().Add(timeout))
+			next.ServeHTTP(w, r)
+		})
+	}
+}
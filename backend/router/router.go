@@ -2,11 +2,93 @@ package router
 
 import (
 	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/kinpatsu-everyone/backend-template/config"
 	"github.com/kinpatsu-everyone/backend-template/handler"
+	"github.com/kinpatsu-everyone/backend-template/internal/auth"
 	"github.com/kinpatsu-everyone/backend-template/pkg/outorouter"
 )
 
+// generationRateLimits は有料のGeminiモデルを呼び出すエンドポイント（分析・生成）のレート制限です
+//   - ユーザー（未認証の場合はIPアドレス）ごとに1分あたり5回（トークンバケット）
+//   - エンドポイント全体で1分あたり60回（スライディングウィンドウ）
+//   - ユーザーごとに1日あたり config.GenerationDailyQuota 回（すべての分析・生成エンドポイントの合計）
+//     バリデーションエラーなどで失敗したリクエストと、失敗した生成ジョブは数えない
+func generationRateLimits(r *outorouter.Router, name string) []outorouter.MiddlewareFunc {
+	store := r.RateLimitStore()
+	return []outorouter.MiddlewareFunc{
+		outorouter.RateLimitMiddleware(outorouter.RateLimitConfig{
+			Name:  name + ":user",
+			Limit: outorouter.RateLimit{Algorithm: outorouter.TokenBucket, Limit: 5, Window: time.Minute},
+			Key:   outorouter.RateLimitByUser,
+			Store: store,
+		}),
+		outorouter.RateLimitMiddleware(outorouter.RateLimitConfig{
+			Name:  name + ":endpoint",
+			Limit: outorouter.RateLimit{Algorithm: outorouter.SlidingWindow, Limit: 60, Window: time.Minute},
+			Key:   outorouter.RateLimitByEndpoint,
+			Store: store,
+		}),
+		outorouter.RateLimitMiddleware(outorouter.RateLimitConfig{
+			Name:          handler.GenerationQuotaName,
+			Limit:         GenerationQuota(),
+			Key:           outorouter.RateLimitByUser,
+			Store:         store,
+			Error:         outorouter.ErrQuotaExceeded,
+			RefundOnError: true,
+		}),
+	}
+}
+
+// GenerationQuota は生成エンドポイントで共有する1日の利用上限です
+func GenerationQuota() outorouter.RateLimit {
+	return outorouter.DailyQuota(config.GenerationDailyQuota)
+}
+
+// allowWebSocketOrigin はWebSocketのハンドシェイクを許可するオリジンかを返します
+// ネイティブアプリ（Originなし）・同一オリジン・CORSで許可したオリジン（Webアプリ）からの接続を許可します
+func allowWebSocketOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	if u, err := url.Parse(origin); err == nil && strings.EqualFold(u.Host, r.Host) {
+		return true
+	}
+	return slices.Contains(config.CORSAllowedOrigins, "*") || slices.Contains(config.CORSAllowedOrigins, origin)
+}
+
+// deviceRegistrationRateLimit は端末登録エンドポイントのレート制限です
+// 端末登録のたびに新しいユーザー（＝新しい1日の利用上限）が作られるため、
+// IPアドレスごとに24時間あたり config.DeviceRegistrationDailyLimit 回（スライディングウィンドウ）に制限します
+func deviceRegistrationRateLimit(r *outorouter.Router) outorouter.MiddlewareFunc {
+	return outorouter.RateLimitMiddleware(outorouter.RateLimitConfig{
+		Name:  "user.RegisterDevice:ip",
+		Limit: outorouter.RateLimit{Algorithm: outorouter.SlidingWindow, Limit: config.DeviceRegistrationDailyLimit, Window: 24 * time.Hour},
+		Key:   outorouter.RateLimitByIP,
+		Store: r.RateLimitStore(),
+	})
+}
+
+// 一覧・検索エンドポイントのレスポンスのCache-Controlです
+// 一覧は新しいMonsterの登録ですぐに変わるため短い時間だけキャッシュし、ゴミ種別の定義はデプロイまで変わらないため1日キャッシュします
+// レスポンスの画像の署名付きURLの有効期限（24時間）より短くする必要があります
+const (
+	listCacheControl            = "public, max-age=30"
+	trashCategoriesCacheControl = "public, max-age=86400"
+)
+
+// syncGenerationWriteTimeout は同期的に画像を生成するエンドポイントのレスポンスの書き込み期限です
+// サーバー全体の WriteTimeout（30秒）より長くかかるため延長します
+const syncGenerationWriteTimeout = 120 * time.Second
+
+// generationErrors はレート制限を適用したエンドポイントが返しうるエラーです
+var generationErrors = []outorouter.HTTPError{outorouter.ErrRateLimited, outorouter.ErrQuotaExceeded}
+
 func Build(r *outorouter.Router) (http.Handler, error) {
 	outorouter.RegisterUnaryJSONEndpoint(r, outorouter.UnaryJSONEndpoint[handler.HealthzRequest, handler.HealthzResponse]{
 		Domain:      "healthz",
@@ -24,9 +106,12 @@ func Build(r *outorouter.Router) (http.Handler, error) {
 		Version:     1,
 		MethodName:  "GenerateImage",
 		Summary:     "Generate Image using Gemini 3 Pro Image",
-		Description: "Generates an image from a text prompt using Google Gemini 3 Pro Image API.",
+		Description: "Generates an image from a text prompt with the configured monster AI (Google Gemini 3 Pro Image API by default).",
 		Tags:        outorouter.RegisterTags("AI", "Image"),
 		Handler:     handler.GenerateImage,
+		Auth:        outorouter.AuthOptional,
+		Middlewares: append(generationRateLimits(r, "gemini.GenerateImage"), outorouter.WriteDeadlineMiddleware(syncGenerationWriteTimeout)),
+		Errors:      outorouter.RegisterErrors(generationErrors...),
 	})
 
 	// 画像分析用単体テストエンドポイント
@@ -34,10 +119,13 @@ func Build(r *outorouter.Router) (http.Handler, error) {
 		Domain:      "gemini",
 		Version:     1,
 		MethodName:  "AnalyzeImage",
-		Summary:     "Analyze Image using Gemini",
-		Description: "Analyzes an image with a text prompt and returns text analysis results using Google Gemini API.",
+		Summary:     "Analyze Trash Bin Image",
+		Description: "Analyzes a trash bin image with the configured monster AI and returns the trash type, the confidence and the raw analysis text.",
 		Tags:        outorouter.RegisterTags("AI", "Image", "Analysis"),
 		Handler:     handler.AnalyzeImage,
+		Auth:        outorouter.AuthOptional,
+		Middlewares: generationRateLimits(r, "gemini.AnalyzeImage"),
+		Errors:      outorouter.RegisterErrors(generationErrors...),
 	})
 
 	//　画像分析と画像生成を統合したテストエンドポイント (TODO: ゴミ箱データ登録処理と統合する)
@@ -63,40 +151,181 @@ func Build(r *outorouter.Router) (http.Handler, error) {
 		Tags:        outorouter.RegisterTags("AI", "Image", "Analysis", "Generation"),
 		Handler:     handler.AnalyzeAndGenerateImageMultipart,
 		MaxMemory:   32 * 1024 * 1024, // 32MB
+		Auth:        outorouter.AuthOptional,
+		Middlewares: append(generationRateLimits(r, "gemini.AnalyzeAndGenerateImage"), outorouter.WriteDeadlineMiddleware(syncGenerationWriteTimeout)),
+		Errors:      outorouter.RegisterErrors(generationErrors...),
+	})
+
+	// 端末登録エンドポイント（匿名ユーザーの作成と認証トークンの発行）
+	outorouter.RegisterUnaryJSONEndpoint(r, outorouter.UnaryJSONEndpoint[handler.RegisterDeviceRequest, handler.RegisterDeviceResponse]{
+		Domain:      "user",
+		Version:     1,
+		MethodName:  "RegisterDevice",
+		Summary:     "Register Device",
+		Description: "Creates an anonymous user for the device and issues an API token. The token is returned only once and must be sent as a Bearer token.",
+		Tags:        outorouter.RegisterTags("User", "Auth"),
+		Handler:     handler.RegisterDevice,
+		Middlewares: []outorouter.MiddlewareFunc{deviceRegistrationRateLimit(r)},
+		Errors:      outorouter.RegisterErrors(outorouter.ErrRateLimited),
 	})
 
-	// Monster登録エンドポイント
+	// Monster登録エンドポイント（認証されている場合は所有者を記録する）
 	outorouter.RegisterMultipartEndpoint(r, outorouter.MultipartEndpoint[handler.CreateMonsterRequest, handler.CreateMonsterResponse]{
 		Domain:      "monster",
 		Version:     1,
 		MethodName:  "CreateMonster",
 		Summary:     "Create Monster",
-		Description: "Creates a new monster by analyzing a trash bin image, generating a monster character, and persisting the monster data. Returns the monster ID.",
+		Description: "Creates a new monster and queues a generation job that analyzes the trash bin image and generates a monster character asynchronously. When authenticated, the monster is owned by the user. Returns the monster ID and the job ID to watch with WatchMonsterGeneration or poll with GetMonsterGenerationStatus.",
 		Tags:        outorouter.RegisterTags("Monster", "AI", "Image"),
 		Handler:     handler.CreateMonster,
 		MaxMemory:   32 * 1024 * 1024, // 32MB
+		Auth:        outorouter.AuthOptional,
+		Middlewares: generationRateLimits(r, "monster.CreateMonster"),
+		Errors:      outorouter.RegisterErrors(append(generationErrors, handler.ErrStorageUnavailable)...),
 	})
 
-	// Monster一覧取得エンドポイント（生成画像）
-	outorouter.RegisterUnaryJSONEndpoint(r, outorouter.UnaryJSONEndpoint[handler.GetMonstersRequest, handler.GetMonstersResponse]{
+	// Monster生成ジョブの状態取得エンドポイント（CreateMonsterの後にポーリングする）
+	outorouter.RegisterUnaryJSONEndpoint(r, outorouter.UnaryJSONEndpoint[handler.GetMonsterGenerationStatusRequest, handler.GetMonsterGenerationStatusResponse]{
+		Domain:      "monster",
+		Version:     1,
+		MethodName:  "GetMonsterGenerationStatus",
+		Summary:     "Get Monster Generation Status",
+		Description: "Returns the state of a monster generation job (queued, analyzing, generating, uploading, done, failed) with the failure reason. Jobs created by an authenticated user are only visible to that user.",
+		Tags:        outorouter.RegisterTags("Monster", "AI"),
+		Handler:     handler.GetMonsterGenerationStatus,
+		Auth:        outorouter.AuthOptional,
+		Errors:      outorouter.RegisterErrors(handler.ErrGenerationJobNotFound),
+	})
+
+	// Monster生成ジョブの状態を購読するWebSocketエンドポイント（ポーリングの代わりに使う）
+	outorouter.RegisterWebSocketEndpoint(r, outorouter.WebSocketEndpoint[handler.WatchMonsterGenerationRequest, handler.WatchMonsterGenerationResponse]{
 		Domain:      "monster",
 		Version:     1,
-		MethodName:  "GetMonsters",
-		Summary:     "Get Monsters",
-		Description: "Returns a list of all monsters with their ID, nickname, latitude, longitude, trash category, and generated monster image URL.",
+		MethodName:  "WatchMonsterGeneration",
+		Summary:     "Watch Monster Generation",
+		Description: "Streams the state of a monster generation job created by the authenticated user. Send a frame with the job ID; a frame is sent whenever the state changes and the connection is closed when the job is done or failed.",
+		Tags:        outorouter.RegisterTags("Monster", "AI"),
+		Handler:     handler.WatchMonsterGeneration,
+		Auth:        outorouter.AuthRequired,
+		CheckOrigin: allowWebSocketOrigin,
+		Errors:      outorouter.RegisterErrors(handler.ErrGenerationJobNotFound),
+	})
+
+	// 自分のMonster一覧取得エンドポイント
+	outorouter.RegisterUnaryJSONEndpoint(r, outorouter.UnaryJSONEndpoint[handler.GetMyMonstersRequest, handler.GetMyMonstersResponse]{
+		Domain:      "monster",
+		Version:     1,
+		MethodName:  "GetMyMonsters",
+		Summary:     "Get My Monsters",
+		Description: "Returns the monsters owned by the authenticated user.",
 		Tags:        outorouter.RegisterTags("Monster"),
-		Handler:     handler.GetMonsters,
+		Handler:     handler.GetMyMonsters,
+		Auth:        outorouter.AuthRequired,
+	})
+
+	// Monsterのニックネーム変更エンドポイント（所有者のみ）
+	outorouter.RegisterUnaryJSONEndpoint(r, outorouter.UnaryJSONEndpoint[handler.RenameMonsterRequest, handler.RenameMonsterResponse]{
+		Domain:      "monster",
+		Version:     1,
+		MethodName:  "RenameMonster",
+		Summary:     "Rename Monster",
+		Description: "Changes the nickname of a monster owned by the authenticated user.",
+		Tags:        outorouter.RegisterTags("Monster"),
+		Handler:     handler.RenameMonster,
+		Auth:        outorouter.AuthRequired,
+		Errors:      outorouter.RegisterErrors(handler.ErrMonsterNotFound, handler.ErrNotMonsterOwner),
+	})
+
+	// Monster削除エンドポイント（所有者のみ）
+	outorouter.RegisterUnaryJSONEndpoint(r, outorouter.UnaryJSONEndpoint[handler.DeleteMonsterRequest, handler.DeleteMonsterResponse]{
+		Domain:      "monster",
+		Version:     1,
+		MethodName:  "DeleteMonster",
+		Summary:     "Delete Monster",
+		Description: "Deletes a monster owned by the authenticated user together with its trash categories, attribute, analysis, species profile, stats, generation jobs, and stored images. Fails with 409 while a generation job for the monster is still running.",
+		Tags:        outorouter.RegisterTags("Monster"),
+		Handler:     handler.DeleteMonster,
+		Auth:        outorouter.AuthRequired,
+		Errors:      outorouter.RegisterErrors(handler.ErrMonsterNotFound, handler.ErrNotMonsterOwner, handler.ErrMonsterGenerating),
+	})
+
+	// Monsterの種族名・説明文の再生成エンドポイント（管理者のみ）
+	outorouter.RegisterUnaryJSONEndpoint(r, outorouter.UnaryJSONEndpoint[handler.RegenerateMonsterProfileRequest, handler.RegenerateMonsterProfileResponse]{
+		Domain:      "monster",
+		Version:     1,
+		MethodName:  "RegenerateMonsterProfile",
+		Summary:     "Regenerate Monster Profile",
+		Description: "Regenerates the species name and description of a monster from its saved generated image and trash analysis, and recalculates its stats. Set reroll_stats to roll the stats with a new seed. Requires the admin scope.",
+		Tags:        outorouter.RegisterTags("Monster", "AI", "Admin"),
+		Handler:     handler.RegenerateMonsterProfile,
+		Auth:        outorouter.AuthRequired,
+		Scopes:      []string{auth.ScopeAdmin},
+		Errors:      outorouter.RegisterErrors(handler.ErrMonsterNotFound, handler.ErrImageNotFound),
+	})
+
+	// Monster一覧取得エンドポイント（生成画像）
+	outorouter.RegisterUnaryJSONEndpoint(r, outorouter.UnaryJSONEndpoint[handler.GetMonstersRequest, handler.GetMonstersResponse]{
+		Domain:       "monster",
+		Version:      1,
+		MethodName:   "GetMonsters",
+		HTTPMethod:   http.MethodGet,
+		Summary:      "Get Monsters",
+		Description:  "Returns a list of all monsters with their ID, nickname, latitude, longitude, trash category name and slug, attribute, dominant color code, species name, description, stats, and generated monster image URL.",
+		Tags:         outorouter.RegisterTags("Monster"),
+		Handler:      handler.GetMonsters,
+		CacheControl: listCacheControl,
+	})
+
+	// 周辺のMonster検索エンドポイント（地図・近くのモンスター用）
+	outorouter.RegisterUnaryJSONEndpoint(r, outorouter.UnaryJSONEndpoint[handler.SearchMonstersNearbyRequest, handler.SearchMonstersNearbyResponse]{
+		Domain:       "monster",
+		Version:      1,
+		MethodName:   "SearchMonstersNearby",
+		HTTPMethod:   http.MethodGet,
+		Summary:      "Search Monsters Nearby",
+		Description:  "Returns monsters within radius_m meters of the given latitude and longitude, nearest first, with their haversine distance. Optionally filters by trash category slug. Candidates are pre-filtered by a bounding box on the location index.",
+		Tags:         outorouter.RegisterTags("Monster"),
+		Handler:      handler.SearchMonstersNearby,
+		CacheControl: listCacheControl,
+	})
+
+	// 範囲内のMonster取得エンドポイント（地図の表示範囲用）
+	outorouter.RegisterUnaryJSONEndpoint(r, outorouter.UnaryJSONEndpoint[handler.GetMonstersInBoundsRequest, handler.GetMonstersInBoundsResponse]{
+		Domain:       "monster",
+		Version:      1,
+		MethodName:   "GetMonstersInBounds",
+		HTTPMethod:   http.MethodGet,
+		Summary:      "Get Monsters In Bounds",
+		Description:  "Returns monsters inside the box given by its south-west (sw_latitude, sw_longitude) and north-east (ne_latitude, ne_longitude) corners, newest first. A box whose ne_longitude is smaller than its sw_longitude crosses the antimeridian. Optionally filters by trash category slug.",
+		Tags:         outorouter.RegisterTags("Monster"),
+		Handler:      handler.GetMonstersInBounds,
+		CacheControl: listCacheControl,
 	})
 
 	// ゴミ箱一覧取得エンドポイント（元画像）
 	outorouter.RegisterUnaryJSONEndpoint(r, outorouter.UnaryJSONEndpoint[handler.GetTrashsRequest, handler.GetTrashsResponse]{
-		Domain:      "trash",
-		Version:     1,
-		MethodName:  "GetTrashs",
-		Summary:     "Get Trashs",
-		Description: "Returns a list of all trash bins with their ID, nickname, latitude, longitude, trash category, and original trash bin image URL.",
-		Tags:        outorouter.RegisterTags("Trash"),
-		Handler:     handler.GetTrashs,
+		Domain:       "trash",
+		Version:      1,
+		MethodName:   "GetTrashs",
+		HTTPMethod:   http.MethodGet,
+		Summary:      "Get Trashs",
+		Description:  "Returns a list of all trash bins with their ID, nickname, latitude, longitude, trash category name and slug, and original trash bin image URL.",
+		Tags:         outorouter.RegisterTags("Trash"),
+		Handler:      handler.GetTrashs,
+		CacheControl: listCacheControl,
+	})
+
+	// ゴミ種別一覧取得エンドポイント（クライアントの表示・フィルター用）
+	outorouter.RegisterUnaryJSONEndpoint(r, outorouter.UnaryJSONEndpoint[handler.GetTrashCategoriesRequest, handler.GetTrashCategoriesResponse]{
+		Domain:       "trash",
+		Version:      1,
+		MethodName:   "GetTrashCategories",
+		HTTPMethod:   http.MethodGet,
+		Summary:      "Get Trash Categories",
+		Description:  "Returns the supported trash categories with their ID, Japanese name, English slug, aliases, monster attribute, and color code.",
+		Tags:         outorouter.RegisterTags("Trash"),
+		Handler:      handler.GetTrashCategories,
+		CacheControl: trashCategoriesCacheControl,
 	})
 
 	// Monster一件取得エンドポイント
@@ -104,10 +333,42 @@ func Build(r *outorouter.Router) (http.Handler, error) {
 		Domain:      "monster",
 		Version:     1,
 		MethodName:  "GetMonster",
+		HTTPMethod:  http.MethodGet,
+		Path:        "/monster/v1/monsters/{id}",
 		Summary:     "Get Monster",
-		Description: "Returns a single monster by ID with its nickname, latitude, longitude, trash category, and image URL.",
+		Description: "Returns a single monster by ID with its nickname, latitude, longitude, trash category name and slug, attribute, dominant color code, species name, description, stats, and image URL.",
 		Tags:        outorouter.RegisterTags("Monster"),
 		Handler:     handler.GetMonster,
+		Errors:      outorouter.RegisterErrors(handler.ErrMonsterNotFound),
+	})
+
+	// Monster画像ダウンロードエンドポイント（署名付きURLが利用できない場合のフォールバック）
+	outorouter.RegisterFileDownloadEndpoint(r, outorouter.FileDownloadEndpoint[handler.DownloadMonsterImageRequest]{
+		Domain:       "monster",
+		Version:      1,
+		MethodName:   "DownloadMonsterImage",
+		Summary:      "Download Monster Image",
+		Description:  "Streams the generated monster image or the original trash bin image through the API. Supports Range and ETag conditional requests.",
+		Tags:         outorouter.RegisterTags("Monster", "Image"),
+		Handler:      handler.DownloadMonsterImage,
+		ETag:         handler.DownloadMonsterImageETag,
+		CacheControl: "private, max-age=86400",
+		Errors:       outorouter.RegisterErrors(handler.ErrMonsterNotFound, handler.ErrImageNotFound, handler.ErrStorageUnavailable),
+	})
+
+	// 署名付きURLによる画像取得エンドポイント（ローカル・メモリのストレージ用）
+	// internal/blob の ObjectPath と同じパスになるように登録する
+	outorouter.RegisterFileDownloadEndpoint(r, outorouter.FileDownloadEndpoint[handler.GetStorageObjectRequest]{
+		Domain:       "storage",
+		Version:      1,
+		MethodName:   "GetStorageObject",
+		Summary:      "Get Storage Object",
+		Description:  "Serves an image through a signed URL issued by the local or in-memory blob store. The URL expires after the time in the expires parameter.",
+		Tags:         outorouter.RegisterTags("Image"),
+		Handler:      handler.GetStorageObject,
+		ETag:         handler.GetStorageObjectETag,
+		CacheControl: "private, max-age=3600",
+		Errors:       outorouter.RegisterErrors(handler.ErrInvalidSignedURL, handler.ErrSignedURLExpired, handler.ErrImageNotFound, handler.ErrStorageUnavailable),
 	})
 
 	return r.Handler(), nil
@@ -1,11 +1,29 @@
 package router
 
 import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"net/netip"
+	"net/url"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/alicebob/miniredis/v2"
+	"github.com/kinpatsu-everyone/backend-template/config"
+	"github.com/kinpatsu-everyone/backend-template/internal/blob"
+	"github.com/kinpatsu-everyone/backend-template/internal/monsterai"
+	"github.com/kinpatsu-everyone/backend-template/internal/redis"
 	"github.com/kinpatsu-everyone/backend-template/pkg/outorouter"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -99,19 +117,19 @@ func TestBuild_POSTメソッドのみ受け付ける(t *testing.T) {
 			expectedStatus: http.StatusOK,
 		},
 		{
-			name:           "GETメソッドは404を返す（ルートが登録されていない）",
+			name:           "GETメソッドは405を返す（Allowヘッダーに登録済みのメソッド）",
 			method:         http.MethodGet,
-			expectedStatus: http.StatusNotFound,
+			expectedStatus: http.StatusMethodNotAllowed,
 		},
 		{
-			name:           "PUTメソッドは404を返す（ルートが登録されていない）",
+			name:           "PUTメソッドは405を返す（Allowヘッダーに登録済みのメソッド）",
 			method:         http.MethodPut,
-			expectedStatus: http.StatusNotFound,
+			expectedStatus: http.StatusMethodNotAllowed,
 		},
 		{
-			name:           "DELETEメソッドは404を返す（ルートが登録されていない）",
+			name:           "DELETEメソッドは405を返す（Allowヘッダーに登録済みのメソッド）",
 			method:         http.MethodDelete,
-			expectedStatus: http.StatusNotFound,
+			expectedStatus: http.StatusMethodNotAllowed,
 		},
 	}
 
@@ -124,6 +142,9 @@ func TestBuild_POSTメソッドのみ受け付ける(t *testing.T) {
 			handler.ServeHTTP(w, req)
 
 			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusMethodNotAllowed {
+				assert.Equal(t, http.MethodPost, w.Header().Get("Allow"))
+			}
 		})
 	}
 }
@@ -194,3 +215,1553 @@ func TestBuild_正しいJSONレスポンスを返す(t *testing.T) {
 	assert.Contains(t, body, `"status"`)
 	assert.Contains(t, body, `"message"`)
 }
+
+func TestBuild_エラーは共通のエラーレスポンス形式で返す(t *testing.T) {
+	router := outorouter.New()
+	handler, err := Build(router)
+
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		contentType    string
+		body           string
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name:           "存在しないパスはNOT_FOUND",
+			method:         http.MethodPost,
+			path:           "/notfound/v1/Test",
+			contentType:    "application/json",
+			body:           "{}",
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   outorouter.ErrorCodeNotFound,
+		},
+		{
+			name:           "JSON以外のContent-TypeはUNSUPPORTED_MEDIA_TYPE",
+			method:         http.MethodPost,
+			path:           "/healthz/v1/Healthz",
+			contentType:    "text/plain",
+			body:           "{}",
+			expectedStatus: http.StatusUnsupportedMediaType,
+			expectedCode:   outorouter.ErrorCodeUnsupportedMediaType,
+		},
+		{
+			name:           "不正なJSONはINVALID_JSON",
+			method:         http.MethodPost,
+			path:           "/monster/v1/GetMonsterGenerationStatus",
+			contentType:    "application/json",
+			body:           "{",
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   outorouter.ErrorCodeInvalidJSON,
+		},
+		{
+			name:           "バリデーションエラーはVALIDATION_FAILED",
+			method:         http.MethodPost,
+			path:           "/monster/v1/GetMonsterGenerationStatus",
+			contentType:    "application/json",
+			body:           "{}",
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   outorouter.ErrorCodeValidationFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Contains(t, w.Header().Get("Content-Type"), "application/json")
+
+			var res outorouter.ErrorResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+			assert.Equal(t, tt.expectedCode, res.Error.Code)
+			assert.NotEmpty(t, res.Error.Message)
+		})
+	}
+}
+
+func TestBuild_validateタグの違反はフィールド単位で返す(t *testing.T) {
+	router := outorouter.New()
+	handler, err := Build(router)
+
+	require.NoError(t, err)
+
+	newMultipart := func(t *testing.T, fields map[string]string) (string, *bytes.Buffer) {
+		t.Helper()
+		body := &bytes.Buffer{}
+		mw := multipart.NewWriter(body)
+		for k, v := range fields {
+			require.NoError(t, mw.WriteField(k, v))
+		}
+		require.NoError(t, mw.Close())
+		return mw.FormDataContentType(), body
+	}
+
+	tests := []struct {
+		name            string
+		method          string // 空の場合はPOST
+		path            string
+		build           func(t *testing.T) (string, io.Reader)
+		expectedDetails []outorouter.FieldError
+	}{
+		{
+			name: "必須項目が空",
+			path: "/monster/v1/GetMonsterGenerationStatus",
+			build: func(t *testing.T) (string, io.Reader) {
+				return "application/json", strings.NewReader(`{}`)
+			},
+			expectedDetails: []outorouter.FieldError{
+				{Field: "job_id", Code: "required"},
+			},
+		},
+		{
+			name:   "パスパラメータの最大長超過",
+			method: http.MethodGet,
+			path:   "/monster/v1/monsters/" + strings.Repeat("a", 37),
+			build: func(t *testing.T) (string, io.Reader) {
+				return "", nil
+			},
+			expectedDetails: []outorouter.FieldError{
+				{Field: "id", Code: "max"},
+			},
+		},
+		{
+			name: "緯度・経度の範囲外と画像なし",
+			path: "/monster/v1/CreateMonster",
+			build: func(t *testing.T) (string, io.Reader) {
+				return newMultipart(t, map[string]string{
+					"nickname":  "たろう",
+					"latitude":  "91",
+					"longitude": "-181",
+				})
+			},
+			expectedDetails: []outorouter.FieldError{
+				{Field: "latitude", Code: "max"},
+				{Field: "longitude", Code: "min"},
+				{Field: "image", Code: "required"},
+			},
+		},
+		{
+			name: "緯度・経度がNaNと無限大",
+			path: "/monster/v1/CreateMonster",
+			build: func(t *testing.T) (string, io.Reader) {
+				return newMultipart(t, map[string]string{
+					"nickname":  "たろう",
+					"latitude":  "NaN",
+					"longitude": "+Inf",
+				})
+			},
+			expectedDetails: []outorouter.FieldError{
+				{Field: "latitude", Code: "min"},
+				{Field: "longitude", Code: "min"},
+				{Field: "image", Code: "required"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			method := tt.method
+			if method == "" {
+				method = http.MethodPost
+			}
+			contentType, body := tt.build(t)
+			req := httptest.NewRequest(method, tt.path, body)
+			if contentType != "" {
+				req.Header.Set("Content-Type", contentType)
+			}
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+
+			var res outorouter.ErrorResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+			assert.Equal(t, outorouter.ErrorCodeValidationFailed, res.Error.Code)
+			require.Len(t, res.Error.Details, len(tt.expectedDetails))
+			for i, expected := range tt.expectedDetails {
+				assert.Equal(t, expected.Field, res.Error.Details[i].Field)
+				assert.Equal(t, expected.Code, res.Error.Details[i].Code)
+				assert.NotEmpty(t, res.Error.Details[i].Message)
+			}
+		})
+	}
+}
+
+func TestBuild_multipartの数値として不正な値はフィールド名を含むエラーを返す(t *testing.T) {
+	router := outorouter.New()
+	handler, err := Build(router)
+
+	require.NoError(t, err)
+
+	body := &bytes.Buffer{}
+	mw := multipart.NewWriter(body)
+	require.NoError(t, mw.WriteField("nickname", "たろう"))
+	require.NoError(t, mw.WriteField("latitude", "abc"))
+	require.NoError(t, mw.WriteField("longitude", "139.7"))
+	require.NoError(t, mw.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/monster/v1/CreateMonster", body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	// ゼロ値（緯度0）として受け付けずに400を返す
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var res outorouter.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, outorouter.ErrorCodeInvalidRequest, res.Error.Code)
+	assert.Contains(t, res.Error.Message, "latitude")
+}
+
+func TestBuild_OpenAPIドキュメントを配信できる(t *testing.T) {
+	router := outorouter.New()
+	handler, err := Build(router)
+
+	require.NoError(t, err)
+	router.RegisterCustomHandler(http.MethodGet, outorouter.OpenAPIDocumentPath, outorouter.OpenAPIHandler(router, ""))
+
+	req := httptest.NewRequest(http.MethodGet, outorouter.OpenAPIDocumentPath, nil)
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "application/json")
+
+	var doc struct {
+		OpenAPI string                     `json:"openapi"`
+		Paths   map[string]json.RawMessage `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &doc))
+	assert.Equal(t, "3.1.0", doc.OpenAPI)
+	assert.Contains(t, doc.Paths, "/healthz/v1/Healthz")
+	assert.Contains(t, doc.Paths, "/monster/v1/CreateMonster")
+	assert.NotContains(t, doc.Paths, outorouter.OpenAPIDocumentPath)
+}
+
+type routingMonsterRequest struct {
+	ID   string `path:"id" validate:"required"`
+	Lang string `query:"lang" validate:"oneof=ja en"`
+}
+
+func (r routingMonsterRequest) Validate() error { return nil }
+
+type routingLatestRequest struct{}
+
+func (r routingLatestRequest) Validate() error { return nil }
+
+// routingUnboundRequest はパスパラメータ {id} に対応するフィールドを持たないリクエストです
+type routingUnboundRequest struct {
+	Lang string `query:"lang"`
+}
+
+func (r routingUnboundRequest) Validate() error { return nil }
+
+// routingBodyRequest はGETでは受け取れないボディのフィールドを持つリクエストです
+type routingBodyRequest struct {
+	ID   string `path:"id"`
+	Name string `json:"name"`
+}
+
+func (r routingBodyRequest) Validate() error { return nil }
+
+type routingMonsterResponse struct {
+	ID   string `json:"id"`
+	Lang string `json:"lang"`
+}
+
+func TestRouter_パスパラメータとHTTPメソッドでルーティングする(t *testing.T) {
+	router := outorouter.New()
+	outorouter.RegisterUnaryJSONEndpoint(router, outorouter.UnaryJSONEndpoint[routingMonsterRequest, routingMonsterResponse]{
+		Domain:     "monster",
+		Version:    1,
+		MethodName: "FindMonster",
+		HTTPMethod: http.MethodGet,
+		Path:       "/monster/v1/monsters/{id}",
+		Handler: func(ctx context.Context, req *routingMonsterRequest) (*routingMonsterResponse, error) {
+			return &routingMonsterResponse{ID: req.ID, Lang: req.Lang}, nil
+		},
+	})
+	outorouter.RegisterUnaryJSONEndpoint(router, outorouter.UnaryJSONEndpoint[routingLatestRequest, routingMonsterResponse]{
+		Domain:     "monster",
+		Version:    1,
+		MethodName: "FindLatestMonster",
+		HTTPMethod: http.MethodGet,
+		Path:       "/monster/v1/monsters/latest",
+		Handler: func(ctx context.Context, req *routingLatestRequest) (*routingMonsterResponse, error) {
+			return &routingMonsterResponse{ID: "latest"}, nil
+		},
+	})
+	handler := router.Handler()
+
+	tests := []struct {
+		name           string
+		method         string
+		target         string
+		expectedStatus int
+		expectedID     string
+		expectedLang   string
+		expectedAllow  string
+	}{
+		{
+			name:           "パスパラメータとクエリ文字列をリクエストにセットする",
+			method:         http.MethodGet,
+			target:         "/monster/v1/monsters/abc?lang=ja",
+			expectedStatus: http.StatusOK,
+			expectedID:     "abc",
+			expectedLang:   "ja",
+		},
+		{
+			name:           "静的なセグメントはパスパラメータより優先する",
+			method:         http.MethodGet,
+			target:         "/monster/v1/monsters/latest",
+			expectedStatus: http.StatusOK,
+			expectedID:     "latest",
+		},
+		{
+			name:           "クエリ文字列もバリデーションする",
+			method:         http.MethodGet,
+			target:         "/monster/v1/monsters/abc?lang=fr",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "登録されていないメソッドは405を返す",
+			method:         http.MethodDelete,
+			target:         "/monster/v1/monsters/abc",
+			expectedStatus: http.StatusMethodNotAllowed,
+			expectedAllow:  http.MethodGet,
+		},
+		{
+			name:           "セグメント数が一致しないパスは404を返す",
+			method:         http.MethodGet,
+			target:         "/monster/v1/monsters/abc/extra",
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.target, nil)
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			assert.Equal(t, tt.expectedAllow, w.Header().Get("Allow"))
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var res routingMonsterResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+			assert.Equal(t, tt.expectedID, res.ID)
+			assert.Equal(t, tt.expectedLang, res.Lang)
+		})
+	}
+}
+
+func TestRouter_パスパラメータの登録ミスはpanicする(t *testing.T) {
+	tests := []struct {
+		name     string
+		register func(r *outorouter.Router)
+	}{
+		{
+			name: "パスパラメータに対応するフィールドがない",
+			register: func(r *outorouter.Router) {
+				outorouter.RegisterUnaryJSONEndpoint(r, outorouter.UnaryJSONEndpoint[routingUnboundRequest, routingMonsterResponse]{
+					Domain: "monster", Version: 1, MethodName: "FindMonster",
+					HTTPMethod: http.MethodGet, Path: "/monster/v1/monsters/{id}",
+				})
+			},
+		},
+		{
+			name: "GETのリクエストにpath・queryタグのないフィールドがある",
+			register: func(r *outorouter.Router) {
+				outorouter.RegisterUnaryJSONEndpoint(r, outorouter.UnaryJSONEndpoint[routingBodyRequest, routingMonsterResponse]{
+					Domain: "monster", Version: 1, MethodName: "FindMonster",
+					HTTPMethod: http.MethodGet, Path: "/monster/v1/monsters/{id}",
+				})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Panics(t, func() { tt.register(outorouter.New()) })
+		})
+	}
+}
+
+// recordMiddleware は呼び出された順に name を記録するミドルウェアを返します
+func recordMiddleware(name string, calls *[]string) outorouter.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			*calls = append(*calls, name)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func TestRouter_ミドルウェアは登録順に関わらずグローバルとグループとエンドポイントの順に適用する(t *testing.T) {
+	var calls []string
+	router := outorouter.New()
+	register := func(domain string, mws ...outorouter.MiddlewareFunc) {
+		outorouter.RegisterUnaryJSONEndpoint(router, outorouter.UnaryJSONEndpoint[routingLatestRequest, routingMonsterResponse]{
+			Domain:      domain,
+			Version:     1,
+			MethodName:  "Ping",
+			Middlewares: mws,
+			Handler: func(ctx context.Context, req *routingLatestRequest) (*routingMonsterResponse, error) {
+				calls = append(calls, "handler")
+				return &routingMonsterResponse{}, nil
+			},
+		})
+	}
+
+	// エンドポイントを登録した後にグローバル・グループのミドルウェアを追加する
+	register("monster", recordMiddleware("endpoint1", &calls), recordMiddleware("endpoint2", &calls))
+	register("trash")
+	router.Group("monster", 1).Use(recordMiddleware("group", &calls))
+	router.Use(recordMiddleware("global1", &calls), recordMiddleware("global2", &calls))
+	router.Group("monster", 2).Use(recordMiddleware("other-version", &calls))
+
+	tests := []struct {
+		name     string
+		path     string
+		expected []string
+	}{
+		{
+			name:     "グループ・エンドポイントのミドルウェアを持つエンドポイント",
+			path:     "/monster/v1/Ping",
+			expected: []string{"global1", "global2", "group", "endpoint1", "endpoint2", "handler"},
+		},
+		{
+			name:     "グループのミドルウェアは他のドメインに適用しない",
+			path:     "/trash/v1/Ping",
+			expected: []string{"global1", "global2", "handler"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls = nil
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader("{}"))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.Handler().ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+			assert.Equal(t, tt.expected, calls)
+		})
+	}
+
+	t.Run("メタデータに適用されるミドルウェアを出力する", func(t *testing.T) {
+		meta, err := outorouter.ExportMetadata(router)
+		require.NoError(t, err)
+		require.Len(t, meta["monster"][1], 1)
+		assert.Equal(t, []string{
+			"router.recordMiddleware",
+			"router.recordMiddleware",
+			"router.recordMiddleware",
+			"router.recordMiddleware",
+			"router.recordMiddleware",
+		}, meta["monster"][1][0].Middlewares)
+		assert.Len(t, meta["trash"][1][0].Middlewares, 2)
+	})
+}
+
+// enumLang は EnumValues でとりうる値を返す列挙型です
+type enumLang string
+
+func (enumLang) EnumValues() []string { return []string{"ja", "en"} }
+
+type enumRequest struct {
+	Lang enumLang `json:"lang"`
+}
+
+func (r enumRequest) Validate() error { return nil }
+
+func TestRouter_列挙型のフィールドはEnumValuesで検証しメタデータに出力する(t *testing.T) {
+	router := outorouter.New()
+	outorouter.RegisterUnaryJSONEndpoint(router, outorouter.UnaryJSONEndpoint[enumRequest, enumRequest]{
+		Domain:     "monster",
+		Version:    1,
+		MethodName: "Translate",
+		Handler: func(ctx context.Context, req *enumRequest) (*enumRequest, error) {
+			return req, nil
+		},
+	})
+
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+	}{
+		{name: "列挙値は受け付ける", body: `{"lang":"en"}`, expectedStatus: http.StatusOK},
+		{name: "列挙値以外は400を返す", body: `{"lang":"fr"}`, expectedStatus: http.StatusBadRequest},
+		{name: "省略した場合は検証しない", body: `{}`, expectedStatus: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/monster/v1/Translate", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.Handler().ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+		})
+	}
+
+	t.Run("メタデータにoneofルールを出力する", func(t *testing.T) {
+		meta, err := outorouter.ExportMetadata(router)
+		require.NoError(t, err)
+		require.Len(t, meta["monster"][1], 1)
+		want := []outorouter.ValidationRule{{Name: outorouter.RuleOneOf, Value: "ja en"}}
+		assert.Equal(t, want, meta["monster"][1][0].RequestTypeInfo.Fields[0].Validation)
+		assert.Equal(t, want, meta["monster"][1][0].ResponseTypeInfo.Fields[0].Validation)
+	})
+}
+
+// signHS256 はテスト用にHS256で署名したJWTを生成します
+func signHS256(t *testing.T, secret string, claims map[string]any) string {
+	t.Helper()
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+	signingInput := header + "." + base64.RawURLEncoding.EncodeToString(payload)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(signingInput))
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// fakeAPIKeyStore はハッシュ化したAPIキー → Principal のテスト用の APIKeyStore です
+type fakeAPIKeyStore map[string]*outorouter.Principal
+
+func (s fakeAPIKeyStore) LookupAPIKey(ctx context.Context, keyHash string) (*outorouter.Principal, error) {
+	p, ok := s[keyHash]
+	if !ok {
+		return nil, outorouter.ErrInvalidToken
+	}
+	return p, nil
+}
+
+func TestRouter_認証を宣言したエンドポイントはトークンを検証する(t *testing.T) {
+	const secret = "test-secret"
+	router := outorouter.New(outorouter.WithVerifiers(
+		outorouter.APIKeyVerifier{
+			Store:  fakeAPIKeyStore{outorouter.HashAPIKey("ak_valid"): {Subject: "key-user", Method: "api_key"}},
+			Prefix: "ak_",
+		},
+		outorouter.JWTVerifier{HMACSecret: []byte(secret)},
+	))
+	register := func(methodName string, auth outorouter.AuthRequirement, scopes ...string) {
+		outorouter.RegisterUnaryJSONEndpoint(router, outorouter.UnaryJSONEndpoint[routingLatestRequest, routingMonsterResponse]{
+			Domain:     "monster",
+			Version:    1,
+			MethodName: methodName,
+			Auth:       auth,
+			Scopes:     scopes,
+			Handler: func(ctx context.Context, req *routingLatestRequest) (*routingMonsterResponse, error) {
+				p, ok := outorouter.GetPrincipalFromContext(ctx)
+				if !ok {
+					return &routingMonsterResponse{ID: "anonymous"}, nil
+				}
+				return &routingMonsterResponse{ID: p.Subject, Lang: p.Method}, nil
+			},
+		})
+	}
+	register("Required", outorouter.AuthRequired)
+	register("Optional", outorouter.AuthOptional)
+	register("Scoped", outorouter.AuthRequired, "monster:write")
+	handler := router.Handler()
+
+	tests := []struct {
+		name                    string
+		path                    string
+		authorization           string
+		apiKey                  string
+		expectedStatus          int
+		expectedCode            string
+		expectedWWWAuthenticate string
+		expectedID              string
+		expectedMethod          string
+	}{
+		{
+			name:                    "トークンがない場合は401を返す",
+			path:                    "/monster/v1/Required",
+			expectedStatus:          http.StatusUnauthorized,
+			expectedCode:            outorouter.ErrorCodeUnauthorized,
+			expectedWWWAuthenticate: "Bearer",
+		},
+		{
+			name:           "有効なJWTの場合はPrincipalをコンテキストにセットする",
+			path:           "/monster/v1/Required",
+			authorization:  "Bearer " + signHS256(t, secret, map[string]any{"sub": "user-1", "exp": time.Now().Add(time.Hour).Unix()}),
+			expectedStatus: http.StatusOK,
+			expectedID:     "user-1",
+			expectedMethod: "jwt",
+		},
+		{
+			name:                    "署名が一致しないJWTは401を返す",
+			path:                    "/monster/v1/Required",
+			authorization:           "Bearer " + signHS256(t, "other-secret", map[string]any{"sub": "user-1"}),
+			expectedStatus:          http.StatusUnauthorized,
+			expectedCode:            outorouter.ErrorCodeInvalidToken,
+			expectedWWWAuthenticate: `Bearer error="invalid_token"`,
+		},
+		{
+			name:                    "期限切れのJWTは401を返す",
+			path:                    "/monster/v1/Required",
+			authorization:           "Bearer " + signHS256(t, secret, map[string]any{"sub": "user-1", "exp": time.Now().Add(-time.Hour).Unix()}),
+			expectedStatus:          http.StatusUnauthorized,
+			expectedCode:            outorouter.ErrorCodeInvalidToken,
+			expectedWWWAuthenticate: `Bearer error="invalid_token"`,
+		},
+		{
+			name:                    "expのないJWTは401を返す",
+			path:                    "/monster/v1/Required",
+			authorization:           "Bearer " + signHS256(t, secret, map[string]any{"sub": "user-1"}),
+			expectedStatus:          http.StatusUnauthorized,
+			expectedCode:            outorouter.ErrorCodeInvalidToken,
+			expectedWWWAuthenticate: `Bearer error="invalid_token"`,
+		},
+		{
+			name:                    "expが数値でないJWTは401を返す",
+			path:                    "/monster/v1/Required",
+			authorization:           "Bearer " + signHS256(t, secret, map[string]any{"sub": "user-1", "exp": "never"}),
+			expectedStatus:          http.StatusUnauthorized,
+			expectedCode:            outorouter.ErrorCodeInvalidToken,
+			expectedWWWAuthenticate: `Bearer error="invalid_token"`,
+		},
+		{
+			name:           "有効なAPIキーの場合はPrincipalをコンテキストにセットする",
+			path:           "/monster/v1/Required",
+			apiKey:         "ak_valid",
+			expectedStatus: http.StatusOK,
+			expectedID:     "key-user",
+			expectedMethod: "api_key",
+		},
+		{
+			name:                    "登録されていないAPIキーは401を返す",
+			path:                    "/monster/v1/Required",
+			authorization:           "Bearer ak_unknown",
+			expectedStatus:          http.StatusUnauthorized,
+			expectedCode:            outorouter.ErrorCodeInvalidToken,
+			expectedWWWAuthenticate: `Bearer error="invalid_token"`,
+		},
+		{
+			name:           "スコープが不足している場合は403を返す",
+			path:           "/monster/v1/Scoped",
+			authorization:  "Bearer " + signHS256(t, secret, map[string]any{"sub": "user-1", "scope": "monster:read", "exp": time.Now().Add(time.Hour).Unix()}),
+			expectedStatus: http.StatusForbidden,
+			expectedCode:   outorouter.ErrorCodeForbidden,
+		},
+		{
+			name:           "スコープを満たす場合は200を返す",
+			path:           "/monster/v1/Scoped",
+			authorization:  "Bearer " + signHS256(t, secret, map[string]any{"sub": "user-1", "scope": "monster:read monster:write", "exp": time.Now().Add(time.Hour).Unix()}),
+			expectedStatus: http.StatusOK,
+			expectedID:     "user-1",
+			expectedMethod: "jwt",
+		},
+		{
+			name:           "認証が任意の場合はトークンがなくても処理する",
+			path:           "/monster/v1/Optional",
+			expectedStatus: http.StatusOK,
+			expectedID:     "anonymous",
+		},
+		{
+			name:                    "認証が任意の場合も不正なトークンは401を返す",
+			path:                    "/monster/v1/Optional",
+			authorization:           "Bearer invalid",
+			expectedStatus:          http.StatusUnauthorized,
+			expectedCode:            outorouter.ErrorCodeInvalidToken,
+			expectedWWWAuthenticate: `Bearer error="invalid_token"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader("{}"))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			if tt.apiKey != "" {
+				req.Header.Set("X-API-Key", tt.apiKey)
+			}
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			assert.Equal(t, tt.expectedWWWAuthenticate, w.Header().Get("WWW-Authenticate"))
+			if tt.expectedStatus != http.StatusOK {
+				var res outorouter.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+				assert.Equal(t, tt.expectedCode, res.Error.Code)
+				return
+			}
+
+			var res routingMonsterResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+			assert.Equal(t, tt.expectedID, res.ID)
+			assert.Equal(t, tt.expectedMethod, res.Lang)
+		})
+	}
+
+	t.Run("メタデータに認証の要否とスコープを出力する", func(t *testing.T) {
+		meta, err := outorouter.ExportMetadata(router)
+		require.NoError(t, err)
+		endpoints := map[string]outorouter.ExportedEndpoint{}
+		for _, ep := range meta["monster"][1] {
+			endpoints[ep.MethodName] = ep
+		}
+		assert.Equal(t, "required", endpoints["Required"].Auth)
+		assert.Equal(t, "optional", endpoints["Optional"].Auth)
+		assert.Equal(t, []string{"monster:write"}, endpoints["Scoped"].Scopes)
+		assert.Equal(t, []string{"outorouter.AuthMiddleware"}, endpoints["Required"].Middlewares)
+	})
+}
+
+func TestJWTVerifier_expのないトークンはAllowMissingExpの場合のみ受け付ける(t *testing.T) {
+	const secret = "test-secret"
+	token := signHS256(t, secret, map[string]any{"sub": "user-1"})
+
+	tests := []struct {
+		name        string
+		verifier    outorouter.JWTVerifier
+		expectedErr error
+	}{
+		{name: "デフォルトは拒否する", verifier: outorouter.JWTVerifier{HMACSecret: []byte(secret)}, expectedErr: outorouter.ErrInvalidToken},
+		{name: "AllowMissingExpの場合は受け付ける", verifier: outorouter.JWTVerifier{HMACSecret: []byte(secret), AllowMissingExp: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := tt.verifier.Verify(context.Background(), token)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "user-1", p.Subject)
+		})
+	}
+}
+
+func TestRouter_認証の宣言ミスはpanicする(t *testing.T) {
+	tests := []struct {
+		name string
+		auth outorouter.AuthRequirement
+	}{
+		{name: "認証なしでスコープを宣言する", auth: outorouter.AuthNone},
+		{name: "任意の認証でスコープを宣言する", auth: outorouter.AuthOptional},
+		{name: "未知の認証の要否", auth: "always"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Panics(t, func() {
+				outorouter.RegisterUnaryJSONEndpoint(outorouter.New(), outorouter.UnaryJSONEndpoint[routingLatestRequest, routingMonsterResponse]{
+					Domain: "monster", Version: 1, MethodName: "Ping",
+					Auth: tt.auth, Scopes: []string{"monster:write"},
+				})
+			})
+		})
+	}
+}
+
+func TestRouter_レート制限を超えると429とRetryAfterを返す(t *testing.T) {
+	router := outorouter.New()
+	outorouter.RegisterUnaryJSONEndpoint(router, outorouter.UnaryJSONEndpoint[routingLatestRequest, routingMonsterResponse]{
+		Domain:     "monster",
+		Version:    1,
+		MethodName: "Generate",
+		Middlewares: []outorouter.MiddlewareFunc{
+			outorouter.RateLimitMiddleware(outorouter.RateLimitConfig{
+				Name:  "generate",
+				Limit: outorouter.RateLimit{Algorithm: outorouter.TokenBucket, Limit: 2, Window: time.Minute},
+				Store: router.RateLimitStore(),
+			}),
+			outorouter.RateLimitMiddleware(outorouter.RateLimitConfig{
+				Name:  "quota",
+				Limit: outorouter.DailyQuota(10),
+				Store: router.RateLimitStore(),
+				Error: outorouter.ErrQuotaExceeded,
+			}),
+		},
+		Handler: func(ctx context.Context, req *routingLatestRequest) (*routingMonsterResponse, error) {
+			return &routingMonsterResponse{}, nil
+		},
+	})
+	handler := router.Handler()
+
+	tests := []struct {
+		name              string
+		ip                string
+		expectedStatus    int
+		expectedRemaining string
+		expectedRetry     bool
+	}{
+		{name: "1回目は許可する", ip: "192.0.2.1", expectedStatus: http.StatusOK, expectedRemaining: "1"},
+		{name: "2回目は許可する", ip: "192.0.2.1", expectedStatus: http.StatusOK, expectedRemaining: "0"},
+		{name: "上限を超えると429を返す", ip: "192.0.2.1", expectedStatus: http.StatusTooManyRequests, expectedRemaining: "0", expectedRetry: true},
+		{name: "別のIPアドレスは制限しない", ip: "192.0.2.2", expectedStatus: http.StatusOK, expectedRemaining: "1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/monster/v1/Generate", strings.NewReader("{}"))
+			req.Header.Set("Content-Type", "application/json")
+			req.RemoteAddr = tt.ip + ":12345"
+			// 信頼するプロキシを設定していないため、X-Forwarded-For は使わない
+			req.Header.Set("X-Forwarded-For", "203.0.113.9")
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			assert.Equal(t, "2", w.Header().Get("RateLimit-Limit"))
+			assert.Equal(t, tt.expectedRemaining, w.Header().Get("RateLimit-Remaining"))
+			assert.Equal(t, "2;w=60", w.Header().Get("RateLimit-Policy"))
+			if !tt.expectedRetry {
+				assert.Empty(t, w.Header().Get("Retry-After"))
+				return
+			}
+			assert.Equal(t, "30", w.Header().Get("Retry-After"))
+			var res outorouter.ErrorResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+			assert.Equal(t, outorouter.ErrorCodeRateLimited, res.Error.Code)
+		})
+	}
+}
+
+func TestBuild_同じIPアドレスからの端末登録は上限を超えると429を返す(t *testing.T) {
+	handler, err := Build(outorouter.New())
+	require.NoError(t, err)
+
+	// DBに接続しないため、バリデーションで失敗するリクエストを送る（失敗したリクエストも登録の試行として数える）
+	body := `{"nickname":"` + strings.Repeat("a", 51) + `"}`
+	register := func(ip string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/user/v1/RegisterDevice", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.RemoteAddr = ip + ":12345"
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+
+	for i := 0; i < config.DeviceRegistrationDailyLimit; i++ {
+		w := register("192.0.2.1")
+		require.Equal(t, http.StatusBadRequest, w.Code, "request %d: %s", i, w.Body.String())
+	}
+
+	w := register("192.0.2.1")
+	require.Equal(t, http.StatusTooManyRequests, w.Code, w.Body.String())
+	assert.NotEmpty(t, w.Header().Get("Retry-After"))
+	var res outorouter.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, outorouter.ErrorCodeRateLimited, res.Error.Code)
+
+	w = register("192.0.2.2")
+	assert.Equal(t, http.StatusBadRequest, w.Code, "別のIPアドレスは制限しない")
+}
+
+func TestBuild_有料のAIモデルを呼び出すエンドポイントはレート制限を適用する(t *testing.T) {
+	router := outorouter.New()
+	_, err := Build(router)
+	require.NoError(t, err)
+
+	metadata, err := outorouter.ExportMetadata(router)
+	require.NoError(t, err)
+	middlewares := map[string][]string{}
+	for _, versions := range metadata {
+		for _, eps := range versions {
+			for _, ep := range eps {
+				middlewares[ep.Path] = ep.Middlewares
+			}
+		}
+	}
+
+	for _, path := range []string{
+		"/gemini/v1/GenerateImage",
+		"/gemini/v1/AnalyzeImage",
+		"/gemini/v1/AnalyzeAndGenerateImage",
+		"/monster/v1/CreateMonster",
+	} {
+		t.Run(path, func(t *testing.T) {
+			require.Contains(t, middlewares, path)
+			assert.Contains(t, middlewares[path], "outorouter.RateLimitMiddleware")
+		})
+	}
+}
+
+func TestBuild_画像生成と画像分析のエンドポイントはMonsterAIを使う(t *testing.T) {
+	previous := monsterai.GetMonsterAI()
+	monsterai.SetMonsterAI(monsterai.NewFake())
+	t.Cleanup(func() { monsterai.SetMonsterAI(previous) })
+
+	router := outorouter.New()
+	handler, err := Build(router)
+	require.NoError(t, err)
+
+	post := func(path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("GenerateImage", func(t *testing.T) {
+		w := post("/gemini/v1/GenerateImage", `{"prompt":"ゴミ箱に住むペンギン","model":"gemini-3-pro-image-preview"}`)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var res struct {
+			Candidates []struct {
+				Content struct {
+					Role  string `json:"role"`
+					Parts []struct {
+						Text      string `json:"text"`
+						ImageData *struct {
+							MimeType string `json:"mime_type"`
+							Data     string `json:"data"`
+						} `json:"image_data"`
+					} `json:"parts"`
+				} `json:"content"`
+				FinishReason string `json:"finish_reason"`
+			} `json:"candidates"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+		require.Len(t, res.Candidates, 1)
+		assert.Equal(t, "model", res.Candidates[0].Content.Role)
+		assert.Equal(t, "STOP", res.Candidates[0].FinishReason)
+
+		var image []byte
+		for _, part := range res.Candidates[0].Content.Parts {
+			if part.ImageData != nil {
+				assert.Equal(t, "image/png", part.ImageData.MimeType)
+				image, err = base64.StdEncoding.DecodeString(part.ImageData.Data)
+				require.NoError(t, err)
+			}
+		}
+		assert.NotEmpty(t, image)
+	})
+
+	t.Run("AnalyzeImageは非推奨のmodelを受け付けてtextを返す", func(t *testing.T) {
+		body := fmt.Sprintf(`{"image_data":%q,"mime_type":"image/png","model":"gemini-2.5-flash"}`, base64.StdEncoding.EncodeToString([]byte("trash-bin")))
+		w := post("/gemini/v1/AnalyzeImage", body)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var res analyzeImageResponseJSON
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+		assert.NotEmpty(t, res.Text)
+		assert.NotEmpty(t, res.TrashType)
+		assert.Positive(t, res.Confidence)
+	})
+}
+
+// analyzeImageResponseJSON は AnalyzeImage のレスポンスのJSONです
+type analyzeImageResponseJSON struct {
+	TrashType  string  `json:"trash_type"`
+	Confidence float64 `json:"confidence"`
+	Text       string  `json:"text"`
+}
+
+type generateRequest struct {
+	Name string `json:"name"`
+}
+
+func (r generateRequest) Validate() error { return nil }
+
+func TestRouter_RefundOnErrorは失敗したリクエストを上限に数えない(t *testing.T) {
+	router := outorouter.New()
+	var keys []string
+	outorouter.RegisterUnaryJSONEndpoint(router, outorouter.UnaryJSONEndpoint[generateRequest, routingMonsterResponse]{
+		Domain:     "monster",
+		Version:    1,
+		MethodName: "Generate",
+		Middlewares: []outorouter.MiddlewareFunc{
+			outorouter.RateLimitMiddleware(outorouter.RateLimitConfig{
+				Name:          "quota",
+				Limit:         outorouter.DailyQuota(1),
+				Store:         router.RateLimitStore(),
+				Error:         outorouter.ErrQuotaExceeded,
+				RefundOnError: true,
+			}),
+		},
+		Handler: func(ctx context.Context, req *generateRequest) (*routingMonsterResponse, error) {
+			key, _ := outorouter.GetRateLimitKeyFromContext(ctx, "quota")
+			keys = append(keys, key)
+			if req.Name == "fail" {
+				return nil, errors.New("generation failed")
+			}
+			return &routingMonsterResponse{}, nil
+		},
+	})
+	handler := router.Handler()
+
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+	}{
+		{name: "不正なリクエストは数えない", body: `{"name":`, expectedStatus: http.StatusBadRequest},
+		{name: "ハンドラーのエラーは数えない", body: `{"name":"fail"}`, expectedStatus: http.StatusInternalServerError},
+		{name: "成功したリクエストを数える", body: `{"name":"ok"}`, expectedStatus: http.StatusOK},
+		{name: "上限に達すると429を返す", body: `{"name":"ok"}`, expectedStatus: http.StatusTooManyRequests},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/monster/v1/Generate", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.RemoteAddr = "192.0.2.1:12345"
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+		})
+	}
+	assert.Equal(t, []string{"quota:ip:192.0.2.1", "quota:ip:192.0.2.1"}, keys)
+}
+
+func TestClientIPMiddleware_信頼するプロキシ経由の場合のみXForwardedForを使う(t *testing.T) {
+	trustedProxies, err := outorouter.ParseTrustedProxies([]string{"10.0.0.0/8", "192.0.2.10"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		trustedProxies []netip.Prefix
+		remoteAddr     string
+		xff            []string
+		expected       string
+	}{
+		{name: "信頼するプロキシがない場合は接続元のアドレス", remoteAddr: "198.51.100.1:1234", xff: []string{"203.0.113.9"}, expected: "198.51.100.1"},
+		{name: "接続元が信頼するプロキシでない場合は接続元のアドレス", trustedProxies: trustedProxies, remoteAddr: "198.51.100.1:1234", xff: []string{"203.0.113.9"}, expected: "198.51.100.1"},
+		{name: "信頼するプロキシが付与した末尾の値", trustedProxies: trustedProxies, remoteAddr: "10.0.0.1:1234", xff: []string{"203.0.113.9, 198.51.100.7"}, expected: "198.51.100.7"},
+		{name: "複数の信頼するプロキシを経由した場合はプロキシを除いた値", trustedProxies: trustedProxies, remoteAddr: "10.0.0.1:1234", xff: []string{"198.51.100.7, 192.0.2.10", "10.1.2.3"}, expected: "198.51.100.7"},
+		{name: "解析できない値より前は使わない", trustedProxies: trustedProxies, remoteAddr: "10.0.0.1:1234", xff: []string{"203.0.113.9, unknown, 10.1.2.3"}, expected: "10.1.2.3"},
+		{name: "X-Forwarded-Forがない場合は接続元のアドレス", trustedProxies: trustedProxies, remoteAddr: "10.0.0.1:1234", expected: "10.0.0.1"},
+		{name: "IPv6の接続元", trustedProxies: trustedProxies, remoteAddr: "[2001:db8::1]:1234", xff: []string{"203.0.113.9"}, expected: "2001:db8::1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			handler := outorouter.ClientIPMiddleware(tt.trustedProxies)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = outorouter.GetClientIPFromContext(r.Context())
+			}))
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			req.RemoteAddr = tt.remoteAddr
+			for _, xff := range tt.xff {
+				req.Header.Add("X-Forwarded-For", xff)
+			}
+
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestParseTrustedProxies_不正な値はエラーを返す(t *testing.T) {
+	_, err := outorouter.ParseTrustedProxies([]string{"10.0.0.0/8", "proxy.example.com"})
+	assert.Error(t, err)
+}
+
+func TestWriteDeadlineMiddleware_サーバーのWriteTimeoutより長くかかるレスポンスを返せる(t *testing.T) {
+	tests := []struct {
+		name        string
+		middlewares []outorouter.MiddlewareFunc
+		expectedErr bool
+	}{
+		{name: "延長しない場合は書き込めない", expectedErr: true},
+		{name: "延長した場合は書き込める", middlewares: []outorouter.MiddlewareFunc{outorouter.WriteDeadlineMiddleware(5 * time.Second)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := outorouter.New()
+			outorouter.RegisterUnaryJSONEndpoint(router, outorouter.UnaryJSONEndpoint[generateRequest, routingMonsterResponse]{
+				Domain:      "monster",
+				Version:     1,
+				MethodName:  "Generate",
+				Middlewares: tt.middlewares,
+				Handler: func(ctx context.Context, req *generateRequest) (*routingMonsterResponse, error) {
+					time.Sleep(300 * time.Millisecond)
+					return &routingMonsterResponse{ID: "1"}, nil
+				},
+			})
+			srv := httptest.NewUnstartedServer(router.Handler())
+			srv.Config.WriteTimeout = 100 * time.Millisecond
+			srv.Start()
+			t.Cleanup(srv.Close)
+
+			res, err := http.Post(srv.URL+"/monster/v1/Generate", "application/json", strings.NewReader("{}"))
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			defer res.Body.Close()
+			assert.Equal(t, http.StatusOK, res.StatusCode)
+		})
+	}
+}
+
+// rateLimitStores はメモリとRedis（miniredis）のレート制限のストアを作成します
+// 同じテストを両方のストアで実行し、Luaスクリプトがメモリの実装と同じ計算をすることを確認します
+func rateLimitStores(t *testing.T) map[string]outorouter.RateLimitStore {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(config.CacheConfig{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return map[string]outorouter.RateLimitStore{
+		"memory": outorouter.NewMemoryRateLimitStore(),
+		"redis":  &outorouter.RedisRateLimitStore{Client: client, Prefix: "test:ratelimit:"},
+	}
+}
+
+func TestRateLimitStore_アルゴリズムごとに制限する(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	type take struct {
+		after   time.Duration
+		allowed bool
+	}
+
+	tests := []struct {
+		name  string
+		limit outorouter.RateLimit
+		takes []take
+	}{
+		{
+			name:  "トークンバケットは時間経過でトークンを補充する",
+			limit: outorouter.RateLimit{Algorithm: outorouter.TokenBucket, Limit: 2, Window: time.Minute},
+			takes: []take{
+				{0, true}, {0, true}, {0, false},
+				{30 * time.Second, true}, {30 * time.Second, false},
+			},
+		},
+		{
+			name:  "スライディングウィンドウは前のウィンドウを重み付けして数える",
+			limit: outorouter.RateLimit{Algorithm: outorouter.SlidingWindow, Limit: 2, Window: time.Minute},
+			takes: []take{
+				{0, true}, {0, true}, {0, false},
+				{60 * time.Second, false},
+				{90 * time.Second, true}, {90 * time.Second, false},
+			},
+		},
+		{
+			name:  "固定ウィンドウはウィンドウが変わるとリセットする",
+			limit: outorouter.RateLimit{Algorithm: outorouter.FixedWindow, Limit: 2, Window: time.Minute},
+			takes: []take{
+				{0, true}, {0, true}, {59 * time.Second, false},
+				{60 * time.Second, true},
+			},
+		},
+		{
+			name:  "日次の上限は日付が変わるとリセットする",
+			limit: outorouter.DailyQuota(2),
+			takes: []take{
+				{0, true}, {23 * time.Hour, true}, {23 * time.Hour, false},
+				{24 * time.Hour, true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := map[string][]outorouter.RateLimitResult{}
+			for name, store := range rateLimitStores(t) {
+				for i, tk := range tt.takes {
+					result, err := store.Take(context.Background(), "key", tt.limit, start.Add(tk.after))
+					require.NoError(t, err, "%s: take %d", name, i)
+					assert.Equal(t, tk.allowed, result.Allowed, "%s: take %d", name, i)
+					if !result.Allowed {
+						assert.Positive(t, result.RetryAfter, "%s: take %d", name, i)
+					}
+					results[name] = append(results[name], result)
+				}
+			}
+			// 残り回数やリセットまでの時間もメモリとRedisで一致する
+			assert.Equal(t, results["memory"], results["redis"])
+		})
+	}
+}
+
+func TestRateLimitStore_Refundは消費した分を戻す(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		limit outorouter.RateLimit
+	}{
+		{name: "トークンバケットはトークンを1つ戻す", limit: outorouter.RateLimit{Algorithm: outorouter.TokenBucket, Limit: 1, Window: time.Hour}},
+		{name: "スライディングウィンドウは今のウィンドウの回数を戻す", limit: outorouter.RateLimit{Algorithm: outorouter.SlidingWindow, Limit: 1, Window: time.Hour}},
+		{name: "日次の上限は同じ日の回数を戻す", limit: outorouter.DailyQuota(1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, store := range rateLimitStores(t) {
+				ctx := context.Background()
+
+				result, err := store.Take(ctx, "key", tt.limit, now)
+				require.NoError(t, err, name)
+				require.True(t, result.Allowed, name)
+				result, err = store.Take(ctx, "key", tt.limit, now)
+				require.NoError(t, err, name)
+				require.False(t, result.Allowed, name)
+
+				require.NoError(t, store.Refund(ctx, "key", tt.limit, now), name)
+				result, err = store.Take(ctx, "key", tt.limit, now)
+				require.NoError(t, err, name)
+				assert.True(t, result.Allowed, name)
+			}
+		})
+	}
+}
+
+func TestRateLimitStore_日付が変わった後のRefundは新しい日の回数を減らさない(t *testing.T) {
+	now := time.Date(2026, 1, 1, 23, 0, 0, 0, time.UTC)
+	limit := outorouter.DailyQuota(1)
+
+	for name, store := range rateLimitStores(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			result, err := store.Take(ctx, "key", limit, now)
+			require.NoError(t, err)
+			require.True(t, result.Allowed)
+
+			// 翌日に消費した後、前日の分を戻しても翌日の上限は戻らない
+			next := now.Add(2 * time.Hour)
+			result, err = store.Take(ctx, "key", limit, next)
+			require.NoError(t, err)
+			require.True(t, result.Allowed)
+			require.NoError(t, store.Refund(ctx, "key", limit, now))
+			result, err = store.Take(ctx, "key", limit, next)
+			require.NoError(t, err)
+			assert.False(t, result.Allowed)
+		})
+	}
+}
+
+func TestRouter_レート制限の設定ミスはpanicする(t *testing.T) {
+	store := outorouter.NewMemoryRateLimitStore()
+	tests := []struct {
+		name   string
+		config outorouter.RateLimitConfig
+	}{
+		{name: "名前がない", config: outorouter.RateLimitConfig{Limit: outorouter.DailyQuota(1), Store: store}},
+		{name: "ストアがない", config: outorouter.RateLimitConfig{Name: "x", Limit: outorouter.DailyQuota(1)}},
+		{name: "上限が0", config: outorouter.RateLimitConfig{Name: "x", Limit: outorouter.DailyQuota(0), Store: store}},
+		{name: "未知のアルゴリズム", config: outorouter.RateLimitConfig{Name: "x", Limit: outorouter.RateLimit{Algorithm: "leaky", Limit: 1, Window: time.Second}, Store: store}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Panics(t, func() { outorouter.RateLimitMiddleware(tt.config) })
+		})
+	}
+}
+
+func TestRouter_署名付きURLで保存した画像を取得できる(t *testing.T) {
+	ctx := context.Background()
+	signer := &blob.URLSigner{BaseURL: "http://example.com", Secret: []byte("secret")}
+	store := blob.NewMemoryStore(signer)
+	blob.SetStore(store)
+	t.Cleanup(func() { blob.SetStore(nil) })
+
+	require.NoError(t, store.Put(ctx, "monsters/1/generated.png", []byte("png-data"), "image/png"))
+
+	handler, err := Build(outorouter.New())
+	require.NoError(t, err)
+
+	signedURL, err := store.SignedURL(ctx, "monsters/1/generated.png", time.Hour)
+	require.NoError(t, err)
+	missingURL, err := store.SignedURL(ctx, "monsters/2/generated.png", time.Hour)
+	require.NoError(t, err)
+	expiredURL := signer.Sign("monsters/1/generated.png", -time.Minute)
+
+	tests := []struct {
+		name           string
+		url            string
+		expectedStatus int
+		expectedCode   string
+	}{
+		{name: "署名付きURLで画像を取得できる", url: signedURL, expectedStatus: http.StatusOK},
+		{name: "署名を改ざんしたURLは403を返す", url: strings.Replace(signedURL, "monsters%2F1", "monsters%2F2", 1), expectedStatus: http.StatusForbidden, expectedCode: "INVALID_SIGNED_URL"},
+		{name: "有効期限が切れたURLは403を返す", url: expiredURL, expectedStatus: http.StatusForbidden, expectedCode: "SIGNED_URL_EXPIRED"},
+		{name: "存在しない画像は404を返す", url: missingURL, expectedStatus: http.StatusNotFound, expectedCode: "IMAGE_NOT_FOUND"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			if tt.expectedCode == "" {
+				assert.Equal(t, "image/png", w.Header().Get("Content-Type"))
+				assert.Equal(t, "png-data", w.Body.String())
+				return
+			}
+			var res outorouter.ErrorResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+			assert.Equal(t, tt.expectedCode, res.Error.Code)
+		})
+	}
+}
+
+type downloadRequest struct {
+	Name string `query:"name"`
+}
+
+func (r downloadRequest) Validate() error { return nil }
+
+func TestRouter_ファイルダウンロードはRangeと条件付きリクエストに対応する(t *testing.T) {
+	modTime := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	var handlerCalls int
+	router := outorouter.New()
+	register := func(methodName string, etag outorouter.FileDownloadETagFunc[downloadRequest]) {
+		outorouter.RegisterFileDownloadEndpoint(router, outorouter.FileDownloadEndpoint[downloadRequest]{
+			Domain:     "monster",
+			Version:    1,
+			MethodName: methodName,
+			Handler: func(ctx context.Context, req *downloadRequest) (*outorouter.FileDownloadResponseObject, error) {
+				handlerCalls++
+				name := req.Name
+				if name == "" {
+					name = "monster.png"
+				}
+				return &outorouter.FileDownloadResponseObject{
+					Filename:    name,
+					ContentType: "image/png",
+					Content:     []byte("0123456789"),
+					Inline:      true,
+					ETag:        "v1",
+					ModTime:     modTime,
+				}, nil
+			},
+			ETag: etag,
+		})
+	}
+	register("Download", nil)
+	register("DownloadWithETag", func(ctx context.Context, req *downloadRequest) (string, error) {
+		return "v1", nil
+	})
+	handler := router.Handler()
+
+	tests := []struct {
+		name                string
+		method              string
+		path                string
+		header              map[string]string
+		expectedStatus      int
+		expectedBody        string
+		expectedHeader      map[string]string
+		expectedHandlerCall int
+	}{
+		{
+			name:                "全体を取得する",
+			method:              http.MethodGet,
+			path:                "/monster/v1/Download",
+			expectedStatus:      http.StatusOK,
+			expectedBody:        "0123456789",
+			expectedHeader:      map[string]string{"ETag": `"v1"`, "Accept-Ranges": "bytes", "Content-Disposition": `inline; filename=monster.png`, "Last-Modified": "Thu, 01 Jan 2026 00:00:00 GMT"},
+			expectedHandlerCall: 1,
+		},
+		{
+			name:                "Rangeを指定すると206で一部を返す",
+			method:              http.MethodGet,
+			path:                "/monster/v1/Download",
+			header:              map[string]string{"Range": "bytes=2-5"},
+			expectedStatus:      http.StatusPartialContent,
+			expectedBody:        "2345",
+			expectedHeader:      map[string]string{"Content-Range": "bytes 2-5/10", "Content-Length": "4"},
+			expectedHandlerCall: 1,
+		},
+		{
+			name:                "If-Rangeが一致しない場合は全体を返す",
+			method:              http.MethodGet,
+			path:                "/monster/v1/Download",
+			header:              map[string]string{"Range": "bytes=2-5", "If-Range": `"v0"`},
+			expectedStatus:      http.StatusOK,
+			expectedBody:        "0123456789",
+			expectedHandlerCall: 1,
+		},
+		{
+			name:                "If-None-Matchが一致すると304を返す",
+			method:              http.MethodGet,
+			path:                "/monster/v1/Download",
+			header:              map[string]string{"If-None-Match": `"v1"`},
+			expectedStatus:      http.StatusNotModified,
+			expectedHeader:      map[string]string{"ETag": `"v1"`},
+			expectedHandlerCall: 1,
+		},
+		{
+			name:                "ETagを指定したエンドポイントはファイルを取得せずに304を返す",
+			method:              http.MethodGet,
+			path:                "/monster/v1/DownloadWithETag",
+			header:              map[string]string{"If-None-Match": `"v0", W/"v1"`},
+			expectedStatus:      http.StatusNotModified,
+			expectedHeader:      map[string]string{"ETag": `"v1"`},
+			expectedHandlerCall: 0,
+		},
+		{
+			name:                "ETagを指定したエンドポイントでも一致しない場合はファイルを返す",
+			method:              http.MethodGet,
+			path:                "/monster/v1/DownloadWithETag",
+			header:              map[string]string{"If-None-Match": `"v0"`},
+			expectedStatus:      http.StatusOK,
+			expectedBody:        "0123456789",
+			expectedHeader:      map[string]string{"ETag": `"v1"`},
+			expectedHandlerCall: 1,
+		},
+		{
+			name:                "HEADはボディなしでヘッダーを返す",
+			method:              http.MethodHead,
+			path:                "/monster/v1/Download",
+			expectedStatus:      http.StatusOK,
+			expectedHeader:      map[string]string{"Content-Length": "10", "Content-Type": "image/png", "ETag": `"v1"`},
+			expectedHandlerCall: 1,
+		},
+		{
+			name:                "非ASCIIのファイル名はRFC 2231形式でエンコードする",
+			method:              http.MethodGet,
+			path:                "/monster/v1/Download?name=" + url.QueryEscape("もんすたー.png"),
+			expectedStatus:      http.StatusOK,
+			expectedBody:        "0123456789",
+			expectedHeader:      map[string]string{"Content-Disposition": `inline; filename*=utf-8''%E3%82%82%E3%82%93%E3%81%99%E3%81%9F%E3%83%BC.png`},
+			expectedHandlerCall: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handlerCalls = 0
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			for k, v := range tt.header {
+				req.Header.Set(k, v)
+			}
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			assert.Equal(t, tt.expectedBody, w.Body.String())
+			for k, v := range tt.expectedHeader {
+				assert.Equal(t, v, w.Header().Get(k), k)
+			}
+			assert.Equal(t, tt.expectedHandlerCall, handlerCalls)
+		})
+	}
+}
+
+func TestRouter_署名付きURLの画像はETagが一致すると304を返す(t *testing.T) {
+	ctx := context.Background()
+	store := blob.NewMemoryStore(&blob.URLSigner{BaseURL: "http://example.com", Secret: []byte("secret")})
+	blob.SetStore(store)
+	t.Cleanup(func() { blob.SetStore(nil) })
+
+	require.NoError(t, store.Put(ctx, "monsters/1/generated.png", []byte("png-data"), "image/png"))
+
+	handler, err := Build(outorouter.New())
+	require.NoError(t, err)
+	signedURL, err := store.SignedURL(ctx, "monsters/1/generated.png", time.Hour)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, signedURL, nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	etag := w.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+
+	req = httptest.NewRequest(http.MethodGet, signedURL, nil)
+	req.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Equal(t, etag, w.Header().Get("ETag"))
+	assert.Empty(t, w.Body.String())
+
+	// 署名が不正な場合は、ETagが一致しても画像の有無を返さない
+	req = httptest.NewRequest(http.MethodGet, strings.Replace(signedURL, "monsters%2F1", "monsters%2F2", 1), nil)
+	req.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestAllowWebSocketOrigin(t *testing.T) {
+	original := config.CORSAllowedOrigins
+	t.Cleanup(func() { config.CORSAllowedOrigins = original })
+
+	tests := []struct {
+		name           string
+		allowedOrigins []string
+		origin         string
+		expected       bool
+	}{
+		{name: "Originのないネイティブアプリは許可する", origin: "", expected: true},
+		{name: "同一オリジンは許可する", origin: "http://example.com", expected: true},
+		{name: "CORSで許可したオリジンは許可する", allowedOrigins: []string{"http://localhost:8081"}, origin: "http://localhost:8081", expected: true},
+		{name: "CORSですべて許可した場合は許可する", allowedOrigins: []string{"*"}, origin: "http://other.example", expected: true},
+		{name: "許可していないオリジンは拒否する", allowedOrigins: []string{"http://localhost:8081"}, origin: "http://evil.example", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config.CORSAllowedOrigins = tt.allowedOrigins
+			req := httptest.NewRequest(http.MethodGet, "/monster/v1/WatchMonsterGeneration", nil)
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+
+			assert.Equal(t, tt.expected, allowWebSocketOrigin(req))
+		})
+	}
+}
+
+func TestBuild_一覧エンドポイントはGETでCacheControlを付与する(t *testing.T) {
+	router := outorouter.New()
+	handler, err := Build(router)
+
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/trash/v1/GetTrashCategories", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, trashCategoriesCacheControl, w.Header().Get("Cache-Control"))
+
+	// POSTは受け付けない
+	req = httptest.NewRequest(http.MethodPost, "/trash/v1/GetTrashCategories", strings.NewReader("{}"))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+}
@@ -2,6 +2,9 @@ import { DefaultTheme, ThemeProvider } from '@react-navigation/native';
 import { Stack } from 'expo-router';
 import { StatusBar } from 'expo-status-bar';
 import 'react-native-reanimated';
+import { setupDeviceAuth } from '@/lib/auth';
+
+setupDeviceAuth();
 
 export default function RootLayout() {
   return (
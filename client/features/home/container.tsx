@@ -9,7 +9,7 @@ export const HomeContainer = () => {
   const { location, errorMsg } = useLocation();
   const [trashBins, setTrashBins] = useState<TrashItem[]>([]);
 
-  const { data, isLoading } = useApi('/trash/v1/GetTrashs', {});
+  const { data, isLoading } = useApi('GET /trash/v1/GetTrashs', {});
 
   useEffect(() => {
     if (data) {
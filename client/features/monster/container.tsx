@@ -45,7 +45,7 @@ function convertToMonster(
 
 export function MonsterDetailContainer({ monsterId, isFromRegister }: Props) {
   const { data, isLoading, error } = useApi(
-    '/monster/v1/GetMonster',
+    'GET /monster/v1/monsters/{id}',
     { id: monsterId },
     { enabled: !!monsterId }
   );
@@ -6,7 +6,7 @@ import { View, StyleSheet } from 'react-native';
 import { ActivityIndicator } from 'react-native';
 
 export const MonsterListContainer = () => {
-  const { data, isLoading } = useApi('/monster/v1/GetMonsters', {});
+  const { data, isLoading } = useApi('GET /monster/v1/GetMonsters', {});
   const [monsters, setMonsters] = useState<MonsterItem[]>([]);
 
   useEffect(() => {
@@ -3,7 +3,71 @@ import { Alert, Keyboard } from 'react-native';
 import { router } from 'expo-router';
 import { TrashboxPresentational } from './presentational';
 import { useCamera } from './hooks/useCamera';
-import { createMonster } from '@/lib/client';
+import { apiCallers, createMonster, watchMonsterGeneration } from '@/lib/client';
+
+// モンスターの生成は非同期で行われるため、WebSocketで生成状況を受け取って完了を待つ
+// WebSocketで接続できない場合はポーリングで確認する
+const GENERATION_POLL_INTERVAL_MS = 2000;
+const GENERATION_TIMEOUT_MS = 5 * 60 * 1000;
+
+const sleep = (ms: number) => new Promise((resolve) => setTimeout(resolve, ms));
+
+const pollGeneration = async (jobId: string, deadline: number) => {
+  while (Date.now() < deadline) {
+    const { data } = await apiCallers.GetMonsterGenerationStatus({ job_id: jobId });
+    if (data.status === 'done') {
+      return data.monsterid;
+    }
+    if (data.status === 'failed') {
+      throw new Error(data.error_reason || 'モンスターの生成に失敗しました');
+    }
+    await sleep(GENERATION_POLL_INTERVAL_MS);
+  }
+  throw new Error('モンスターの生成がタイムアウトしました');
+};
+
+const waitForGeneration = (jobId: string) =>
+  new Promise<string>((resolve, reject) => {
+    const deadline = Date.now() + GENERATION_TIMEOUT_MS;
+    let settled = false;
+    const settle = (finish: () => void) => {
+      if (settled) {
+        return;
+      }
+      settled = true;
+      clearTimeout(timer);
+      subscription.close();
+      finish();
+    };
+
+    const timer = setTimeout(
+      () => settle(() => reject(new Error('モンスターの生成がタイムアウトしました'))),
+      GENERATION_TIMEOUT_MS
+    );
+
+    const subscription = watchMonsterGeneration(
+      { job_id: jobId },
+      {
+        onMessage: (data) => {
+          if (data.status === 'done') {
+            settle(() => resolve(data.monsterid));
+          } else if (data.status === 'failed') {
+            settle(() => reject(new Error(data.error_reason || 'モンスターの生成に失敗しました')));
+          }
+        },
+        onError: (error) => {
+          // 接続のエラーは onClose でポーリングに切り替え、サーバーが返したエラーはそのまま失敗にする
+          if (error.code !== 'WEBSOCKET_ERROR') {
+            settle(() => reject(error));
+          }
+        },
+        onClose: () => {
+          // 完了する前に接続が切れた場合はポーリングで確認する
+          settle(() => pollGeneration(jobId, deadline).then(resolve, reject));
+        },
+      }
+    );
+  });
 
 export const TrashboxContainer = () => {
   const {
@@ -42,8 +106,10 @@ export const TrashboxContainer = () => {
         image: photo,
       });
 
+      const monsterId = await waitForGeneration(response.data.job_id);
+
       reset();
-      router.push(`/monsters/${response.data.monsterid}?fromRegister=true`);
+      router.push(`/monsters/${monsterId}?fromRegister=true`);
     } catch (error) {
       console.error('モンスター登録エラー:', error);
       Alert.alert(
@@ -0,0 +1,26 @@
+import { apiCallers, configureApiClient } from '@/lib/client';
+
+// 端末登録で発行されたトークン（アプリを起動している間だけ保持する）
+let deviceToken: Promise<string | undefined> | undefined;
+
+const registerDevice = async () => {
+  try {
+    const { data } = await apiCallers.RegisterDevice({});
+    return data.token;
+  } catch (error) {
+    // 登録に失敗した場合は未認証のままリクエストし、次のリクエストで登録し直す
+    console.error('端末登録エラー:', error);
+    deviceToken = undefined;
+    return undefined;
+  }
+};
+
+// 認証を宣言したエンドポイントを初めて呼び出した時に端末を登録し、以降は同じトークンを使う
+const getDeviceToken = () => {
+  deviceToken ??= registerDevice();
+  return deviceToken;
+};
+
+export const setupDeviceAuth = () => {
+  configureApiClient({ getAccessToken: getDeviceToken });
+};
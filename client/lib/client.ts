@@ -10,6 +10,8 @@ const DEFAULT_BASE_URL = "https://backend-api-713089770976.asia-northeast1.run.a
 export interface ApiClientConfig {
   baseUrl?: string;
   headers?: Record<string, string>;
+  /** Returns the access token sent as "Authorization: Bearer" to endpoints that declare auth */
+  getAccessToken?: () => string | null | undefined | Promise<string | null | undefined>;
   onRequest?: (config: RequestConfig) => RequestConfig | Promise<RequestConfig>;
   onResponse?: <T>(response: ApiResponse<T>) => ApiResponse<T> | Promise<ApiResponse<T>>;
   onError?: (error: ApiError) => void;
@@ -38,31 +40,112 @@ export interface RequestConfig {
   body?: string;
 }
 
+/** Authentication requirement of an endpoint */
+export type AuthRequirement = "none" | "optional" | "required";
+
+/** HTTP method, path pattern, parameter locations and auth requirement of an endpoint */
+export interface RouteDefinition {
+  method: string;
+  path: string;
+  pathParams: readonly string[];
+  queryParams: readonly string[];
+  hasBody: boolean;
+  auth: AuthRequirement;
+}
+
 export interface ApiResponse<T> {
   data: T;
   status: number;
   headers: Headers;
 }
 
+/**
+ * Error codes that the server may return.
+ * "UNKNOWN_ERROR" is used when the error response could not be parsed.
+ */
+export type ApiErrorCode =
+  | "FORBIDDEN"
+  | "GENERATION_JOB_NOT_FOUND"
+  | "IMAGE_NOT_FOUND"
+  | "INVALID_FRAME"
+  | "INVALID_JSON"
+  | "INVALID_MULTIPART"
+  | "INVALID_REQUEST"
+  | "INVALID_SIGNED_URL"
+  | "INVALID_TOKEN"
+  | "METHOD_NOT_ALLOWED"
+  | "MONSTER_GENERATING"
+  | "MONSTER_NOT_FOUND"
+  | "NOT_MONSTER_OWNER"
+  | "QUOTA_EXCEEDED"
+  | "RATE_LIMITED"
+  | "REQUEST_TOO_LARGE"
+  | "SIGNED_URL_EXPIRED"
+  | "STORAGE_UNAVAILABLE"
+  | "UNAUTHORIZED"
+  | "UNKNOWN_INTERNAL_ERROR"
+  | "UNSUPPORTED_MEDIA_TYPE"
+  | "VALIDATION_FAILED"
+  | "UNKNOWN_ERROR"
+  | "WEBSOCKET_ERROR";
+
+/** Field-level error detail (e.g. validation failures) */
+export interface ApiFieldError {
+  field: string;
+  code: string;
+  message: string;
+}
+
 export interface ApiErrorResponse {
   error: {
-    error: string;
+    code: ApiErrorCode;
     message: string;
+    request_id?: string;
+    details?: ApiFieldError[];
   };
 }
 
 export class ApiError extends Error {
   constructor(
     public readonly status: number,
-    public readonly code: string,
+    public readonly code: ApiErrorCode,
     message: string,
-    public readonly response?: Response
+    public readonly response?: Response,
+    public readonly requestId?: string,
+    public readonly details: ApiFieldError[] = []
   ) {
     super(message);
     this.name = "ApiError";
   }
 }
 
+/** Returns true if the error is an ApiError with one of the given codes */
+export function isApiError<C extends ApiErrorCode>(
+  error: unknown,
+  ...codes: C[]
+): error is ApiError & { code: C } {
+  if (!(error instanceof ApiError)) {
+    return false;
+  }
+  return codes.length === 0 || (codes as ApiErrorCode[]).includes(error.code);
+}
+
+function createApiError(
+  status: number,
+  errorData: Partial<ApiErrorResponse> | null,
+  fallbackMessage: string,
+  response?: Response
+): ApiError {
+  return new ApiError(
+    status,
+    errorData?.error?.code ?? "UNKNOWN_ERROR",
+    errorData?.error?.message ?? fallbackMessage,
+    response,
+    errorData?.error?.request_id,
+    errorData?.error?.details ?? []
+  );
+}
+
 // ============================================================================
 // Nested Type Definitions
 // ============================================================================
@@ -104,17 +187,54 @@ export interface MonsterItem {
   nickname: string;
   latitude: number;
   longitude: number;
-  trash_category: string;
+  /** @enum 指定なし, 燃えるゴミ, 不燃ごみ, 缶, 瓶, ペットボトル */
+  trash_category: "指定なし" | "燃えるゴミ" | "不燃ごみ" | "缶" | "瓶" | "ペットボトル";
+  /** @enum none, burnable, non_burnable, can, glass_bottle, pet_bottle */
+  trash_category_slug: "none" | "burnable" | "non_burnable" | "can" | "glass_bottle" | "pet_bottle";
+  attribute: string;
+  color_code: string;
+  species_name: string;
+  description: string;
+  stats: MonsterStats;
   image_url: string;
 }
 
+/** Nested type: MonsterStats */
+export interface MonsterStats {
+  hp: number;
+  attack: number;
+  defense: number;
+  speed: number;
+}
+
+/** Nested type: NearbyMonsterItem */
+export interface NearbyMonsterItem {
+  monster: MonsterItem;
+  distance_m: number;
+}
+
+/** Nested type: TrashCategoryItem */
+export interface TrashCategoryItem {
+  id: number;
+  /** @enum 指定なし, 燃えるゴミ, 不燃ごみ, 缶, 瓶, ペットボトル */
+  name: "指定なし" | "燃えるゴミ" | "不燃ごみ" | "缶" | "瓶" | "ペットボトル";
+  /** @enum none, burnable, non_burnable, can, glass_bottle, pet_bottle */
+  slug: "none" | "burnable" | "non_burnable" | "can" | "glass_bottle" | "pet_bottle";
+  aliases: string[];
+  attribute: string;
+  color_code: string;
+}
+
 /** Nested type: TrashItem */
 export interface TrashItem {
   id: string;
   nickname: string;
   latitude: number;
   longitude: number;
-  trash_category: string;
+  /** @enum 指定なし, 燃えるゴミ, 不燃ごみ, 缶, 瓶, ペットボトル */
+  trash_category: "指定なし" | "燃えるゴミ" | "不燃ごみ" | "缶" | "瓶" | "ペットボトル";
+  /** @enum none, burnable, non_burnable, can, glass_bottle, pet_bottle */
+  trash_category_slug: "none" | "burnable" | "non_burnable" | "can" | "glass_bottle" | "pet_bottle";
   image_url: string;
 }
 
@@ -122,8 +242,24 @@ export interface TrashItem {
 // Request/Response Type Definitions
 // ============================================================================
 
+/**
+ * Analyze Trash Bin and Generate Monster Character (Multipart) - Error codes
+ */
+export type AnalyzeAndGenerateImageErrorCode =
+  | "METHOD_NOT_ALLOWED"
+  | "VALIDATION_FAILED"
+  | "UNSUPPORTED_MEDIA_TYPE"
+  | "REQUEST_TOO_LARGE"
+  | "INVALID_MULTIPART"
+  | "INVALID_REQUEST"
+  | "UNKNOWN_INTERNAL_ERROR"
+  | "INVALID_TOKEN"
+  | "RATE_LIMITED"
+  | "QUOTA_EXCEEDED";
+
 /** Analyze Trash Bin and Generate Monster Character (Multipart) - Request */
 export interface AnalyzeAndGenerateImageRequest {
+  /** @required @maxSize 20MB @mimeTypes image/* */
   image: FileHeader;
   model: string;
 }
@@ -134,20 +270,52 @@ export interface AnalyzeAndGenerateImageResponse {
   mime_type: string;
 }
 
-/** Analyze Image using Gemini - Request */
+/**
+ * Analyze Trash Bin Image - Error codes
+ */
+export type AnalyzeImageErrorCode =
+  | "METHOD_NOT_ALLOWED"
+  | "VALIDATION_FAILED"
+  | "UNSUPPORTED_MEDIA_TYPE"
+  | "REQUEST_TOO_LARGE"
+  | "INVALID_JSON"
+  | "UNKNOWN_INTERNAL_ERROR"
+  | "INVALID_TOKEN"
+  | "RATE_LIMITED"
+  | "QUOTA_EXCEEDED";
+
+/** Analyze Trash Bin Image - Request */
 export interface AnalyzeImageRequest {
+  /** @required */
   image_data: string;
   mime_type?: string;
   model?: string;
 }
 
-/** Analyze Image using Gemini - Response */
+/** Analyze Trash Bin Image - Response */
 export interface AnalyzeImageResponse {
+  trash_type: string;
+  confidence: number;
   text: string;
 }
 
+/**
+ * Generate Image using Gemini 3 Pro Image - Error codes
+ */
+export type GenerateImageErrorCode =
+  | "METHOD_NOT_ALLOWED"
+  | "VALIDATION_FAILED"
+  | "UNSUPPORTED_MEDIA_TYPE"
+  | "REQUEST_TOO_LARGE"
+  | "INVALID_JSON"
+  | "UNKNOWN_INTERNAL_ERROR"
+  | "INVALID_TOKEN"
+  | "RATE_LIMITED"
+  | "QUOTA_EXCEEDED";
+
 /** Generate Image using Gemini 3 Pro Image - Request */
 export interface GenerateImageRequest {
+  /** @required */
   prompt: string;
   model?: string;
 }
@@ -157,6 +325,17 @@ export interface GenerateImageResponse {
   candidates: CandidateResponse[];
 }
 
+/**
+ * Health Check Endpoint - Error codes
+ */
+export type HealthzErrorCode =
+  | "METHOD_NOT_ALLOWED"
+  | "VALIDATION_FAILED"
+  | "UNSUPPORTED_MEDIA_TYPE"
+  | "REQUEST_TOO_LARGE"
+  | "INVALID_JSON"
+  | "UNKNOWN_INTERNAL_ERROR";
+
 /** Health Check Endpoint - Request */
 export interface HealthzRequest {
   // Empty request
@@ -169,24 +348,106 @@ export interface HealthzResponse {
   message: string;
 }
 
+/**
+ * Create Monster - Error codes
+ */
+export type CreateMonsterErrorCode =
+  | "METHOD_NOT_ALLOWED"
+  | "VALIDATION_FAILED"
+  | "UNSUPPORTED_MEDIA_TYPE"
+  | "REQUEST_TOO_LARGE"
+  | "INVALID_MULTIPART"
+  | "INVALID_REQUEST"
+  | "UNKNOWN_INTERNAL_ERROR"
+  | "INVALID_TOKEN"
+  | "RATE_LIMITED"
+  | "QUOTA_EXCEEDED"
+  | "STORAGE_UNAVAILABLE";
+
 /** Create Monster - Request */
 export interface CreateMonsterRequest {
+  /** @required @maxLength 50 */
   nickname: string;
+  /** @minimum -90 @maximum 90 */
   latitude: number;
+  /** @minimum -180 @maximum 180 */
   longitude: number;
+  /** @required @maxSize 20MB @mimeTypes image/* */
   image: FileHeader;
 }
 
 /** Create Monster - Response */
 export interface CreateMonsterResponse {
   monsterid: string;
-  trash_type: string;
-  generated_image_url: string;
-  original_image_url: string;
+  job_id: string;
+  status: string;
+}
+
+/**
+ * Delete Monster - Error codes
+ */
+export type DeleteMonsterErrorCode =
+  | "METHOD_NOT_ALLOWED"
+  | "VALIDATION_FAILED"
+  | "UNSUPPORTED_MEDIA_TYPE"
+  | "REQUEST_TOO_LARGE"
+  | "INVALID_JSON"
+  | "UNKNOWN_INTERNAL_ERROR"
+  | "INVALID_TOKEN"
+  | "UNAUTHORIZED"
+  | "MONSTER_NOT_FOUND"
+  | "NOT_MONSTER_OWNER"
+  | "MONSTER_GENERATING";
+
+/** Delete Monster - Request */
+export interface DeleteMonsterRequest {
+  /** @required @maxLength 36 */
+  id: string;
+}
+
+/** Delete Monster - Response */
+export interface DeleteMonsterResponse {
+  id: string;
 }
 
+/**
+ * Download Monster Image - Error codes
+ */
+export type DownloadMonsterImageErrorCode =
+  | "METHOD_NOT_ALLOWED"
+  | "VALIDATION_FAILED"
+  | "INVALID_REQUEST"
+  | "UNKNOWN_INTERNAL_ERROR"
+  | "MONSTER_NOT_FOUND"
+  | "IMAGE_NOT_FOUND"
+  | "STORAGE_UNAVAILABLE";
+
+/** Download Monster Image - Request */
+export interface DownloadMonsterImageRequest {
+  /** @required @maxLength 36 */
+  id: string;
+  /** @enum generated, original */
+  type?: "generated" | "original";
+}
+
+/** Download Monster Image - Response (binary file) */
+export type DownloadMonsterImageResponse = Blob;
+
+/**
+ * Get Monster - Error codes
+ */
+export type GetMonsterErrorCode =
+  | "METHOD_NOT_ALLOWED"
+  | "VALIDATION_FAILED"
+  | "UNSUPPORTED_MEDIA_TYPE"
+  | "REQUEST_TOO_LARGE"
+  | "INVALID_JSON"
+  | "UNKNOWN_INTERNAL_ERROR"
+  | "MONSTER_NOT_FOUND";
+
 /** Get Monster - Request */
 export interface GetMonsterRequest {
+  /** @required @maxLength 36 */
   id: string;
 }
 
@@ -197,6 +458,47 @@ export interface GetMonsterResponse {
   generated_image_url: string;
 }
 
+/**
+ * Get Monster Generation Status - Error codes
+ */
+export type GetMonsterGenerationStatusErrorCode =
+  | "METHOD_NOT_ALLOWED"
+  | "VALIDATION_FAILED"
+  | "UNSUPPORTED_MEDIA_TYPE"
+  | "REQUEST_TOO_LARGE"
+  | "INVALID_JSON"
+  | "UNKNOWN_INTERNAL_ERROR"
+  | "INVALID_TOKEN"
+  | "GENERATION_JOB_NOT_FOUND";
+
+/** Get Monster Generation Status - Request */
+export interface GetMonsterGenerationStatusRequest {
+  /** @required @maxLength 36 */
+  job_id: string;
+}
+
+/** Get Monster Generation Status - Response */
+export interface GetMonsterGenerationStatusResponse {
+  job_id: string;
+  monsterid: string;
+  status: string;
+  attempts: number;
+  max_attempts: number;
+  error_code: string;
+  error_reason: string;
+}
+
+/**
+ * Get Monsters - Error codes
+ */
+export type GetMonstersErrorCode =
+  | "METHOD_NOT_ALLOWED"
+  | "VALIDATION_FAILED"
+  | "UNSUPPORTED_MEDIA_TYPE"
+  | "REQUEST_TOO_LARGE"
+  | "INVALID_JSON"
+  | "UNKNOWN_INTERNAL_ERROR";
+
 /** Get Monsters - Request */
 export interface GetMonstersRequest {
   // Empty request
@@ -207,6 +509,239 @@ export interface GetMonstersResponse {
   monsters: MonsterItem[];
 }
 
+/**
+ * Get Monsters In Bounds - Error codes
+ */
+export type GetMonstersInBoundsErrorCode =
+  | "METHOD_NOT_ALLOWED"
+  | "VALIDATION_FAILED"
+  | "UNSUPPORTED_MEDIA_TYPE"
+  | "REQUEST_TOO_LARGE"
+  | "INVALID_JSON"
+  | "UNKNOWN_INTERNAL_ERROR";
+
+/** Get Monsters In Bounds - Request */
+export interface GetMonstersInBoundsRequest {
+  /** @minimum -90 @maximum 90 */
+  sw_latitude?: number;
+  /** @minimum -180 @maximum 180 */
+  sw_longitude?: number;
+  /** @minimum -90 @maximum 90 */
+  ne_latitude?: number;
+  /** @minimum -180 @maximum 180 */
+  ne_longitude?: number;
+  /** @minimum 1 @maximum 500 */
+  limit?: number;
+  /** @enum burnable, non_burnable, can, glass_bottle, pet_bottle */
+  trash_category?: "burnable" | "non_burnable" | "can" | "glass_bottle" | "pet_bottle";
+}
+
+/** Get Monsters In Bounds - Response */
+export interface GetMonstersInBoundsResponse {
+  monsters: MonsterItem[];
+}
+
+/**
+ * Get My Monsters - Error codes
+ */
+export type GetMyMonstersErrorCode =
+  | "METHOD_NOT_ALLOWED"
+  | "VALIDATION_FAILED"
+  | "UNSUPPORTED_MEDIA_TYPE"
+  | "REQUEST_TOO_LARGE"
+  | "INVALID_JSON"
+  | "UNKNOWN_INTERNAL_ERROR"
+  | "INVALID_TOKEN"
+  | "UNAUTHORIZED";
+
+/** Get My Monsters - Request */
+export interface GetMyMonstersRequest {
+  // Empty request
+}
+
+/** Get My Monsters - Response */
+export interface GetMyMonstersResponse {
+  monsters: MonsterItem[];
+}
+
+/**
+ * Regenerate Monster Profile - Error codes
+ */
+export type RegenerateMonsterProfileErrorCode =
+  | "METHOD_NOT_ALLOWED"
+  | "VALIDATION_FAILED"
+  | "UNSUPPORTED_MEDIA_TYPE"
+  | "REQUEST_TOO_LARGE"
+  | "INVALID_JSON"
+  | "UNKNOWN_INTERNAL_ERROR"
+  | "INVALID_TOKEN"
+  | "UNAUTHORIZED"
+  | "FORBIDDEN"
+  | "MONSTER_NOT_FOUND"
+  | "IMAGE_NOT_FOUND";
+
+/** Regenerate Monster Profile - Request */
+export interface RegenerateMonsterProfileRequest {
+  /** @required @maxLength 36 */
+  id: string;
+  reroll_stats: boolean;
+}
+
+/** Regenerate Monster Profile - Response */
+export interface RegenerateMonsterProfileResponse {
+  id: string;
+  species_name: string;
+  description: string;
+  stats: MonsterStats;
+}
+
+/**
+ * Rename Monster - Error codes
+ */
+export type RenameMonsterErrorCode =
+  | "METHOD_NOT_ALLOWED"
+  | "VALIDATION_FAILED"
+  | "UNSUPPORTED_MEDIA_TYPE"
+  | "REQUEST_TOO_LARGE"
+  | "INVALID_JSON"
+  | "UNKNOWN_INTERNAL_ERROR"
+  | "INVALID_TOKEN"
+  | "UNAUTHORIZED"
+  | "MONSTER_NOT_FOUND"
+  | "NOT_MONSTER_OWNER";
+
+/** Rename Monster - Request */
+export interface RenameMonsterRequest {
+  /** @required @maxLength 36 */
+  id: string;
+  /** @required @maxLength 50 */
+  nickname: string;
+}
+
+/** Rename Monster - Response */
+export interface RenameMonsterResponse {
+  id: string;
+  nickname: string;
+}
+
+/**
+ * Search Monsters Nearby - Error codes
+ */
+export type SearchMonstersNearbyErrorCode =
+  | "METHOD_NOT_ALLOWED"
+  | "VALIDATION_FAILED"
+  | "UNSUPPORTED_MEDIA_TYPE"
+  | "REQUEST_TOO_LARGE"
+  | "INVALID_JSON"
+  | "UNKNOWN_INTERNAL_ERROR";
+
+/** Search Monsters Nearby - Request */
+export interface SearchMonstersNearbyRequest {
+  /** @minimum -90 @maximum 90 */
+  latitude?: number;
+  /** @minimum -180 @maximum 180 */
+  longitude?: number;
+  /** @required @minimum 1 @maximum 50000 */
+  radius_m: number;
+  /** @minimum 1 @maximum 100 */
+  limit?: number;
+  /** @enum burnable, non_burnable, can, glass_bottle, pet_bottle */
+  trash_category?: "burnable" | "non_burnable" | "can" | "glass_bottle" | "pet_bottle";
+}
+
+/** Search Monsters Nearby - Response */
+export interface SearchMonstersNearbyResponse {
+  monsters: NearbyMonsterItem[];
+}
+
+/**
+ * Watch Monster Generation - Error codes
+ */
+export type WatchMonsterGenerationErrorCode =
+  | "METHOD_NOT_ALLOWED"
+  | "VALIDATION_FAILED"
+  | "INVALID_REQUEST"
+  | "INVALID_FRAME"
+  | "UNKNOWN_INTERNAL_ERROR"
+  | "INVALID_TOKEN"
+  | "UNAUTHORIZED"
+  | "GENERATION_JOB_NOT_FOUND";
+
+/** Watch Monster Generation - Request */
+export interface WatchMonsterGenerationRequest {
+  /** @required @maxLength 36 */
+  job_id: string;
+}
+
+/** Watch Monster Generation - Response */
+export interface WatchMonsterGenerationResponse {
+  job_id: string;
+  monsterid: string;
+  status: string;
+  attempts: number;
+  max_attempts: number;
+  error_code: string;
+  error_reason: string;
+}
+
+/**
+ * Get Storage Object - Error codes
+ */
+export type GetStorageObjectErrorCode =
+  | "METHOD_NOT_ALLOWED"
+  | "VALIDATION_FAILED"
+  | "INVALID_REQUEST"
+  | "UNKNOWN_INTERNAL_ERROR"
+  | "INVALID_SIGNED_URL"
+  | "SIGNED_URL_EXPIRED"
+  | "IMAGE_NOT_FOUND"
+  | "STORAGE_UNAVAILABLE";
+
+/** Get Storage Object - Request */
+export interface GetStorageObjectRequest {
+  /** @required @maxLength 1024 */
+  key: string;
+  /** @required */
+  expires: number;
+  /** @required */
+  signature: string;
+}
+
+/** Get Storage Object - Response (binary file) */
+export type GetStorageObjectResponse = Blob;
+
+/**
+ * Get Trash Categories - Error codes
+ */
+export type GetTrashCategoriesErrorCode =
+  | "METHOD_NOT_ALLOWED"
+  | "VALIDATION_FAILED"
+  | "UNSUPPORTED_MEDIA_TYPE"
+  | "REQUEST_TOO_LARGE"
+  | "INVALID_JSON"
+  | "UNKNOWN_INTERNAL_ERROR";
+
+/** Get Trash Categories - Request */
+export interface GetTrashCategoriesRequest {
+  // Empty request
+}
+
+/** Get Trash Categories - Response */
+export interface GetTrashCategoriesResponse {
+  trash_categories: TrashCategoryItem[];
+}
+
+/**
+ * Get Trashs - Error codes
+ */
+export type GetTrashsErrorCode =
+  | "METHOD_NOT_ALLOWED"
+  | "VALIDATION_FAILED"
+  | "UNSUPPORTED_MEDIA_TYPE"
+  | "REQUEST_TOO_LARGE"
+  | "INVALID_JSON"
+  | "UNKNOWN_INTERNAL_ERROR";
+
 /** Get Trashs - Request */
 export interface GetTrashsRequest {
   // Empty request
@@ -217,6 +752,30 @@ export interface GetTrashsResponse {
   trashs: TrashItem[];
 }
 
+/**
+ * Register Device - Error codes
+ */
+export type RegisterDeviceErrorCode =
+  | "METHOD_NOT_ALLOWED"
+  | "VALIDATION_FAILED"
+  | "UNSUPPORTED_MEDIA_TYPE"
+  | "REQUEST_TOO_LARGE"
+  | "INVALID_JSON"
+  | "UNKNOWN_INTERNAL_ERROR"
+  | "RATE_LIMITED";
+
+/** Register Device - Request */
+export interface RegisterDeviceRequest {
+  /** @maxLength 50 */
+  nickname?: string;
+}
+
+/** Register Device - Response */
+export interface RegisterDeviceResponse {
+  user_id: string;
+  token: string;
+}
+
 // ============================================================================
 // Endpoint Path Definitions
 // ============================================================================
@@ -227,13 +786,239 @@ export const Endpoints = {
   GenerateImage: "/gemini/v1/GenerateImage",
   Healthz: "/healthz/v1/Healthz",
   CreateMonster: "/monster/v1/CreateMonster",
-  GetMonster: "/monster/v1/GetMonster",
-  GetMonsters: "/monster/v1/GetMonsters",
-  GetTrashs: "/trash/v1/GetTrashs",
+  DeleteMonster: "/monster/v1/DeleteMonster",
+  DownloadMonsterImage: "/monster/v1/DownloadMonsterImage",
+  GetMonster: "GET /monster/v1/monsters/{id}",
+  GetMonsterGenerationStatus: "/monster/v1/GetMonsterGenerationStatus",
+  GetMonsters: "GET /monster/v1/GetMonsters",
+  GetMonstersInBounds: "GET /monster/v1/GetMonstersInBounds",
+  GetMyMonsters: "/monster/v1/GetMyMonsters",
+  RegenerateMonsterProfile: "/monster/v1/RegenerateMonsterProfile",
+  RenameMonster: "/monster/v1/RenameMonster",
+  SearchMonstersNearby: "GET /monster/v1/SearchMonstersNearby",
+  WatchMonsterGeneration: "/monster/v1/WatchMonsterGeneration",
+  GetStorageObject: "/storage/v1/GetStorageObject",
+  GetTrashCategories: "GET /trash/v1/GetTrashCategories",
+  GetTrashs: "GET /trash/v1/GetTrashs",
+  RegisterDevice: "/user/v1/RegisterDevice",
 } as const;
 
 export type EndpointPath = (typeof Endpoints)[keyof typeof Endpoints];
 
+// ============================================================================
+// Route Definitions
+// ============================================================================
+
+export const Routes = {
+  AnalyzeAndGenerateImage: {
+    method: "POST",
+    path: "/gemini/v1/AnalyzeAndGenerateImage",
+    pathParams: [],
+    queryParams: [],
+    hasBody: true,
+    auth: "optional",
+  },
+  AnalyzeImage: {
+    method: "POST",
+    path: "/gemini/v1/AnalyzeImage",
+    pathParams: [],
+    queryParams: [],
+    hasBody: true,
+    auth: "optional",
+  },
+  GenerateImage: {
+    method: "POST",
+    path: "/gemini/v1/GenerateImage",
+    pathParams: [],
+    queryParams: [],
+    hasBody: true,
+    auth: "optional",
+  },
+  Healthz: {
+    method: "POST",
+    path: "/healthz/v1/Healthz",
+    pathParams: [],
+    queryParams: [],
+    hasBody: true,
+    auth: "none",
+  },
+  CreateMonster: {
+    method: "POST",
+    path: "/monster/v1/CreateMonster",
+    pathParams: [],
+    queryParams: [],
+    hasBody: true,
+    auth: "optional",
+  },
+  DeleteMonster: {
+    method: "POST",
+    path: "/monster/v1/DeleteMonster",
+    pathParams: [],
+    queryParams: [],
+    hasBody: true,
+    auth: "required",
+  },
+  DownloadMonsterImage: {
+    method: "GET",
+    path: "/monster/v1/DownloadMonsterImage",
+    pathParams: [],
+    queryParams: ["id", "type"],
+    hasBody: false,
+    auth: "none",
+  },
+  GetMonster: {
+    method: "GET",
+    path: "/monster/v1/monsters/{id}",
+    pathParams: ["id"],
+    queryParams: [],
+    hasBody: false,
+    auth: "none",
+  },
+  GetMonsterGenerationStatus: {
+    method: "POST",
+    path: "/monster/v1/GetMonsterGenerationStatus",
+    pathParams: [],
+    queryParams: [],
+    hasBody: true,
+    auth: "optional",
+  },
+  GetMonsters: {
+    method: "GET",
+    path: "/monster/v1/GetMonsters",
+    pathParams: [],
+    queryParams: [],
+    hasBody: false,
+    auth: "none",
+  },
+  GetMonstersInBounds: {
+    method: "GET",
+    path: "/monster/v1/GetMonstersInBounds",
+    pathParams: [],
+    queryParams: ["sw_latitude", "sw_longitude", "ne_latitude", "ne_longitude", "limit", "trash_category"],
+    hasBody: false,
+    auth: "none",
+  },
+  GetMyMonsters: {
+    method: "POST",
+    path: "/monster/v1/GetMyMonsters",
+    pathParams: [],
+    queryParams: [],
+    hasBody: true,
+    auth: "required",
+  },
+  RegenerateMonsterProfile: {
+    method: "POST",
+    path: "/monster/v1/RegenerateMonsterProfile",
+    pathParams: [],
+    queryParams: [],
+    hasBody: true,
+    auth: "required",
+  },
+  RenameMonster: {
+    method: "POST",
+    path: "/monster/v1/RenameMonster",
+    pathParams: [],
+    queryParams: [],
+    hasBody: true,
+    auth: "required",
+  },
+  SearchMonstersNearby: {
+    method: "GET",
+    path: "/monster/v1/SearchMonstersNearby",
+    pathParams: [],
+    queryParams: ["latitude", "longitude", "radius_m", "limit", "trash_category"],
+    hasBody: false,
+    auth: "none",
+  },
+  WatchMonsterGeneration: {
+    method: "GET",
+    path: "/monster/v1/WatchMonsterGeneration",
+    pathParams: [],
+    queryParams: [],
+    hasBody: false,
+    auth: "required",
+  },
+  GetStorageObject: {
+    method: "GET",
+    path: "/storage/v1/GetStorageObject",
+    pathParams: [],
+    queryParams: ["key", "expires", "signature"],
+    hasBody: false,
+    auth: "none",
+  },
+  GetTrashCategories: {
+    method: "GET",
+    path: "/trash/v1/GetTrashCategories",
+    pathParams: [],
+    queryParams: [],
+    hasBody: false,
+    auth: "none",
+  },
+  GetTrashs: {
+    method: "GET",
+    path: "/trash/v1/GetTrashs",
+    pathParams: [],
+    queryParams: [],
+    hasBody: false,
+    auth: "none",
+  },
+  RegisterDevice: {
+    method: "POST",
+    path: "/user/v1/RegisterDevice",
+    pathParams: [],
+    queryParams: [],
+    hasBody: true,
+    auth: "none",
+  },
+} as const;
+
+/**
+ * Fills the path parameters of the route and appends the query parameters.
+ * Returns the resolved path and the remaining fields to send in the request body.
+ */
+export function resolveRoute(
+  route: RouteDefinition,
+  request: object
+): { path: string; body: Record<string, unknown> } {
+  const body: Record<string, unknown> = { ...(request as Record<string, unknown>) };
+
+  let path = route.path;
+  for (const name of route.pathParams) {
+    path = path.replace("{" + name + "}", encodeURIComponent(String(body[name] ?? "")));
+    delete body[name];
+  }
+
+  const params = new URLSearchParams();
+  for (const name of route.queryParams) {
+    const value = body[name];
+    delete body[name];
+    if (value === undefined || value === null) {
+      continue;
+    }
+    if (Array.isArray(value)) {
+      value.forEach((v) => params.append(name, String(v)));
+    } else {
+      params.append(name, String(value));
+    }
+  }
+
+  const query = params.toString();
+  return { path: query ? path + "?" + query : path, body };
+}
+
+/**
+ * Returns the Authorization header for endpoints that declare auth.
+ * The token is obtained from getAccessToken in the client configuration.
+ */
+export async function authHeaders(route: Pick<RouteDefinition, "auth">): Promise<Record<string, string>> {
+  const config = getApiClientConfig();
+  if (route.auth === "none" || !config.getAccessToken) {
+    return {};
+  }
+  const token = await config.getAccessToken();
+  return token ? { Authorization: `Bearer ${token}` } : {};
+}
+
 // ============================================================================
 // Endpoint Type Mapping
 // ============================================================================
@@ -263,20 +1048,76 @@ export interface EndpointTypes {
     request: CreateMonsterRequest;
     response: CreateMonsterResponse;
   };
-  "/monster/v1/GetMonster": {
+  "/monster/v1/DeleteMonster": {
+    request: DeleteMonsterRequest;
+    response: DeleteMonsterResponse;
+  };
+  "GET /monster/v1/monsters/{id}": {
     request: GetMonsterRequest;
     response: GetMonsterResponse;
   };
-  "/monster/v1/GetMonsters": {
+  "/monster/v1/GetMonsterGenerationStatus": {
+    request: GetMonsterGenerationStatusRequest;
+    response: GetMonsterGenerationStatusResponse;
+  };
+  "GET /monster/v1/GetMonsters": {
     request: GetMonstersRequest;
     response: GetMonstersResponse;
   };
-  "/trash/v1/GetTrashs": {
+  "GET /monster/v1/GetMonstersInBounds": {
+    request: GetMonstersInBoundsRequest;
+    response: GetMonstersInBoundsResponse;
+  };
+  "/monster/v1/GetMyMonsters": {
+    request: GetMyMonstersRequest;
+    response: GetMyMonstersResponse;
+  };
+  "/monster/v1/RegenerateMonsterProfile": {
+    request: RegenerateMonsterProfileRequest;
+    response: RegenerateMonsterProfileResponse;
+  };
+  "/monster/v1/RenameMonster": {
+    request: RenameMonsterRequest;
+    response: RenameMonsterResponse;
+  };
+  "GET /monster/v1/SearchMonstersNearby": {
+    request: SearchMonstersNearbyRequest;
+    response: SearchMonstersNearbyResponse;
+  };
+  "GET /trash/v1/GetTrashCategories": {
+    request: GetTrashCategoriesRequest;
+    response: GetTrashCategoriesResponse;
+  };
+  "GET /trash/v1/GetTrashs": {
     request: GetTrashsRequest;
     response: GetTrashsResponse;
   };
+  "/user/v1/RegisterDevice": {
+    request: RegisterDeviceRequest;
+    response: RegisterDeviceResponse;
+  };
 }
 
+const endpointRoutes: { [P in keyof EndpointTypes]: RouteDefinition } = {
+  "/gemini/v1/AnalyzeAndGenerateImage": Routes.AnalyzeAndGenerateImage,
+  "/gemini/v1/AnalyzeImage": Routes.AnalyzeImage,
+  "/gemini/v1/GenerateImage": Routes.GenerateImage,
+  "/healthz/v1/Healthz": Routes.Healthz,
+  "/monster/v1/CreateMonster": Routes.CreateMonster,
+  "/monster/v1/DeleteMonster": Routes.DeleteMonster,
+  "GET /monster/v1/monsters/{id}": Routes.GetMonster,
+  "/monster/v1/GetMonsterGenerationStatus": Routes.GetMonsterGenerationStatus,
+  "GET /monster/v1/GetMonsters": Routes.GetMonsters,
+  "GET /monster/v1/GetMonstersInBounds": Routes.GetMonstersInBounds,
+  "/monster/v1/GetMyMonsters": Routes.GetMyMonsters,
+  "/monster/v1/RegenerateMonsterProfile": Routes.RegenerateMonsterProfile,
+  "/monster/v1/RenameMonster": Routes.RenameMonster,
+  "GET /monster/v1/SearchMonstersNearby": Routes.SearchMonstersNearby,
+  "GET /trash/v1/GetTrashCategories": Routes.GetTrashCategories,
+  "GET /trash/v1/GetTrashs": Routes.GetTrashs,
+  "/user/v1/RegisterDevice": Routes.RegisterDevice,
+};
+
 // ============================================================================
 // Type-Safe API Client
 // ============================================================================
@@ -305,17 +1146,20 @@ export async function api<P extends keyof EndpointTypes>(
   }
 ): Promise<ApiResponse<EndpointTypes[P]["response"]>> {
   const config = getApiClientConfig();
-  const url = `${config.baseUrl ?? DEFAULT_BASE_URL}${endpoint}`;
+  const route = endpointRoutes[endpoint];
+  const { path, body } = resolveRoute(route, request);
+  const url = `${config.baseUrl ?? DEFAULT_BASE_URL}${path}`;
 
   let requestConfig: RequestConfig = {
-    method: "POST",
+    method: route.method,
     url,
     headers: {
-      "Content-Type": "application/json",
+      ...(route.hasBody ? { "Content-Type": "application/json" } : {}),
       ...config.headers,
+      ...(await authHeaders(route)),
       ...options?.headers,
     },
-    body: JSON.stringify(request),
+    body: route.hasBody ? JSON.stringify(body) : undefined,
   };
 
   // Apply request interceptor
@@ -340,12 +1184,7 @@ export async function api<P extends keyof EndpointTypes>(
       // Response body is not JSON
     }
 
-    const apiError = new ApiError(
-      response.status,
-      errorData?.error?.error ?? "UNKNOWN_ERROR",
-      errorData?.error?.message ?? response.statusText,
-      response
-    );
+    const apiError = createApiError(response.status, errorData, response.statusText, response);
 
     if (config.onError) {
       config.onError(apiError);
@@ -401,7 +1240,7 @@ export function createApiCaller<P extends keyof EndpointTypes>(endpoint: P) {
 export const apiCallers = {
   /** Analyze Trash Bin and Generate Monster Character (Multipart) */
   AnalyzeAndGenerateImage: createApiCaller(Endpoints.AnalyzeAndGenerateImage),
-  /** Analyze Image using Gemini */
+  /** Analyze Trash Bin Image */
   AnalyzeImage: createApiCaller(Endpoints.AnalyzeImage),
   /** Generate Image using Gemini 3 Pro Image */
   GenerateImage: createApiCaller(Endpoints.GenerateImage),
@@ -409,12 +1248,30 @@ export const apiCallers = {
   Healthz: createApiCaller(Endpoints.Healthz),
   /** Create Monster */
   CreateMonster: createApiCaller(Endpoints.CreateMonster),
+  /** Delete Monster */
+  DeleteMonster: createApiCaller(Endpoints.DeleteMonster),
   /** Get Monster */
   GetMonster: createApiCaller(Endpoints.GetMonster),
+  /** Get Monster Generation Status */
+  GetMonsterGenerationStatus: createApiCaller(Endpoints.GetMonsterGenerationStatus),
   /** Get Monsters */
   GetMonsters: createApiCaller(Endpoints.GetMonsters),
+  /** Get Monsters In Bounds */
+  GetMonstersInBounds: createApiCaller(Endpoints.GetMonstersInBounds),
+  /** Get My Monsters */
+  GetMyMonsters: createApiCaller(Endpoints.GetMyMonsters),
+  /** Regenerate Monster Profile */
+  RegenerateMonsterProfile: createApiCaller(Endpoints.RegenerateMonsterProfile),
+  /** Rename Monster */
+  RenameMonster: createApiCaller(Endpoints.RenameMonster),
+  /** Search Monsters Nearby */
+  SearchMonstersNearby: createApiCaller(Endpoints.SearchMonstersNearby),
+  /** Get Trash Categories */
+  GetTrashCategories: createApiCaller(Endpoints.GetTrashCategories),
   /** Get Trashs */
   GetTrashs: createApiCaller(Endpoints.GetTrashs),
+  /** Register Device */
+  RegisterDevice: createApiCaller(Endpoints.RegisterDevice),
 };
 
 // ============================================================================
@@ -431,15 +1288,17 @@ export async function apiMultipart<T>(
   options?: {
     headers?: Record<string, string>;
     signal?: AbortSignal;
-  }
+  },
+  route: Pick<RouteDefinition, "method" | "auth"> = { method: "POST", auth: "none" }
 ): Promise<ApiResponse<T>> {
   const config = getApiClientConfig();
   const url = `${config.baseUrl ?? DEFAULT_BASE_URL}${endpoint}`;
 
   const fetchOptions: RequestInit = {
-    method: "POST",
+    method: route.method,
     headers: {
       ...config.headers,
+      ...(await authHeaders(route)),
       ...options?.headers,
     },
     body: formData,
@@ -456,12 +1315,7 @@ export async function apiMultipart<T>(
       // Response body is not JSON
     }
 
-    const apiError = new ApiError(
-      response.status,
-      errorData?.error?.error ?? "UNKNOWN_ERROR",
-      errorData?.error?.message ?? response.statusText,
-      response
-    );
+    const apiError = createApiError(response.status, errorData, response.statusText, response);
 
     if (config.onError) {
       config.onError(apiError);
@@ -490,6 +1344,7 @@ export async function apiMultipart<T>(
 // ============================================================================
 
 export interface AnalyzeAndGenerateImageParams {
+  /** @required @maxSize 20MB @mimeTypes image/* */
   image: string; // File URI (e.g., "file:///path/to/photo.jpg")
   model: string;
 }
@@ -505,6 +1360,7 @@ export async function analyzeAndGenerateImage(
     signal?: AbortSignal;
   }
 ): Promise<ApiResponse<AnalyzeAndGenerateImageResponse>> {
+  const { path } = resolveRoute(Routes.AnalyzeAndGenerateImage, params);
   const formData = new FormData();
   formData.append("image", {
     uri: params.image,
@@ -514,16 +1370,21 @@ export async function analyzeAndGenerateImage(
   formData.append("model", String(params.model));
 
   return apiMultipart<AnalyzeAndGenerateImageResponse>(
-    Endpoints.AnalyzeAndGenerateImage,
+    path,
     formData,
-    options
+    options,
+    Routes.AnalyzeAndGenerateImage
   );
 }
 
 export interface CreateMonsterParams {
+  /** @required @maxLength 50 */
   nickname: string;
+  /** @minimum -90 @maximum 90 */
   latitude: number;
+  /** @minimum -180 @maximum 180 */
   longitude: number;
+  /** @required @maxSize 20MB @mimeTypes image/* */
   image: string; // File URI (e.g., "file:///path/to/photo.jpg")
 }
 
@@ -538,6 +1399,7 @@ export async function createMonster(
     signal?: AbortSignal;
   }
 ): Promise<ApiResponse<CreateMonsterResponse>> {
+  const { path } = resolveRoute(Routes.CreateMonster, params);
   const formData = new FormData();
   formData.append("nickname", String(params.nickname));
   formData.append("latitude", String(params.latitude));
@@ -549,8 +1411,221 @@ export async function createMonster(
   } as unknown as Blob);
 
   return apiMultipart<CreateMonsterResponse>(
-    Endpoints.CreateMonster,
+    path,
     formData,
-    options
+    options,
+    Routes.CreateMonster
+  );
+}
+
+// ============================================================================
+// File Download Client
+// ============================================================================
+
+/**
+ * Downloads a file via GET. The request fields are sent as path and query parameters.
+ * Pass a Range header in options.headers to fetch part of the file.
+ */
+export async function apiDownload(
+  route: RouteDefinition,
+  request: object,
+  options?: {
+    headers?: Record<string, string>;
+    signal?: AbortSignal;
+  }
+): Promise<ApiResponse<Blob>> {
+  const config = getApiClientConfig();
+  const { path } = resolveRoute(route, request);
+  const url = `${config.baseUrl ?? DEFAULT_BASE_URL}${path}`;
+
+  const response = await fetch(url, {
+    method: "GET",
+    headers: {
+      ...config.headers,
+      ...(await authHeaders(route)),
+      ...options?.headers,
+    },
+    signal: options?.signal,
+  });
+
+  if (!response.ok) {
+    let errorData: ApiErrorResponse | null = null;
+    try {
+      errorData = await response.json();
+    } catch {
+      // Response body is not JSON
+    }
+
+    const apiError = createApiError(response.status, errorData, response.statusText, response);
+
+    if (config.onError) {
+      config.onError(apiError);
+    }
+
+    throw apiError;
+  }
+
+  const data = await response.blob();
+
+  let result: ApiResponse<Blob> = {
+    data,
+    status: response.status,
+    headers: response.headers,
+  };
+
+  if (config.onResponse) {
+    result = await config.onResponse(result);
+  }
+
+  return result;
+}
+
+// ============================================================================
+// File Download Helper Functions
+// ============================================================================
+
+/**
+ * Download Monster Image
+ * Downloads the file as a Blob.
+ */
+export async function downloadMonsterImage(
+  request: DownloadMonsterImageRequest,
+  options?: {
+    headers?: Record<string, string>;
+    signal?: AbortSignal;
+  }
+): Promise<ApiResponse<DownloadMonsterImageResponse>> {
+  return apiDownload(Routes.DownloadMonsterImage, request, options);
+}
+
+/**
+ * Get Storage Object
+ * Downloads the file as a Blob.
+ */
+export async function getStorageObject(
+  request: GetStorageObjectRequest,
+  options?: {
+    headers?: Record<string, string>;
+    signal?: AbortSignal;
+  }
+): Promise<ApiResponse<GetStorageObjectResponse>> {
+  return apiDownload(Routes.GetStorageObject, request, options);
+}
+
+// ============================================================================
+// WebSocket Client (for subscriptions)
+// ============================================================================
+
+export interface WebSocketHandlers<T> {
+  onOpen?: () => void;
+  onMessage: (message: T) => void;
+  onError?: (error: ApiError) => void;
+  onClose?: (code: number, reason: string) => void;
+}
+
+export interface WebSocketSubscription<Req> {
+  send: (request: Req) => void;
+  close: () => void;
+}
+
+/**
+ * Opens a WebSocket connection to the endpoint and sends the initial request on open.
+ * Error frames sent by the server are passed to onError instead of onMessage.
+ * For endpoints that declare auth, the token from getAccessToken is sent as the
+ * "bearer" subprotocol because the WebSocket API cannot set the Authorization header.
+ */
+export function subscribe<Req, Res>(
+  endpoint: string,
+  auth: AuthRequirement,
+  request: Req | undefined,
+  handlers: WebSocketHandlers<Res>
+): WebSocketSubscription<Req> {
+  const config = getApiClientConfig();
+  const baseUrl = (config.baseUrl ?? DEFAULT_BASE_URL).replace(/^http/, "ws");
+  let socket: WebSocket | undefined;
+  let closed = false;
+  // Requests sent before the connection opens are sent on open
+  const pending: Req[] = [];
+
+  const open = (token: string | null | undefined) => {
+    if (closed) {
+      return;
+    }
+    const ws = new WebSocket(`${baseUrl}${endpoint}`, token ? ["bearer", token] : undefined);
+    socket = ws;
+
+    ws.onopen = () => {
+      handlers.onOpen?.();
+      if (request !== undefined) {
+        ws.send(JSON.stringify(request));
+      }
+      pending.splice(0).forEach((req) => ws.send(JSON.stringify(req)));
+    };
+
+    ws.onmessage = (event: MessageEvent) => {
+      let payload: unknown;
+      try {
+        payload = JSON.parse(String(event.data));
+      } catch {
+        return;
+      }
+
+      const errorData = payload as Partial<ApiErrorResponse>;
+      if (errorData && typeof errorData === "object" && errorData.error) {
+        const apiError = createApiError(0, errorData, "WebSocket error");
+        handlers.onError?.(apiError);
+        config.onError?.(apiError);
+        return;
+      }
+
+      handlers.onMessage(payload as Res);
+    };
+
+    ws.onerror = () => {
+      handlers.onError?.(new ApiError(0, "WEBSOCKET_ERROR", "WebSocket connection error"));
+    };
+
+    ws.onclose = (event: CloseEvent) => {
+      handlers.onClose?.(event.code, event.reason);
+    };
+  };
+
+  const token = auth === "none" || !config.getAccessToken ? undefined : config.getAccessToken();
+  Promise.resolve(token).then(open, () => {
+    handlers.onError?.(new ApiError(0, "WEBSOCKET_ERROR", "Failed to get the access token"));
+  });
+
+  return {
+    send: (req: Req) => {
+      if (socket?.readyState === WebSocket.OPEN) {
+        socket.send(JSON.stringify(req));
+      } else {
+        pending.push(req);
+      }
+    },
+    close: () => {
+      closed = true;
+      socket?.close(1000);
+    },
+  };
+}
+
+// ============================================================================
+// WebSocket Helper Functions
+// ============================================================================
+
+/**
+ * Watch Monster Generation
+ * Subscribes to server-pushed WatchMonsterGenerationResponse messages over WebSocket.
+ */
+export function watchMonsterGeneration(
+  request: WatchMonsterGenerationRequest | undefined,
+  handlers: WebSocketHandlers<WatchMonsterGenerationResponse>
+): WebSocketSubscription<WatchMonsterGenerationRequest> {
+  return subscribe<WatchMonsterGenerationRequest, WatchMonsterGenerationResponse>(
+    Endpoints.WatchMonsterGeneration,
+    "required",
+    request,
+    handlers
   );
 }